@@ -7,6 +7,7 @@ import java.nio.file.Path;
 import java.nio.file.Paths;
 import java.util.ArrayList;
 import java.util.Arrays;
+import java.util.Iterator;
 import java.util.List;
 
 public class FilerClient {
@@ -159,12 +160,17 @@ public class FilerClient {
     }
 
     public List<FilerProto.Entry> listEntries(String path, String entryPrefix, String lastEntryName, int limit) {
-        return filerGrpcClient.getBlockingStub().listEntries(FilerProto.ListEntriesRequest.newBuilder()
+        Iterator<FilerProto.ListEntriesResponse> iter = filerGrpcClient.getBlockingStub().listEntries(FilerProto.ListEntriesRequest.newBuilder()
             .setDirectory(path)
             .setPrefix(entryPrefix)
             .setStartFromFileName(lastEntryName)
             .setLimit(limit)
-            .build()).getEntriesList();
+            .build());
+        List<FilerProto.Entry> entries = new ArrayList<FilerProto.Entry>();
+        while (iter.hasNext()) {
+            entries.add(iter.next().getEntry());
+        }
+        return entries;
     }
 
     public FilerProto.Entry lookupEntry(String directory, String entryName) {
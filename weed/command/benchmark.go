@@ -35,6 +35,7 @@ type BenchmarkOptions struct {
 	deletePercentage *int
 	read             *bool
 	sequentialRead   *bool
+	readHotVolumes   *int
 	collection       *string
 	cpuprofile       *string
 	maxCpu           *int
@@ -60,6 +61,7 @@ func init() {
 	b.deletePercentage = cmdBenchmark.Flag.Int("deletePercent", 0, "the percent of writes that are deletes")
 	b.read = cmdBenchmark.Flag.Bool("read", true, "enable read")
 	b.sequentialRead = cmdBenchmark.Flag.Bool("readSequentially", false, "randomly read by ids from \"-list\" specified file")
+	b.readHotVolumes = cmdBenchmark.Flag.Int("readHotVolumes", 0, "only read the files in this many volumes from \"-list\" specified file. 0 means all volumes")
 	b.collection = cmdBenchmark.Flag.String("collection", "benchmark", "write data to this collection")
 	b.cpuprofile = cmdBenchmark.Flag.String("cpuprofile", "", "cpu profile output file")
 	b.maxCpu = cmdBenchmark.Flag.Int("maxCpu", 0, "maximum number of CPUs. 0 means all available CPUs")
@@ -92,6 +94,10 @@ var cmdBenchmark = &Command{
   before starting the benchmark command:
     http://localhost:9333/vol/grow?collection=benchmark&count=5
 
+  To benchmark concurrent reads on a few hot volumes, e.g., the volumes holding the popular images,
+  use "-readHotVolumes" to only read the files in the first few volumes of the list:
+    weed benchmark -write=false -c=64 -readHotVolumes=2
+
   After benchmarking, you can clean up the written data by deleting the benchmark collection
     http://localhost:9333/col/delete?collection=benchmark
 
@@ -320,12 +326,15 @@ func readFileIds(fileName string, fileIdLineChan chan string) {
 	defer file.Close()
 
 	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	hotVolumes := newHotVolumeFilter(*b.readHotVolumes)
 
 	r := bufio.NewReader(file)
 	if *b.sequentialRead {
 		for {
 			if line, err := Readln(r); err == nil {
-				fileIdLineChan <- string(line)
+				if hotVolumes.accept(string(line)) {
+					fileIdLineChan <- string(line)
+				}
 			} else {
 				break
 			}
@@ -334,7 +343,9 @@ func readFileIds(fileName string, fileIdLineChan chan string) {
 		lines := make([]string, 0, readStats.total)
 		for {
 			if line, err := Readln(r); err == nil {
-				lines = append(lines, string(line))
+				if hotVolumes.accept(string(line)) {
+					lines = append(lines, string(line))
+				}
 			} else {
 				break
 			}
@@ -349,6 +360,37 @@ func readFileIds(fileName string, fileIdLineChan chan string) {
 	close(fileIdLineChan)
 }
 
+// hotVolumeFilter only accepts the file ids in the first limit volumes, or all if limit is not positive
+type hotVolumeFilter struct {
+	limit   int
+	volumes map[string]bool
+}
+
+func newHotVolumeFilter(limit int) *hotVolumeFilter {
+	return &hotVolumeFilter{
+		limit:   limit,
+		volumes: make(map[string]bool),
+	}
+}
+
+func (f *hotVolumeFilter) accept(fid string) bool {
+	if f.limit <= 0 {
+		return true
+	}
+	commaIndex := strings.Index(fid, ",")
+	if commaIndex <= 0 {
+		return false
+	}
+	vid := fid[:commaIndex]
+	if !f.volumes[vid] {
+		if len(f.volumes) >= f.limit {
+			return false
+		}
+		f.volumes[vid] = true
+	}
+	return true
+}
+
 const (
 	benchResolution = 10000 //0.1 microsecond
 	benchBucket     = 1000000000 / benchResolution
@@ -25,6 +25,7 @@ type FilerOptions struct {
 	port                    *int
 	grpcPort                *int
 	publicPort              *int
+	metricsPort             *int
 	collection              *string
 	defaultReplicaPlacement *string
 	redirectOnRead          *bool
@@ -33,6 +34,7 @@ type FilerOptions struct {
 	dirListingLimit         *int
 	dataCenter              *string
 	enableNotification      *bool
+	metaLogDir              *string
 
 	// default leveldb directory, used in "weed server" mode
 	defaultLevelDbDirectory *string
@@ -46,12 +48,14 @@ func init() {
 	f.port = cmdFiler.Flag.Int("port", 8888, "filer server http listen port")
 	f.grpcPort = cmdFiler.Flag.Int("port.grpc", 0, "filer grpc server listen port, default to http port + 10000")
 	f.publicPort = cmdFiler.Flag.Int("port.public", 0, "port opened to public")
+	f.metricsPort = cmdFiler.Flag.Int("port.metrics", 9326, "port serving the Prometheus metrics at /metrics, disabled if 0")
 	f.defaultReplicaPlacement = cmdFiler.Flag.String("defaultReplicaPlacement", "000", "default replication type if not specified")
 	f.redirectOnRead = cmdFiler.Flag.Bool("redirectOnRead", false, "whether proxy or redirect to volume server during file GET request")
 	f.disableDirListing = cmdFiler.Flag.Bool("disableDirListing", false, "turn off directory listing")
 	f.maxMB = cmdFiler.Flag.Int("maxMB", 32, "split files larger than the limit")
 	f.dirListingLimit = cmdFiler.Flag.Int("dirListLimit", 100000, "limit sub dir listing size")
 	f.dataCenter = cmdFiler.Flag.String("dataCenter", "", "prefer to write to volumes in this data center")
+	f.metaLogDir = cmdFiler.Flag.String("metaLogDir", "", "directory to store the metadata change log, default to ./filermetalog")
 }
 
 var cmdFiler = &Command{
@@ -94,8 +98,13 @@ func (fo *FilerOptions) startFiler() {
 	}
 
 	defaultLevelDbDirectory := "./filerdb"
+	metaLogDirectory := "./filermetalog"
 	if fo.defaultLevelDbDirectory != nil {
 		defaultLevelDbDirectory = *fo.defaultLevelDbDirectory + "/filerdb"
+		metaLogDirectory = *fo.defaultLevelDbDirectory + "/filermetalog"
+	}
+	if fo.metaLogDir != nil && *fo.metaLogDir != "" {
+		metaLogDirectory = *fo.metaLogDir
 	}
 
 	fs, nfs_err := weed_server.NewFilerServer(defaultMux, publicVolumeMux, &weed_server.FilerOption{
@@ -108,6 +117,7 @@ func (fo *FilerOptions) startFiler() {
 		DirListingLimit:    *fo.dirListingLimit,
 		DataCenter:         *fo.dataCenter,
 		DefaultLevelDbDir:  defaultLevelDbDirectory,
+		MetaLogDir:         metaLogDirectory,
 	})
 	if nfs_err != nil {
 		glog.Fatalf("Filer startup error: %v", nfs_err)
@@ -127,6 +137,17 @@ func (fo *FilerOptions) startFiler() {
 		}()
 	}
 
+	// the metrics are on their own port, since any path on the filer port can be a file
+	if *fo.metricsPort != 0 {
+		metricsListeningAddress := *fo.ip + ":" + strconv.Itoa(*fo.metricsPort)
+		glog.V(0).Infoln("Start Seaweed filer server", util.VERSION, "metrics at", metricsListeningAddress)
+		go func() {
+			if e := weed_server.ServeMetrics(metricsListeningAddress); e != nil {
+				glog.Fatalf("Filer server fail to serve metrics: %v", e)
+			}
+		}()
+	}
+
 	glog.V(0).Infof("Start Seaweed Filer %s at %s:%d", util.VERSION, *fo.ip, *fo.port)
 	filerListener, e := util.NewListener(
 		":"+strconv.Itoa(*fo.port),
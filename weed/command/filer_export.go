@@ -141,11 +141,16 @@ func doTraverse(stat *statistics, filerStore filer2.FilerStore, parentPath filer
 	limit := *dirListLimit
 	lastEntryName := ""
 	for {
-		entries, err := filerStore.ListDirectoryEntries(parentPath, lastEntryName, false, limit)
+		var entries []*filer2.Entry
+		_, err := filerStore.ListDirectoryEntries(parentPath, lastEntryName, false, limit, "", func(entry *filer2.Entry) bool {
+			entries = append(entries, entry)
+			return true
+		})
 		if err != nil {
 			break
 		}
 		for _, entry := range entries {
+			lastEntryName = entry.Name()
 			if fnErr := fn(level, entry); fnErr != nil {
 				glog.Errorf("failed to process entry: %s", entry.FullPath)
 			}
@@ -17,6 +17,10 @@ type MountOptions struct {
 	ttlSec             *int
 	chunkSizeLimitMB   *int
 	dataCenter         *string
+	chunkCacheMemoryMB *int
+	chunkCacheDir      *string
+	chunkCacheDiskMB   *int
+	readAheadChunks    *int
 }
 
 var (
@@ -37,6 +41,10 @@ func init() {
 	mountOptions.ttlSec = cmdMount.Flag.Int("ttl", 0, "file ttl in seconds")
 	mountOptions.chunkSizeLimitMB = cmdMount.Flag.Int("chunkSizeLimitMB", 4, "local write buffer size, also chunk large files")
 	mountOptions.dataCenter = cmdMount.Flag.String("dataCenter", "", "prefer to write to the data center")
+	mountOptions.chunkCacheMemoryMB = cmdMount.Flag.Int("chunkCacheMemoryMB", 64, "cache the recently read chunks in memory, 0 to disable the chunk cache")
+	mountOptions.chunkCacheDir = cmdMount.Flag.String("chunkCacheDir", "", "also cache the chunks evicted from memory in this local directory, not shared with other mounts")
+	mountOptions.chunkCacheDiskMB = cmdMount.Flag.Int("chunkCacheDiskMB", 1024, "chunk cache size in the local directory")
+	mountOptions.readAheadChunks = cmdMount.Flag.Int("readAheadChunks", 2, "number of the following chunks to read ahead into the chunk cache for sequential reads")
 	mountCpuProfile = cmdMount.Flag.String("cpuprofile", "", "cpu profile output file")
 	mountMemProfile = cmdMount.Flag.String("memprofile", "", "memory profile output file")
 }
@@ -109,6 +109,11 @@ func runMount(cmd *Command, args []string) bool {
 		MountUid:           uid,
 		MountGid:           gid,
 		MountMode:          mountMode,
+
+		ChunkCacheMemorySize: int64(*mountOptions.chunkCacheMemoryMB) * 1024 * 1024,
+		ChunkCacheDir:        *mountOptions.chunkCacheDir,
+		ChunkCacheDiskSize:   int64(*mountOptions.chunkCacheDiskMB) * 1024 * 1024,
+		ReadAheadChunks:      *mountOptions.readAheadChunks,
 	}))
 	if err != nil {
 		fuse.Unmount(*mountOptions.dir)
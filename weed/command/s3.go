@@ -26,6 +26,7 @@ type S3Options struct {
 	domainName       *string
 	tlsPrivateKey    *string
 	tlsCertificate   *string
+	config           *string
 }
 
 func init() {
@@ -37,6 +38,7 @@ func init() {
 	s3options.domainName = cmdS3.Flag.String("domainName", "", "suffix of the host name, {bucket}.{domainName}")
 	s3options.tlsPrivateKey = cmdS3.Flag.String("key.file", "", "path to the TLS private key file")
 	s3options.tlsCertificate = cmdS3.Flag.String("cert.file", "", "path to the TLS certificate file")
+	s3options.config = cmdS3.Flag.String("config", "", "path to the json file of identities and their access keys, secret keys and actions")
 }
 
 var cmdS3 = &Command{
@@ -44,6 +46,31 @@ var cmdS3 = &Command{
 	Short:     "start a s3 API compatible server that is backed by a filer",
 	Long: `start a s3 API compatible server that is backed by a filer.
 
+	By default, all requests are allowed. To verify the request signatures,
+	list the identities in a json file and start with -config=s3.json:
+
+	{
+	  "identities": [
+	    {
+	      "name": "anonymous",
+	      "actions": ["Read:public_bucket", "List:public_bucket"]
+	    },
+	    {
+	      "name": "some_admin_user",
+	      "credentials": [{"accessKey": "some_access_key1", "secretKey": "some_secret_key1"}],
+	      "actions": ["Admin"]
+	    },
+	    {
+	      "name": "some_user_limited_to_bucket1",
+	      "credentials": [{"accessKey": "some_access_key2", "secretKey": "some_secret_key2"}],
+	      "actions": ["Read:bucket1", "Write:bucket1", "List:bucket1"]
+	    }
+	  ]
+	}
+
+	The actions are Read, Write, List and Admin, optionally limited to one bucket as "<action>:<bucket>".
+	Requests without signatures are done as the "anonymous" identity, and denied if it is not listed.
+
 `,
 }
 
@@ -65,6 +92,7 @@ func runS3(cmd *Command, args []string) bool {
 		DomainName:       *s3options.domainName,
 		BucketsPath:      *s3options.filerBucketsPath,
 		GrpcDialOption:   security.LoadClientTLS(viper.Sub("grpc"), "client"),
+		Config:           *s3options.config,
 	})
 	if s3ApiServer_err != nil {
 		glog.Fatalf("S3 API Server startup error: %v", s3ApiServer_err)
@@ -135,6 +135,21 @@ addresses = [
     "localhost:30006",
 ]
 
+####################################################
+# multiple filers replicating the metadata with raft,
+# each keeping a full copy in a local leveldb, no external database
+####################################################
+
+[raft]
+enabled = false
+dir = "./filerraft"         # directory to store the raft log and the local metadata copy
+self = "localhost:18889"    # this filer's raft address, listened on by this filer
+peers = [                   # the raft addresses of all the filers, including this one
+    "localhost:18889",
+    "localhost:18890",
+    "localhost:18891",
+]
+
 `
 
 	NOTIFICATION_TOML_EXAMPLE = `
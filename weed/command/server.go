@@ -81,11 +81,13 @@ func init() {
 	filerOptions.port = cmdServer.Flag.Int("filer.port", 8888, "filer server http listen port")
 	filerOptions.grpcPort = cmdServer.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to http port + 10000")
 	filerOptions.publicPort = cmdServer.Flag.Int("filer.port.public", 0, "filer server public http listen port")
+	filerOptions.metricsPort = cmdServer.Flag.Int("filer.port.metrics", 9326, "filer server port serving the Prometheus metrics at /metrics, disabled if 0")
 	filerOptions.defaultReplicaPlacement = cmdServer.Flag.String("filer.defaultReplicaPlacement", "", "Default replication type if not specified during runtime.")
 	filerOptions.redirectOnRead = cmdServer.Flag.Bool("filer.redirectOnRead", false, "whether proxy or redirect to volume server during file GET request")
 	filerOptions.disableDirListing = cmdServer.Flag.Bool("filer.disableDirListing", false, "turn off directory listing")
 	filerOptions.maxMB = cmdServer.Flag.Int("filer.maxMB", 32, "split files larger than the limit")
 	filerOptions.dirListingLimit = cmdServer.Flag.Int("filer.dirListLimit", 1000, "limit sub dir listing size")
+	filerOptions.metaLogDir = cmdServer.Flag.String("filer.metaLogDir", "", "directory to store the filer metadata change log, default to <mdir>/filermetalog")
 
 	serverOptions.v.port = cmdServer.Flag.Int("volume.port", 8080, "volume server http listen port")
 	serverOptions.v.publicPort = cmdServer.Flag.Int("volume.port.public", 0, "volume server public port")
@@ -93,6 +95,12 @@ func init() {
 	serverOptions.v.fixJpgOrientation = cmdServer.Flag.Bool("volume.images.fix.orientation", false, "Adjust jpg orientation when uploading.")
 	serverOptions.v.readRedirect = cmdServer.Flag.Bool("volume.read.redirect", true, "Redirect moved or non-local volumes.")
 	serverOptions.v.publicUrl = cmdServer.Flag.String("volume.publicUrl", "", "publicly accessible address")
+	serverOptions.v.scrubIntervalHours = cmdServer.Flag.Int("volume.scrub.intervalHours", 24*7, "verify the CRC of all the needles in each volume once in this many hours. 0 to disable")
+	serverOptions.v.scrubMBps = cmdServer.Flag.Int("volume.scrub.maxMBps", 10, "limit the reads of the volume scrubbing in MB per second. 0 means no limit")
+	serverOptions.v.fsync = cmdServer.Flag.String("volume.fsync", "none", "Choose [none|batch|write] to fsync the writes before acknowledging them: never, once for the concurrent writes to a volume, or once for each write.")
+	serverOptions.v.tierS3Endpoint = cmdServer.Flag.String("volume.tier.s3.endpoint", "", "the S3 compatible storage endpoint to move the .dat files to, if not given by volume.tier.upload")
+	serverOptions.v.tierS3AccessKey = cmdServer.Flag.String("volume.tier.s3.accessKey", "", "the S3 compatible storage access key. If empty, loads from the default aws credential chain.")
+	serverOptions.v.tierS3SecretKey = cmdServer.Flag.String("volume.tier.s3.secretKey", "", "the S3 compatible storage secret key. If empty, loads from the default aws credential chain.")
 
 }
 
@@ -43,6 +43,12 @@ type VolumeServerOptions struct {
 	readRedirect          *bool
 	cpuProfile            *string
 	memProfile            *string
+	scrubIntervalHours    *int
+	scrubMBps             *int
+	fsync                 *string
+	tierS3Endpoint        *string
+	tierS3AccessKey       *string
+	tierS3SecretKey       *string
 }
 
 func init() {
@@ -63,6 +69,12 @@ func init() {
 	v.readRedirect = cmdVolume.Flag.Bool("read.redirect", true, "Redirect moved or non-local volumes.")
 	v.cpuProfile = cmdVolume.Flag.String("cpuprofile", "", "cpu profile output file")
 	v.memProfile = cmdVolume.Flag.String("memprofile", "", "memory profile output file")
+	v.scrubIntervalHours = cmdVolume.Flag.Int("scrub.intervalHours", 24*7, "verify the CRC of all the needles in each volume once in this many hours. 0 to disable")
+	v.scrubMBps = cmdVolume.Flag.Int("scrub.maxMBps", 10, "limit the reads of the volume scrubbing in MB per second. 0 means no limit")
+	v.fsync = cmdVolume.Flag.String("fsync", "none", "Choose [none|batch|write] to fsync the writes before acknowledging them: never, once for the concurrent writes to a volume, or once for each write.")
+	v.tierS3Endpoint = cmdVolume.Flag.String("tier.s3.endpoint", "", "the S3 compatible storage endpoint to move the .dat files to, if not given by volume.tier.upload")
+	v.tierS3AccessKey = cmdVolume.Flag.String("tier.s3.accessKey", "", "the S3 compatible storage access key. If empty, loads from the default aws credential chain.")
+	v.tierS3SecretKey = cmdVolume.Flag.String("tier.s3.secretKey", "", "the S3 compatible storage secret key. If empty, loads from the default aws credential chain.")
 }
 
 var cmdVolume = &Command{
@@ -148,6 +160,19 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 		volumeNeedleMapKind = storage.NeedleMapBtree
 	}
 
+	fsyncMode := storage.FsyncNone
+	switch *v.fsync {
+	case "none":
+	case "batch":
+		fsyncMode = storage.FsyncBatch
+	case "write":
+		fsyncMode = storage.FsyncWrite
+	default:
+		glog.Fatalf("unknown -fsync mode %s, expecting none, batch, or write", *v.fsync)
+	}
+
+	storage.SetTierS3Config(*v.tierS3Endpoint, *v.tierS3AccessKey, *v.tierS3SecretKey)
+
 	masters := *v.masters
 
 	volumeServer := weed_server.NewVolumeServer(volumeMux, publicVolumeMux,
@@ -157,6 +182,8 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 		strings.Split(masters, ","), *v.pulseSeconds, *v.dataCenter, *v.rack,
 		v.whiteList,
 		*v.fixJpgOrientation, *v.readRedirect,
+		*v.scrubIntervalHours, *v.scrubMBps,
+		fsyncMode,
 	)
 
 	listeningAddress := *v.bindIp + ":" + strconv.Itoa(*v.port)
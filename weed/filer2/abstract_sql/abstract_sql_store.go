@@ -3,22 +3,35 @@ package abstract_sql
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 )
 
 type AbstractSqlStore struct {
-	DB               *sql.DB
-	SqlInsert        string
-	SqlUpdate        string
-	SqlFind          string
-	SqlDelete        string
-	SqlListExclusive string
-	SqlListInclusive string
+	DB                      *sql.DB
+	SqlInsert               string
+	SqlUpdate               string
+	SqlFind                 string
+	SqlDelete               string
+	SqlDeleteFolderChildren string
+	SqlListExclusive        string
+	SqlListInclusive        string
+}
+
+// sqlExecutor is either the *sql.DB or one *sql.Tx of it
+type sqlExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
 func (store *AbstractSqlStore) InsertEntry(entry *filer2.Entry) (err error) {
+	return store.insertEntry(store.DB, entry)
+}
+
+func (store *AbstractSqlStore) insertEntry(db sqlExecutor, entry *filer2.Entry) (err error) {
 
 	dir, name := entry.FullPath.DirAndName()
 	meta, err := entry.EncodeAttributesAndChunks()
@@ -26,7 +39,7 @@ func (store *AbstractSqlStore) InsertEntry(entry *filer2.Entry) (err error) {
 		return fmt.Errorf("encode %s: %s", entry.FullPath, err)
 	}
 
-	res, err := store.DB.Exec(store.SqlInsert, hashToLong(dir), name, dir, meta)
+	res, err := db.Exec(store.SqlInsert, hashToLong(dir), name, dir, meta)
 	if err != nil {
 		return fmt.Errorf("insert %s: %s", entry.FullPath, err)
 	}
@@ -39,6 +52,10 @@ func (store *AbstractSqlStore) InsertEntry(entry *filer2.Entry) (err error) {
 }
 
 func (store *AbstractSqlStore) UpdateEntry(entry *filer2.Entry) (err error) {
+	return store.updateEntry(store.DB, entry)
+}
+
+func (store *AbstractSqlStore) updateEntry(db sqlExecutor, entry *filer2.Entry) (err error) {
 
 	dir, name := entry.FullPath.DirAndName()
 	meta, err := entry.EncodeAttributesAndChunks()
@@ -46,7 +63,7 @@ func (store *AbstractSqlStore) UpdateEntry(entry *filer2.Entry) (err error) {
 		return fmt.Errorf("encode %s: %s", entry.FullPath, err)
 	}
 
-	res, err := store.DB.Exec(store.SqlUpdate, meta, hashToLong(dir), name, dir)
+	res, err := db.Exec(store.SqlUpdate, meta, hashToLong(dir), name, dir)
 	if err != nil {
 		return fmt.Errorf("update %s: %s", entry.FullPath, err)
 	}
@@ -59,9 +76,13 @@ func (store *AbstractSqlStore) UpdateEntry(entry *filer2.Entry) (err error) {
 }
 
 func (store *AbstractSqlStore) FindEntry(fullpath filer2.FullPath) (*filer2.Entry, error) {
+	return store.findEntry(store.DB, fullpath)
+}
+
+func (store *AbstractSqlStore) findEntry(db sqlExecutor, fullpath filer2.FullPath) (*filer2.Entry, error) {
 
 	dir, name := fullpath.DirAndName()
-	row := store.DB.QueryRow(store.SqlFind, hashToLong(dir), name, dir)
+	row := db.QueryRow(store.SqlFind, hashToLong(dir), name, dir)
 	var data []byte
 	if err := row.Scan(&data); err != nil {
 		return nil, filer2.ErrNotFound
@@ -78,10 +99,14 @@ func (store *AbstractSqlStore) FindEntry(fullpath filer2.FullPath) (*filer2.Entr
 }
 
 func (store *AbstractSqlStore) DeleteEntry(fullpath filer2.FullPath) error {
+	return store.deleteEntry(store.DB, fullpath)
+}
+
+func (store *AbstractSqlStore) deleteEntry(db sqlExecutor, fullpath filer2.FullPath) error {
 
 	dir, name := fullpath.DirAndName()
 
-	res, err := store.DB.Exec(store.SqlDelete, hashToLong(dir), name, dir)
+	res, err := db.Exec(store.SqlDelete, hashToLong(dir), name, dir)
 	if err != nil {
 		return fmt.Errorf("delete %s: %s", fullpath, err)
 	}
@@ -94,16 +119,38 @@ func (store *AbstractSqlStore) DeleteEntry(fullpath filer2.FullPath) error {
 	return nil
 }
 
-func (store *AbstractSqlStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int) (entries []*filer2.Entry, err error) {
+func (store *AbstractSqlStore) DeleteFolderChildren(fullpath filer2.FullPath) error {
+
+	// the direct children are found by the dirhash, and the deeper descendants by the sub folder prefix
+	subFolderPrefix := string(fullpath) + "/"
+	if fullpath == "/" {
+		subFolderPrefix = "/"
+	}
+
+	_, err := store.DB.Exec(store.SqlDeleteFolderChildren, hashToLong(string(fullpath)), string(fullpath), likePrefix(subFolderPrefix))
+	if err != nil {
+		return fmt.Errorf("delete folder children %s: %s", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *AbstractSqlStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
+	return store.listDirectoryEntries(store.DB, fullpath, startFileName, inclusive, limit, prefix, eachEntryFn)
+}
+
+func (store *AbstractSqlStore) listDirectoryEntries(db sqlExecutor, fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
 
 	sqlText := store.SqlListExclusive
 	if inclusive {
 		sqlText = store.SqlListInclusive
 	}
 
-	rows, err := store.DB.Query(sqlText, hashToLong(string(fullpath)), startFileName, string(fullpath), limit)
+	rows, err := db.Query(sqlText, hashToLong(string(fullpath)), startFileName, string(fullpath), likePrefix(prefix), limit)
 	if err != nil {
-		return nil, fmt.Errorf("list %s : %v", fullpath, err)
+		return "", fmt.Errorf("list %s : %v", fullpath, err)
 	}
 	defer rows.Close()
 
@@ -112,7 +159,7 @@ func (store *AbstractSqlStore) ListDirectoryEntries(fullpath filer2.FullPath, st
 		var data []byte
 		if err = rows.Scan(&name, &data); err != nil {
 			glog.V(0).Infof("scan %s : %v", fullpath, err)
-			return nil, fmt.Errorf("scan %s: %v", fullpath, err)
+			return lastFileName, fmt.Errorf("scan %s: %v", fullpath, err)
 		}
 
 		entry := &filer2.Entry{
@@ -120,11 +167,62 @@ func (store *AbstractSqlStore) ListDirectoryEntries(fullpath filer2.FullPath, st
 		}
 		if err = entry.DecodeAttributesAndChunks(data); err != nil {
 			glog.V(0).Infof("scan decode %s : %v", entry.FullPath, err)
-			return nil, fmt.Errorf("scan decode %s : %v", entry.FullPath, err)
+			return lastFileName, fmt.Errorf("scan decode %s : %v", entry.FullPath, err)
+		}
+
+		lastFileName = name
+		if !eachEntryFn(entry) {
+			break
 		}
+	}
+
+	return lastFileName, rows.Err()
+}
+
+// likePrefix is the LIKE pattern matching the names starting with the prefix, with "!" as the ESCAPE character.
+// Unlike the backslash, "!" is not special in the sql string literals of any sql mode.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix) + "%"
+}
 
-		entries = append(entries, entry)
+func (store *AbstractSqlStore) BeginTransaction() (filer2.FilerStoreTransaction, error) {
+	tx, err := store.DB.Begin()
+	if err != nil {
+		return nil, err
 	}
+	return &sqlTransaction{store: store, tx: tx}, nil
+}
+
+type sqlTransaction struct {
+	store *AbstractSqlStore
+	tx    *sql.Tx
+}
+
+func (t *sqlTransaction) InsertEntry(entry *filer2.Entry) error {
+	return t.store.insertEntry(t.tx, entry)
+}
+
+func (t *sqlTransaction) UpdateEntry(entry *filer2.Entry) error {
+	return t.store.updateEntry(t.tx, entry)
+}
+
+func (t *sqlTransaction) FindEntry(fullpath filer2.FullPath) (*filer2.Entry, error) {
+	return t.store.findEntry(t.tx, fullpath)
+}
+
+func (t *sqlTransaction) DeleteEntry(fullpath filer2.FullPath) error {
+	return t.store.deleteEntry(t.tx, fullpath)
+}
+
+func (t *sqlTransaction) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (string, error) {
+	return t.store.listDirectoryEntries(t.tx, fullpath, startFileName, inclusive, limit, prefix, eachEntryFn)
+}
+
+func (t *sqlTransaction) Commit() error {
+	return t.tx.Commit()
+}
 
-	return entries, nil
+func (t *sqlTransaction) Rollback() error {
+	return t.tx.Rollback()
 }
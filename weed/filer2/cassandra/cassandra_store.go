@@ -2,6 +2,8 @@ package cassandra
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/util"
@@ -102,17 +104,26 @@ func (store *CassandraStore) DeleteEntry(fullpath filer2.FullPath) error {
 }
 
 func (store *CassandraStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool,
-	limit int) (entries []*filer2.Entry, err error) {
+	limit int, prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
 
 	cqlStr := "SELECT NAME, meta FROM filemeta WHERE directory=? AND name>? ORDER BY NAME ASC LIMIT ?"
 	if inclusive {
 		cqlStr = "SELECT NAME, meta FROM filemeta WHERE directory=? AND name>=? ORDER BY NAME ASC LIMIT ?"
 	}
+	startFrom := startFileName
+	if prefix > startFileName {
+		// cassandra has no LIKE on the clustering column, so start from the prefix and stop after it
+		cqlStr = "SELECT NAME, meta FROM filemeta WHERE directory=? AND name>=? ORDER BY NAME ASC LIMIT ?"
+		startFrom = prefix
+	}
 
 	var data []byte
 	var name string
-	iter := store.session.Query(cqlStr, string(fullpath), startFileName, limit).Iter()
+	iter := store.session.Query(cqlStr, string(fullpath), startFrom, limit).Iter()
 	for iter.Scan(&name, &data) {
+		if !strings.HasPrefix(name, prefix) {
+			break
+		}
 		entry := &filer2.Entry{
 			FullPath: filer2.NewFullPath(string(fullpath), name),
 		}
@@ -121,11 +132,14 @@ func (store *CassandraStore) ListDirectoryEntries(fullpath filer2.FullPath, star
 			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
 			break
 		}
-		entries = append(entries, entry)
+		lastFileName = name
+		if !eachEntryFn(entry) {
+			break
+		}
 	}
 	if err := iter.Close(); err != nil {
 		glog.V(0).Infof("list iterator close: %v", err)
 	}
 
-	return entries, err
+	return lastFileName, err
 }
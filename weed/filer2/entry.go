@@ -33,6 +33,13 @@ type Entry struct {
 
 	// the following is for files
 	Chunks []*filer_pb.FileChunk `json:"chunks,omitempty"`
+
+	// extended attributes, e.g., the s3 object metadata
+	Extended map[string][]byte `json:"extended,omitempty"`
+
+	// the hard links share the attributes and the chunks, which are kept once under the HardLinkId
+	HardLinkId      HardLinkId `json:"hardLinkId,omitempty"`
+	HardLinkCounter int32      `json:"hardLinkCounter,omitempty"`
 }
 
 func (entry *Entry) Size() uint64 {
@@ -52,9 +59,12 @@ func (entry *Entry) ToProtoEntry() *filer_pb.Entry {
 		return nil
 	}
 	return &filer_pb.Entry{
-		Name:        string(entry.FullPath),
-		IsDirectory: entry.IsDirectory(),
-		Attributes:  EntryAttributeToPb(entry),
-		Chunks:      entry.Chunks,
+		Name:            string(entry.FullPath),
+		IsDirectory:     entry.IsDirectory(),
+		Attributes:      EntryAttributeToPb(entry),
+		Chunks:          entry.Chunks,
+		Extended:        entry.Extended,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 }
@@ -0,0 +1,44 @@
+package filer2
+
+import (
+	"net/http"
+	"strings"
+)
+
+const AmzUserMetaPrefix = "X-Amz-Meta-"
+
+// the object headers kept in Entry.Extended, besides the "X-Amz-Meta-" user metadata
+var extendedHeaders = map[string]bool{
+	"Cache-Control":       true,
+	"Content-Disposition": true,
+	"Content-Encoding":    true,
+	"X-Amz-Version-Id":    true, // set by the s3 gateway for versioned buckets
+}
+
+func IsExtendedHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	return strings.HasPrefix(name, AmzUserMetaPrefix) || extendedHeaders[name]
+}
+
+// ExtendedFromHeader collects the user metadata and content headers, keyed by the canonical header names
+func ExtendedFromHeader(h http.Header) (extended map[string][]byte) {
+	for name, values := range h {
+		if len(values) == 0 || !IsExtendedHeader(name) {
+			continue
+		}
+		if extended == nil {
+			extended = make(map[string][]byte)
+		}
+		extended[http.CanonicalHeaderKey(name)] = []byte(values[0])
+	}
+	return
+}
+
+// SetHeaderFromExtended sets the headers saved by ExtendedFromHeader, and skips other extended attributes
+func SetHeaderFromExtended(h http.Header, extended map[string][]byte) {
+	for name, value := range extended {
+		if IsExtendedHeader(name) {
+			h.Set(name, string(value))
+		}
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 	"google.golang.org/grpc"
-	"math"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/wdclient"
 	"github.com/karlseguin/ccache"
 )
@@ -21,11 +22,16 @@ var (
 )
 
 type Filer struct {
-	store              FilerStore
+	store              *FilerStoreWrapper
 	directoryCache     *ccache.Cache
 	MasterClient       *wdclient.MasterClient
 	fileIdDeletionChan chan string
 	GrpcDialOption     grpc.DialOption
+	MetaLog            *MetaLog
+	// renames hold the renameLock exclusively, so the moved subtree does not change during the rename,
+	// and the other changes hold it shared
+	renameLock   sync.RWMutex
+	hardLinkLock sync.Mutex
 }
 
 func NewFiler(masters []string, grpcDialOption grpc.DialOption) *Filer {
@@ -42,7 +48,7 @@ func NewFiler(masters []string, grpcDialOption grpc.DialOption) *Filer {
 }
 
 func (f *Filer) SetStore(store FilerStore) {
-	f.store = store
+	f.store = NewFilerStoreWrapper(store)
 }
 
 func (f *Filer) DisableDirectoryCache() {
@@ -63,34 +69,103 @@ func (f *Filer) CreateEntry(entry *Entry) error {
 		return nil
 	}
 
+	f.renameLock.RLock()
+	defer f.renameLock.RUnlock()
+
+	missingDirs, err := f.findMissingParentDirectories(entry)
+	if err != nil {
+		return err
+	}
+
+	oldEntry, _ := f.FindEntry(entry.FullPath)
+
+	if isHardLinkSave(oldEntry, entry) {
+		f.hardLinkLock.Lock()
+		defer f.hardLinkLock.Unlock()
+	}
+
+	if len(missingDirs) == 0 {
+		err = f.saveEntry(f.store, oldEntry, entry)
+	} else {
+		// create the parent directories and the entry all together
+		err = f.withTransaction(func(tx FilerStoreTransaction) error {
+			var mkdirErr error
+			if missingDirs, mkdirErr = f.createDirectories(tx, missingDirs); mkdirErr != nil {
+				return mkdirErr
+			}
+			return f.saveEntry(tx, oldEntry, entry)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	f.onDirectoriesCreated(missingDirs)
+
+	f.NotifyUpdateEvent(oldEntry, entry, true)
+
+	f.deleteChunksIfNotNew(oldEntry, entry)
+
+	return nil
+}
+
+// entryWriter is either the filer store or one transaction of it
+type entryWriter interface {
+	InsertEntry(*Entry) error
+	UpdateEntry(*Entry) (err error)
+}
+
+func (f *Filer) saveEntry(store entryWriter, oldEntry, entry *Entry) error {
+	if oldEntry == nil {
+		if err := store.InsertEntry(entry); err != nil {
+			return fmt.Errorf("insert entry %s: %v", entry.FullPath, err)
+		}
+		return nil
+	}
+	if err := checkEntryType(oldEntry, entry); err != nil {
+		return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
+	}
+	inheritHardLink(oldEntry, entry)
+	if err := store.UpdateEntry(entry); err != nil {
+		return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
+	}
+	return nil
+}
+
+// findMissingParentDirectories lists the parent directories of the entry to create, from the top down
+func (f *Filer) findMissingParentDirectories(entry *Entry) (missingDirs []*Entry, err error) {
+
 	dirParts := strings.Split(string(entry.FullPath), "/")
 
 	// fmt.Printf("directory parts: %+v\n", dirParts)
 
-	var lastDirectoryEntry *Entry
-
 	for i := 1; i < len(dirParts); i++ {
 		dirPath := "/" + filepath.Join(dirParts[:i]...)
 		// fmt.Printf("%d directory: %+v\n", i, dirPath)
 
-		// first check local cache
-		dirEntry := f.cacheGetDirectory(dirPath)
+		var dirEntry *Entry
 
-		// not found, check the store directly
-		if dirEntry == nil {
-			glog.V(4).Infof("find uncached directory: %s", dirPath)
-			dirEntry, _ = f.FindEntry(FullPath(dirPath))
-		} else {
-			glog.V(4).Infof("found cached directory: %s", dirPath)
+		// the sub directories of a missing directory are all missing
+		if len(missingDirs) == 0 {
+
+			// first check local cache
+			dirEntry = f.cacheGetDirectory(dirPath)
+
+			// not found, check the store directly
+			if dirEntry == nil {
+				glog.V(4).Infof("find uncached directory: %s", dirPath)
+				dirEntry, _ = f.FindEntry(FullPath(dirPath))
+			} else {
+				glog.V(4).Infof("found cached directory: %s", dirPath)
+			}
 		}
 
 		// no such existing directory
 		if dirEntry == nil {
 
-			// create the directory
 			now := time.Now()
 
-			dirEntry = &Entry{
+			missingDirs = append(missingDirs, &Entry{
 				FullPath: FullPath(dirPath),
 				Attr: Attr{
 					Mtime:  now,
@@ -99,64 +174,84 @@ func (f *Filer) CreateEntry(entry *Entry) error {
 					Uid:    entry.Uid,
 					Gid:    entry.Gid,
 				},
-			}
+			})
+			continue
 
-			glog.V(2).Infof("create directory: %s %v", dirPath, dirEntry.Mode)
-			mkdirErr := f.store.InsertEntry(dirEntry)
-			if mkdirErr != nil {
-				if _, err := f.FindEntry(FullPath(dirPath)); err == ErrNotFound {
-					return fmt.Errorf("mkdir %s: %v", dirPath, mkdirErr)
-				}
-			} else {
-				f.NotifyUpdateEvent(nil, dirEntry, false)
-			}
+		}
 
-		} else if !dirEntry.IsDirectory() {
-			return fmt.Errorf("%s is a file", dirPath)
+		if !dirEntry.IsDirectory() {
+			return nil, fmt.Errorf("%s is a file", dirPath)
 		}
 
 		// cache the directory entry
 		f.cacheSetDirectory(dirPath, dirEntry, i)
 
-		// remember the direct parent directory entry
-		if i == len(dirParts)-1 {
-			lastDirectoryEntry = dirEntry
-		}
-
 	}
 
-	if lastDirectoryEntry == nil {
-		return fmt.Errorf("parent folder not found: %v", entry.FullPath)
-	}
+	return missingDirs, nil
+}
 
-	/*
-		if !hasWritePermission(lastDirectoryEntry, entry) {
-			glog.V(0).Infof("directory %s: %v, entry: uid=%d gid=%d",
-				lastDirectoryEntry.FullPath, lastDirectoryEntry.Attr, entry.Uid, entry.Gid)
-			return fmt.Errorf("no write permission in folder %v", lastDirectoryEntry.FullPath)
+// createDirectories inserts the missing directories, and returns the ones actually created,
+// skipping the ones just created by others.
+// A failed insert is not skipped, since it aborts the whole transaction in some sql databases.
+func (f *Filer) createDirectories(tx FilerStoreTransaction, missingDirs []*Entry) (createdDirs []*Entry, err error) {
+	for _, dirEntry := range missingDirs {
+		existing, findErr := tx.FindEntry(dirEntry.FullPath)
+		if findErr == nil {
+			if !existing.IsDirectory() {
+				return nil, fmt.Errorf("mkdir %s: existing entry is a file", dirEntry.FullPath)
+			}
+			continue
 		}
-	*/
-
-	oldEntry, _ := f.FindEntry(entry.FullPath)
-
-	if oldEntry == nil {
-		if err := f.store.InsertEntry(entry); err != nil {
-			return fmt.Errorf("insert entry %s: %v", entry.FullPath, err)
+		if findErr != ErrNotFound {
+			return nil, fmt.Errorf("mkdir %s: %v", dirEntry.FullPath, findErr)
 		}
-	} else {
-		if err := f.UpdateEntry(oldEntry, entry); err != nil {
-			return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
+		glog.V(2).Infof("create directory: %s %v", dirEntry.FullPath, dirEntry.Mode)
+		if mkdirErr := tx.InsertEntry(dirEntry); mkdirErr != nil {
+			return nil, fmt.Errorf("mkdir %s: %v", dirEntry.FullPath, mkdirErr)
 		}
+		createdDirs = append(createdDirs, dirEntry)
 	}
+	return createdDirs, nil
+}
 
-	f.NotifyUpdateEvent(oldEntry, entry, true)
+// onDirectoriesCreated caches and notifies the directories after they are committed
+func (f *Filer) onDirectoriesCreated(createdDirs []*Entry) {
+	for _, dirEntry := range createdDirs {
+		f.cacheSetDirectory(string(dirEntry.FullPath), dirEntry, strings.Count(string(dirEntry.FullPath), "/"))
+		f.NotifyUpdateEvent(nil, dirEntry, false)
+	}
+}
 
-	f.deleteChunksIfNotNew(oldEntry, entry)
+func (f *Filer) UpdateEntry(oldEntry, entry *Entry) (err error) {
+	if err = checkEntryType(oldEntry, entry); err != nil {
+		return err
+	}
+	f.renameLock.RLock()
+	defer f.renameLock.RUnlock()
+	if isHardLinkSave(oldEntry, entry) {
+		f.hardLinkLock.Lock()
+		defer f.hardLinkLock.Unlock()
+	}
+	inheritHardLink(oldEntry, entry)
+	return f.store.UpdateEntry(entry)
+}
 
-	return nil
+// isHardLinkSave checks whether saving the entry also saves the shared content of a hard link,
+// which keeps the number of links and so must not interleave with linking or unlinking
+func isHardLinkSave(oldEntry, entry *Entry) bool {
+	return len(entry.HardLinkId) > 0 || oldEntry != nil && len(oldEntry.HardLinkId) > 0
 }
 
-func (f *Filer) UpdateEntry(oldEntry, entry *Entry) (err error) {
+// inheritHardLink keeps the entry as the same hard link, changing the content shared by all the links
+func inheritHardLink(oldEntry, entry *Entry) {
+	if oldEntry != nil && len(oldEntry.HardLinkId) > 0 && len(entry.HardLinkId) == 0 {
+		entry.HardLinkId = oldEntry.HardLinkId
+		entry.HardLinkCounter = oldEntry.HardLinkCounter
+	}
+}
+
+func checkEntryType(oldEntry, entry *Entry) error {
 	if oldEntry != nil {
 		if oldEntry.IsDirectory() && !entry.IsDirectory() {
 			return fmt.Errorf("existing %s is a directory", entry.FullPath)
@@ -165,7 +260,7 @@ func (f *Filer) UpdateEntry(oldEntry, entry *Entry) (err error) {
 			return fmt.Errorf("existing %s is a file", entry.FullPath)
 		}
 	}
-	return f.store.UpdateEntry(entry)
+	return nil
 }
 
 func (f *Filer) FindEntry(p FullPath) (entry *Entry, err error) {
@@ -188,67 +283,181 @@ func (f *Filer) FindEntry(p FullPath) (entry *Entry, err error) {
 }
 
 func (f *Filer) DeleteEntryMetaAndData(p FullPath, isRecursive bool, shouldDeleteChunks bool) (err error) {
+
+	f.renameLock.RLock()
+	defer f.renameLock.RUnlock()
+
 	entry, err := f.FindEntry(p)
 	if err != nil {
 		return err
 	}
 
-	if entry.IsDirectory() {
-		limit := int(1)
-		if isRecursive {
-			limit = math.MaxInt32
+	// the chunks are deleted only after the deletion of their entries is committed
+	d := newEntryDeletion(f, func(deletedEntries []*Entry) {
+		var chunks []*filer_pb.FileChunk
+		for _, deletedEntry := range deletedEntries {
+			if deletedEntry.IsDirectory() {
+				f.cacheDelDirectory(string(deletedEntry.FullPath))
+			}
+			chunks = append(chunks, deletedEntry.Chunks...)
+		}
+		if shouldDeleteChunks {
+			f.DeleteChunks(chunks)
 		}
+	})
+	defer d.unlockHardLinks()
+
+	if entry.IsDirectory() && isRecursive && f.store.CanDeleteFolderChildren() {
+		err = f.doDeleteFolder(d, entry)
+		if err == nil && entry.FullPath != "/" {
+			glog.V(3).Infof("deleting folder %v", entry.FullPath)
+			if err = f.store.DeleteEntry(entry.FullPath); err != nil {
+				err = fmt.Errorf("delete %s: %v", entry.FullPath, err)
+			} else {
+				d.onCommitted([]*Entry{entry})
+			}
+		}
+	} else if err = f.doDeleteEntryMeta(d, entry, isRecursive); err == nil {
+		err = d.commit()
+	}
+	d.rollback()
+
+	// one event for the whole deleted tree, only once all of it is deleted,
+	// so the subscribers never delete the entries still kept after a failure
+	if err == nil {
+		f.NotifyUpdateEvent(entry, nil, shouldDeleteChunks)
+	}
+
+	return err
+}
+
+// doDeleteFolder empties the folder, sub folders first, one page of children at a time, so only one page
+// of each folder being deleted is held in memory. The full pages are deleted in one transaction each,
+// and the last page by the store with DeleteFolderChildren.
+func (f *Filer) doDeleteFolder(d *entryDeletion, folder *Entry) error {
+
+	lastFileName := ""
+	for {
+		children, err := listEntries(f.store, folder.FullPath, lastFileName, false, deleteBatchSize)
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", folder.FullPath, err)
+		}
+		for _, sub := range children {
+			lastFileName = sub.Name()
+			if sub.IsDirectory() {
+				if err = f.doDeleteFolder(d, sub); err != nil {
+					return err
+				}
+			}
+		}
+		if len(children) < deleteBatchSize {
+			return f.doDeleteFolderChildren(d, folder, children)
+		}
+		for _, child := range children {
+			if err = d.deleteEntry(child); err != nil {
+				return err
+			}
+		}
+		if err = d.commit(); err != nil {
+			return err
+		}
+	}
+}
+
+// doDeleteFolderChildren lets the store delete the last children of the folder at once
+func (f *Filer) doDeleteFolderChildren(d *entryDeletion, folder *Entry, children []*Entry) error {
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	if err := f.store.DeleteFolderChildren(folder.FullPath); err != nil {
+		return fmt.Errorf("delete folder children %s: %v", folder.FullPath, err)
+	}
+
+	// the shared content of the hard links are not under the folder
+	for i, child := range children {
+		if len(child.HardLinkId) == 0 {
+			continue
+		}
+		d.lockHardLinks()
+		isLastLink, err := unlinkHardLink(f.store.actualStore, child, d.hardLinks)
+		if err != nil {
+			return fmt.Errorf("unlink %s: %v", child.FullPath, err)
+		}
+		if !isLastLink {
+			children[i] = withoutChunks(child)
+		}
+	}
+
+	d.onCommitted(children)
+
+	return nil
+}
+
+// doDeleteEntryMeta deletes the entry and its descendants, children before parents.
+func (f *Filer) doDeleteEntryMeta(d *entryDeletion, entry *Entry, isRecursive bool) error {
+
+	p := entry.FullPath
+
+	if entry.IsDirectory() {
 		lastFileName := ""
-		includeLastFile := false
-		for limit > 0 {
-			entries, err := f.ListDirectoryEntries(p, lastFileName, includeLastFile, 1024)
+		for {
+			tx, err := d.transaction()
+			if err != nil {
+				return err
+			}
+			entries, err := listEntries(tx, p, lastFileName, false, 1024)
 			if err != nil {
 				return fmt.Errorf("list folder %s: %v", p, err)
 			}
-			if len(entries) == 0 {
-				break
-			} else {
-				if isRecursive {
-					for _, sub := range entries {
-						lastFileName = sub.Name()
-						f.DeleteEntryMetaAndData(sub.FullPath, isRecursive, shouldDeleteChunks)
-						limit--
-						if limit <= 0 {
-							break
-						}
-					}
-				} else {
-					if len(entries) > 0 {
-						return fmt.Errorf("folder %s is not empty", p)
-					}
-				}
-				f.cacheDelDirectory(string(p))
-				if len(entries) < 1024 {
-					break
+			if len(entries) > 0 && !isRecursive {
+				return fmt.Errorf("folder %s is not empty", p)
+			}
+			for _, sub := range entries {
+				lastFileName = sub.Name()
+				if err = f.doDeleteEntryMeta(d, sub, isRecursive); err != nil {
+					return err
 				}
 			}
+			if len(entries) < 1024 {
+				break
+			}
 		}
 	}
 
-	if shouldDeleteChunks {
-		f.DeleteChunks(entry.Chunks)
-	}
-
 	if p == "/" {
 		return nil
 	}
 	glog.V(3).Infof("deleting entry %v", p)
 
-	f.NotifyUpdateEvent(entry, nil, shouldDeleteChunks)
-
-	return f.store.DeleteEntry(p)
+	return d.deleteEntry(entry)
 }
 
 func (f *Filer) ListDirectoryEntries(p FullPath, startFileName string, inclusive bool, limit int) ([]*Entry, error) {
 	if strings.HasSuffix(string(p), "/") && len(p) > 1 {
 		p = p[0 : len(p)-1]
 	}
-	return f.store.ListDirectoryEntries(p, startFileName, inclusive, limit)
+	return listEntries(f.store, p, startFileName, inclusive, limit)
+}
+
+// StreamListDirectoryEntries calls eachEntryFn on the entries one by one, without holding all of them in memory
+func (f *Filer) StreamListDirectoryEntries(p FullPath, startFileName string, inclusive bool, limit int, prefix string, eachEntryFn ListEachEntryFunc) (lastFileName string, err error) {
+	if strings.HasSuffix(string(p), "/") && len(p) > 1 {
+		p = p[0 : len(p)-1]
+	}
+	return f.store.ListDirectoryEntries(p, startFileName, inclusive, limit, prefix, eachEntryFn)
+}
+
+// listEntries collects one page of the entries, e.g., before changing them in the same transaction
+func listEntries(store interface {
+	ListDirectoryEntries(FullPath, string, bool, int, string, ListEachEntryFunc) (string, error)
+}, p FullPath, startFileName string, inclusive bool, limit int) (entries []*Entry, err error) {
+	_, err = store.ListDirectoryEntries(p, startFileName, inclusive, limit, "", func(entry *Entry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	return entries, err
 }
 
 func (f *Filer) cacheDelDirectory(dirpath string) {
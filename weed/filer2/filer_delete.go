@@ -0,0 +1,122 @@
+package filer2
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// deleteBatchSize caps the number of entries deleted in one store transaction
+const deleteBatchSize = 1024
+
+// entryDeletion deletes the entries in batches, one store transaction for each batch, so deleting a large tree
+// neither holds one huge transaction nor all the deleted entries in memory. The entries are deleted children
+// before parents, so each committed batch leaves no orphans. A failed deletion only keeps the committed batches.
+// The chunks of a hard link are kept until its last link is deleted.
+type entryDeletion struct {
+	f              *Filer
+	tx             FilerStoreTransaction
+	batch          []*Entry
+	hardLinks      map[string]*Entry
+	hardLinkLocked bool
+	onCommitted    func(deletedEntries []*Entry)
+}
+
+func newEntryDeletion(f *Filer, onCommitted func(deletedEntries []*Entry)) *entryDeletion {
+	return &entryDeletion{
+		f:           f,
+		hardLinks:   make(map[string]*Entry),
+		onCommitted: onCommitted,
+	}
+}
+
+// transaction returns the transaction of the current batch
+func (d *entryDeletion) transaction() (FilerStoreTransaction, error) {
+	if d.tx == nil {
+		tx, err := d.f.store.BeginTransaction()
+		if err != nil {
+			return nil, fmt.Errorf("begin transaction: %v", err)
+		}
+		d.tx = tx
+	}
+	return d.tx, nil
+}
+
+func (d *entryDeletion) deleteEntry(entry *Entry) error {
+
+	tx, err := d.transaction()
+	if err != nil {
+		return err
+	}
+
+	if err = tx.DeleteEntry(entry.FullPath); err != nil {
+		return fmt.Errorf("delete %s: %v", entry.FullPath, err)
+	}
+	if len(entry.HardLinkId) > 0 {
+		d.lockHardLinks()
+		isLastLink, unlinkErr := unlinkHardLink(tx, entry, d.hardLinks)
+		if unlinkErr != nil {
+			return fmt.Errorf("unlink %s: %v", entry.FullPath, unlinkErr)
+		}
+		if !isLastLink {
+			entry = withoutChunks(entry)
+		}
+	}
+	d.batch = append(d.batch, entry)
+
+	if len(d.batch) >= deleteBatchSize {
+		return d.commit()
+	}
+	return nil
+}
+
+// commit commits the current batch, and passes its deleted entries to onCommitted
+func (d *entryDeletion) commit() error {
+
+	if d.tx == nil {
+		return nil
+	}
+
+	tx, batch := d.tx, d.batch
+	d.tx, d.batch = nil, nil
+	if err := tx.Commit(); err != nil {
+		// the cached hard links may have the changes not committed
+		d.hardLinks = make(map[string]*Entry)
+		return fmt.Errorf("commit transaction: %v", err)
+	}
+
+	if len(batch) > 0 {
+		d.onCommitted(batch)
+	}
+	return nil
+}
+
+// rollback drops the current batch, if not committed yet
+func (d *entryDeletion) rollback() {
+
+	if d.tx == nil {
+		return
+	}
+
+	if err := d.tx.Rollback(); err != nil {
+		glog.Errorf("rollback transaction: %v", err)
+	}
+	d.tx, d.batch = nil, nil
+	d.hardLinks = make(map[string]*Entry)
+}
+
+// lockHardLinks takes the hardLinkLock before unlinking the first hard link, and keeps it until unlockHardLinks,
+// so only the deletions with hard links wait for the other changes of the hard links
+func (d *entryDeletion) lockHardLinks() {
+	if !d.hardLinkLocked {
+		d.f.hardLinkLock.Lock()
+		d.hardLinkLocked = true
+	}
+}
+
+func (d *entryDeletion) unlockHardLinks() {
+	if d.hardLinkLocked {
+		d.f.hardLinkLock.Unlock()
+		d.hardLinkLocked = false
+	}
+}
@@ -0,0 +1,235 @@
+package filer2
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// HardLinkDirectory keeps one entry for each group of hard links, with their shared attributes,
+// chunks, and the number of links. It is not under "/", so it is never listed.
+// Each hard link itself only keeps the HardLinkId.
+const HardLinkDirectory = "hardlinks"
+
+type HardLinkId []byte
+
+func NewHardLinkId() (HardLinkId, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("generate hard link id: %v", err)
+	}
+	return id, nil
+}
+
+// FullPath locates the entry with the shared content of the hard links
+func (id HardLinkId) FullPath() FullPath {
+	return FullPath(HardLinkDirectory + "/" + hex.EncodeToString(id))
+}
+
+// entryStore is either the actual filer store or one transaction of it
+type entryStore interface {
+	InsertEntry(*Entry) error
+	UpdateEntry(*Entry) (err error)
+	FindEntry(FullPath) (entry *Entry, err error)
+	DeleteEntry(FullPath) (err error)
+}
+
+// saveHardLinkEntry inserts or updates the entry. For a hard link, the attributes and the chunks
+// are saved once for all the links, and the link itself only keeps the hard link id.
+// The filer holds the hardLinkLock when saving a link, since the number of links is read and written back.
+func saveHardLinkEntry(store entryStore, entry *Entry, isInsert bool) error {
+
+	if len(entry.HardLinkId) == 0 {
+		if isInsert {
+			return store.InsertEntry(entry)
+		}
+		return store.UpdateEntry(entry)
+	}
+
+	content := &Entry{
+		FullPath:        entry.HardLinkId.FullPath(),
+		Attr:            entry.Attr,
+		Chunks:          entry.Chunks,
+		Extended:        entry.Extended,
+		HardLinkCounter: entry.HardLinkCounter,
+	}
+	existing, err := store.FindEntry(content.FullPath)
+	if err == ErrNotFound {
+		err = store.InsertEntry(content)
+	} else if err == nil {
+		// the number of links is only changed when linking or unlinking
+		content.HardLinkCounter = existing.HardLinkCounter
+		err = store.UpdateEntry(content)
+	}
+	if err != nil {
+		return fmt.Errorf("save hard link %s of %s: %v", content.FullPath, entry.FullPath, err)
+	}
+
+	if isInsert {
+		return store.InsertEntry(linkOnly(entry))
+	}
+	return store.UpdateEntry(linkOnly(entry))
+}
+
+// linkOnly is the hard link as saved in the store, without the shared content
+func linkOnly(entry *Entry) *Entry {
+	return &Entry{
+		FullPath:   entry.FullPath,
+		Attr:       Attr{Mode: entry.Mode},
+		HardLinkId: entry.HardLinkId,
+	}
+}
+
+// resolveHardLink returns a copy of the hard link with the shared content, or the entry itself if not a hard link.
+// A link without the shared content is returned as is, so that it can still be deleted.
+func resolveHardLink(store entryStore, entry *Entry) *Entry {
+
+	if entry == nil || len(entry.HardLinkId) == 0 {
+		return entry
+	}
+
+	content, err := store.FindEntry(entry.HardLinkId.FullPath())
+	if err != nil {
+		glog.Errorf("find hard link %s of %s: %v", entry.HardLinkId.FullPath(), entry.FullPath, err)
+		return entry
+	}
+
+	return &Entry{
+		FullPath:        entry.FullPath,
+		Attr:            content.Attr,
+		Chunks:          content.Chunks,
+		Extended:        content.Extended,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: content.HardLinkCounter,
+	}
+}
+
+func resolveHardLinks(store entryStore, eachEntryFn ListEachEntryFunc) ListEachEntryFunc {
+	return func(entry *Entry) bool {
+		return eachEntryFn(resolveHardLink(store, entry))
+	}
+}
+
+// unlinkHardLink decreases the number of the links, and removes the shared content with the last link.
+// The contents already changed by the same operation are kept in hardLinks,
+// since the reads in a transaction may not see its own changes.
+func unlinkHardLink(store entryStore, entry *Entry, hardLinks map[string]*Entry) (isLastLink bool, err error) {
+
+	key := string(entry.HardLinkId)
+	content, found := hardLinks[key]
+	if !found {
+		existing, findErr := store.FindEntry(entry.HardLinkId.FullPath())
+		if findErr == ErrNotFound {
+			return true, nil
+		}
+		if findErr != nil {
+			return false, fmt.Errorf("find hard link %s: %v", entry.HardLinkId.FullPath(), findErr)
+		}
+		copied := *existing
+		content = &copied
+		hardLinks[key] = content
+	}
+
+	content.HardLinkCounter--
+	if content.HardLinkCounter > 0 {
+		return false, store.UpdateEntry(content)
+	}
+	return true, store.DeleteEntry(content.FullPath)
+}
+
+// withoutChunks is the deleted hard link whose chunks are still used by the other links
+func withoutChunks(entry *Entry) *Entry {
+	return &Entry{
+		FullPath:        entry.FullPath,
+		Attr:            entry.Attr,
+		Extended:        entry.Extended,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
+	}
+}
+
+// CreateHardLink adds newPath as another hard link of the file at oldPath
+func (f *Filer) CreateHardLink(oldPath, newPath FullPath) (*Entry, error) {
+
+	f.renameLock.RLock()
+	defer f.renameLock.RUnlock()
+	f.hardLinkLock.Lock()
+	defer f.hardLinkLock.Unlock()
+
+	oldEntry, err := f.FindEntry(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("link %s: %v", oldPath, err)
+	}
+	if oldEntry.IsDirectory() {
+		return nil, fmt.Errorf("can not hard link directory %s", oldPath)
+	}
+	if _, err = f.FindEntry(newPath); err == nil {
+		return nil, fmt.Errorf("link %s: %s already exists", oldPath, newPath)
+	} else if err != ErrNotFound {
+		return nil, fmt.Errorf("link %s to %s: %v", oldPath, newPath, err)
+	}
+
+	isNewHardLink := len(oldEntry.HardLinkId) == 0
+	if isNewHardLink {
+		if oldEntry.HardLinkId, err = NewHardLinkId(); err != nil {
+			return nil, err
+		}
+		oldEntry.HardLinkCounter = 1
+	}
+	oldEntry.HardLinkCounter++
+
+	newEntry := &Entry{
+		FullPath:        newPath,
+		Attr:            oldEntry.Attr,
+		Chunks:          oldEntry.Chunks,
+		Extended:        oldEntry.Extended,
+		HardLinkId:      oldEntry.HardLinkId,
+		HardLinkCounter: oldEntry.HardLinkCounter,
+	}
+
+	missingDirs, err := f.findMissingParentDirectories(newEntry)
+	if err != nil {
+		return nil, fmt.Errorf("link %s to %s: %v", oldPath, newPath, err)
+	}
+
+	err = f.withTransaction(func(tx FilerStoreTransaction) error {
+		var mkdirErr error
+		if missingDirs, mkdirErr = f.createDirectories(tx, missingDirs); mkdirErr != nil {
+			return mkdirErr
+		}
+		// write the links and the shared content directly, to change the number of links
+		rawTx := tx.(*hardLinkTransaction).tx
+		content := &Entry{
+			FullPath:        oldEntry.HardLinkId.FullPath(),
+			Attr:            oldEntry.Attr,
+			Chunks:          oldEntry.Chunks,
+			Extended:        oldEntry.Extended,
+			HardLinkCounter: oldEntry.HardLinkCounter,
+		}
+		if isNewHardLink {
+			if err := rawTx.InsertEntry(content); err != nil {
+				return fmt.Errorf("insert hard link %s: %v", content.FullPath, err)
+			}
+			// the existing file becomes the first link
+			if err := rawTx.UpdateEntry(linkOnly(oldEntry)); err != nil {
+				return fmt.Errorf("update %s: %v", oldPath, err)
+			}
+		} else if err := rawTx.UpdateEntry(content); err != nil {
+			return fmt.Errorf("update hard link %s: %v", content.FullPath, err)
+		}
+		if err := rawTx.InsertEntry(linkOnly(newEntry)); err != nil {
+			return fmt.Errorf("insert %s: %v", newPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("link %s to %s: %v", oldPath, newPath, err)
+	}
+
+	f.onDirectoriesCreated(missingDirs)
+	f.NotifyUpdateEvent(nil, newEntry, false)
+
+	return newEntry, nil
+}
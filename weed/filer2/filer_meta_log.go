@@ -0,0 +1,493 @@
+package filer2
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	metaLogSegmentSuffix   = ".log"
+	metaLogMaxSegmentSize  = 64 * 1024 * 1024
+	metaLogRetention       = 7 * 24 * time.Hour
+	metaLogRecentEventSize = 1024
+	metaLogExpiredFileName = "expired"
+)
+
+// appending one batch to a segment, and flushing it, replaced in the tests to count the batches and the fsyncs
+var (
+	writeMetaLogBatch = func(file *os.File, b []byte) (int, error) {
+		return file.Write(b)
+	}
+	syncMetaLogFile = func(file *os.File) error {
+		return file.Sync()
+	}
+)
+
+// ErrMetaLogExpired is returned to the subscribers whose events are partly removed by the retention
+var ErrMetaLogExpired = errors.New("meta log: the events since the subscribed time are removed by the retention")
+
+// MetaLog persists the metadata change events into segment files under one local directory.
+// Each segment is named by the timestamp of its first event, and holds the events as
+// 4-byte big endian length prefixed SubscribeMetadataResponse messages, ordered by TsNs.
+// The latest events are also kept in memory, so the live subscribers do not read the files.
+// Each event is fsynced before AppendEvent returns, together with the events appended concurrently.
+type MetaLog struct {
+	sync.Mutex
+	dir           string
+	segments      []int64 // the start timestamp of each segment, in ascending order
+	expiredTsNs   int64   // the events before it may be removed by the retention, persisted in the "expired" file
+	lastTsNs      int64   // the last queued event
+	pendingWrites []*metaLogWrite
+	syncedTsNs    int64 // the events up to it are persisted, and sent to the subscribers
+	recentEvents  []*filer_pb.SubscribeMetadataResponse
+	appendedChan  chan struct{} // closed and renewed whenever new events are persisted
+
+	// held while appending the queued events to the segment file, taken before the MetaLog lock
+	writeLock sync.Mutex
+	file      *os.File
+	fileSize  int64
+}
+
+// metaLogWrite is one event queued for appending
+type metaLogWrite struct {
+	event *filer_pb.SubscribeMetadataResponse
+	data  []byte
+
+	// the results, set while holding the writeLock
+	done bool
+	err  error
+}
+
+func NewMetaLog(dir string) (*MetaLog, error) {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create meta log dir %s: %v", dir, err)
+	}
+
+	m := &MetaLog{
+		dir:          dir,
+		appendedChan: make(chan struct{}),
+	}
+
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read meta log dir %s: %v", dir, err)
+	}
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if !strings.HasSuffix(name, metaLogSegmentSuffix) {
+			continue
+		}
+		startTsNs, parseErr := strconv.ParseInt(strings.TrimSuffix(name, metaLogSegmentSuffix), 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		m.segments = append(m.segments, startTsNs)
+	}
+	sort.Slice(m.segments, func(i, j int) bool { return m.segments[i] < m.segments[j] })
+
+	if data, readErr := ioutil.ReadFile(filepath.Join(dir, metaLogExpiredFileName)); readErr == nil {
+		if m.expiredTsNs, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err != nil {
+			return nil, fmt.Errorf("parse meta log expired time: %v", err)
+		}
+	} else if !os.IsNotExist(readErr) {
+		return nil, fmt.Errorf("read meta log expired time: %v", readErr)
+	}
+
+	if len(m.segments) > 0 {
+		if err = m.openLastSegment(); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// openLastSegment opens the last segment for appending, and drops the partially written event at the end, if any
+func (m *MetaLog) openLastSegment() error {
+
+	startTsNs := m.segments[len(m.segments)-1]
+	file, err := os.OpenFile(m.segmentFileName(startTsNs), os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("open meta log segment: %v", err)
+	}
+
+	var validSize int64
+	readErr := readMetaLogEvents(file, func(event *filer_pb.SubscribeMetadataResponse, endOffset int64) error {
+		m.lastTsNs, validSize = event.TsNs, endOffset
+		return nil
+	})
+	if readErr != nil && readErr != io.ErrUnexpectedEOF {
+		file.Close()
+		return fmt.Errorf("read meta log segment %s: %v", file.Name(), readErr)
+	}
+
+	if err = file.Truncate(validSize); err != nil {
+		file.Close()
+		return fmt.Errorf("truncate meta log segment %s: %v", file.Name(), err)
+	}
+	if _, err = file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return fmt.Errorf("seek meta log segment %s: %v", file.Name(), err)
+	}
+
+	m.file, m.fileSize = file, validSize
+	m.syncedTsNs = m.lastTsNs
+
+	return nil
+}
+
+func (m *MetaLog) segmentFileName(startTsNs int64) string {
+	return filepath.Join(m.dir, fmt.Sprintf("%019d%s", startTsNs, metaLogSegmentSuffix))
+}
+
+// AppendEvent records one metadata change under the directory, and returns its timestamp
+func (m *MetaLog) AppendEvent(directory string, eventNotification *filer_pb.EventNotification) (tsNs int64, err error) {
+
+	write, err := m.queueEvent(directory, eventNotification)
+	if err != nil {
+		return 0, err
+	}
+
+	m.commitWrite(write)
+	if write.err != nil {
+		return 0, write.err
+	}
+
+	return write.event.TsNs, nil
+}
+
+// queueEvent timestamps the event, and queues it for appending
+func (m *MetaLog) queueEvent(directory string, eventNotification *filer_pb.EventNotification) (*metaLogWrite, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	// the timestamps are unique, so that the subscribers can resume right after the last received event
+	tsNs := time.Now().UnixNano()
+	if tsNs <= m.lastTsNs {
+		tsNs = m.lastTsNs + 1
+	}
+
+	event := &filer_pb.SubscribeMetadataResponse{
+		Directory:         directory,
+		EventNotification: eventNotification,
+		TsNs:              tsNs,
+	}
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal meta log event: %v", err)
+	}
+	m.lastTsNs = tsNs
+
+	write := &metaLogWrite{event: event, data: data}
+	m.pendingWrites = append(m.pendingWrites, write)
+
+	return write, nil
+}
+
+/*
+ * commitWrite appends the queued events to the segment files, until the write is done.
+ *
+ * This is a group commit. The events queue up while the writeLock is held, and whoever gets the lock next
+ * appends all the queued events with one write and one fsync, for each segment they go to.
+ * The other writers then find their events done, once they get the lock.
+ */
+func (m *MetaLog) commitWrite(write *metaLogWrite) {
+
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+	if write.done {
+		return
+	}
+
+	m.Lock()
+	writes := m.pendingWrites
+	m.pendingWrites = nil
+	m.Unlock()
+
+	for len(writes) > 0 {
+		count := m.appendWrites(writes)
+		writes = writes[count:]
+	}
+}
+
+// appendWrites appends the leading events to the last segment, and returns the number of events done.
+// It requires holding the writeLock.
+func (m *MetaLog) appendWrites(writes []*metaLogWrite) (count int) {
+
+	if m.file == nil || m.fileSize >= metaLogMaxSegmentSize {
+		m.Lock()
+		err := m.rotate(writes[0].event.TsNs)
+		m.Unlock()
+		if err != nil {
+			for _, write := range writes {
+				write.done, write.err = true, err
+			}
+			return len(writes)
+		}
+	}
+
+	var buf bytes.Buffer
+	sizeBuf := make([]byte, 4)
+	for _, write := range writes {
+		if count > 0 && m.fileSize+int64(buf.Len()) >= metaLogMaxSegmentSize {
+			break
+		}
+		binary.BigEndian.PutUint32(sizeBuf, uint32(len(write.data)))
+		buf.Write(sizeBuf)
+		buf.Write(write.data)
+		count++
+	}
+	appended := writes[:count]
+
+	_, err := writeMetaLogBatch(m.file, buf.Bytes())
+	if err == nil {
+		err = syncMetaLogFile(m.file)
+	}
+	if err != nil {
+		// start a new segment next time, instead of appending after partially written events,
+		// and do not reuse the timestamps, which may already name the current segment
+		m.file.Close()
+		m.file = nil
+		for _, write := range appended {
+			write.done, write.err = true, fmt.Errorf("write meta log event: %v", err)
+		}
+		return
+	}
+	m.fileSize += int64(buf.Len())
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, write := range appended {
+		write.done = true
+		m.recentEvents = append(m.recentEvents, write.event)
+	}
+	if len(m.recentEvents) > metaLogRecentEventSize {
+		m.recentEvents = m.recentEvents[len(m.recentEvents)-metaLogRecentEventSize:]
+	}
+	m.syncedTsNs = appended[len(appended)-1].event.TsNs
+
+	close(m.appendedChan)
+	m.appendedChan = make(chan struct{})
+
+	return
+}
+
+// rotate starts a new segment, and removes the segments that only have events older than the retention.
+// It requires holding the writeLock and the MetaLog lock.
+func (m *MetaLog) rotate(startTsNs int64) error {
+
+	if m.file != nil {
+		m.file.Close()
+		m.file = nil
+	}
+
+	file, err := os.OpenFile(m.segmentFileName(startTsNs), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("create meta log segment: %v", err)
+	}
+	m.file, m.fileSize = file, 0
+	m.segments = append(m.segments, startTsNs)
+	if err = syncDir(m.dir); err != nil {
+		glog.V(0).Infof("sync meta log dir %s: %v", m.dir, err)
+	}
+
+	// a segment only has expired events if the next segment started before the expiry
+	expireTsNs := startTsNs - int64(metaLogRetention)
+	expired := 0
+	for expired+1 < len(m.segments) && m.segments[expired+1] < expireTsNs {
+		expired++
+	}
+	if expired == 0 {
+		return nil
+	}
+
+	// persist the expiry before removing the segments, so the subscribers never miss the removed events silently
+	if err = m.saveExpiredTsNs(m.segments[expired]); err != nil {
+		glog.V(0).Infof("keep the expired meta log segments: %v", err)
+		return nil
+	}
+	for _, expiredStartTsNs := range m.segments[:expired] {
+		if err = os.Remove(m.segmentFileName(expiredStartTsNs)); err != nil {
+			glog.V(0).Infof("remove expired meta log segment: %v", err)
+		}
+	}
+	m.segments = m.segments[expired:]
+
+	return nil
+}
+
+func (m *MetaLog) saveExpiredTsNs(expiredTsNs int64) error {
+	fileName := filepath.Join(m.dir, metaLogExpiredFileName)
+	if err := ioutil.WriteFile(fileName+".tmp", []byte(strconv.FormatInt(expiredTsNs, 10)), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(fileName+".tmp", fileName); err != nil {
+		return err
+	}
+	m.expiredTsNs = expiredTsNs
+	return syncDir(m.dir)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// Subscribe calls eachEventFn for every event after sinceNs, first the persisted ones and then
+// the new ones as they are appended, until the context is done or eachEventFn returns an error.
+// It returns ErrMetaLogExpired if the events after sinceNs are not all kept any more.
+func (m *MetaLog) Subscribe(ctx context.Context, sinceNs int64, eachEventFn func(event *filer_pb.SubscribeMetadataResponse) error) error {
+
+	for {
+
+		m.Lock()
+		lastTsNs, appendedChan := m.syncedTsNs, m.appendedChan
+		var events []*filer_pb.SubscribeMetadataResponse
+		inMemory := len(m.recentEvents) > 0 && m.recentEvents[0].TsNs <= sinceNs+1
+		if inMemory {
+			i := sort.Search(len(m.recentEvents), func(i int) bool { return m.recentEvents[i].TsNs > sinceNs })
+			events = m.recentEvents[i:]
+		}
+		m.Unlock()
+
+		if lastTsNs > sinceNs {
+			if !inMemory {
+				var err error
+				if sinceNs, err = m.readPersistedEvents(sinceNs, lastTsNs, eachEventFn); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, event := range events {
+				if err := eachEventFn(event); err != nil {
+					return err
+				}
+			}
+			sinceNs = lastTsNs
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-appendedChan:
+		}
+	}
+}
+
+// readPersistedEvents reads the events in (sinceNs, untilNs] from the segment files,
+// or returns ErrMetaLogExpired if some of them are already removed by the retention
+func (m *MetaLog) readPersistedEvents(sinceNs, untilNs int64, eachEventFn func(event *filer_pb.SubscribeMetadataResponse) error) (lastTsNs int64, err error) {
+
+	m.Lock()
+	segments := make([]int64, len(m.segments))
+	copy(segments, m.segments)
+	expiredTsNs := m.expiredTsNs
+	m.Unlock()
+
+	// the events in (sinceNs, expiredTsNs) may be removed
+	if sinceNs+1 < expiredTsNs {
+		return sinceNs, ErrMetaLogExpired
+	}
+
+	lastTsNs = sinceNs
+	for i, startTsNs := range segments {
+		if i+1 < len(segments) && segments[i+1] <= sinceNs {
+			continue
+		}
+		if startTsNs > untilNs {
+			break
+		}
+		file, openErr := os.Open(m.segmentFileName(startTsNs))
+		if openErr != nil {
+			if os.IsNotExist(openErr) {
+				// removed by the retention while reading
+				return lastTsNs, ErrMetaLogExpired
+			}
+			return lastTsNs, fmt.Errorf("open meta log segment: %v", openErr)
+		}
+		err = readMetaLogEvents(file, func(event *filer_pb.SubscribeMetadataResponse, endOffset int64) error {
+			if event.TsNs <= lastTsNs || event.TsNs > untilNs {
+				return nil
+			}
+			if fnErr := eachEventFn(event); fnErr != nil {
+				return fnErr
+			}
+			lastTsNs = event.TsNs
+			return nil
+		})
+		file.Close()
+		if err == io.ErrUnexpectedEOF {
+			// the last event of the segment is being written
+			err = nil
+		}
+		if err != nil {
+			return lastTsNs, err
+		}
+	}
+
+	// the events up to untilNs are all persisted
+	return untilNs, nil
+}
+
+func readMetaLogEvents(r io.Reader, eachEventFn func(event *filer_pb.SubscribeMetadataResponse, endOffset int64) error) error {
+
+	var offset int64
+	sizeBuf := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, sizeBuf); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		data := make([]byte, binary.BigEndian.Uint32(sizeBuf))
+		if _, err := io.ReadFull(r, data); err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		offset += int64(4 + len(data))
+
+		event := &filer_pb.SubscribeMetadataResponse{}
+		if err := proto.Unmarshal(data, event); err != nil {
+			return fmt.Errorf("unmarshal meta log event: %v", err)
+		}
+		if err := eachEventFn(event, offset); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *MetaLog) Shutdown() {
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+	if m.file != nil {
+		m.file.Close()
+		m.file = nil
+	}
+}
@@ -0,0 +1,311 @@
+package filer2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestMetaLogSubscribe(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "filer_meta_log")
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("new meta log: %v", err)
+	}
+
+	var tsNsList []int64
+	for i := 0; i < 3; i++ {
+		tsNs, appendErr := metaLog.AppendEvent("/dir", &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: fmt.Sprintf("file%d", i)},
+		})
+		if appendErr != nil {
+			t.Fatalf("append event: %v", appendErr)
+		}
+		tsNsList = append(tsNsList, tsNs)
+	}
+	metaLog.Shutdown()
+
+	// reopen, so that the events can only be read from the segment file
+	metaLog, err = NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("reopen meta log: %v", err)
+	}
+	defer metaLog.Shutdown()
+
+	tsNs, err := metaLog.AppendEvent("/dir", &filer_pb.EventNotification{
+		NewEntry: &filer_pb.Entry{Name: "file3"},
+	})
+	if err != nil {
+		t.Fatalf("append event after reopen: %v", err)
+	}
+	if tsNs <= tsNsList[2] {
+		t.Fatalf("timestamp %d after reopen is not after %d", tsNs, tsNsList[2])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var names []string
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		metaLog.AppendEvent("/dir", &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: "file4"},
+		})
+	}()
+	err = metaLog.Subscribe(ctx, tsNsList[0], func(event *filer_pb.SubscribeMetadataResponse) error {
+		names = append(names, event.EventNotification.NewEntry.Name)
+		if len(names) == 4 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	expected := []string{"file1", "file2", "file3", "file4"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("subscribed events %v, expected %v", names, expected)
+	}
+}
+
+func TestMetaLogExpired(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "filer_meta_log")
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("new meta log: %v", err)
+	}
+
+	appendEvent := func(name string) int64 {
+		tsNs, appendErr := metaLog.AppendEvent("/dir", &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: name},
+		})
+		if appendErr != nil {
+			t.Fatalf("append event %s: %v", name, appendErr)
+		}
+		return tsNs
+	}
+
+	firstTsNs := appendEvent("file0")
+
+	// start two more segments, the second one beyond the retention of the first segment
+	secondStartTsNs := firstTsNs + 1000
+	thirdStartTsNs := secondStartTsNs + int64(metaLogRetention) + 1
+	for _, startTsNs := range []int64{secondStartTsNs, thirdStartTsNs} {
+		metaLog.Lock()
+		if err = metaLog.rotate(startTsNs); err != nil {
+			metaLog.Unlock()
+			t.Fatalf("rotate: %v", err)
+		}
+		metaLog.lastTsNs = startTsNs
+		metaLog.Unlock()
+	}
+	appendEvent("file1")
+	metaLog.Shutdown()
+
+	if _, err = os.Stat(metaLog.segmentFileName(firstTsNs)); !os.IsNotExist(err) {
+		t.Fatalf("expired segment is not removed: %v", err)
+	}
+
+	// reopen, so that the events can only be read from the segment files
+	metaLog, err = NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("reopen meta log: %v", err)
+	}
+	defer metaLog.Shutdown()
+
+	subscribe := func(sinceNs int64) (names []string, err error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err = metaLog.Subscribe(ctx, sinceNs, func(event *filer_pb.SubscribeMetadataResponse) error {
+			names = append(names, event.EventNotification.NewEntry.Name)
+			return nil
+		})
+		return
+	}
+
+	if _, err = subscribe(firstTsNs - 1); err != ErrMetaLogExpired {
+		t.Errorf("subscribe to the removed events: %v", err)
+	}
+	names, err := subscribe(secondStartTsNs - 1)
+	if err != nil || fmt.Sprint(names) != "[file1]" {
+		t.Errorf("subscribe to the kept events: %v %v", names, err)
+	}
+}
+
+func TestMetaLogGroupCommit(t *testing.T) {
+
+	var batchCount, syncCount int64
+	defer func(write func(*os.File, []byte) (int, error), flush func(*os.File) error) {
+		writeMetaLogBatch, syncMetaLogFile = write, flush
+	}(writeMetaLogBatch, syncMetaLogFile)
+	writeMetaLogBatch = func(file *os.File, b []byte) (int, error) {
+		atomic.AddInt64(&batchCount, 1)
+		return file.Write(b)
+	}
+	syncMetaLogFile = func(file *os.File) error {
+		atomic.AddInt64(&syncCount, 1)
+		// a slow disk, so the concurrent events queue up meanwhile
+		time.Sleep(time.Millisecond)
+		return file.Sync()
+	}
+
+	dir, _ := ioutil.TempDir("", "filer_meta_log")
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("new meta log: %v", err)
+	}
+	defer metaLog.Shutdown()
+
+	writerCount, eventCount := 8, 20
+	appendConcurrently(metaLog, writerCount, eventCount, nil, func(name string, err error) {
+		t.Errorf("append event %s: %v", name, err)
+	})
+
+	batches, syncs := atomic.LoadInt64(&batchCount), atomic.LoadInt64(&syncCount)
+	if syncs != batches || batches == 0 || batches >= int64(writerCount*eventCount) {
+		t.Errorf("%d events in %d batches with %d syncs, expected fewer batches each with one sync", writerCount*eventCount, batches, syncs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var lastTsNs int64
+	count := 0
+	err = metaLog.Subscribe(ctx, 0, func(event *filer_pb.SubscribeMetadataResponse) error {
+		if event.TsNs <= lastTsNs {
+			return fmt.Errorf("event %d after %d", event.TsNs, lastTsNs)
+		}
+		lastTsNs = event.TsNs
+		count++
+		return nil
+	})
+	if err != nil || count != writerCount*eventCount {
+		t.Errorf("subscribed %d events: %v", count, err)
+	}
+}
+
+func TestMetaLogSyncError(t *testing.T) {
+
+	defer func(flush func(*os.File) error) {
+		syncMetaLogFile = flush
+	}(syncMetaLogFile)
+	syncMetaLogFile = func(file *os.File) error {
+		return errors.New("disk failure")
+	}
+
+	dir, _ := ioutil.TempDir("", "filer_meta_log")
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("new meta log: %v", err)
+	}
+	defer metaLog.Shutdown()
+
+	appendEvent := func(name string) (int64, error) {
+		return metaLog.AppendEvent("/dir", &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: name},
+		})
+	}
+
+	if _, err = appendEvent("file0"); err == nil {
+		t.Fatalf("append event with a failed sync succeeded")
+	}
+
+	syncMetaLogFile = func(file *os.File) error {
+		return file.Sync()
+	}
+	tsNs, err := appendEvent("file1")
+	if err != nil {
+		t.Fatalf("append event after a failed sync: %v", err)
+	}
+	if len(metaLog.segments) != 2 || metaLog.segments[1] != tsNs {
+		t.Errorf("segments %v, expected a new segment starting at %d", metaLog.segments, tsNs)
+	}
+}
+
+/*
+ * BenchmarkMetaLogAppendEvent appends the events from concurrent writers.
+ *
+ * "serialized" fsyncs each event under one lock, as AppendEvent did before the group commit.
+ * "group" is how the events are appended now.
+ *
+ *   go test -run=XXX -bench=MetaLogAppendEvent -cpu=8 ./weed/filer2
+ */
+func BenchmarkMetaLogAppendEvent(b *testing.B) {
+	for _, writerCount := range []int{1, 16} {
+		b.Run(fmt.Sprintf("serialized-%d", writerCount), func(b *testing.B) {
+			benchmarkMetaLogAppendEvent(b, writerCount, true)
+		})
+		b.Run(fmt.Sprintf("group-%d", writerCount), func(b *testing.B) {
+			benchmarkMetaLogAppendEvent(b, writerCount, false)
+		})
+	}
+}
+
+func benchmarkMetaLogAppendEvent(b *testing.B, writerCount int, serialized bool) {
+
+	dir, _ := ioutil.TempDir("", "filer_meta_log")
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir)
+	if err != nil {
+		b.Fatalf("new meta log: %v", err)
+	}
+	defer metaLog.Shutdown()
+
+	// the old AppendEvent held its lock during the fsync
+	var lock sync.Locker
+	if serialized {
+		lock = &sync.Mutex{}
+	}
+
+	b.ResetTimer()
+	appendConcurrently(metaLog, writerCount, (b.N+writerCount-1)/writerCount, lock, func(name string, err error) {
+		b.Errorf("append event %s: %v", name, err)
+	})
+}
+
+// appendConcurrently appends the events from each writer, holding the lock for each event if not nil
+func appendConcurrently(metaLog *MetaLog, writerCount, eventCount int, lock sync.Locker, onError func(name string, err error)) {
+	var wg sync.WaitGroup
+	for w := 0; w < writerCount; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < eventCount; i++ {
+				name := fmt.Sprintf("file%d_%d", w, i)
+				if lock != nil {
+					lock.Lock()
+				}
+				_, err := metaLog.AppendEvent("/dir", &filer_pb.EventNotification{
+					NewEntry: &filer_pb.Entry{Name: name},
+				})
+				if lock != nil {
+					lock.Unlock()
+				}
+				if err != nil {
+					onError(name, err)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
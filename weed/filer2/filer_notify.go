@@ -16,18 +16,28 @@ func (f *Filer) NotifyUpdateEvent(oldEntry, newEntry *Entry, deleteChunks bool)
 		return
 	}
 
+	if notification.Queue == nil && f.MetaLog == nil {
+		return
+	}
+
+	eventNotification := &filer_pb.EventNotification{
+		OldEntry:     oldEntry.ToProtoEntry(),
+		NewEntry:     newEntry.ToProtoEntry(),
+		DeleteChunks: deleteChunks,
+	}
+
 	if notification.Queue != nil {
 
 		glog.V(3).Infof("notifying entry update %v", key)
 
-		notification.Queue.SendMessage(
-			key,
-			&filer_pb.EventNotification{
-				OldEntry:     oldEntry.ToProtoEntry(),
-				NewEntry:     newEntry.ToProtoEntry(),
-				DeleteChunks: deleteChunks,
-			},
-		)
+		notification.Queue.SendMessage(key, eventNotification)
+
+	}
 
+	if f.MetaLog != nil {
+		dir, _ := FullPath(key).DirAndName()
+		if _, err := f.MetaLog.AppendEvent(dir, eventNotification); err != nil {
+			glog.Errorf("log entry update %v: %v", key, err)
+		}
 	}
 }
@@ -0,0 +1,178 @@
+package filer2
+
+import (
+	"fmt"
+	"strings"
+)
+
+type renameMove struct {
+	oldEntry *Entry
+	newEntry *Entry
+}
+
+// AtomicRenameEntry renames a file, or a directory together with all its descendants.
+// An existing file, or an empty directory, at the new path is replaced.
+// All the store changes are in one transaction, so the entries are either
+// all under the old path or all under the new path.
+func (f *Filer) AtomicRenameEntry(oldPath, newPath FullPath) error {
+
+	if oldPath == "/" || newPath == "/" {
+		return fmt.Errorf("can not rename the root directory")
+	}
+	if oldPath == newPath {
+		return nil
+	}
+	if strings.HasPrefix(string(newPath), string(oldPath)+"/") {
+		return fmt.Errorf("can not move %s into its sub directory %s", oldPath, newPath)
+	}
+
+	f.renameLock.Lock()
+	defer f.renameLock.Unlock()
+	f.hardLinkLock.Lock()
+	defer f.hardLinkLock.Unlock()
+
+	oldEntry, err := f.FindEntry(oldPath)
+	if err != nil {
+		return fmt.Errorf("rename %s: %v", oldPath, err)
+	}
+
+	targetEntry, err := f.FindEntry(newPath)
+	if err != nil && err != ErrNotFound {
+		return fmt.Errorf("rename %s to %s: %v", oldPath, newPath, err)
+	}
+	if targetEntry != nil {
+		if err = f.checkRenameTarget(oldEntry, targetEntry); err != nil {
+			return err
+		}
+		if len(oldEntry.HardLinkId) > 0 && string(oldEntry.HardLinkId) == string(targetEntry.HardLinkId) {
+			// both are links of the same file
+			return nil
+		}
+	}
+
+	missingDirs, err := f.findMissingParentDirectories(&Entry{FullPath: newPath, Attr: oldEntry.Attr})
+	if err != nil {
+		return fmt.Errorf("rename %s to %s: %v", oldPath, newPath, err)
+	}
+
+	var moves []*renameMove
+	if err = f.collectRenameMoves(oldEntry, newPath, &moves); err != nil {
+		return fmt.Errorf("rename %s to %s: %v", oldPath, newPath, err)
+	}
+
+	err = f.withTransaction(func(tx FilerStoreTransaction) error {
+		var mkdirErr error
+		if missingDirs, mkdirErr = f.createDirectories(tx, missingDirs); mkdirErr != nil {
+			return mkdirErr
+		}
+		if targetEntry != nil && len(targetEntry.HardLinkId) > 0 {
+			isLastLink, unlinkErr := unlinkHardLink(tx, targetEntry, make(map[string]*Entry))
+			if unlinkErr != nil {
+				return fmt.Errorf("unlink %s: %v", newPath, unlinkErr)
+			}
+			if !isLastLink {
+				targetEntry = withoutChunks(targetEntry)
+			}
+		}
+		return applyRenameMoves(tx, moves, targetEntry)
+	})
+	if err != nil {
+		return fmt.Errorf("rename %s to %s: %v", oldPath, newPath, err)
+	}
+
+	f.onDirectoriesCreated(missingDirs)
+	if targetEntry != nil {
+		f.NotifyUpdateEvent(targetEntry, nil, true)
+		f.DeleteChunks(targetEntry.Chunks)
+		f.cacheDelDirectory(string(targetEntry.FullPath))
+	}
+	for _, move := range moves {
+		f.NotifyUpdateEvent(move.oldEntry, move.newEntry, false)
+		if move.oldEntry.IsDirectory() {
+			f.cacheDelDirectory(string(move.oldEntry.FullPath))
+		}
+	}
+
+	return nil
+}
+
+func (f *Filer) checkRenameTarget(oldEntry, targetEntry *Entry) error {
+	if oldEntry.IsDirectory() && !targetEntry.IsDirectory() {
+		return fmt.Errorf("existing %s is a file", targetEntry.FullPath)
+	}
+	if !oldEntry.IsDirectory() && targetEntry.IsDirectory() {
+		return fmt.Errorf("existing %s is a directory", targetEntry.FullPath)
+	}
+	if targetEntry.IsDirectory() {
+		entries, err := f.ListDirectoryEntries(targetEntry.FullPath, "", false, 1)
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", targetEntry.FullPath, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("folder %s is not empty", targetEntry.FullPath)
+		}
+	}
+	return nil
+}
+
+// collectRenameMoves lists the entry and all its descendants, parents before children
+func (f *Filer) collectRenameMoves(entry *Entry, newPath FullPath, moves *[]*renameMove) error {
+
+	*moves = append(*moves, &renameMove{
+		oldEntry: entry,
+		newEntry: &Entry{
+			FullPath: newPath,
+			Attr:     entry.Attr,
+			Chunks:   entry.Chunks,
+			Extended: entry.Extended,
+
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
+		},
+	})
+
+	if !entry.IsDirectory() {
+		return nil
+	}
+
+	lastFileName := ""
+	for {
+		entries, err := f.ListDirectoryEntries(entry.FullPath, lastFileName, false, 1024)
+		if err != nil {
+			return fmt.Errorf("list folder %s: %v", entry.FullPath, err)
+		}
+		for _, sub := range entries {
+			lastFileName = sub.Name()
+			if err = f.collectRenameMoves(sub, NewFullPath(string(newPath), sub.Name()), moves); err != nil {
+				return err
+			}
+		}
+		if len(entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+// applyRenameMoves creates the new entries and then deletes the old ones
+func applyRenameMoves(tx FilerStoreTransaction, moves []*renameMove, targetEntry *Entry) error {
+
+	for i, move := range moves {
+		if i == 0 && targetEntry != nil {
+			if err := tx.UpdateEntry(move.newEntry); err != nil {
+				return fmt.Errorf("replace %s: %v", move.newEntry.FullPath, err)
+			}
+			continue
+		}
+		if err := tx.InsertEntry(move.newEntry); err != nil {
+			return fmt.Errorf("insert %s: %v", move.newEntry.FullPath, err)
+		}
+	}
+
+	for i := len(moves) - 1; i >= 0; i-- {
+		if err := tx.DeleteEntry(moves[i].oldEntry.FullPath); err != nil {
+			return fmt.Errorf("delete %s: %v", moves[i].oldEntry.FullPath, err)
+		}
+	}
+
+	return nil
+}
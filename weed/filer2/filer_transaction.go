@@ -0,0 +1,101 @@
+package filer2
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// withTransaction runs fn in one store transaction, which is committed if fn succeeds and rolled back otherwise
+func (f *Filer) withTransaction(fn func(tx FilerStoreTransaction) error) error {
+
+	tx, err := f.store.BeginTransaction()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %v", err)
+	}
+
+	if err = fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			glog.Errorf("rollback transaction: %v", rollbackErr)
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %v", err)
+	}
+
+	return nil
+}
+
+// undoLogTransaction applies the changes to the store right away, and remembers how to revert them.
+// It is only as atomic as the reverting steps are successful, e.g., the filer crashing in between
+// still leaves the partial changes.
+type undoLogTransaction struct {
+	store FilerStore
+	undos []func() error
+}
+
+func newUndoLogTransaction(store FilerStore) *undoLogTransaction {
+	return &undoLogTransaction{store: store}
+}
+
+func (tx *undoLogTransaction) InsertEntry(entry *Entry) error {
+	if err := tx.store.InsertEntry(entry); err != nil {
+		return err
+	}
+	tx.undos = append(tx.undos, func() error { return tx.store.DeleteEntry(entry.FullPath) })
+	return nil
+}
+
+func (tx *undoLogTransaction) UpdateEntry(entry *Entry) error {
+	oldEntry, err := tx.store.FindEntry(entry.FullPath)
+	if err != nil {
+		return fmt.Errorf("find %s before update: %v", entry.FullPath, err)
+	}
+	if err = tx.store.UpdateEntry(entry); err != nil {
+		return err
+	}
+	tx.undos = append(tx.undos, func() error { return tx.store.UpdateEntry(oldEntry) })
+	return nil
+}
+
+func (tx *undoLogTransaction) FindEntry(fullpath FullPath) (*Entry, error) {
+	return tx.store.FindEntry(fullpath)
+}
+
+func (tx *undoLogTransaction) DeleteEntry(fullpath FullPath) error {
+	oldEntry, err := tx.store.FindEntry(fullpath)
+	if err == ErrNotFound {
+		return tx.store.DeleteEntry(fullpath)
+	}
+	if err != nil {
+		return fmt.Errorf("find %s before delete: %v", fullpath, err)
+	}
+	if err = tx.store.DeleteEntry(fullpath); err != nil {
+		return err
+	}
+	tx.undos = append(tx.undos, func() error { return tx.store.InsertEntry(oldEntry) })
+	return nil
+}
+
+func (tx *undoLogTransaction) ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int, prefix string, eachEntryFn ListEachEntryFunc) (string, error) {
+	return tx.store.ListDirectoryEntries(dirPath, startFileName, includeStartFile, limit, prefix, eachEntryFn)
+}
+
+func (tx *undoLogTransaction) Commit() error {
+	tx.undos = nil
+	return nil
+}
+
+func (tx *undoLogTransaction) Rollback() error {
+	var lastErr error
+	for i := len(tx.undos) - 1; i >= 0; i-- {
+		if err := tx.undos[i](); err != nil {
+			glog.Errorf("revert filer store change: %v", err)
+			lastErr = err
+		}
+	}
+	tx.undos = nil
+	return lastErr
+}
@@ -2,6 +2,10 @@ package filer2
 
 import (
 	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/stats"
 	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
@@ -15,7 +19,155 @@ type FilerStore interface {
 	// err == filer2.ErrNotFound if not found
 	FindEntry(FullPath) (entry *Entry, err error)
 	DeleteEntry(FullPath) (err error)
-	ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int) ([]*Entry, error)
+	// ListDirectoryEntries calls eachEntryFn on at most limit entries under dirPath, sorted by the names,
+	// starting from startFileName and with the name prefix, until eachEntryFn returns false.
+	// lastFileName is the name of the last entry passed to eachEntryFn.
+	ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int, prefix string, eachEntryFn ListEachEntryFunc) (lastFileName string, err error)
+}
+
+type ListEachEntryFunc func(entry *Entry) bool
+
+// TransactionalFilerStore is implemented by the stores that can apply a group of changes atomically
+type TransactionalFilerStore interface {
+	FilerStore
+	BeginTransaction() (FilerStoreTransaction, error)
+}
+
+// FolderChildrenDeleter is implemented by the stores that can delete all the descendants of a folder
+// in one operation, instead of deleting the entries one by one. The folder itself is kept.
+// The filer empties the sub folders first, and deletes the full pages of the direct children,
+// so only the last page of the direct children is left.
+type FolderChildrenDeleter interface {
+	DeleteFolderChildren(fullpath FullPath) (err error)
+}
+
+// FilerStoreTransaction applies all the changes made through it on Commit, or none of them on Rollback.
+// Depending on the store, the reads in a transaction may not see its own uncommitted changes.
+type FilerStoreTransaction interface {
+	InsertEntry(*Entry) error
+	UpdateEntry(*Entry) (err error)
+	FindEntry(FullPath) (entry *Entry, err error)
+	DeleteEntry(FullPath) (err error)
+	ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int, prefix string, eachEntryFn ListEachEntryFunc) (lastFileName string, err error)
+	Commit() error
+	Rollback() error
 }
 
 var ErrNotFound = errors.New("filer: no entry is found in filer store")
+
+// FilerStoreWrapper observes the latency of each filer store operation,
+// and keeps the shared content of the hard links apart from the links
+type FilerStoreWrapper struct {
+	actualStore FilerStore
+}
+
+func NewFilerStoreWrapper(store FilerStore) *FilerStoreWrapper {
+	if innerStore, ok := store.(*FilerStoreWrapper); ok {
+		return innerStore
+	}
+	return &FilerStoreWrapper{
+		actualStore: store,
+	}
+}
+
+func (fsw *FilerStoreWrapper) GetName() string {
+	return fsw.actualStore.GetName()
+}
+
+func (fsw *FilerStoreWrapper) Initialize(configuration util.Configuration) error {
+	return fsw.actualStore.Initialize(configuration)
+}
+
+func (fsw *FilerStoreWrapper) InsertEntry(entry *Entry) error {
+	defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "insert", time.Now())
+	return saveHardLinkEntry(fsw.actualStore, entry, true)
+}
+
+func (fsw *FilerStoreWrapper) UpdateEntry(entry *Entry) error {
+	defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "update", time.Now())
+	return saveHardLinkEntry(fsw.actualStore, entry, false)
+}
+
+func (fsw *FilerStoreWrapper) FindEntry(fp FullPath) (entry *Entry, err error) {
+	defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "find", time.Now())
+	entry, err = fsw.actualStore.FindEntry(fp)
+	if err != nil {
+		return nil, err
+	}
+	return resolveHardLink(fsw.actualStore, entry), nil
+}
+
+func (fsw *FilerStoreWrapper) DeleteEntry(fp FullPath) (err error) {
+	defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "delete", time.Now())
+	return fsw.actualStore.DeleteEntry(fp)
+}
+
+func (fsw *FilerStoreWrapper) ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int, prefix string, eachEntryFn ListEachEntryFunc) (string, error) {
+	defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "list", time.Now())
+	return fsw.actualStore.ListDirectoryEntries(dirPath, startFileName, includeStartFile, limit, prefix, resolveHardLinks(fsw.actualStore, eachEntryFn))
+}
+
+func (fsw *FilerStoreWrapper) CanDeleteFolderChildren() bool {
+	_, ok := fsw.actualStore.(FolderChildrenDeleter)
+	return ok
+}
+
+func (fsw *FilerStoreWrapper) DeleteFolderChildren(fp FullPath) (err error) {
+	store, ok := fsw.actualStore.(FolderChildrenDeleter)
+	if !ok {
+		return fmt.Errorf("filer store %s can not delete folder children", fsw.actualStore.GetName())
+	}
+	defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "deleteFolderChildren", time.Now())
+	return store.DeleteFolderChildren(fp)
+}
+
+// BeginTransaction starts a store transaction, or falls back to reverting the changes one by one
+// for the stores without transactions
+func (fsw *FilerStoreWrapper) BeginTransaction() (FilerStoreTransaction, error) {
+	if store, ok := fsw.actualStore.(TransactionalFilerStore); ok {
+		defer stats.ObserveFilerStore(fsw.actualStore.GetName(), "begin", time.Now())
+		tx, err := store.BeginTransaction()
+		if err != nil {
+			return nil, err
+		}
+		return &hardLinkTransaction{tx: tx}, nil
+	}
+	return &hardLinkTransaction{tx: newUndoLogTransaction(fsw.actualStore)}, nil
+}
+
+// hardLinkTransaction keeps the shared content of the hard links apart from the links, like FilerStoreWrapper
+type hardLinkTransaction struct {
+	tx FilerStoreTransaction
+}
+
+func (h *hardLinkTransaction) InsertEntry(entry *Entry) error {
+	return saveHardLinkEntry(h.tx, entry, true)
+}
+
+func (h *hardLinkTransaction) UpdateEntry(entry *Entry) error {
+	return saveHardLinkEntry(h.tx, entry, false)
+}
+
+func (h *hardLinkTransaction) FindEntry(fp FullPath) (*Entry, error) {
+	entry, err := h.tx.FindEntry(fp)
+	if err != nil {
+		return nil, err
+	}
+	return resolveHardLink(h.tx, entry), nil
+}
+
+func (h *hardLinkTransaction) DeleteEntry(fp FullPath) error {
+	return h.tx.DeleteEntry(fp)
+}
+
+func (h *hardLinkTransaction) ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int, prefix string, eachEntryFn ListEachEntryFunc) (string, error) {
+	return h.tx.ListDirectoryEntries(dirPath, startFileName, includeStartFile, limit, prefix, resolveHardLinks(h.tx, eachEntryFn))
+}
+
+func (h *hardLinkTransaction) Commit() error {
+	return h.tx.Commit()
+}
+
+func (h *hardLinkTransaction) Rollback() error {
+	return h.tx.Rollback()
+}
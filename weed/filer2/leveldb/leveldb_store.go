@@ -105,12 +105,58 @@ func (store *LevelDBStore) DeleteEntry(fullpath filer2.FullPath) (err error) {
 	return nil
 }
 
+func (store *LevelDBStore) DeleteFolderChildren(fullpath filer2.FullPath) (err error) {
+
+	// the direct children are keyed by the folder, and the deeper ones by the sub folders
+	keyPrefixes := [][]byte{genDirectoryKeyPrefix(fullpath, ""), []byte(string(fullpath) + "/")}
+	if fullpath == "/" {
+		keyPrefixes = [][]byte{[]byte("/")}
+	}
+	folderKey := genKey(fullpath.DirAndName())
+
+	batch := new(leveldb.Batch)
+	for _, keyPrefix := range keyPrefixes {
+		iter := store.db.NewIterator(leveldb_util.BytesPrefix(keyPrefix), nil)
+		for iter.Next() {
+			key := iter.Key()
+			if bytes.Equal(key, folderKey) {
+				continue
+			}
+			batch.Delete(append([]byte(nil), key...))
+			if batch.Len() >= 1024 {
+				if err = store.db.Write(batch, nil); err != nil {
+					break
+				}
+				batch.Reset()
+			}
+		}
+		iter.Release()
+		if err == nil {
+			err = iter.Error()
+		}
+		if err != nil {
+			return fmt.Errorf("delete folder children %s : %v", fullpath, err)
+		}
+	}
+
+	if err = store.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("delete folder children %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
 func (store *LevelDBStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool,
-	limit int) (entries []*filer2.Entry, err error) {
+	limit int, prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
 
-	directoryPrefix := genDirectoryKeyPrefix(fullpath, "")
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, prefix)
 
-	iter := store.db.NewIterator(&leveldb_util.Range{Start: genDirectoryKeyPrefix(fullpath, startFileName)}, nil)
+	startFrom := startFileName
+	if prefix > startFrom {
+		startFrom = prefix
+	}
+
+	iter := store.db.NewIterator(&leveldb_util.Range{Start: genDirectoryKeyPrefix(fullpath, startFrom)}, nil)
 	for iter.Next() {
 		key := iter.Key()
 		if !bytes.HasPrefix(key, directoryPrefix) {
@@ -135,11 +181,14 @@ func (store *LevelDBStore) ListDirectoryEntries(fullpath filer2.FullPath, startF
 			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
 			break
 		}
-		entries = append(entries, entry)
+		lastFileName = fileName
+		if !eachEntryFn(entry) {
+			break
+		}
 	}
 	iter.Release()
 
-	return entries, err
+	return lastFileName, err
 }
 
 func genKey(dirPath, fileName string) (key []byte) {
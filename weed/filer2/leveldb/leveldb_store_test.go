@@ -81,3 +81,38 @@ func TestEmptyRoot(t *testing.T) {
 	}
 
 }
+
+func TestTransactionRollback(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test3")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	tx, err := store.BeginTransaction()
+	if err != nil {
+		t.Fatalf("begin transaction: %v", err)
+	}
+	tx.InsertEntry(&filer2.Entry{FullPath: "/home/chris/file1.jpg"})
+	tx.Rollback()
+	tx.Commit()
+
+	if _, err = filer.FindEntry("/home/chris/file1.jpg"); err != filer2.ErrNotFound {
+		t.Errorf("rolled back entry is found: %v", err)
+	}
+
+	tx, _ = store.BeginTransaction()
+	tx.InsertEntry(&filer2.Entry{FullPath: "/home/chris/file2.jpg"})
+	if _, err = filer.FindEntry("/home/chris/file2.jpg"); err != filer2.ErrNotFound {
+		t.Errorf("uncommitted entry is found: %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if _, err = filer.FindEntry("/home/chris/file2.jpg"); err != nil {
+		t.Errorf("find committed entry: %v", err)
+	}
+
+}
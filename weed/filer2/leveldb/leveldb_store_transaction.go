@@ -0,0 +1,61 @@
+package leveldb
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// BeginTransaction collects the changes into one leveldb batch, which is written atomically on commit.
+// The reads in the transaction do not see the changes in the batch.
+func (store *LevelDBStore) BeginTransaction() (filer2.FilerStoreTransaction, error) {
+	return &leveldbTransaction{
+		store: store,
+		batch: new(leveldb.Batch),
+	}, nil
+}
+
+type leveldbTransaction struct {
+	store *LevelDBStore
+	batch *leveldb.Batch
+}
+
+func (t *leveldbTransaction) InsertEntry(entry *filer2.Entry) error {
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+	t.batch.Put(genKey(entry.DirAndName()), value)
+	return nil
+}
+
+func (t *leveldbTransaction) UpdateEntry(entry *filer2.Entry) error {
+	return t.InsertEntry(entry)
+}
+
+func (t *leveldbTransaction) FindEntry(fullpath filer2.FullPath) (*filer2.Entry, error) {
+	return t.store.FindEntry(fullpath)
+}
+
+func (t *leveldbTransaction) DeleteEntry(fullpath filer2.FullPath) error {
+	t.batch.Delete(genKey(fullpath.DirAndName()))
+	return nil
+}
+
+func (t *leveldbTransaction) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (string, error) {
+	return t.store.ListDirectoryEntries(fullpath, startFileName, inclusive, limit, prefix, eachEntryFn)
+}
+
+func (t *leveldbTransaction) Commit() error {
+	if err := t.store.db.Write(t.batch, nil); err != nil {
+		return fmt.Errorf("write batch of %d changes: %v", t.batch.Len(), err)
+	}
+	return nil
+}
+
+func (t *leveldbTransaction) Rollback() error {
+	t.batch.Reset()
+	return nil
+}
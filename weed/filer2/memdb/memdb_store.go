@@ -61,11 +61,41 @@ func (store *MemDbStore) DeleteEntry(fullpath filer2.FullPath) (err error) {
 	return nil
 }
 
-func (store *MemDbStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int) (entries []*filer2.Entry, err error) {
+func (store *MemDbStore) DeleteFolderChildren(fullpath filer2.FullPath) (err error) {
+
+	childPrefix := string(fullpath) + "/"
+	if fullpath == "/" {
+		childPrefix = "/"
+	}
+
+	var items []btree.Item
+	store.tree.AscendGreaterOrEqual(entryItem{&filer2.Entry{FullPath: filer2.FullPath(childPrefix)}},
+		func(item btree.Item) bool {
+			if !strings.HasPrefix(string(item.(entryItem).FullPath), childPrefix) {
+				return false
+			}
+			items = append(items, item)
+			return true
+		},
+	)
+
+	for _, item := range items {
+		if item.(entryItem).FullPath == fullpath {
+			continue
+		}
+		store.tree.Delete(item)
+	}
+
+	return nil
+}
+
+func (store *MemDbStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
 
 	startFrom := string(fullpath)
-	if startFileName != "" {
-		startFrom = startFrom + "/" + startFileName
+	if startFileName != "" || prefix != "" {
+		// names before the prefix can be skipped
+		startFrom = startFrom + "/" + maxString(startFileName, prefix)
 	}
 
 	store.tree.AscendGreaterOrEqual(entryItem{&filer2.Entry{FullPath: filer2.FullPath(startFrom)}},
@@ -82,32 +112,41 @@ func (store *MemDbStore) ListDirectoryEntries(fullpath filer2.FullPath, startFil
 				return true
 			}
 
-			dir, name := entry.FullPath.DirAndName()
-			if name == startFileName {
-				if inclusive {
-					limit--
-					entries = append(entries, entry)
-				}
-				return true
-			}
-
 			// only iterate the same prefix
 			if !strings.HasPrefix(string(entry.FullPath), string(fullpath)) {
 				// println("breaking from", entry.FullPath)
 				return false
 			}
 
+			dir, name := entry.FullPath.DirAndName()
 			if dir != string(fullpath) {
 				// this could be items in deeper directories
 				// println("skipping deeper folder", entry.FullPath)
 				return true
 			}
+
+			// the names are sorted, so no more names with the prefix
+			if !strings.HasPrefix(name, prefix) {
+				return false
+			}
+
+			if name == startFileName && !inclusive {
+				return true
+			}
+
 			// now process the directory items
 			// println("adding entry", entry.FullPath)
 			limit--
-			entries = append(entries, entry)
-			return true
+			lastFileName = name
+			return eachEntryFn(entry)
 		},
 	)
-	return entries, nil
+	return lastFileName, nil
+}
+
+func maxString(a, b string) string {
+	if a > b {
+		return a
+	}
+	return b
 }
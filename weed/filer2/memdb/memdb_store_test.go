@@ -1,8 +1,18 @@
 package memdb
 
 import (
-	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
 func TestCreateAndFind(t *testing.T) {
@@ -142,3 +152,422 @@ func TestCreateFileAndList(t *testing.T) {
 	}
 
 }
+
+func TestStreamListWithPrefix(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	store := &MemDbStore{}
+	store.Initialize(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	for _, p := range []string{"/dir/a1", "/dir/b1", "/dir/b3/c1", "/dir/b2", "/dir/c1"} {
+		filer.CreateEntry(&filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0440},
+		})
+	}
+
+	var names []string
+	lastFileName, err := filer.StreamListDirectoryEntries(filer2.FullPath("/dir"), "", false, 100, "b", func(entry *filer2.Entry) bool {
+		names = append(names, entry.Name())
+		return true
+	})
+	if err != nil {
+		t.Errorf("list entries: %v", err)
+		return
+	}
+	if fmt.Sprint(names) != "[b1 b2 b3]" || lastFileName != "b3" {
+		t.Errorf("list entries with prefix: %v, last %s", names, lastFileName)
+		return
+	}
+
+	// stop after the first entry
+	names = nil
+	filer.StreamListDirectoryEntries(filer2.FullPath("/dir"), "b1", true, 100, "b", func(entry *filer2.Entry) bool {
+		names = append(names, entry.Name())
+		return false
+	})
+	if fmt.Sprint(names) != "[b1]" {
+		t.Errorf("list entries until stopped: %v", names)
+		return
+	}
+
+}
+
+func TestAtomicRenameDirectory(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	store := &MemDbStore{}
+	store.Initialize(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	for _, p := range []string{"/home/chris/dir1/file1.jpg", "/home/chris/dir1/sub/file2.jpg", "/home/chris/dir2/file3.jpg"} {
+		filer.CreateEntry(&filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr: filer2.Attr{
+				Mode: 0440,
+				Uid:  1234,
+				Gid:  5678,
+			},
+		})
+	}
+
+	if err := filer.AtomicRenameEntry("/home/chris/dir1", "/home/chris/dir1/sub/dir"); err == nil {
+		t.Errorf("moving a directory into itself should fail")
+		return
+	}
+
+	if err := filer.AtomicRenameEntry("/home/chris/dir1", "/home/chris/dir2"); err == nil {
+		t.Errorf("replacing a non-empty directory should fail")
+		return
+	}
+
+	if err := filer.AtomicRenameEntry("/home/chris/dir1", "/home/chris/new/dir1"); err != nil {
+		t.Errorf("rename directory: %v", err)
+		return
+	}
+
+	if _, err := filer.FindEntry("/home/chris/dir1"); err != filer2.ErrNotFound {
+		t.Errorf("old directory still exists: %v", err)
+		return
+	}
+
+	if _, err := filer.FindEntry("/home/chris/new/dir1/sub/file2.jpg"); err != nil {
+		t.Errorf("find moved file: %v", err)
+		return
+	}
+
+	entries, _ := filer.ListDirectoryEntries(filer2.FullPath("/home/chris/new/dir1"), "", false, 100)
+	if len(entries) != 2 {
+		t.Errorf("list entries count: %v", len(entries))
+		return
+	}
+
+	// rename one file over another one
+	if err := filer.AtomicRenameEntry("/home/chris/new/dir1/file1.jpg", "/home/chris/dir2/file3.jpg"); err != nil {
+		t.Errorf("rename file: %v", err)
+		return
+	}
+	entries, _ = filer.ListDirectoryEntries(filer2.FullPath("/home/chris/dir2"), "", false, 100)
+	if len(entries) != 1 {
+		t.Errorf("list entries count: %v", len(entries))
+		return
+	}
+
+}
+
+func TestDeleteFolderRecursively(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	store := &MemDbStore{}
+	store.Initialize(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+	dir, err := ioutil.TempDir("", "meta_log")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if filer.MetaLog, err = filer2.NewMetaLog(dir); err != nil {
+		t.Fatalf("meta log creation: %v", err)
+	}
+	defer filer.MetaLog.Shutdown()
+
+	for _, p := range []string{"/home/chris/dir1/file1.jpg", "/home/chris/dir1/sub/file2.jpg", "/home/chris/dir10/file3.jpg"} {
+		filer.CreateEntry(&filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0440},
+		})
+	}
+
+	if err := filer.DeleteEntryMetaAndData("/home/chris/dir1", false, false); err == nil {
+		t.Errorf("deleting a non-empty directory without recursion should fail")
+		return
+	}
+
+	if err := filer.DeleteEntryMetaAndData("/home/chris/dir1", true, false); err != nil {
+		t.Errorf("delete directory: %v", err)
+		return
+	}
+
+	for _, p := range []string{"/home/chris/dir1", "/home/chris/dir1/file1.jpg", "/home/chris/dir1/sub", "/home/chris/dir1/sub/file2.jpg"} {
+		if _, err := filer.FindEntry(filer2.FullPath(p)); err != filer2.ErrNotFound {
+			t.Errorf("%s still exists: %v", p, err)
+			return
+		}
+	}
+
+	if _, err := filer.FindEntry("/home/chris/dir10/file3.jpg"); err != nil {
+		t.Errorf("find file in sibling directory: %v", err)
+		return
+	}
+
+	// one event for the whole deleted folder
+	if deleted := deleteEvents(filer); strings.Join(deleted, ",") != "/home/chris/dir1" {
+		t.Errorf("delete events: %v", deleted)
+	}
+
+	// more children than one page, some in full pages deleted in transactions, and the rest by the store
+	fileCount := 2500
+	for i := 0; i < fileCount; i++ {
+		filer.CreateEntry(&filer2.Entry{
+			FullPath: filer2.FullPath(fmt.Sprintf("/home/chris/dir2/sub/file%04d.jpg", i)),
+			Attr:     filer2.Attr{Mode: 0440},
+		})
+	}
+	if err := filer.DeleteEntryMetaAndData("/home/chris/dir2", true, false); err != nil {
+		t.Errorf("delete large directory: %v", err)
+		return
+	}
+	if entries, _ := filer.ListDirectoryEntries("/home/chris/dir2/sub", "", false, fileCount); len(entries) != 0 {
+		t.Errorf("%d entries left in deleted directory", len(entries))
+	}
+	if _, err := filer.FindEntry("/home/chris/dir2"); err != filer2.ErrNotFound {
+		t.Errorf("large directory still exists: %v", err)
+	}
+	if deleted := deleteEvents(filer); strings.Join(deleted, ",") != "/home/chris/dir1,/home/chris/dir2" {
+		t.Errorf("delete events: %v", deleted)
+	}
+
+}
+
+func TestHardLink(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	store := &MemDbStore{}
+	store.Initialize(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	filer.CreateEntry(&filer2.Entry{
+		FullPath: "/home/chris/file1.jpg",
+		Attr:     filer2.Attr{Mode: 0440},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,abc", Size: 100}},
+	})
+
+	link, err := filer.CreateHardLink("/home/chris/file1.jpg", "/home/other/file2.jpg")
+	if err != nil {
+		t.Errorf("create hard link: %v", err)
+		return
+	}
+	if link.HardLinkCounter != 2 || len(link.HardLinkId) == 0 {
+		t.Errorf("unexpected hard link id %x, counter %d", link.HardLinkId, link.HardLinkCounter)
+		return
+	}
+
+	if _, err = filer.CreateHardLink("/home/chris/file1.jpg", "/home/other/file2.jpg"); err == nil {
+		t.Errorf("linking to an existing file should fail")
+		return
+	}
+
+	// changing one link changes all of them
+	entry, _ := filer.FindEntry("/home/chris/file1.jpg")
+	updated := &filer2.Entry{
+		FullPath: entry.FullPath,
+		Attr:     entry.Attr,
+		Chunks:   []*filer_pb.FileChunk{{FileId: "2,def", Size: 200}},
+	}
+	updated.Mime = "image/jpeg"
+	if err = filer.UpdateEntry(entry, updated); err != nil {
+		t.Errorf("update hard link: %v", err)
+		return
+	}
+	entry, err = filer.FindEntry("/home/other/file2.jpg")
+	if err != nil || entry.Mime != "image/jpeg" || entry.Size() != 200 || entry.HardLinkCounter != 2 {
+		t.Errorf("unexpected other link %+v: %v", entry, err)
+		return
+	}
+
+	if err = filer.DeleteEntryMetaAndData("/home/chris/file1.jpg", false, false); err != nil {
+		t.Errorf("delete hard link: %v", err)
+		return
+	}
+	entry, err = filer.FindEntry("/home/other/file2.jpg")
+	if err != nil || entry.Size() != 200 || entry.HardLinkCounter != 1 {
+		t.Errorf("unexpected remaining link %+v: %v", entry, err)
+		return
+	}
+
+	if err = filer.DeleteEntryMetaAndData("/home/other", true, false); err != nil {
+		t.Errorf("delete directory with the last link: %v", err)
+		return
+	}
+	if _, err = store.FindEntry(link.HardLinkId.FullPath()); err != filer2.ErrNotFound {
+		t.Errorf("shared content of the hard link still exists: %v", err)
+		return
+	}
+
+}
+
+// storeWithoutFolderDeletion hides DeleteFolderChildren, so the folders are deleted entry by entry
+type storeWithoutFolderDeletion struct {
+	filer2.FilerStore
+}
+
+func TestDeleteLargeFolder(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	store := &MemDbStore{}
+	store.Initialize(nil)
+	filer.SetStore(&storeWithoutFolderDeletion{store})
+	filer.DisableDirectoryCache()
+
+	// more entries than one deletion batch
+	for i := 0; i < 2500; i++ {
+		entry := &filer2.Entry{
+			FullPath: filer2.FullPath(fmt.Sprintf("/home/dir%d/file%d", i%3, i)),
+			Attr: filer2.Attr{
+				Mode: 0440,
+			},
+		}
+		if err := filer.CreateEntry(entry); err != nil {
+			t.Fatalf("create entry %v: %v", entry.FullPath, err)
+		}
+	}
+
+	if err := filer.DeleteEntryMetaAndData("/home", false, false); err == nil {
+		t.Fatalf("deleted non empty folder without recursion")
+	}
+	if entries, _ := filer.ListDirectoryEntries("/home/dir1", "", false, 2000); len(entries) != 833 {
+		t.Fatalf("%d entries left after the failed deletion", len(entries))
+	}
+
+	if err := filer.DeleteEntryMetaAndData("/home", true, false); err != nil {
+		t.Fatalf("delete folder: %v", err)
+	}
+	if _, err := filer.FindEntry("/home"); err != filer2.ErrNotFound {
+		t.Errorf("find deleted folder: %v", err)
+	}
+	if entries, _ := filer.ListDirectoryEntries("/home/dir1", "", false, 2000); len(entries) != 0 {
+		t.Errorf("%d entries left after the deletion", len(entries))
+	}
+}
+
+// lockedStore serializes the changes, so the store can be used concurrently
+type lockedStore struct {
+	sync.Mutex
+	store *MemDbStore
+}
+
+func (s *lockedStore) GetName() string {
+	return s.store.GetName()
+}
+
+func (s *lockedStore) Initialize(configuration util.Configuration) error {
+	return s.store.Initialize(configuration)
+}
+
+func (s *lockedStore) InsertEntry(entry *filer2.Entry) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.store.InsertEntry(entry)
+}
+
+func (s *lockedStore) UpdateEntry(entry *filer2.Entry) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.store.UpdateEntry(entry)
+}
+
+// FindEntry yields after reading, so the concurrent changes interleave between reading and writing an entry
+func (s *lockedStore) FindEntry(fullpath filer2.FullPath) (*filer2.Entry, error) {
+	defer runtime.Gosched()
+	s.Lock()
+	defer s.Unlock()
+	return s.store.FindEntry(fullpath)
+}
+
+func (s *lockedStore) DeleteEntry(fullpath filer2.FullPath) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.store.DeleteEntry(fullpath)
+}
+
+func (s *lockedStore) DeleteFolderChildren(fullpath filer2.FullPath) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.store.DeleteFolderChildren(fullpath)
+}
+
+// ListDirectoryEntries calls eachEntryFn after unlocking, since it may read the store again
+func (s *lockedStore) ListDirectoryEntries(dirPath filer2.FullPath, startFileName string, includeStartFile bool, limit int, prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
+	var entries []*filer2.Entry
+	s.Lock()
+	lastFileName, err = s.store.ListDirectoryEntries(dirPath, startFileName, includeStartFile, limit, prefix, func(entry *filer2.Entry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	s.Unlock()
+	for _, entry := range entries {
+		if !eachEntryFn(entry) {
+			break
+		}
+	}
+	return
+}
+
+func TestConcurrentHardLinkChanges(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	store := &lockedStore{store: &MemDbStore{}}
+	store.Initialize(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	linkCount := 16
+	filer.CreateEntry(&filer2.Entry{
+		FullPath: "/home/src/file.jpg",
+		Attr:     filer2.Attr{Mode: 0440},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,abc", Size: 100}},
+	})
+	for i := 0; i < linkCount; i++ {
+		if _, err := filer.CreateHardLink("/home/src/file.jpg", filer2.FullPath(fmt.Sprintf("/home/old/dir%d/link.jpg", i))); err != nil {
+			t.Fatalf("create hard link %d: %v", i, err)
+		}
+		filer.CreateEntry(&filer2.Entry{
+			FullPath: filer2.FullPath(fmt.Sprintf("/home/plain/dir%d/file.jpg", i)),
+			Attr:     filer2.Attr{Mode: 0440},
+		})
+	}
+
+	// deleting the folders with and without hard links, while linking the file again
+	var wg sync.WaitGroup
+	for i := 0; i < linkCount; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			if err := filer.DeleteEntryMetaAndData(filer2.FullPath(fmt.Sprintf("/home/old/dir%d", i)), true, false); err != nil {
+				t.Errorf("delete folder with hard link %d: %v", i, err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			if err := filer.DeleteEntryMetaAndData(filer2.FullPath(fmt.Sprintf("/home/plain/dir%d", i)), true, false); err != nil {
+				t.Errorf("delete folder %d: %v", i, err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := filer.CreateHardLink("/home/src/file.jpg", filer2.FullPath(fmt.Sprintf("/home/new/link%d.jpg", i))); err != nil {
+				t.Errorf("create new hard link %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entry, err := filer.FindEntry("/home/src/file.jpg")
+	if err != nil || entry.HardLinkCounter != int32(linkCount+1) {
+		t.Errorf("unexpected hard link %+v: %v", entry, err)
+	}
+	if entries, _ := filer.ListDirectoryEntries("/home/plain", "", false, linkCount); len(entries) != 0 {
+		t.Errorf("%d folders left after the deletion", len(entries))
+	}
+}
+
+func deleteEvents(filer *filer2.Filer) (deleted []string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	filer.MetaLog.Subscribe(ctx, 0, func(event *filer_pb.SubscribeMetadataResponse) error {
+		if oldEntry := event.EventNotification.OldEntry; oldEntry != nil && event.EventNotification.NewEntry == nil {
+			deleted = append(deleted, oldEntry.Name)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,249 @@
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	goraft "github.com/chrislusf/raft"
+	"github.com/chrislusf/raft/protobuf"
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/filer2/leveldb"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_raft_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	weed_util "github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &RaftStore{})
+}
+
+// RaftStore replicates the filer metadata changes to all the filers with raft.
+// Each filer keeps a full copy of the metadata in a local leveldb, and serves the reads from it.
+// The changes are applied by the raft leader, and the followers forward their changes to the leader.
+type RaftStore struct {
+	local          *leveldb.LevelDBStore
+	dumpDir        string
+	raftServer     goraft.Server
+	self           string
+	grpcDialOption grpc.DialOption
+}
+
+func (store *RaftStore) GetName() string {
+	return "raft"
+}
+
+func (store *RaftStore) Initialize(configuration weed_util.Configuration) (err error) {
+	return store.initialize(
+		configuration.GetString("dir"),
+		configuration.GetString("self"),
+		configuration.GetStringSlice("peers"),
+	)
+}
+
+func (store *RaftStore) initialize(dir, self string, peers []string) (err error) {
+
+	if self == "" {
+		return fmt.Errorf("raft store needs its own address as self")
+	}
+	store.self = self
+
+	metaDir, raftDir := filepath.Join(dir, "meta"), filepath.Join(dir, "raft")
+	store.dumpDir = filepath.Join(dir, "dump")
+	for _, d := range []string{metaDir, raftDir, store.dumpDir} {
+		if err = os.MkdirAll(d, 0755); err != nil {
+			return fmt.Errorf("create raft store dir %s: %v", d, err)
+		}
+	}
+
+	metaConfiguration := viper.New()
+	metaConfiguration.Set("dir", metaDir)
+	store.local = &leveldb.LevelDBStore{}
+	if err = store.local.Initialize(metaConfiguration); err != nil {
+		return err
+	}
+
+	registerCommands()
+
+	store.grpcDialOption = security.LoadClientTLS(viper.Sub("grpc"), "filer")
+	transporter := goraft.NewGrpcTransporter(store.grpcDialOption)
+	store.raftServer, err = goraft.NewServer(self, raftDir, transporter, store, store, "")
+	if err != nil {
+		return fmt.Errorf("create raft server: %v", err)
+	}
+	store.raftServer.SetHeartbeatInterval(100 * time.Millisecond)
+	store.raftServer.SetElectionTimeout(1000 * time.Millisecond)
+
+	if hasSnapshot(raftDir) {
+		if err = store.raftServer.LoadSnapshot(); err != nil {
+			return fmt.Errorf("load raft snapshot: %v", err)
+		}
+	}
+
+	if err = store.raftServer.Start(); err != nil {
+		return fmt.Errorf("start raft server: %v", err)
+	}
+
+	if err = store.startGrpcServer(); err != nil {
+		return err
+	}
+
+	for _, peer := range peers {
+		store.raftServer.AddPeer(peer, peer)
+	}
+
+	if store.raftServer.IsLogEmpty() && isTheFirstOne(self, peers) {
+		// Initialize the cluster by joining itself.
+		glog.V(0).Infoln("Initializing new filer raft cluster")
+		_, err = store.raftServer.Do(&goraft.DefaultJoinCommand{
+			Name:             store.raftServer.Name(),
+			ConnectionString: self,
+		})
+		if err != nil {
+			return fmt.Errorf("join filer raft cluster: %v", err)
+		}
+	}
+
+	go store.loopTakingSnapshots()
+
+	glog.V(0).Infof("filer raft store %s, current leader: %v", self, store.raftServer.Leader())
+
+	return nil
+}
+
+// startGrpcServer serves the raft messages between the filers, and the changes forwarded by the followers
+func (store *RaftStore) startGrpcServer() error {
+	_, port, err := net.SplitHostPort(store.self)
+	if err != nil {
+		return fmt.Errorf("raft store self %s: %v", store.self, err)
+	}
+	grpcL, err := weed_util.NewListener(":"+port, 0)
+	if err != nil {
+		return fmt.Errorf("listen on raft store port %s: %v", port, err)
+	}
+	grpcS := weed_util.NewGrpcServer(security.LoadServerTLS(viper.Sub("grpc"), "filer"))
+	protobuf.RegisterRaftServer(grpcS, goraft.NewGrpcServer(store.raftServer))
+	filer_raft_pb.RegisterFilerRaftStoreServer(grpcS, store)
+	go grpcS.Serve(grpcL)
+	return nil
+}
+
+func (store *RaftStore) InsertEntry(entry *filer2.Entry) (err error) {
+	command, err := newSaveEntryCommand(entry)
+	if err != nil {
+		return err
+	}
+	return store.do(command)
+}
+
+func (store *RaftStore) UpdateEntry(entry *filer2.Entry) (err error) {
+	command, err := newSaveEntryCommand(entry)
+	if err != nil {
+		return err
+	}
+	return store.do(command)
+}
+
+func (store *RaftStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+	return store.local.FindEntry(fullpath)
+}
+
+func (store *RaftStore) DeleteEntry(fullpath filer2.FullPath) (err error) {
+	return store.do(&DeleteEntryCommand{FullPath: string(fullpath)})
+}
+
+func (store *RaftStore) DeleteFolderChildren(fullpath filer2.FullPath) (err error) {
+	return store.do(&DeleteFolderChildrenCommand{FullPath: string(fullpath)})
+}
+
+func (store *RaftStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
+	return store.local.ListDirectoryEntries(fullpath, startFileName, inclusive, limit, prefix, eachEntryFn)
+}
+
+// do applies the command through the raft leader, and waits until it is also applied locally,
+// so that the following reads on this filer can see the change
+func (store *RaftStore) do(command goraft.Command) error {
+
+	_, err := store.raftServer.Do(command)
+	if err != goraft.NotLeaderError {
+		return err
+	}
+
+	leader := store.raftServer.Leader()
+	if leader == "" {
+		return fmt.Errorf("filer raft leader is unknown")
+	}
+
+	data, err := json.Marshal(command)
+	if err != nil {
+		return fmt.Errorf("encode %s: %v", command.CommandName(), err)
+	}
+
+	var commitIndex uint64
+	err = weed_util.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		client := filer_raft_pb.NewFilerRaftStoreClient(grpcConnection)
+		resp, applyErr := client.ApplyCommand(context.Background(), &filer_raft_pb.ApplyCommandRequest{
+			CommandName: command.CommandName(),
+			Command:     data,
+		})
+		if applyErr != nil {
+			return applyErr
+		}
+		commitIndex = resp.CommitIndex
+		return nil
+	}, leader, store.grpcDialOption)
+	if err != nil {
+		return fmt.Errorf("forward %s to raft leader %s: %v", command.CommandName(), leader, err)
+	}
+
+	return store.waitForCommitIndex(commitIndex)
+}
+
+func (store *RaftStore) waitForCommitIndex(commitIndex uint64) error {
+	for i := 0; i < 500; i++ {
+		if store.raftServer.CommitIndex() >= commitIndex {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("timeout waiting for raft commit index %d, currently %d", commitIndex, store.raftServer.CommitIndex())
+}
+
+// ApplyCommand applies the changes forwarded by the followers
+func (store *RaftStore) ApplyCommand(ctx context.Context, req *filer_raft_pb.ApplyCommandRequest) (*filer_raft_pb.ApplyCommandResponse, error) {
+
+	command, err := newCommand(req.CommandName)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(req.Command, command); err != nil {
+		return nil, fmt.Errorf("decode %s: %v", req.CommandName, err)
+	}
+
+	if _, err = store.raftServer.Do(command); err != nil {
+		return nil, err
+	}
+
+	return &filer_raft_pb.ApplyCommandResponse{
+		CommitIndex: store.raftServer.CommitIndex(),
+	}, nil
+}
+
+func isTheFirstOne(self string, peers []string) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	sorted := make([]string, len(peers))
+	copy(sorted, peers)
+	sort.Strings(sorted)
+	return self == sorted[0]
+}
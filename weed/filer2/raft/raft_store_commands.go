@@ -0,0 +1,151 @@
+package raft
+
+import (
+	"fmt"
+	"sync"
+
+	goraft "github.com/chrislusf/raft"
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// the command names share the registry with the master raft commands
+const (
+	saveEntryCommandName            = "filer.SaveEntry"
+	deleteEntryCommandName          = "filer.DeleteEntry"
+	deleteFolderChildrenCommandName = "filer.DeleteFolderChildren"
+	batchCommandName                = "filer.Batch"
+)
+
+var registerCommandsOnce sync.Once
+
+func registerCommands() {
+	registerCommandsOnce.Do(func() {
+		goraft.RegisterCommand(&SaveEntryCommand{})
+		goraft.RegisterCommand(&DeleteEntryCommand{})
+		goraft.RegisterCommand(&DeleteFolderChildrenCommand{})
+		goraft.RegisterCommand(&BatchCommand{})
+	})
+}
+
+func newCommand(commandName string) (goraft.Command, error) {
+	switch commandName {
+	case saveEntryCommandName:
+		return &SaveEntryCommand{}, nil
+	case deleteEntryCommandName:
+		return &DeleteEntryCommand{}, nil
+	case deleteFolderChildrenCommandName:
+		return &DeleteFolderChildrenCommand{}, nil
+	case batchCommandName:
+		return &BatchCommand{}, nil
+	}
+	return nil, fmt.Errorf("unknown filer raft command %s", commandName)
+}
+
+// SaveEntryCommand inserts or updates one entry
+type SaveEntryCommand struct {
+	FullPath string `json:"fullPath"`
+	Meta     []byte `json:"meta"`
+}
+
+func newSaveEntryCommand(entry *filer2.Entry) (*SaveEntryCommand, error) {
+	meta, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+	return &SaveEntryCommand{
+		FullPath: string(entry.FullPath),
+		Meta:     meta,
+	}, nil
+}
+
+func (c *SaveEntryCommand) CommandName() string {
+	return saveEntryCommandName
+}
+
+func (c *SaveEntryCommand) Apply(server goraft.Server) (interface{}, error) {
+	store := server.Context().(*RaftStore)
+	entry := &filer2.Entry{
+		FullPath: filer2.FullPath(c.FullPath),
+	}
+	if err := entry.DecodeAttributesAndChunks(c.Meta); err != nil {
+		return nil, fmt.Errorf("decode %s : %v", c.FullPath, err)
+	}
+	glog.V(4).Infof("raft save entry %s", c.FullPath)
+	return nil, store.local.InsertEntry(entry)
+}
+
+// DeleteEntryCommand deletes one entry
+type DeleteEntryCommand struct {
+	FullPath string `json:"fullPath"`
+}
+
+func (c *DeleteEntryCommand) CommandName() string {
+	return deleteEntryCommandName
+}
+
+func (c *DeleteEntryCommand) Apply(server goraft.Server) (interface{}, error) {
+	store := server.Context().(*RaftStore)
+	glog.V(4).Infof("raft delete entry %s", c.FullPath)
+	return nil, store.local.DeleteEntry(filer2.FullPath(c.FullPath))
+}
+
+// DeleteFolderChildrenCommand deletes all the descendants of a folder
+type DeleteFolderChildrenCommand struct {
+	FullPath string `json:"fullPath"`
+}
+
+func (c *DeleteFolderChildrenCommand) CommandName() string {
+	return deleteFolderChildrenCommandName
+}
+
+func (c *DeleteFolderChildrenCommand) Apply(server goraft.Server) (interface{}, error) {
+	store := server.Context().(*RaftStore)
+	glog.V(4).Infof("raft delete folder children %s", c.FullPath)
+	return nil, store.local.DeleteFolderChildren(filer2.FullPath(c.FullPath))
+}
+
+// BatchCommand applies the changes of a transaction atomically, in one local leveldb batch
+type BatchCommand struct {
+	Changes []BatchChange `json:"changes"`
+}
+
+// BatchChange saves the entry, or deletes it if Deleted is set
+type BatchChange struct {
+	FullPath string `json:"fullPath"`
+	Meta     []byte `json:"meta,omitempty"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+func (c *BatchCommand) CommandName() string {
+	return batchCommandName
+}
+
+func (c *BatchCommand) Apply(server goraft.Server) (interface{}, error) {
+	store := server.Context().(*RaftStore)
+	glog.V(4).Infof("raft apply %d changes", len(c.Changes))
+
+	tx, err := store.local.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range c.Changes {
+		if change.Deleted {
+			err = tx.DeleteEntry(filer2.FullPath(change.FullPath))
+		} else {
+			entry := &filer2.Entry{
+				FullPath: filer2.FullPath(change.FullPath),
+			}
+			if err = entry.DecodeAttributesAndChunks(change.Meta); err != nil {
+				err = fmt.Errorf("decode %s : %v", change.FullPath, err)
+			} else {
+				err = tx.InsertEntry(entry)
+			}
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return nil, tx.Commit()
+}
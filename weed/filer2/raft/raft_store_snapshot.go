@@ -0,0 +1,332 @@
+package raft
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_raft_pb"
+	weed_util "github.com/chrislusf/seaweedfs/weed/util"
+	"google.golang.org/grpc"
+)
+
+const (
+	snapshotCheckInterval = time.Minute
+	snapshotLogEntryCount = 10000
+	snapshotDumpExt       = ".dump"
+	snapshotDumpsKept     = 2
+	snapshotDumpChunkSize = 1024 * 1024
+)
+
+// loopTakingSnapshots compacts the raft log, which is otherwise all kept in memory
+func (store *RaftStore) loopTakingSnapshots() {
+	lastSnapshotIndex := store.raftServer.CommitIndex()
+	for {
+		time.Sleep(snapshotCheckInterval)
+		commitIndex := store.raftServer.CommitIndex()
+		if commitIndex < lastSnapshotIndex+snapshotLogEntryCount {
+			continue
+		}
+		if err := store.raftServer.TakeSnapshot(); err != nil {
+			glog.V(0).Infof("take filer raft snapshot: %v", err)
+			continue
+		}
+		lastSnapshotIndex = commitIndex
+	}
+}
+
+func hasSnapshot(raftDir string) bool {
+	fileInfos, err := ioutil.ReadDir(filepath.Join(raftDir, "snapshot"))
+	if err != nil {
+		return false
+	}
+	for _, fileInfo := range fileInfos {
+		if strings.HasSuffix(fileInfo.Name(), ".ss") {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotDump is the raft snapshot state. The entries are too many to be kept in memory,
+// so they are streamed to a dump file, and the followers recovering from the snapshot
+// fetch the dump from the filer that took it.
+type snapshotDump struct {
+	Name  string `json:"name"`
+	Filer string `json:"filer"`
+}
+
+// Save dumps all the entries, and the shared content of the hard links, for the raft snapshot.
+// The changes applied during the dump can also be included, which is fine
+// since applying them again from the raft log leads to the same entries.
+func (store *RaftStore) Save() ([]byte, error) {
+
+	dump := snapshotDump{
+		Name:  fmt.Sprintf("%d%s", time.Now().UnixNano(), snapshotDumpExt),
+		Filer: store.self,
+	}
+	if err := store.writeSnapshotDump(dump.Name); err != nil {
+		return nil, err
+	}
+	store.removeOldSnapshotDumps()
+
+	return json.Marshal(dump)
+}
+
+func (store *RaftStore) writeSnapshotDump(name string) error {
+
+	tmpFileName := filepath.Join(store.dumpDir, name+".tmp")
+	file, err := os.Create(tmpFileName)
+	if err != nil {
+		return fmt.Errorf("create raft snapshot dump: %v", err)
+	}
+	defer os.Remove(tmpFileName)
+
+	w := bufio.NewWriter(file)
+	for _, dirPath := range []filer2.FullPath{"/", filer2.HardLinkDirectory} {
+		if err = store.saveEntries(w, dirPath); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err = w.Flush(); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write raft snapshot dump %s: %v", tmpFileName, err)
+	}
+
+	if err = os.Rename(tmpFileName, filepath.Join(store.dumpDir, name)); err != nil {
+		return fmt.Errorf("rename raft snapshot dump %s: %v", tmpFileName, err)
+	}
+	return nil
+}
+
+// removeOldSnapshotDumps keeps the last few dumps, which the lagging followers may still be fetching
+func (store *RaftStore) removeOldSnapshotDumps() {
+	fileInfos, err := ioutil.ReadDir(store.dumpDir)
+	if err != nil {
+		glog.V(0).Infof("list raft snapshot dumps: %v", err)
+		return
+	}
+	var names []string
+	for _, fileInfo := range fileInfos {
+		if strings.HasSuffix(fileInfo.Name(), snapshotDumpExt) {
+			names = append(names, fileInfo.Name())
+		}
+	}
+	sort.Strings(names)
+	for i := 0; i+snapshotDumpsKept < len(names); i++ {
+		if err = os.Remove(filepath.Join(store.dumpDir, names[i])); err != nil {
+			glog.V(0).Infof("remove raft snapshot dump %s: %v", names[i], err)
+		}
+	}
+}
+
+func (store *RaftStore) saveEntries(w io.Writer, dirPath filer2.FullPath) error {
+	lastFileName := ""
+	for {
+		var entries []*filer2.Entry
+		_, err := store.local.ListDirectoryEntries(dirPath, lastFileName, false, 1024, "", func(entry *filer2.Entry) bool {
+			entries = append(entries, entry)
+			return true
+		})
+		if err != nil {
+			return fmt.Errorf("list %s: %v", dirPath, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			meta, err := entry.EncodeAttributesAndChunks()
+			if err != nil {
+				return fmt.Errorf("encoding %s: %v", entry.FullPath, err)
+			}
+			if err = writeSnapshotRecord(w, []byte(entry.FullPath), meta); err != nil {
+				return err
+			}
+			if entry.IsDirectory() {
+				if err = store.saveEntries(w, entry.FullPath); err != nil {
+					return err
+				}
+			}
+		}
+		if len(entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+// Recovery replaces all the local entries with the ones in the raft snapshot dump,
+// which is fetched first if it was taken by another filer
+func (store *RaftStore) Recovery(data []byte) error {
+
+	var dump snapshotDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return fmt.Errorf("decode raft snapshot: %v", err)
+	}
+	if dump.Name != filepath.Base(dump.Name) || !strings.HasSuffix(dump.Name, snapshotDumpExt) {
+		return fmt.Errorf("invalid raft snapshot dump name %q", dump.Name)
+	}
+
+	dumpFileName := filepath.Join(store.dumpDir, dump.Name)
+	if _, err := os.Stat(dumpFileName); os.IsNotExist(err) {
+		if err = store.fetchSnapshotDump(dump); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(dumpFileName)
+	if err != nil {
+		return fmt.Errorf("open raft snapshot dump: %v", err)
+	}
+	defer file.Close()
+
+	for _, dirPath := range []filer2.FullPath{"/", filer2.HardLinkDirectory} {
+		if err := store.local.DeleteFolderChildren(dirPath); err != nil {
+			return fmt.Errorf("clear local entries under %s: %v", dirPath, err)
+		}
+	}
+
+	return store.loadEntries(bufio.NewReader(file))
+}
+
+func (store *RaftStore) loadEntries(r io.Reader) error {
+	for {
+		fullpath, meta, err := readSnapshotRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read raft snapshot: %v", err)
+		}
+		entry := &filer2.Entry{
+			FullPath: filer2.FullPath(fullpath),
+		}
+		if err = entry.DecodeAttributesAndChunks(meta); err != nil {
+			return fmt.Errorf("decode %s : %v", fullpath, err)
+		}
+		if err = store.local.InsertEntry(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// fetchSnapshotDump downloads the dump from the filer that took the snapshot
+func (store *RaftStore) fetchSnapshotDump(dump snapshotDump) error {
+
+	dumpFileName := filepath.Join(store.dumpDir, dump.Name)
+	tmpFileName := dumpFileName + ".tmp"
+	file, err := os.Create(tmpFileName)
+	if err != nil {
+		return fmt.Errorf("create raft snapshot dump: %v", err)
+	}
+	defer os.Remove(tmpFileName)
+
+	err = weed_util.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		client := filer_raft_pb.NewFilerRaftStoreClient(grpcConnection)
+		stream, readErr := client.ReadSnapshotDump(context.Background(), &filer_raft_pb.ReadSnapshotDumpRequest{
+			Name: dump.Name,
+		})
+		if readErr != nil {
+			return readErr
+		}
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr == io.EOF {
+				return nil
+			}
+			if recvErr != nil {
+				return recvErr
+			}
+			if _, writeErr := file.Write(resp.Data); writeErr != nil {
+				return writeErr
+			}
+		}
+	}, dump.Filer, store.grpcDialOption)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("fetch raft snapshot dump %s from %s: %v", dump.Name, dump.Filer, err)
+	}
+
+	if err = os.Rename(tmpFileName, dumpFileName); err != nil {
+		return fmt.Errorf("rename raft snapshot dump %s: %v", tmpFileName, err)
+	}
+	return nil
+}
+
+// ReadSnapshotDump streams a snapshot dump to the followers recovering from it
+func (store *RaftStore) ReadSnapshotDump(req *filer_raft_pb.ReadSnapshotDumpRequest, stream filer_raft_pb.FilerRaftStore_ReadSnapshotDumpServer) error {
+
+	if req.Name != filepath.Base(req.Name) || !strings.HasSuffix(req.Name, snapshotDumpExt) {
+		return fmt.Errorf("invalid raft snapshot dump name %q", req.Name)
+	}
+	file, err := os.Open(filepath.Join(store.dumpDir, req.Name))
+	if err != nil {
+		return fmt.Errorf("open raft snapshot dump: %v", err)
+	}
+	defer file.Close()
+
+	buf := make([]byte, snapshotDumpChunkSize)
+	for {
+		n, readErr := file.Read(buf)
+		if n > 0 {
+			if err = stream.Send(&filer_raft_pb.ReadSnapshotDumpResponse{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("read raft snapshot dump %s: %v", req.Name, readErr)
+		}
+	}
+}
+
+// each record is the full path and the encoded entry, both 4-byte big endian length prefixed
+func writeSnapshotRecord(w io.Writer, fullpath, meta []byte) error {
+	buf := make([]byte, 8+len(fullpath)+len(meta))
+	binary.BigEndian.PutUint32(buf, uint32(len(fullpath)))
+	copy(buf[4:], fullpath)
+	binary.BigEndian.PutUint32(buf[4+len(fullpath):], uint32(len(meta)))
+	copy(buf[8+len(fullpath):], meta)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readSnapshotRecord(r io.Reader) (fullpath string, meta []byte, err error) {
+	sizeBuf := make([]byte, 4)
+	if _, err = io.ReadFull(r, sizeBuf); err != nil {
+		return
+	}
+	pathBuf := make([]byte, binary.BigEndian.Uint32(sizeBuf))
+	if _, err = io.ReadFull(r, pathBuf); err != nil {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	if _, err = io.ReadFull(r, sizeBuf); err != nil {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	meta = make([]byte, binary.BigEndian.Uint32(sizeBuf))
+	if _, err = io.ReadFull(r, meta); err != nil {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	return string(pathBuf), meta, nil
+}
@@ -0,0 +1,190 @@
+package raft
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goraft "github.com/chrislusf/raft"
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/filer2/leveldb"
+	"github.com/spf13/viper"
+)
+
+// testRaftServer passes the store to the commands, the same as the raft server
+type testRaftServer struct {
+	goraft.Server
+	store *RaftStore
+}
+
+func (s *testRaftServer) Context() interface{} {
+	return s.store
+}
+
+func newTestRaftStore(t *testing.T, dir string) *RaftStore {
+	store := &RaftStore{
+		local:   &leveldb.LevelDBStore{},
+		dumpDir: filepath.Join(dir, "dump"),
+	}
+	metaConfiguration := viper.New()
+	metaConfiguration.Set("dir", filepath.Join(dir, "meta"))
+	if err := store.local.Initialize(metaConfiguration); err != nil {
+		t.Fatalf("local store creation: %v", err)
+	}
+	if err := os.MkdirAll(store.dumpDir, 0755); err != nil {
+		t.Fatalf("dump dir creation: %v", err)
+	}
+	return store
+}
+
+func testEntry(fullpath string) *filer2.Entry {
+	return &filer2.Entry{
+		FullPath: filer2.FullPath(fullpath),
+		Attr:     filer2.Attr{Mode: 0644, Uid: 1234},
+	}
+}
+
+func TestApplyCommands(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft_store")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	store := newTestRaftStore(t, dir)
+	server := &testRaftServer{store: store}
+
+	apply := func(command goraft.Command) error {
+		_, err := command.Apply(server)
+		return err
+	}
+
+	saveCommand, _ := newSaveEntryCommand(testEntry("/a/file1"))
+	if err = apply(saveCommand); err != nil {
+		t.Fatalf("save entry: %v", err)
+	}
+	if entry, err := store.local.FindEntry("/a/file1"); err != nil || entry.Uid != 1234 {
+		t.Fatalf("find saved entry: %+v %v", entry, err)
+	}
+
+	batch := &BatchCommand{}
+	for _, p := range []string{"/a/file2", "/a/file3"} {
+		saveCommand, _ = newSaveEntryCommand(testEntry(p))
+		batch.Changes = append(batch.Changes, BatchChange{FullPath: saveCommand.FullPath, Meta: saveCommand.Meta})
+	}
+	batch.Changes = append(batch.Changes, BatchChange{FullPath: "/a/file1", Deleted: true})
+	if err = apply(batch); err != nil {
+		t.Fatalf("apply batch: %v", err)
+	}
+	for p, exists := range map[string]bool{"/a/file1": false, "/a/file2": true, "/a/file3": true} {
+		if _, err = store.local.FindEntry(filer2.FullPath(p)); (err == nil) != exists {
+			t.Errorf("%s after the batch: %v", p, err)
+		}
+	}
+
+	// a batch with an invalid change is not applied at all
+	invalid := &BatchCommand{Changes: []BatchChange{
+		{FullPath: "/a/file2", Deleted: true},
+		{FullPath: "/a/file4", Meta: []byte("not an entry")},
+	}}
+	if err = apply(invalid); err == nil {
+		t.Fatalf("apply invalid batch: no error")
+	}
+	if _, err = store.local.FindEntry("/a/file2"); err != nil {
+		t.Errorf("partially applied batch: %v", err)
+	}
+
+	if err = apply(&DeleteFolderChildrenCommand{FullPath: "/a"}); err != nil {
+		t.Fatalf("delete folder children: %v", err)
+	}
+	if _, err = store.local.FindEntry("/a/file3"); err != filer2.ErrNotFound {
+		t.Errorf("find deleted folder child: %v", err)
+	}
+}
+
+func TestSnapshotRecords(t *testing.T) {
+	records := [][2]string{{"/a", "meta of a"}, {"/a/b", ""}, {"/empty/meta", ""}}
+
+	var buf bytes.Buffer
+	for _, record := range records {
+		if err := writeSnapshotRecord(&buf, []byte(record[0]), []byte(record[1])); err != nil {
+			t.Fatalf("write record: %v", err)
+		}
+	}
+	data := buf.Bytes()
+
+	r := bytes.NewReader(data)
+	for _, record := range records {
+		fullpath, meta, err := readSnapshotRecord(r)
+		if err != nil || fullpath != record[0] || string(meta) != record[1] {
+			t.Fatalf("read record %v: %q %q %v", record, fullpath, meta, err)
+		}
+	}
+	if _, _, err := readSnapshotRecord(r); err != io.EOF {
+		t.Errorf("read after the last record: %v", err)
+	}
+
+	for _, size := range []int{2, 6, len(data) - 1} {
+		r = bytes.NewReader(data[:size])
+		var err error
+		for err == nil {
+			_, _, err = readSnapshotRecord(r)
+		}
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("read records truncated to %d bytes: %v", size, err)
+		}
+	}
+}
+
+func TestSnapshotSaveAndRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft_store")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := newTestRaftStore(t, filepath.Join(dir, "1"))
+	for _, p := range []string{"/a", "/a/b", "/a/b/file1", "/c"} {
+		entry := testEntry(p)
+		if p == "/a" || p == "/a/b" {
+			entry.Mode |= os.ModeDir
+		}
+		if err = store.local.InsertEntry(entry); err != nil {
+			t.Fatalf("insert %s: %v", p, err)
+		}
+	}
+
+	var data []byte
+	for i := 0; i < snapshotDumpsKept+1; i++ {
+		if data, err = store.Save(); err != nil {
+			t.Fatalf("save snapshot: %v", err)
+		}
+	}
+	if dumps, _ := filepath.Glob(filepath.Join(store.dumpDir, "*")); len(dumps) != snapshotDumpsKept {
+		t.Errorf("snapshot dumps: %v", dumps)
+	}
+
+	// the recovering store has the dump, and its own stale entries
+	recovered := newTestRaftStore(t, filepath.Join(dir, "2"))
+	recovered.dumpDir = store.dumpDir
+	if err = recovered.local.InsertEntry(testEntry("/stale")); err != nil {
+		t.Fatalf("insert stale entry: %v", err)
+	}
+	if err = recovered.Recovery(data); err != nil {
+		t.Fatalf("recover from snapshot: %v", err)
+	}
+	for _, p := range []string{"/a", "/a/b", "/a/b/file1", "/c"} {
+		if entry, err := recovered.local.FindEntry(filer2.FullPath(p)); err != nil || entry.Uid != 1234 {
+			t.Errorf("find recovered %s: %+v %v", p, entry, err)
+		}
+	}
+	if _, err = recovered.local.FindEntry("/stale"); err != filer2.ErrNotFound {
+		t.Errorf("find stale entry: %v", err)
+	}
+
+	if err = recovered.Recovery([]byte(`{"name":"../meta/LOCK.dump"}`)); err == nil {
+		t.Errorf("recover from a dump outside of the dump dir: no error")
+	}
+}
@@ -0,0 +1,64 @@
+package raft
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+)
+
+// BeginTransaction collects the changes into one raft command, which is applied atomically on commit.
+// The reads in the transaction do not see the changes in it.
+func (store *RaftStore) BeginTransaction() (filer2.FilerStoreTransaction, error) {
+	return &raftTransaction{
+		store:   store,
+		command: &BatchCommand{},
+	}, nil
+}
+
+type raftTransaction struct {
+	store   *RaftStore
+	command *BatchCommand
+}
+
+func (t *raftTransaction) InsertEntry(entry *filer2.Entry) error {
+	saveCommand, err := newSaveEntryCommand(entry)
+	if err != nil {
+		return err
+	}
+	t.command.Changes = append(t.command.Changes, BatchChange{
+		FullPath: saveCommand.FullPath,
+		Meta:     saveCommand.Meta,
+	})
+	return nil
+}
+
+func (t *raftTransaction) UpdateEntry(entry *filer2.Entry) error {
+	return t.InsertEntry(entry)
+}
+
+func (t *raftTransaction) FindEntry(fullpath filer2.FullPath) (*filer2.Entry, error) {
+	return t.store.local.FindEntry(fullpath)
+}
+
+func (t *raftTransaction) DeleteEntry(fullpath filer2.FullPath) error {
+	t.command.Changes = append(t.command.Changes, BatchChange{
+		FullPath: string(fullpath),
+		Deleted:  true,
+	})
+	return nil
+}
+
+func (t *raftTransaction) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int,
+	prefix string, eachEntryFn filer2.ListEachEntryFunc) (string, error) {
+	return t.store.local.ListDirectoryEntries(fullpath, startFileName, inclusive, limit, prefix, eachEntryFn)
+}
+
+func (t *raftTransaction) Commit() error {
+	if len(t.command.Changes) == 0 {
+		return nil
+	}
+	return t.store.do(t.command)
+}
+
+func (t *raftTransaction) Rollback() error {
+	t.command.Changes = nil
+	return nil
+}
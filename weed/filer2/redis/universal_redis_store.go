@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"container/heap"
 	"fmt"
 	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -88,53 +89,116 @@ func (store *UniversalRedisStore) DeleteEntry(fullpath filer2.FullPath) (err err
 	return nil
 }
 
-func (store *UniversalRedisStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool,
-	limit int) (entries []*filer2.Entry, err error) {
+func (store *UniversalRedisStore) DeleteFolderChildren(fullpath filer2.FullPath) (err error) {
 
-	members, err := store.Client.SMembers(genDirectoryListKey(string(fullpath))).Result()
-	if err != nil {
-		return nil, fmt.Errorf("list %s : %v", fullpath, err)
-	}
+	listKey := genDirectoryListKey(string(fullpath))
 
-	// skip
-	if startFileName != "" {
-		var t []string
-		for _, m := range members {
-			if strings.Compare(m, startFileName) >= 0 {
-				if m == startFileName {
-					if inclusive {
-						t = append(t, m)
-					}
-				} else {
-					t = append(t, m)
-				}
+	var members []string
+	var cursor uint64
+	for {
+		members, cursor, err = store.Client.SScan(listKey, cursor, "", 1024).Result()
+		if err != nil {
+			return fmt.Errorf("list %s : %v", fullpath, err)
+		}
+		for _, fileName := range members {
+			path := filer2.NewFullPath(string(fullpath), fileName)
+			// the sub folders have their own children lists
+			if err = store.DeleteFolderChildren(path); err != nil {
+				return err
 			}
+			if _, err = store.Client.Del(string(path)).Result(); err != nil {
+				return fmt.Errorf("delete %s : %v", path, err)
+			}
+		}
+		if cursor == 0 {
+			break
 		}
-		members = t
 	}
 
-	// sort
-	sort.Slice(members, func(i, j int) bool {
-		return strings.Compare(members[i], members[j]) < 0
-	})
+	if _, err = store.Client.Del(listKey).Result(); err != nil {
+		return fmt.Errorf("delete %s children list : %v", fullpath, err)
+	}
+
+	return nil
+}
 
-	// limit
-	if limit < len(members) {
-		members = members[:limit]
+func (store *UniversalRedisStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int, prefix string, eachEntryFn filer2.ListEachEntryFunc) (lastFileName string, err error) {
+
+	// scan the unordered set, only keeping the first limit names, instead of loading the whole directory
+	names := &nameHeap{seen: make(map[string]bool)}
+	listKey := genDirectoryListKey(string(fullpath))
+	var members []string
+	var cursor uint64
+	for {
+		members, cursor, err = store.Client.SScan(listKey, cursor, globEscape(prefix)+"*", 1024).Result()
+		if err != nil {
+			return "", fmt.Errorf("list %s : %v", fullpath, err)
+		}
+		for _, m := range members {
+			if !strings.HasPrefix(m, prefix) || m < startFileName || m == startFileName && !inclusive {
+				continue
+			}
+			names.add(m, limit)
+		}
+		if cursor == 0 {
+			break
+		}
 	}
+	sort.Strings(names.names)
 
 	// fetch entry meta
-	for _, fileName := range members {
+	for _, fileName := range names.names {
 		path := filer2.NewFullPath(string(fullpath), fileName)
 		entry, err := store.FindEntry(path)
 		if err != nil {
 			glog.V(0).Infof("list %s : %v", path, err)
-		} else {
-			entries = append(entries, entry)
+			continue
+		}
+		lastFileName = fileName
+		if !eachEntryFn(entry) {
+			break
 		}
 	}
 
-	return entries, err
+	return lastFileName, nil
+}
+
+// nameHeap keeps the smallest names, with the largest one on top
+type nameHeap struct {
+	names []string
+	seen  map[string]bool // the scan may return a member more than once
+}
+
+func (h *nameHeap) Len() int           { return len(h.names) }
+func (h *nameHeap) Less(i, j int) bool { return h.names[i] > h.names[j] }
+func (h *nameHeap) Swap(i, j int)      { h.names[i], h.names[j] = h.names[j], h.names[i] }
+func (h *nameHeap) Push(x interface{}) { h.names = append(h.names, x.(string)) }
+func (h *nameHeap) Pop() (x interface{}) {
+	x, h.names = h.names[len(h.names)-1], h.names[:len(h.names)-1]
+	return x
+}
+
+func (h *nameHeap) add(name string, limit int) {
+	if h.seen[name] || limit <= 0 {
+		return
+	}
+	if len(h.names) < limit {
+		h.seen[name] = true
+		heap.Push(h, name)
+		return
+	}
+	if name < h.names[0] {
+		delete(h.seen, h.names[0])
+		h.seen[name] = true
+		h.names[0] = name
+		heap.Fix(h, 0)
+	}
+}
+
+// globEscape escapes the glob-style pattern characters of the SSCAN MATCH option
+func globEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`).Replace(s)
 }
 
 func genDirectoryListKey(dir string) (dirList string) {
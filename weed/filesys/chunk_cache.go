@@ -0,0 +1,231 @@
+package filesys
+
+import (
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// ChunkCache keeps the recently read chunks in memory, and the chunks evicted from memory on the local disk.
+// The chunks are never changed once written, so the cached chunks are never invalidated.
+// A nil ChunkCache caches nothing.
+type ChunkCache struct {
+	sync.Mutex
+	memory *chunkLru
+	disk   *chunkLru
+	dir    string
+}
+
+// the chunk files are kept in this sub directory of the chunk cache dir
+const chunkCacheSubDir = "swfs_chunk_cache"
+
+// NewChunkCache creates the chunk cache, with the disk cache only if dir is not empty.
+// The chunk files left in the sub directory by the last mount are removed,
+// so the dir should not be shared with other mounts.
+func NewChunkCache(memorySize int64, dir string, diskSize int64) (*ChunkCache, error) {
+
+	if memorySize <= 0 {
+		return nil, nil
+	}
+
+	c := &ChunkCache{
+		memory: newChunkLru(memorySize),
+	}
+
+	if dir == "" || diskSize <= 0 {
+		return c, nil
+	}
+
+	dir = filepath.Join(dir, chunkCacheSubDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create chunk cache dir %s: %v", dir, err)
+	}
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read chunk cache dir %s: %v", dir, err)
+	}
+	for _, fileInfo := range fileInfos {
+		if strings.HasSuffix(fileInfo.Name(), ".chunk") {
+			os.Remove(filepath.Join(dir, fileInfo.Name()))
+		}
+	}
+
+	c.dir = dir
+	c.disk = newChunkLru(diskSize)
+
+	return c, nil
+}
+
+// CanCache tells whether a chunk of this size is worth caching
+func (c *ChunkCache) CanCache(size uint64) bool {
+	return c != nil && int64(size) <= c.memory.capacity/4
+}
+
+// wholeChunkReadRatio is how much of a chunk a read needs to cover to fetch the whole chunk into the cache,
+// so that the small random reads do not download the whole chunks each time
+const wholeChunkReadRatio = 4
+
+// ShouldFetchWholeChunk tells whether to fetch the whole chunk for reading viewSize bytes of it.
+// The sequential reads still fetch the whole chunks ahead of them.
+func (c *ChunkCache) ShouldFetchWholeChunk(viewSize, chunkSize uint64) bool {
+	return c.CanCache(chunkSize) && viewSize*wholeChunkReadRatio >= chunkSize
+}
+
+func (c *ChunkCache) GetChunk(fileId string) []byte {
+
+	if c == nil {
+		return nil
+	}
+
+	c.Lock()
+	if data := c.memory.get(fileId); data != nil {
+		c.Unlock()
+		return data
+	}
+	onDisk := c.disk != nil && c.disk.contains(fileId)
+	c.Unlock()
+
+	if !onDisk {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(c.chunkFile(fileId))
+	if err != nil {
+		glog.V(0).Infof("read cached chunk %s: %v", fileId, err)
+		c.Lock()
+		c.disk.remove(fileId)
+		c.Unlock()
+		return nil
+	}
+
+	c.SetChunk(fileId, data)
+
+	return data
+}
+
+func (c *ChunkCache) IsCached(fileId string) bool {
+
+	if c == nil {
+		return false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.memory.contains(fileId) || c.disk != nil && c.disk.contains(fileId)
+}
+
+func (c *ChunkCache) SetChunk(fileId string, data []byte) {
+
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	evicted := c.memory.put(fileId, data, int64(len(data)))
+	c.Unlock()
+
+	if c.disk == nil {
+		return
+	}
+
+	// move the chunks evicted from memory to the disk
+	for _, e := range evicted {
+		c.Lock()
+		if c.disk.contains(e.fileId) {
+			c.Unlock()
+			continue
+		}
+		c.Unlock()
+		if err := ioutil.WriteFile(c.chunkFile(e.fileId), e.data, 0644); err != nil {
+			glog.V(0).Infof("write cached chunk %s: %v", e.fileId, err)
+			continue
+		}
+		c.Lock()
+		for _, removed := range c.disk.put(e.fileId, nil, int64(len(e.data))) {
+			os.Remove(c.chunkFile(removed.fileId))
+		}
+		c.Unlock()
+	}
+}
+
+func (c *ChunkCache) chunkFile(fileId string) string {
+	return filepath.Join(c.dir, strings.Replace(fileId, ",", "_", -1)+".chunk")
+}
+
+type chunkEntry struct {
+	fileId string
+	data   []byte
+	size   int64
+}
+
+// chunkLru evicts the least recently used chunks beyond the capacity in bytes
+type chunkLru struct {
+	capacity int64
+	used     int64
+	entries  *list.List
+	index    map[string]*list.Element
+}
+
+func newChunkLru(capacity int64) *chunkLru {
+	return &chunkLru{
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+func (l *chunkLru) get(fileId string) []byte {
+	element, found := l.index[fileId]
+	if !found {
+		return nil
+	}
+	l.entries.MoveToFront(element)
+	return element.Value.(*chunkEntry).data
+}
+
+func (l *chunkLru) contains(fileId string) bool {
+	element, found := l.index[fileId]
+	if found {
+		l.entries.MoveToFront(element)
+	}
+	return found
+}
+
+// put adds the chunk, and returns the evicted ones
+func (l *chunkLru) put(fileId string, data []byte, size int64) (evicted []*chunkEntry) {
+
+	if _, found := l.index[fileId]; found {
+		return nil
+	}
+
+	e := &chunkEntry{fileId: fileId, data: data, size: size}
+	l.index[fileId] = l.entries.PushFront(e)
+	l.used += e.size
+
+	for l.used > l.capacity && l.entries.Len() > 1 {
+		oldest := l.entries.Back()
+		evicted = append(evicted, l.removeElement(oldest))
+	}
+
+	return evicted
+}
+
+func (l *chunkLru) remove(fileId string) {
+	if element, found := l.index[fileId]; found {
+		l.removeElement(element)
+	}
+}
+
+func (l *chunkLru) removeElement(element *list.Element) *chunkEntry {
+	e := l.entries.Remove(element).(*chunkEntry)
+	delete(l.index, e.fileId)
+	l.used -= e.size
+	return e
+}
@@ -0,0 +1,123 @@
+package filesys
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChunkLruEviction(t *testing.T) {
+	l := newChunkLru(100)
+
+	for i := 0; i < 3; i++ {
+		if evicted := l.put(fmt.Sprintf("1,%d", i), nil, 30); len(evicted) != 0 {
+			t.Fatalf("put chunk %d: evicted %d chunks", i, len(evicted))
+		}
+	}
+	// using the oldest chunk makes the second one the least recently used
+	l.get("1,0")
+	evicted := l.put("1,3", nil, 30)
+	if len(evicted) != 1 || evicted[0].fileId != "1,1" {
+		t.Fatalf("evicted %+v", evicted)
+	}
+	if l.used != 90 || l.contains("1,1") || !l.contains("1,0") {
+		t.Errorf("used %d bytes after eviction", l.used)
+	}
+
+	// a chunk larger than the capacity is still kept, evicting all the others
+	if evicted = l.put("1,4", nil, 200); len(evicted) != 3 || l.used != 200 || !l.contains("1,4") {
+		t.Errorf("put large chunk: evicted %d, used %d", len(evicted), l.used)
+	}
+
+	l.remove("1,4")
+	if l.used != 0 || l.entries.Len() != 0 || len(l.index) != 0 {
+		t.Errorf("remove: used %d, %d entries", l.used, l.entries.Len())
+	}
+}
+
+func TestChunkCacheDiskSpill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chunk_cache")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// only the chunk files in the sub directory are removed on start
+	otherFile := filepath.Join(dir, "other.chunk")
+	staleFile := filepath.Join(dir, chunkCacheSubDir, "9_stale.chunk")
+	os.MkdirAll(filepath.Dir(staleFile), 0755)
+	for _, f := range []string{otherFile, staleFile} {
+		if err = ioutil.WriteFile(f, []byte("x"), 0644); err != nil {
+			t.Fatalf("write %s: %v", f, err)
+		}
+	}
+
+	c, err := NewChunkCache(100, dir, 100)
+	if err != nil {
+		t.Fatalf("chunk cache creation: %v", err)
+	}
+	if _, err = os.Stat(otherFile); err != nil {
+		t.Errorf("file outside of the chunk cache removed: %v", err)
+	}
+	if _, err = os.Stat(staleFile); !os.IsNotExist(err) {
+		t.Errorf("stale chunk file kept: %v", err)
+	}
+
+	chunk := func(i int) []byte {
+		return bytes.Repeat([]byte{byte(i)}, 40)
+	}
+	for i := 0; i < 5; i++ {
+		c.SetChunk(fmt.Sprintf("1,%d", i), chunk(i))
+	}
+
+	// 2 chunks in memory, 2 on disk, and the oldest one dropped
+	for i, expected := range []bool{false, true, true, true, true} {
+		fileId := fmt.Sprintf("1,%d", i)
+		if c.IsCached(fileId) != expected {
+			t.Errorf("chunk %d cached: %v", i, !expected)
+		}
+		_, err = os.Stat(c.chunkFile(fileId))
+		if onDisk := err == nil; onDisk != (i == 1 || i == 2) {
+			t.Errorf("chunk %d on disk: %v", i, onDisk)
+		}
+	}
+
+	// reading a chunk on disk brings it back to memory
+	if data := c.GetChunk("1,1"); !bytes.Equal(data, chunk(1)) {
+		t.Fatalf("read chunk from disk: %v", data)
+	}
+	if c.memory.get("1,1") == nil {
+		t.Errorf("chunk read from disk not in memory")
+	}
+
+	// a lost chunk file is a cache miss
+	os.Remove(c.chunkFile("1,2"))
+	if data := c.GetChunk("1,2"); data != nil || c.IsCached("1,2") {
+		t.Errorf("read lost chunk file: %v", data)
+	}
+}
+
+func TestShouldFetchWholeChunk(t *testing.T) {
+	var nilCache *ChunkCache
+	if nilCache.ShouldFetchWholeChunk(100, 100) {
+		t.Errorf("fetch whole chunk without the cache")
+	}
+
+	c, _ := NewChunkCache(4000, "", 0)
+	for _, tc := range []struct {
+		viewSize, chunkSize uint64
+		expected            bool
+	}{
+		{1000, 1000, true},
+		{250, 1000, true},
+		{249, 1000, false},
+		{1001, 1001, false},
+	} {
+		if c.ShouldFetchWholeChunk(tc.viewSize, tc.chunkSize) != tc.expected {
+			t.Errorf("read %d bytes of %d bytes chunk: expected %v", tc.viewSize, tc.chunkSize, tc.expected)
+		}
+	}
+}
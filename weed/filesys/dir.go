@@ -2,6 +2,7 @@ package filesys
 
 import (
 	"context"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -234,6 +235,9 @@ func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.
 		resp.Attr.Mode = os.FileMode(entry.Attributes.FileMode)
 		resp.Attr.Gid = entry.Attributes.Gid
 		resp.Attr.Uid = entry.Attributes.Uid
+		if !entry.IsDirectory {
+			resp.Attr.Nlink = hardLinkCount(entry)
+		}
 
 		return node, nil
 	}
@@ -245,46 +249,42 @@ func (dir *Dir) ReadDirAll(ctx context.Context) (ret []fuse.Dirent, err error) {
 
 	err = dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
-		paginationLimit := 1024
-		remaining := dir.wfs.option.DirListingLimit
-
-		lastEntryName := ""
+		request := &filer_pb.ListEntriesRequest{
+			Directory: dir.Path,
+			Limit:     uint32(dir.wfs.option.DirListingLimit),
+		}
 
-		for remaining >= 0 {
+		glog.V(4).Infof("read directory: %v", request)
+		stream, err := client.ListEntries(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("list %s: %v", dir.Path, err)
+			return fuse.EIO
+		}
 
-			request := &filer_pb.ListEntriesRequest{
-				Directory:         dir.Path,
-				StartFromFileName: lastEntryName,
-				Limit:             uint32(paginationLimit),
+		var entries []*filer_pb.Entry
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr == io.EOF {
+				break
 			}
-
-			glog.V(4).Infof("read directory: %v", request)
-			resp, err := client.ListEntries(ctx, request)
-			if err != nil {
-				glog.V(0).Infof("list %s: %v", dir.Path, err)
+			if recvErr != nil {
+				glog.V(0).Infof("list %s: %v", dir.Path, recvErr)
 				return fuse.EIO
 			}
+			entries = append(entries, resp.Entry)
+		}
 
-			cacheTtl := estimatedCacheTtl(len(resp.Entries))
-
-			for _, entry := range resp.Entries {
-				if entry.IsDirectory {
-					dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_Dir}
-					ret = append(ret, dirent)
-				} else {
-					dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_File}
-					ret = append(ret, dirent)
-				}
-				dir.wfs.listDirectoryEntriesCache.Set(path.Join(dir.Path, entry.Name), entry, cacheTtl)
-				lastEntryName = entry.Name
-			}
-
-			remaining -= len(resp.Entries)
+		cacheTtl := estimatedCacheTtl(len(entries))
 
-			if len(resp.Entries) < paginationLimit {
-				break
+		for _, entry := range entries {
+			if entry.IsDirectory {
+				dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_Dir}
+				ret = append(ret, dirent)
+			} else {
+				dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_File}
+				ret = append(ret, dirent)
 			}
-
+			dir.wfs.listDirectoryEntriesCache.Set(path.Join(dir.Path, entry.Name), entry, cacheTtl)
 		}
 
 		return nil
@@ -3,6 +3,7 @@ package filesys
 import (
 	"context"
 	"os"
+	"path"
 	"syscall"
 	"time"
 
@@ -12,9 +13,52 @@ import (
 	"github.com/seaweedfs/fuse/fs"
 )
 
+var _ = fs.NodeLinker(&Dir{})
 var _ = fs.NodeSymlinker(&Dir{})
 var _ = fs.NodeReadlinker(&File{})
 
+func (dir *Dir) Link(ctx context.Context, req *fuse.LinkRequest, old fs.Node) (fs.Node, error) {
+
+	oldFile, ok := old.(*File)
+	if !ok {
+		return nil, fuse.Errno(syscall.EPERM)
+	}
+
+	glog.V(3).Infof("Link: %v/%v to %v/%v", oldFile.dir.Path, oldFile.Name, dir.Path, req.NewName)
+
+	request := &filer_pb.CreateHardLinkRequest{
+		OldDirectory: oldFile.dir.Path,
+		OldName:      oldFile.Name,
+		NewDirectory: dir.Path,
+		NewName:      req.NewName,
+	}
+
+	var entry *filer_pb.Entry
+	err := dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.CreateHardLink(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("link %s/%s to %s/%s: %v", oldFile.dir.Path, oldFile.Name, dir.Path, req.NewName, err)
+			return fuse.EIO
+		}
+		entry = resp.Entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the existing file is now also a hard link
+	if oldFile.entry != nil {
+		oldFile.entry.HardLinkId = entry.HardLinkId
+		oldFile.entry.HardLinkCounter = entry.HardLinkCounter
+	}
+	dir.wfs.listDirectoryEntriesCache.Delete(oldFile.fullpath())
+	dir.wfs.listDirectoryEntriesCache.Delete(path.Join(dir.Path, req.NewName))
+
+	return dir.newFile(req.NewName, entry), nil
+
+}
+
 func (dir *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node, error) {
 
 	glog.V(3).Infof("Symlink: %v/%v to %v", dir.Path, req.NewName, req.Target)
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -14,152 +13,173 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/security"
 )
 
+const (
+	// the intervals are saved with at most this many uploads at the same time
+	concurrentUploads = 4
+	// the intervals closer than this are saved as one chunk, with the saved data in between
+	mergeGapSize = 64 * 1024
+)
+
+// ContinuousDirtyPages buffers the written data of one file handle as multiple intervals,
+// and saves the largest interval as one chunk when the buffered data is over the chunk size limit.
 type ContinuousDirtyPages struct {
-	hasData bool
-	Offset  int64
-	Size    int64
-	Data    []byte
-	f       *File
-	lock    sync.Mutex
+	intervals ContinuousIntervals
+	f         *File
+	lock      sync.Mutex
+	// readSaved reads the data already saved in the chunks
+	readSaved func(ctx context.Context, buff []byte, offset int64) (int64, error)
 }
 
-func newDirtyPages(file *File) *ContinuousDirtyPages {
+func newDirtyPages(file *File, readSaved func(ctx context.Context, buff []byte, offset int64) (int64, error)) *ContinuousDirtyPages {
 	return &ContinuousDirtyPages{
-		Data: nil,
-		f:    file,
+		f:         file,
+		readSaved: readSaved,
 	}
 }
 
 func (pages *ContinuousDirtyPages) releaseResource() {
-	if pages.Data != nil {
-		pages.f.wfs.bufPool.Put(pages.Data)
-		pages.Data = nil
-		atomic.AddInt32(&counter, -1)
-		glog.V(3).Infof("%s/%s releasing resource %d", pages.f.dir.Path, pages.f.Name, counter)
+	pages.lock.Lock()
+	defer pages.lock.Unlock()
+
+	if pages.intervals.Len() > 0 {
+		glog.V(3).Infof("%s/%s releasing %d dirty intervals", pages.f.dir.Path, pages.f.Name, pages.intervals.Len())
+		pages.intervals.RemoveAll()
 	}
 }
 
-var counter = int32(0)
-
 func (pages *ContinuousDirtyPages) AddPage(ctx context.Context, offset int64, data []byte) (chunks []*filer_pb.FileChunk, err error) {
 
 	pages.lock.Lock()
 	defer pages.lock.Unlock()
 
-	var chunk *filer_pb.FileChunk
-
 	if len(data) > int(pages.f.wfs.option.ChunkSizeLimit) {
 		// this is more than what buffer can hold.
 		return pages.flushAndSave(ctx, offset, data)
 	}
 
-	if pages.Data == nil {
-		pages.Data = pages.f.wfs.bufPool.Get().([]byte)
-		atomic.AddInt32(&counter, 1)
-		glog.V(3).Infof("%s/%s acquire resource %d", pages.f.dir.Path, pages.f.Name, counter)
-	}
-
-	if offset < pages.Offset || offset >= pages.Offset+int64(len(pages.Data)) ||
-		pages.Offset+int64(len(pages.Data)) < offset+int64(len(data)) {
-		// if the data is out of range,
-		// or buffer is full if adding new data,
-		// flush current buffer and add new data
+	pages.intervals.AddInterval(offset, data)
 
-		// println("offset", offset, "size", len(data), "existing offset", pages.Offset, "size", pages.Size)
-
-		if chunk, err = pages.saveExistingPagesToStorage(ctx); err == nil {
-			if chunk != nil {
-				glog.V(4).Infof("%s/%s add save [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-				chunks = append(chunks, chunk)
-			}
-		} else {
-			glog.V(0).Infof("%s/%s add save [%d,%d): %v", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size), err)
-			return
+	for pages.intervals.TotalSize() > pages.f.wfs.option.ChunkSizeLimit {
+		interval := pages.intervals.RemoveLargestInterval()
+		chunk, saveErr := pages.saveToStorage(ctx, interval.Data, interval.Offset)
+		if saveErr != nil {
+			glog.V(0).Infof("%s/%s add save [%d,%d): %v", pages.f.dir.Path, pages.f.Name, interval.Offset, interval.Stop(), saveErr)
+			// keep the data to save later
+			pages.intervals.AddInterval(interval.Offset, interval.Data)
+			return chunks, saveErr
 		}
-		pages.Offset = offset
-		copy(pages.Data, data)
-		pages.Size = int64(len(data))
-		return
-	}
-
-	if offset != pages.Offset+pages.Size {
-		// when this happens, debug shows the data overlapping with existing data is empty
-		// the data is not just append
-		if offset == pages.Offset && int(pages.Size) < len(data) {
-			// glog.V(2).Infof("pages[%d,%d) pages.Data len=%v, data len=%d, pages.Size=%d", pages.Offset, pages.Offset+pages.Size, len(pages.Data), len(data), pages.Size)
-			copy(pages.Data[pages.Size:], data[pages.Size:])
-		} else {
-			if pages.Size != 0 {
-				glog.V(1).Infof("%s/%s add page: pages [%d, %d) write [%d, %d)", pages.f.dir.Path, pages.f.Name, pages.Offset, pages.Offset+pages.Size, offset, offset+int64(len(data)))
-			}
-			return pages.flushAndSave(ctx, offset, data)
-		}
-	} else {
-		copy(pages.Data[offset-pages.Offset:], data)
+		glog.V(4).Infof("%s/%s add save [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
+		chunks = append(chunks, chunk)
 	}
 
-	pages.Size = max(pages.Size, offset+int64(len(data))-pages.Offset)
-
 	return
 }
 
 func (pages *ContinuousDirtyPages) flushAndSave(ctx context.Context, offset int64, data []byte) (chunks []*filer_pb.FileChunk, err error) {
 
-	var chunk *filer_pb.FileChunk
-
-	// flush existing
-	if chunk, err = pages.saveExistingPagesToStorage(ctx); err == nil {
-		if chunk != nil {
-			glog.V(4).Infof("%s/%s flush existing [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-			chunks = append(chunks, chunk)
-		}
-	} else {
-		glog.V(0).Infof("%s/%s failed to flush1 [%d,%d): %v", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size), err)
+	// flush existing, so that the new data is saved later and wins over the existing data
+	if chunks, err = pages.saveExistingPagesToStorage(ctx); err != nil {
+		glog.V(0).Infof("%s/%s failed to flush1: %v", pages.f.dir.Path, pages.f.Name, err)
 		return
 	}
-	pages.Size = 0
-	pages.Offset = 0
 
 	// flush the new page
-	if chunk, err = pages.saveToStorage(ctx, data, offset); err == nil {
-		if chunk != nil {
-			glog.V(4).Infof("%s/%s flush big request [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-			chunks = append(chunks, chunk)
-		}
-	} else {
-		glog.V(0).Infof("%s/%s failed to flush2 [%d,%d): %v", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size), err)
+	chunk, err := pages.saveToStorage(ctx, data, offset)
+	if err != nil {
+		glog.V(0).Infof("%s/%s failed to flush2 [%d,%d): %v", pages.f.dir.Path, pages.f.Name, offset, offset+int64(len(data)), err)
 		return
 	}
+	glog.V(4).Infof("%s/%s flush big request [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
+	chunks = append(chunks, chunk)
 
 	return
 }
 
-func (pages *ContinuousDirtyPages) FlushToStorage(ctx context.Context) (chunk *filer_pb.FileChunk, err error) {
+func (pages *ContinuousDirtyPages) FlushToStorage(ctx context.Context) (chunks []*filer_pb.FileChunk, err error) {
 
 	pages.lock.Lock()
 	defer pages.lock.Unlock()
 
-	if pages.Size == 0 {
+	return pages.saveExistingPagesToStorage(ctx)
+}
+
+// saveExistingPagesToStorage saves all the intervals in parallel, each as one chunk,
+// after merging the nearby ones. The intervals failed to save are kept.
+func (pages *ContinuousDirtyPages) saveExistingPagesToStorage(ctx context.Context) (chunks []*filer_pb.FileChunk, err error) {
+
+	intervals := pages.mergeNearbyIntervals(ctx, pages.intervals.RemoveAll())
+	if len(intervals) == 0 {
 		return nil, nil
 	}
 
-	if chunk, err = pages.saveExistingPagesToStorage(ctx); err == nil {
-		pages.Size = 0
-		pages.Offset = 0
-		if chunk != nil {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	uploads := make(chan struct{}, concurrentUploads)
+	for _, interval := range intervals {
+		wg.Add(1)
+		uploads <- struct{}{}
+		go func(interval *DirtyInterval) {
+			defer wg.Done()
+			defer func() { <-uploads }()
+			chunk, saveErr := pages.saveToStorage(ctx, interval.Data, interval.Offset)
+			lock.Lock()
+			defer lock.Unlock()
+			if saveErr != nil {
+				glog.V(0).Infof("%s/%s flush [%d,%d): %v", pages.f.dir.Path, pages.f.Name, interval.Offset, interval.Stop(), saveErr)
+				pages.intervals.AddInterval(interval.Offset, interval.Data)
+				err = saveErr
+				return
+			}
 			glog.V(4).Infof("%s/%s flush [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-		}
+			chunks = append(chunks, chunk)
+		}(interval)
 	}
+	wg.Wait()
+
 	return
 }
 
-func (pages *ContinuousDirtyPages) saveExistingPagesToStorage(ctx context.Context) (*filer_pb.FileChunk, error) {
+// mergeNearbyIntervals merges the sorted intervals with small gaps in between, filling the gaps with
+// the saved data, so that many small writes are not saved as many small chunks.
+// The intervals are kept apart if the saved data can not be read.
+func (pages *ContinuousDirtyPages) mergeNearbyIntervals(ctx context.Context, intervals []*DirtyInterval) []*DirtyInterval {
 
-	if pages.Size == 0 {
-		return nil, nil
+	if len(intervals) < 2 || pages.readSaved == nil {
+		return intervals
 	}
 
-	return pages.saveToStorage(ctx, pages.Data[:pages.Size], pages.Offset)
+	merged := []*DirtyInterval{intervals[0]}
+	for _, next := range intervals[1:] {
+		last := merged[len(merged)-1]
+		gap := next.Offset - last.Stop()
+		if gap > mergeGapSize || next.Stop()-last.Offset > pages.f.wfs.option.ChunkSizeLimit {
+			merged = append(merged, next)
+			continue
+		}
+		// beyond the saved data, the gap is a hole in the file
+		gapData := make([]byte, gap)
+		if _, err := pages.readSaved(ctx, gapData, last.Stop()); err != nil {
+			glog.V(1).Infof("%s/%s read saved [%d,%d): %v", pages.f.dir.Path, pages.f.Name, last.Stop(), next.Offset, err)
+			merged = append(merged, next)
+			continue
+		}
+		data := make([]byte, 0, next.Stop()-last.Offset)
+		data = append(data, last.Data...)
+		data = append(data, gapData...)
+		data = append(data, next.Data...)
+		merged[len(merged)-1] = &DirtyInterval{Offset: last.Offset, Data: data}
+	}
+
+	return merged
+}
+
+// ReadDirtyData copies the not yet saved data over the data read from the chunks
+func (pages *ContinuousDirtyPages) ReadDirtyData(data []byte, startOffset int64) (maxStop int64) {
+
+	pages.lock.Lock()
+	defer pages.lock.Unlock()
+
+	return pages.intervals.ReadData(data, startOffset)
 }
 
 func (pages *ContinuousDirtyPages) saveToStorage(ctx context.Context, buf []byte, offset int64) (*filer_pb.FileChunk, error) {
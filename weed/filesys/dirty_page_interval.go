@@ -0,0 +1,155 @@
+package filesys
+
+import (
+	"sort"
+)
+
+// DirtyInterval is one range of the written but not yet saved data
+type DirtyInterval struct {
+	Offset int64
+	Data   []byte
+}
+
+func (interval *DirtyInterval) Stop() int64 {
+	return interval.Offset + int64(len(interval.Data))
+}
+
+// ContinuousIntervals keeps the written data as sorted non-overlapping intervals.
+// The overlapping or adjacent writes are merged into one interval, with the later write winning.
+type ContinuousIntervals struct {
+	intervals []*DirtyInterval
+	totalSize int64
+}
+
+func (c *ContinuousIntervals) TotalSize() int64 {
+	return c.totalSize
+}
+
+func (c *ContinuousIntervals) Len() int {
+	return len(c.intervals)
+}
+
+// AddInterval copies the data, and merges it with the overlapping or adjacent intervals
+func (c *ContinuousIntervals) AddInterval(offset int64, data []byte) {
+
+	stop := offset + int64(len(data))
+
+	// the intervals from i to j-1 overlap or touch [offset, stop)
+	i := sort.Search(len(c.intervals), func(k int) bool {
+		return c.intervals[k].Stop() >= offset
+	})
+	j := i
+	for j < len(c.intervals) && c.intervals[j].Offset <= stop {
+		j++
+	}
+
+	// the sequential writes extend the interval they follow in place
+	if j-i == 1 && c.intervals[i].Offset <= offset {
+		interval := c.intervals[i]
+		if stop > interval.Stop() {
+			c.totalSize += stop - interval.Stop()
+			interval.Data = growData(interval.Data, int(stop-interval.Offset))
+		}
+		copy(interval.Data[offset-interval.Offset:], data)
+		return
+	}
+
+	start, end := offset, stop
+	if i < j {
+		if c.intervals[i].Offset < start {
+			start = c.intervals[i].Offset
+		}
+		if c.intervals[j-1].Stop() > end {
+			end = c.intervals[j-1].Stop()
+		}
+	}
+
+	merged := &DirtyInterval{
+		Offset: start,
+		Data:   make([]byte, end-start),
+	}
+	for _, existing := range c.intervals[i:j] {
+		copy(merged.Data[existing.Offset-start:], existing.Data)
+		c.totalSize -= int64(len(existing.Data))
+	}
+	copy(merged.Data[offset-start:], data)
+	c.totalSize += int64(len(merged.Data))
+
+	if i == j {
+		c.intervals = append(c.intervals, nil)
+		copy(c.intervals[i+1:], c.intervals[i:])
+	} else {
+		c.intervals = append(c.intervals[:i+1], c.intervals[j:]...)
+	}
+	c.intervals[i] = merged
+}
+
+// growData resizes the data, doubling the capacity when it is not enough
+func growData(data []byte, size int) []byte {
+	if size <= cap(data) {
+		return data[:size]
+	}
+	newCapacity := 2 * cap(data)
+	if newCapacity < size {
+		newCapacity = size
+	}
+	grown := make([]byte, size, newCapacity)
+	copy(grown, data)
+	return grown
+}
+
+// RemoveLargestInterval takes out the largest interval, to save it as one chunk
+func (c *ContinuousIntervals) RemoveLargestInterval() *DirtyInterval {
+
+	if len(c.intervals) == 0 {
+		return nil
+	}
+
+	largest := 0
+	for k, interval := range c.intervals {
+		if len(interval.Data) > len(c.intervals[largest].Data) {
+			largest = k
+		}
+	}
+
+	interval := c.intervals[largest]
+	c.intervals = append(c.intervals[:largest], c.intervals[largest+1:]...)
+	c.totalSize -= int64(len(interval.Data))
+
+	return interval
+}
+
+// RemoveAll takes out all the intervals
+func (c *ContinuousIntervals) RemoveAll() []*DirtyInterval {
+	intervals := c.intervals
+	c.intervals = nil
+	c.totalSize = 0
+	return intervals
+}
+
+// ReadData copies the written data in [startOffset, startOffset+len(data)) into data,
+// and returns the stop offset of the copied data, or startOffset if none is copied
+func (c *ContinuousIntervals) ReadData(data []byte, startOffset int64) (maxStop int64) {
+
+	maxStop = startOffset
+	stopOffset := startOffset + int64(len(data))
+
+	for _, interval := range c.intervals {
+		start := max(startOffset, interval.Offset)
+		stop := min(stopOffset, interval.Stop())
+		if start >= stop {
+			continue
+		}
+		copy(data[start-startOffset:stop-startOffset], interval.Data[start-interval.Offset:stop-interval.Offset])
+		maxStop = max(maxStop, stop)
+	}
+
+	return
+}
+
+func min(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
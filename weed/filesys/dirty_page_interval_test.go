@@ -0,0 +1,82 @@
+package filesys
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestContinuousIntervals(t *testing.T) {
+
+	c := &ContinuousIntervals{}
+
+	c.AddInterval(10, []byte("aaaaa"))  // [10,15)
+	c.AddInterval(30, []byte("bbbbb"))  // [30,35)
+	c.AddInterval(13, []byte("ccc"))    // overlaps [10,15), extends to 16
+	c.AddInterval(16, []byte("dd"))     // adjacent to [10,16)
+	c.AddInterval(100, []byte("eeeee")) // [100,105)
+
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 intervals, got %d", c.Len())
+	}
+	if c.TotalSize() != 8+5+5 {
+		t.Fatalf("unexpected total size %d", c.TotalSize())
+	}
+
+	data := make([]byte, 10)
+	maxStop := c.ReadData(data, 9)
+	if maxStop != 18 || !bytes.Equal(data[:9], []byte("\x00aaacccdd")) {
+		t.Fatalf("unexpected read %q, stop at %d", data, maxStop)
+	}
+
+	// one write covering all of [10,35)
+	c.AddInterval(8, make([]byte, 30))
+	if c.Len() != 2 || c.TotalSize() != 30+5 {
+		t.Fatalf("expected 2 intervals of 35 bytes, got %d of %d bytes", c.Len(), c.TotalSize())
+	}
+
+	largest := c.RemoveLargestInterval()
+	if largest.Offset != 8 || largest.Stop() != 38 {
+		t.Fatalf("unexpected largest interval [%d,%d)", largest.Offset, largest.Stop())
+	}
+	if c.Len() != 1 || c.TotalSize() != 5 {
+		t.Fatalf("expected 1 interval of 5 bytes, got %d of %d bytes", c.Len(), c.TotalSize())
+	}
+
+	if maxStop = c.ReadData(data, 0); maxStop != 0 {
+		t.Fatalf("read removed interval, stop at %d", maxStop)
+	}
+
+}
+
+func TestSequentialIntervals(t *testing.T) {
+
+	c := &ContinuousIntervals{}
+
+	var expected []byte
+	for i := 0; i < 1000; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 10)
+		c.AddInterval(int64(100+10*i), data)
+		expected = append(expected, data...)
+	}
+	// overwriting the middle, and extending the end with an overlapping write
+	c.AddInterval(200, []byte("middle"))
+	copy(expected[100:], "middle")
+	c.AddInterval(10095, []byte("0123456789"))
+	expected = append(expected[:9995], "0123456789"...)
+
+	if c.Len() != 1 || c.TotalSize() != int64(len(expected)) {
+		t.Fatalf("expected 1 interval of %d bytes, got %d of %d bytes", len(expected), c.Len(), c.TotalSize())
+	}
+	if interval := c.intervals[0]; interval.Offset != 100 || !bytes.Equal(interval.Data, expected) {
+		t.Fatalf("unexpected interval [%d,%d)", interval.Offset, interval.Stop())
+	}
+
+	// a write before the interval merges into a new one
+	c.AddInterval(95, []byte("head!"))
+	expected = append([]byte("head!"), expected...)
+	data := make([]byte, len(expected))
+	if maxStop := c.ReadData(data, 95); c.Len() != 1 || maxStop != 95+int64(len(expected)) || !bytes.Equal(data, expected) {
+		t.Fatalf("unexpected %d intervals, stop at %d", c.Len(), maxStop)
+	}
+
+}
@@ -0,0 +1,54 @@
+package filesys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestMergeNearbyIntervals(t *testing.T) {
+
+	// the saved data is "s" up to offset 1000, and a hole after it
+	var failRead bool
+	readSaved := func(ctx context.Context, buff []byte, offset int64) (int64, error) {
+		if failRead {
+			return 0, fmt.Errorf("volume server is unavailable")
+		}
+		var n int64
+		for ; n < int64(len(buff)) && offset+n < 1000; n++ {
+			buff[n] = 's'
+		}
+		return n, nil
+	}
+	file := &File{wfs: &WFS{option: &Option{ChunkSizeLimit: 128 * 1024}}, dir: &Dir{Path: "/"}, Name: "file"}
+	pages := newDirtyPages(file, readSaved)
+
+	intervals := func() []*DirtyInterval {
+		return []*DirtyInterval{
+			{Offset: 990, Data: []byte("aa")},
+			{Offset: 1005, Data: []byte("bb")},
+			{Offset: 1010 + mergeGapSize, Data: []byte("cc")},
+			{Offset: 100 * 1024, Data: make([]byte, 100*1024)},
+		}
+	}
+
+	merged := pages.mergeNearbyIntervals(context.Background(), intervals())
+	if len(merged) != 3 {
+		t.Fatalf("merged into %d intervals", len(merged))
+	}
+	expected := append([]byte("aassssssss"), make([]byte, 5)...)
+	expected = append(expected, "bb"...)
+	if merged[0].Offset != 990 || !bytes.Equal(merged[0].Data, expected) {
+		t.Errorf("merged interval [%d,%d): %q", merged[0].Offset, merged[0].Stop(), merged[0].Data)
+	}
+	// the last interval is close, but would make the chunk too large
+	if merged[1].Offset != 1010+mergeGapSize || merged[2].Offset != 100*1024 || len(merged[2].Data) != 100*1024 {
+		t.Errorf("merged over the gap or the chunk size limit: [%d,%d) [%d,%d)", merged[1].Offset, merged[1].Stop(), merged[2].Offset, merged[2].Stop())
+	}
+
+	failRead = true
+	if merged = pages.mergeNearbyIntervals(context.Background(), intervals()); len(merged) != 4 {
+		t.Errorf("merged %d intervals without the saved data", len(merged))
+	}
+}
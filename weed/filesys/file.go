@@ -47,6 +47,7 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) error {
 	attr.Uid = file.entry.Attributes.Uid
 	attr.Blocks = attr.Size/blockSize + 1
 	attr.BlockSize = uint32(file.wfs.option.ChunkSizeLimit)
+	attr.Nlink = hardLinkCount(file.entry)
 
 	return nil
 
@@ -174,12 +175,6 @@ func (file *File) maybeLoadAttributes(ctx context.Context) error {
 	return nil
 }
 
-func (file *File) addChunk(chunk *filer_pb.FileChunk) {
-	if chunk != nil {
-		file.addChunks([]*filer_pb.FileChunk{chunk})
-	}
-}
-
 func (file *File) addChunks(chunks []*filer_pb.FileChunk) {
 
 	sort.Slice(chunks, func(i, j int) bool {
@@ -197,7 +192,20 @@ func (file *File) addChunks(chunks []*filer_pb.FileChunk) {
 	file.entry.Chunks = append(file.entry.Chunks, chunks...)
 }
 
+func (file *File) setEntryChunks(chunks []*filer_pb.FileChunk) {
+	file.entry.Chunks = chunks
+	file.entryViewCache = filer2.NonOverlappingVisibleIntervals(chunks)
+}
+
 func (file *File) setEntry(entry *filer_pb.Entry) {
 	file.entry = entry
 	file.entryViewCache = filer2.NonOverlappingVisibleIntervals(file.entry.Chunks)
 }
+
+// hardLinkCount is the number of the hard links of the file, which is 1 for a file never linked
+func hardLinkCount(entry *filer_pb.Entry) uint32 {
+	if entry.HardLinkCounter > 0 {
+		return uint32(entry.HardLinkCounter)
+	}
+	return 1
+}
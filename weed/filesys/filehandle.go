@@ -11,6 +11,7 @@ import (
 	"github.com/seaweedfs/fuse"
 	"github.com/seaweedfs/fuse/fs"
 	"google.golang.org/grpc"
+	"math"
 	"net/http"
 	"strings"
 	"sync"
@@ -24,6 +25,11 @@ type FileHandle struct {
 	dirtyMetadata bool
 	handle        uint64
 
+	// to detect the sequential reads
+	readAheadLock sync.Mutex
+	lastReadStop  int64
+	readAheadStop int64
+
 	f         *File
 	RequestId fuse.RequestID // unique ID for request
 	NodeId    fuse.NodeID    // file or directory the request is about
@@ -32,12 +38,13 @@ type FileHandle struct {
 }
 
 func newFileHandle(file *File, uid, gid uint32) *FileHandle {
-	return &FileHandle{
-		f:          file,
-		dirtyPages: newDirtyPages(file),
-		Uid:        uid,
-		Gid:        gid,
+	fh := &FileHandle{
+		f:   file,
+		Uid: uid,
+		Gid: gid,
 	}
+	fh.dirtyPages = newDirtyPages(file, fh.readFromChunks)
+	return fh
 }
 
 var _ = fs.Handle(&FileHandle{})
@@ -52,90 +59,208 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 
 	glog.V(4).Infof("%s read fh %d: [%d,%d)", fh.f.fullpath(), fh.handle, req.Offset, req.Offset+int64(req.Size))
 
+	buff := make([]byte, req.Size)
+
+	totalRead, err := fh.readFromChunks(ctx, buff, req.Offset)
+	fh.maybeReadAhead(req.Offset, req.Offset+totalRead)
+
+	// the written data not saved as chunks yet
+	if maxStop := fh.dirtyPages.ReadDirtyData(buff, req.Offset); maxStop-req.Offset > totalRead {
+		totalRead = maxStop - req.Offset
+	}
+
+	resp.Data = buff[:totalRead]
+
+	return err
+}
+
+func (fh *FileHandle) readFromChunks(ctx context.Context, buff []byte, offset int64) (int64, error) {
+
 	// this value should come from the filer instead of the old f
 	if len(fh.f.entry.Chunks) == 0 {
 		glog.V(1).Infof("empty fh %v/%v", fh.f.dir.Path, fh.f.Name)
-		return nil
+		return 0, nil
 	}
 
-	buff := make([]byte, req.Size)
-
 	if fh.f.entryViewCache == nil {
 		fh.f.entryViewCache = filer2.NonOverlappingVisibleIntervals(fh.f.entry.Chunks)
 	}
 
-	chunkViews := filer2.ViewFromVisibleIntervals(fh.f.entryViewCache, req.Offset, req.Size)
+	chunkViews := filer2.ViewFromVisibleIntervals(fh.f.entryViewCache, offset, len(buff))
+	chunkSizes := fh.chunkSizes()
+	chunkCache := fh.f.wfs.chunkCache
 
+	var totalRead int64
+	var missingViews []*filer2.ChunkView
 	var vids []string
 	for _, chunkView := range chunkViews {
+		if data := chunkCache.GetChunk(chunkView.FileId); data != nil && copyChunkView(buff, data, chunkView, offset) {
+			glog.V(4).Infof("read fh cached chunk: %+v", chunkView)
+			totalRead += int64(chunkView.Size)
+			continue
+		}
+		missingViews = append(missingViews, chunkView)
 		vids = append(vids, volumeId(chunkView.FileId))
 	}
 
-	vid2Locations := make(map[string]*filer_pb.Locations)
+	var err error
+	if len(missingViews) > 0 {
 
-	err := fh.f.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		vid2Locations, lookupErr := fh.f.wfs.lookupVolumes(ctx, vids)
+		if lookupErr != nil {
+			glog.V(4).Infof("%v/%v read fh lookup volume ids: %v", fh.f.dir.Path, fh.f.Name, lookupErr)
+			return 0, fmt.Errorf("failed to lookup volume ids %v: %v", vids, lookupErr)
+		}
 
-		glog.V(4).Infof("read fh lookup volume id locations: %v", vids)
-		resp, err := client.LookupVolume(ctx, &filer_pb.LookupVolumeRequest{
-			VolumeIds: vids,
-		})
-		if err != nil {
-			return err
+		var wg sync.WaitGroup
+		var lock sync.Mutex
+		for _, chunkView := range missingViews {
+			wg.Add(1)
+			go func(chunkView *filer2.ChunkView) {
+				defer wg.Done()
+
+				glog.V(4).Infof("read fh reading chunk: %+v", chunkView)
+
+				n, readErr := fh.readChunkView(chunkView, chunkSizes[chunkView.FileId], vid2Locations, buff, offset)
+
+				lock.Lock()
+				defer lock.Unlock()
+				if readErr != nil {
+					err = readErr
+					return
+				}
+				glog.V(4).Infof("read fh read %d bytes: %+v", n, chunkView)
+				totalRead += n
+
+			}(chunkView)
 		}
+		wg.Wait()
+	}
+
+	return totalRead, err
+}
 
-		vid2Locations = resp.LocationsMap
+// chunkSizes maps the file ids to the whole chunk sizes
+func (fh *FileHandle) chunkSizes() map[string]uint64 {
+	chunkSizes := make(map[string]uint64, len(fh.f.entry.Chunks))
+	for _, chunk := range fh.f.entry.Chunks {
+		chunkSizes[chunk.FileId] = chunk.Size
+	}
+	return chunkSizes
+}
 
-		return nil
-	})
+// readChunkView reads the whole chunk into the chunk cache if it is small enough and mostly viewed,
+// or just the viewed range
+func (fh *FileHandle) readChunkView(chunkView *filer2.ChunkView, chunkSize uint64, vid2Locations map[string]*filer_pb.Locations, buff []byte, offset int64) (int64, error) {
+
+	locations := vid2Locations[volumeId(chunkView.FileId)]
+	if locations == nil || len(locations.Locations) == 0 {
+		glog.V(0).Infof("failed to locate %s", chunkView.FileId)
+		return 0, fmt.Errorf("failed to locate %s", chunkView.FileId)
+	}
+	fileUrl := fmt.Sprintf("http://%s/%s", locations.Locations[0].Url, chunkView.FileId)
+
+	if fh.f.wfs.chunkCache.ShouldFetchWholeChunk(chunkView.Size, chunkSize) {
+		data, err := fetchChunk(fileUrl, chunkSize)
+		if err == nil && copyChunkView(buff, data, chunkView, offset) {
+			fh.f.wfs.chunkCache.SetChunk(chunkView.FileId, data)
+			return int64(chunkView.Size), nil
+		}
+		glog.V(0).Infof("%v/%v read whole chunk %s: %v", fh.f.dir.Path, fh.f.Name, fileUrl, err)
+	}
+
+	n, err := util.ReadUrl(
+		fileUrl,
+		chunkView.Offset,
+		int(chunkView.Size),
+		buff[chunkView.LogicOffset-offset:chunkView.LogicOffset-offset+int64(chunkView.Size)],
+		!chunkView.IsFullChunk)
 
 	if err != nil {
-		glog.V(4).Infof("%v/%v read fh lookup volume ids: %v", fh.f.dir.Path, fh.f.Name, err)
-		return fmt.Errorf("failed to lookup volume ids %v: %v", vids, err)
+
+		glog.V(0).Infof("%v/%v read %s %v bytes: %v", fh.f.dir.Path, fh.f.Name, fileUrl, n, err)
+
+		return n, fmt.Errorf("failed to read %s: %v", fileUrl, err)
 	}
 
-	var totalRead int64
-	var wg sync.WaitGroup
-	for _, chunkView := range chunkViews {
-		wg.Add(1)
-		go func(chunkView *filer2.ChunkView) {
-			defer wg.Done()
+	return n, nil
+}
 
-			glog.V(4).Infof("read fh reading chunk: %+v", chunkView)
+// maybeReadAhead fetches the following chunks into the chunk cache in the background, for the sequential reads
+func (fh *FileHandle) maybeReadAhead(start, stop int64) {
 
-			locations := vid2Locations[volumeId(chunkView.FileId)]
-			if locations == nil || len(locations.Locations) == 0 {
-				glog.V(0).Infof("failed to locate %s", chunkView.FileId)
-				err = fmt.Errorf("failed to locate %s", chunkView.FileId)
-				return
-			}
+	readAheadChunks := fh.f.wfs.option.ReadAheadChunks
+	chunkCache := fh.f.wfs.chunkCache
 
-			var n int64
-			n, err = util.ReadUrl(
-				fmt.Sprintf("http://%s/%s", locations.Locations[0].Url, chunkView.FileId),
-				chunkView.Offset,
-				int(chunkView.Size),
-				buff[chunkView.LogicOffset-req.Offset:chunkView.LogicOffset-req.Offset+int64(chunkView.Size)],
-				!chunkView.IsFullChunk)
+	fh.readAheadLock.Lock()
+	defer fh.readAheadLock.Unlock()
 
-			if err != nil {
+	isSequential := start == fh.lastReadStop
+	fh.lastReadStop = stop
+	if !isSequential || readAheadChunks <= 0 || chunkCache == nil {
+		return
+	}
 
-				glog.V(0).Infof("%v/%v read http://%s/%v %v bytes: %v", fh.f.dir.Path, fh.f.Name, locations.Locations[0].Url, chunkView.FileId, n, err)
+	chunkSizes := fh.chunkSizes()
+	var fileIds []string
+	for i, visible := range filer2.ViewFromVisibleIntervals(fh.f.entryViewCache, max(stop, fh.readAheadStop), math.MaxInt32) {
+		if i >= readAheadChunks {
+			break
+		}
+		fh.readAheadStop = visible.LogicOffset + int64(visible.Size)
+		if chunkCache.CanCache(chunkSizes[visible.FileId]) && !chunkCache.IsCached(visible.FileId) {
+			fileIds = append(fileIds, visible.FileId)
+		}
+	}
+	if len(fileIds) == 0 {
+		return
+	}
 
-				err = fmt.Errorf("failed to read http://%s/%s: %v",
-					locations.Locations[0].Url, chunkView.FileId, err)
+	go func() {
+		var vids []string
+		for _, fileId := range fileIds {
+			vids = append(vids, volumeId(fileId))
+		}
+		vid2Locations, err := fh.f.wfs.lookupVolumes(context.Background(), vids)
+		if err != nil {
+			glog.V(1).Infof("read ahead lookup volume ids %v: %v", vids, err)
+			return
+		}
+		for _, fileId := range fileIds {
+			locations := vid2Locations[volumeId(fileId)]
+			if locations == nil || len(locations.Locations) == 0 {
+				continue
+			}
+			data, err := fetchChunk(fmt.Sprintf("http://%s/%s", locations.Locations[0].Url, fileId), chunkSizes[fileId])
+			if err != nil {
+				glog.V(1).Infof("read ahead %s: %v", fileId, err)
 				return
 			}
+			glog.V(4).Infof("read ahead chunk %s: %d bytes", fileId, len(data))
+			chunkCache.SetChunk(fileId, data)
+		}
+	}()
+}
 
-			glog.V(4).Infof("read fh read %d bytes: %+v", n, chunkView)
-			totalRead += n
-
-		}(chunkView)
+func fetchChunk(fileUrl string, chunkSize uint64) ([]byte, error) {
+	data := make([]byte, chunkSize)
+	n, err := util.ReadUrl(fileUrl, 0, int(chunkSize), data, false)
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
-
-	resp.Data = buff[:totalRead]
+	if uint64(n) != chunkSize {
+		return nil, fmt.Errorf("read %d bytes, expected %d", n, chunkSize)
+	}
+	return data, nil
+}
 
-	return err
+// copyChunkView copies the viewed range of the whole chunk data into the read buffer
+func copyChunkView(buff []byte, data []byte, chunkView *filer2.ChunkView, offset int64) bool {
+	if chunkView.Offset+int64(chunkView.Size) > int64(len(data)) {
+		return false
+	}
+	copy(buff[chunkView.LogicOffset-offset:], data[chunkView.Offset:chunkView.Offset+int64(chunkView.Size)])
+	return true
 }
 
 // Write to the file handle
@@ -185,13 +310,13 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 	// send the data to the OS
 	glog.V(4).Infof("%s fh %d flush %v", fh.f.fullpath(), fh.handle, req)
 
-	chunk, err := fh.dirtyPages.FlushToStorage(ctx)
+	chunks, err := fh.dirtyPages.FlushToStorage(ctx)
 	if err != nil {
 		glog.Errorf("flush %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
 		return fmt.Errorf("flush %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
 	}
 
-	fh.f.addChunk(chunk)
+	fh.f.addChunks(chunks)
 
 	if !fh.dirtyMetadata {
 		return nil
@@ -218,15 +343,16 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 		//	glog.V(4).Infof("%s/%s chunks %d: %v [%d,%d)", fh.f.dir.Path, fh.f.Name, i, chunk.FileId, chunk.Offset, chunk.Offset+int64(chunk.Size))
 		//}
 
+		// the random writes leave many overlapping chunks, drop the ones fully overwritten
 		chunks, garbages := filer2.CompactFileChunks(fh.f.entry.Chunks)
-		fh.f.entry.Chunks = chunks
-		// fh.f.entryViewCache = nil
-		fh.f.wfs.deleteFileChunks(garbages)
+		fh.f.setEntryChunks(chunks)
 
 		if _, err := client.CreateEntry(ctx, request); err != nil {
 			return fmt.Errorf("update fh: %v", err)
 		}
 
+		fh.f.wfs.deleteFileChunks(garbages)
+
 		return nil
 	})
 }
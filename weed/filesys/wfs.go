@@ -29,6 +29,13 @@ type Option struct {
 	DirListingLimit    int
 	EntryCacheTtl      time.Duration
 
+	// the chunk cache is disabled if the memory size is 0, and only in memory if the dir is empty
+	ChunkCacheMemorySize int64
+	ChunkCacheDir        string
+	ChunkCacheDiskSize   int64
+	// the number of the following chunks to read ahead for sequential reads
+	ReadAheadChunks int
+
 	MountUid  uint32
 	MountGid  uint32
 	MountMode os.FileMode
@@ -40,12 +47,12 @@ var _ = fs.FSStatfser(&WFS{})
 type WFS struct {
 	option                    *Option
 	listDirectoryEntriesCache *ccache.Cache
+	chunkCache                *ChunkCache
 
 	// contains all open handles
 	handles           []*FileHandle
 	pathToHandleIndex map[string]int
 	pathToHandleLock  sync.Mutex
-	bufPool           sync.Pool
 
 	stats statsCache
 }
@@ -59,13 +66,15 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 		option:                    option,
 		listDirectoryEntriesCache: ccache.New(ccache.Configure().MaxSize(1024 * 8).ItemsToPrune(100)),
 		pathToHandleIndex:         make(map[string]int),
-		bufPool: sync.Pool{
-			New: func() interface{} {
-				return make([]byte, option.ChunkSizeLimit)
-			},
-		},
 	}
 
+	chunkCache, err := NewChunkCache(option.ChunkCacheMemorySize, option.ChunkCacheDir, option.ChunkCacheDiskSize)
+	if err != nil {
+		glog.Errorf("chunk cache on disk: %v", err)
+		chunkCache, _ = NewChunkCache(option.ChunkCacheMemorySize, "", 0)
+	}
+	wfs.chunkCache = chunkCache
+
 	return wfs
 }
 
@@ -82,6 +91,26 @@ func (wfs *WFS) withFilerClient(fn func(filer_pb.SeaweedFilerClient) error) erro
 
 }
 
+func (wfs *WFS) lookupVolumes(ctx context.Context, vids []string) (vid2Locations map[string]*filer_pb.Locations, err error) {
+
+	err = wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		glog.V(4).Infof("read fh lookup volume id locations: %v", vids)
+		resp, err := client.LookupVolume(ctx, &filer_pb.LookupVolumeRequest{
+			VolumeIds: vids,
+		})
+		if err != nil {
+			return err
+		}
+
+		vid2Locations = resp.LocationsMap
+
+		return nil
+	})
+
+	return
+}
+
 func (wfs *WFS) AcquireHandle(file *File, uid, gid uint32) (fileHandle *FileHandle) {
 	wfs.pathToHandleLock.Lock()
 	defer wfs.pathToHandleLock.Unlock()
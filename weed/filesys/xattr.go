@@ -0,0 +1,344 @@
+package filesys
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/fuse"
+	"github.com/seaweedfs/fuse/fs"
+)
+
+// the extended attributes are kept in Entry.Extended with this prefix,
+// apart from the other extended entry data, e.g., the s3 object metadata
+const XATTR_PREFIX = "xattr-"
+
+// the same limits as on linux, and the total size of the names and the values of one entry,
+// which are saved and listed together with the entry
+const (
+	MAX_XATTR_NAME_SIZE  = 255
+	MAX_XATTR_VALUE_SIZE = 65536
+	MAX_XATTR_TOTAL_SIZE = 256 * 1024
+)
+
+// the setxattr flags
+const (
+	xattrCreate  = 0x1
+	xattrReplace = 0x2
+)
+
+var _ = fs.NodeGetxattrer(&File{})
+var _ = fs.NodeSetxattrer(&File{})
+var _ = fs.NodeRemovexattrer(&File{})
+var _ = fs.NodeListxattrer(&File{})
+
+var _ = fs.NodeGetxattrer(&Dir{})
+var _ = fs.NodeSetxattrer(&Dir{})
+var _ = fs.NodeRemovexattrer(&Dir{})
+var _ = fs.NodeListxattrer(&Dir{})
+
+func getxattr(entry *filer_pb.Entry, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if entry == nil {
+		return fuse.ErrNoXattr
+	}
+	data, found := entry.Extended[XATTR_PREFIX+req.Name]
+	if !found {
+		return fuse.ErrNoXattr
+	}
+	// the size 0 only asks for the size of the value
+	if req.Size != 0 && int(req.Size) < len(data) {
+		return fuse.Errno(syscall.ERANGE)
+	}
+	resp.Xattr = data
+
+	return nil
+}
+
+// setxattr returns the extended data of the entry with the attribute set. The entry itself is not changed,
+// since it is shared with the cache or the open file handles, and must stay unchanged if not saved.
+func setxattr(entry *filer_pb.Entry, req *fuse.SetxattrRequest) (extended map[string][]byte, err error) {
+
+	if len(req.Name) > MAX_XATTR_NAME_SIZE {
+		return nil, fuse.Errno(syscall.ERANGE)
+	}
+	if len(req.Xattr) > MAX_XATTR_VALUE_SIZE {
+		return nil, fuse.Errno(syscall.E2BIG)
+	}
+
+	_, found := entry.Extended[XATTR_PREFIX+req.Name]
+	if found && req.Flags&xattrCreate != 0 {
+		return nil, fuse.EEXIST
+	}
+	if !found && req.Flags&xattrReplace != 0 {
+		return nil, fuse.ErrNoXattr
+	}
+
+	totalSize := len(req.Name) + len(req.Xattr)
+	for k, v := range entry.Extended {
+		if strings.HasPrefix(k, XATTR_PREFIX) && k != XATTR_PREFIX+req.Name {
+			totalSize += len(k) - len(XATTR_PREFIX) + len(v)
+		}
+	}
+	if totalSize > MAX_XATTR_TOTAL_SIZE {
+		return nil, fuse.Errno(syscall.ENOSPC)
+	}
+
+	extended = copyExtended(entry.Extended)
+	// the request buffer is reused by fuse
+	data := make([]byte, len(req.Xattr))
+	copy(data, req.Xattr)
+	extended[XATTR_PREFIX+req.Name] = data
+
+	return extended, nil
+}
+
+// removexattr returns the extended data of the entry without the attribute, leaving the entry unchanged
+func removexattr(entry *filer_pb.Entry, req *fuse.RemovexattrRequest) (extended map[string][]byte, err error) {
+
+	if _, found := entry.Extended[XATTR_PREFIX+req.Name]; !found {
+		return nil, fuse.ErrNoXattr
+	}
+	extended = copyExtended(entry.Extended)
+	delete(extended, XATTR_PREFIX+req.Name)
+
+	return extended, nil
+}
+
+func listxattr(entry *filer_pb.Entry, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if entry == nil {
+		return nil
+	}
+	var names []string
+	for k := range entry.Extended {
+		if strings.HasPrefix(k, XATTR_PREFIX) {
+			names = append(names, k[len(XATTR_PREFIX):])
+		}
+	}
+	sort.Strings(names)
+	resp.Append(names...)
+	// the size 0 only asks for the size of the list
+	if req.Size != 0 && int(req.Size) < len(resp.Xattr) {
+		return fuse.Errno(syscall.ERANGE)
+	}
+
+	return nil
+}
+
+func copyExtended(extended map[string][]byte) map[string][]byte {
+	copied := make(map[string][]byte, len(extended)+1)
+	for k, v := range extended {
+		copied[k] = v
+	}
+	return copied
+}
+
+func (file *File) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	return getxattr(file.entry, req, resp)
+}
+
+func (file *File) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	glog.V(3).Infof("%v file setxattr %s: %d bytes", file.fullpath(), req.Name, len(req.Xattr))
+
+	extended, err := setxattr(file.entry, req)
+	if err != nil {
+		return err
+	}
+
+	return file.saveExtended(ctx, extended)
+}
+
+func (file *File) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	glog.V(3).Infof("%v file removexattr %s", file.fullpath(), req.Name)
+
+	extended, err := removexattr(file.entry, req)
+	if err != nil {
+		return err
+	}
+
+	return file.saveExtended(ctx, extended)
+}
+
+func (file *File) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	return listxattr(file.entry, req, resp)
+}
+
+// saveExtended saves the file entry with the changed extended data right away, even if the file is still open,
+// and only then changes the file entry
+func (file *File) saveExtended(ctx context.Context, extended map[string][]byte) error {
+	entry := *file.entry
+	entry.Extended = extended
+	err := file.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory:      file.dir.Path,
+			Entry:          &entry,
+			UpdateExtended: true,
+		}
+
+		// the cached entry may have been changed already
+		file.wfs.listDirectoryEntriesCache.Delete(file.fullpath())
+
+		glog.V(1).Infof("save file entry: %v", request)
+		_, err := client.UpdateEntry(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("UpdateEntry file %s/%s: %v", file.dir.Path, file.Name, err)
+			return fuse.EIO
+		}
+
+		return nil
+	})
+	if err == nil {
+		file.entry.Extended = extended
+	}
+	return err
+}
+
+func (dir *Dir) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	return getxattr(entry, req, resp)
+}
+
+func (dir *Dir) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fuse.ENOTSUP
+	}
+
+	glog.V(3).Infof("%v dir setxattr %s: %d bytes", dir.Path, req.Name, len(req.Xattr))
+
+	extended, err := setxattr(entry, req)
+	if err != nil {
+		return err
+	}
+
+	return dir.saveExtended(ctx, entry, extended)
+}
+
+func (dir *Dir) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fuse.ErrNoXattr
+	}
+
+	glog.V(3).Infof("%v dir removexattr %s", dir.Path, req.Name)
+
+	extended, err := removexattr(entry, req)
+	if err != nil {
+		return err
+	}
+
+	return dir.saveExtended(ctx, entry, extended)
+}
+
+func (dir *Dir) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	return listxattr(entry, req, resp)
+}
+
+// maybeLoadEntry looks up the directory entry, which is nil for the filer root directory.
+// The entry may be the cached one, so it must not be changed.
+func (dir *Dir) maybeLoadEntry(ctx context.Context) (entry *filer_pb.Entry, err error) {
+
+	if dir.Path == "/" {
+		return nil, nil
+	}
+
+	item := dir.wfs.listDirectoryEntriesCache.Get(dir.Path)
+	if item != nil && !item.Expired() {
+		return item.Value().(*filer_pb.Entry), nil
+	}
+
+	parentDir, name := filer2.FullPath(dir.Path).DirAndName()
+	err = dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.LookupDirectoryEntryRequest{
+			Directory: parentDir,
+			Name:      name,
+		}
+
+		glog.V(3).Infof("lookup dir entry: %v", request)
+		resp, err := client.LookupDirectoryEntry(ctx, request)
+		if err != nil {
+			glog.V(3).Infof("lookup dir %s entry: %v", dir.Path, err)
+			return fuse.ENOENT
+		}
+
+		entry = resp.Entry
+
+		return nil
+	})
+
+	return entry, err
+}
+
+// saveExtended saves a copy of the directory entry with the changed extended data
+func (dir *Dir) saveExtended(ctx context.Context, entry *filer_pb.Entry, extended map[string][]byte) error {
+
+	changed := *entry
+	changed.Extended = extended
+	parentDir, _ := filer2.FullPath(dir.Path).DirAndName()
+	return dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory:      parentDir,
+			Entry:          &changed,
+			UpdateExtended: true,
+		}
+
+		// the cached entry may have been changed already
+		dir.wfs.listDirectoryEntriesCache.Delete(dir.Path)
+
+		glog.V(1).Infof("save directory entry: %v", request)
+		_, err := client.UpdateEntry(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("UpdateEntry %s: %v", dir.Path, err)
+			return fuse.EIO
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,122 @@
+package filesys
+
+import (
+	"bytes"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/fuse"
+)
+
+func TestSetAndGetXattr(t *testing.T) {
+	entry := &filer_pb.Entry{Extended: map[string][]byte{"s3-metadata": []byte("kept")}}
+
+	value := []byte("value")
+	extended, err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: value})
+	if err != nil {
+		t.Fatalf("setxattr: %v", err)
+	}
+	if _, found := entry.Extended[XATTR_PREFIX+"user.a"]; found {
+		t.Errorf("setxattr changed the entry")
+	}
+	// the request buffer is reused by fuse
+	copy(value, "xxxxx")
+	entry.Extended = extended
+
+	resp := &fuse.GetxattrResponse{}
+	if err = getxattr(entry, &fuse.GetxattrRequest{Name: "user.a"}, resp); err != nil || string(resp.Xattr) != "value" {
+		t.Errorf("getxattr: %q %v", resp.Xattr, err)
+	}
+	if err = getxattr(entry, &fuse.GetxattrRequest{Name: "user.a", Size: 5}, &fuse.GetxattrResponse{}); err != nil {
+		t.Errorf("getxattr with the exact size: %v", err)
+	}
+	if err = getxattr(entry, &fuse.GetxattrRequest{Name: "user.a", Size: 4}, &fuse.GetxattrResponse{}); err != fuse.Errno(syscall.ERANGE) {
+		t.Errorf("getxattr with a small buffer: %v", err)
+	}
+	if err = getxattr(entry, &fuse.GetxattrRequest{Name: "user.missing"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("getxattr of a missing attribute: %v", err)
+	}
+	if err = getxattr(entry, &fuse.GetxattrRequest{Name: "s3-metadata"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("getxattr of the other extended data: %v", err)
+	}
+	if err = getxattr(nil, &fuse.GetxattrRequest{Name: "user.a"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("getxattr of the root directory: %v", err)
+	}
+}
+
+func TestSetxattrFlagsAndLimits(t *testing.T) {
+	entry := &filer_pb.Entry{}
+	extended, err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("1"), Flags: xattrCreate})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	entry.Extended = extended
+
+	for _, tc := range []struct {
+		req      *fuse.SetxattrRequest
+		expected error
+	}{
+		{&fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("2"), Flags: xattrCreate}, fuse.EEXIST},
+		{&fuse.SetxattrRequest{Name: "user.b", Xattr: []byte("2"), Flags: xattrReplace}, fuse.ErrNoXattr},
+		{&fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("2"), Flags: xattrReplace}, nil},
+		{&fuse.SetxattrRequest{Name: "user.b", Xattr: []byte("2")}, nil},
+		{&fuse.SetxattrRequest{Name: strings.Repeat("n", MAX_XATTR_NAME_SIZE+1)}, fuse.Errno(syscall.ERANGE)},
+		{&fuse.SetxattrRequest{Name: "user.c", Xattr: make([]byte, MAX_XATTR_VALUE_SIZE+1)}, fuse.Errno(syscall.E2BIG)},
+	} {
+		if _, err = setxattr(entry, tc.req); err != tc.expected {
+			t.Errorf("setxattr %s flags %d: %v, expected %v", tc.req.Name, tc.req.Flags, err, tc.expected)
+		}
+	}
+
+	// the total size of the attributes is limited, not counting the replaced value
+	for i := 0; i < MAX_XATTR_TOTAL_SIZE/MAX_XATTR_VALUE_SIZE-1; i++ {
+		name := "user.big" + string(rune('a'+i))
+		if extended, err = setxattr(entry, &fuse.SetxattrRequest{Name: name, Xattr: make([]byte, MAX_XATTR_VALUE_SIZE)}); err != nil {
+			t.Fatalf("setxattr %s: %v", name, err)
+		}
+		entry.Extended = extended
+	}
+	if _, err = setxattr(entry, &fuse.SetxattrRequest{Name: "user.bigz", Xattr: make([]byte, MAX_XATTR_VALUE_SIZE)}); err != fuse.Errno(syscall.ENOSPC) {
+		t.Errorf("setxattr over the total size: %v", err)
+	}
+	if _, err = setxattr(entry, &fuse.SetxattrRequest{Name: "user.biga", Xattr: make([]byte, MAX_XATTR_VALUE_SIZE)}); err != nil {
+		t.Errorf("replace a value within the total size: %v", err)
+	}
+}
+
+func TestRemoveAndListXattr(t *testing.T) {
+	entry := &filer_pb.Entry{Extended: map[string][]byte{
+		XATTR_PREFIX + "user.b": []byte("2"),
+		XATTR_PREFIX + "user.a": []byte("1"),
+		"s3-metadata":           []byte("kept"),
+	}}
+
+	resp := &fuse.ListxattrResponse{}
+	if err := listxattr(entry, &fuse.ListxattrRequest{}, resp); err != nil || !bytes.Equal(resp.Xattr, []byte("user.a\x00user.b\x00")) {
+		t.Errorf("listxattr: %q %v", resp.Xattr, err)
+	}
+	if err := listxattr(entry, &fuse.ListxattrRequest{Size: 13}, &fuse.ListxattrResponse{}); err != fuse.Errno(syscall.ERANGE) {
+		t.Errorf("listxattr with a small buffer: %v", err)
+	}
+
+	extended, err := removexattr(entry, &fuse.RemovexattrRequest{Name: "user.a"})
+	if err != nil {
+		t.Fatalf("removexattr: %v", err)
+	}
+	if _, found := entry.Extended[XATTR_PREFIX+"user.a"]; !found {
+		t.Errorf("removexattr changed the entry")
+	}
+	if _, found := extended[XATTR_PREFIX+"user.a"]; found || len(extended) != 2 {
+		t.Errorf("removexattr: %v", extended)
+	}
+	entry.Extended = extended
+
+	if _, err = removexattr(entry, &fuse.RemovexattrRequest{Name: "user.a"}); err != fuse.ErrNoXattr {
+		t.Errorf("removexattr of a missing attribute: %v", err)
+	}
+	if _, err = removexattr(entry, &fuse.RemovexattrRequest{Name: "s3-metadata"}); err != fuse.ErrNoXattr {
+		t.Errorf("removexattr of the other extended data: %v", err)
+	}
+}
@@ -23,6 +23,10 @@ It has these top-level messages:
 	UpdateEntryResponse
 	DeleteEntryRequest
 	DeleteEntryResponse
+	AtomicRenameEntryRequest
+	AtomicRenameEntryResponse
+	CreateHardLinkRequest
+	CreateHardLinkResponse
 	AssignVolumeRequest
 	AssignVolumeResponse
 	LookupVolumeRequest
@@ -33,6 +37,8 @@ It has these top-level messages:
 	DeleteCollectionResponse
 	StatisticsRequest
 	StatisticsResponse
+	SubscribeMetadataRequest
+	SubscribeMetadataResponse
 */
 package filer_pb
 
@@ -145,7 +151,7 @@ func (m *ListEntriesRequest) GetLimit() uint32 {
 }
 
 type ListEntriesResponse struct {
-	Entries []*Entry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	Entry *Entry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
 }
 
 func (m *ListEntriesResponse) Reset()                    { *m = ListEntriesResponse{} }
@@ -153,19 +159,21 @@ func (m *ListEntriesResponse) String() string            { return proto.CompactT
 func (*ListEntriesResponse) ProtoMessage()               {}
 func (*ListEntriesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
-func (m *ListEntriesResponse) GetEntries() []*Entry {
+func (m *ListEntriesResponse) GetEntry() *Entry {
 	if m != nil {
-		return m.Entries
+		return m.Entry
 	}
 	return nil
 }
 
 type Entry struct {
-	Name        string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	IsDirectory bool              `protobuf:"varint,2,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
-	Chunks      []*FileChunk      `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
-	Attributes  *FuseAttributes   `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
-	Extended    map[string][]byte `protobuf:"bytes,5,rep,name=extended" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Name            string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	IsDirectory     bool              `protobuf:"varint,2,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
+	Chunks          []*FileChunk      `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
+	Attributes      *FuseAttributes   `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
+	Extended        map[string][]byte `protobuf:"bytes,5,rep,name=extended" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	HardLinkId      []byte            `protobuf:"bytes,6,opt,name=hard_link_id,json=hardLinkId,proto3" json:"hard_link_id,omitempty"`
+	HardLinkCounter int32             `protobuf:"varint,7,opt,name=hard_link_counter,json=hardLinkCounter" json:"hard_link_counter,omitempty"`
 }
 
 func (m *Entry) Reset()                    { *m = Entry{} }
@@ -208,6 +216,20 @@ func (m *Entry) GetExtended() map[string][]byte {
 	return nil
 }
 
+func (m *Entry) GetHardLinkId() []byte {
+	if m != nil {
+		return m.HardLinkId
+	}
+	return nil
+}
+
+func (m *Entry) GetHardLinkCounter() int32 {
+	if m != nil {
+		return m.HardLinkCounter
+	}
+	return 0
+}
+
 type EventNotification struct {
 	OldEntry     *Entry `protobuf:"bytes,1,opt,name=old_entry,json=oldEntry" json:"old_entry,omitempty"`
 	NewEntry     *Entry `protobuf:"bytes,2,opt,name=new_entry,json=newEntry" json:"new_entry,omitempty"`
@@ -443,6 +465,8 @@ func (*CreateEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor
 type UpdateEntryRequest struct {
 	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
 	Entry     *Entry `protobuf:"bytes,2,opt,name=entry" json:"entry,omitempty"`
+	// the extended attributes are kept if entry.extended is empty, unless update_extended is set
+	UpdateExtended bool `protobuf:"varint,3,opt,name=update_extended,json=updateExtended" json:"update_extended,omitempty"`
 }
 
 func (m *UpdateEntryRequest) Reset()                    { *m = UpdateEntryRequest{} }
@@ -464,6 +488,13 @@ func (m *UpdateEntryRequest) GetEntry() *Entry {
 	return nil
 }
 
+func (m *UpdateEntryRequest) GetUpdateExtended() bool {
+	if m != nil {
+		return m.UpdateExtended
+	}
+	return false
+}
+
 type UpdateEntryResponse struct {
 }
 
@@ -521,6 +552,110 @@ func (m *DeleteEntryResponse) String() string            { return proto.CompactT
 func (*DeleteEntryResponse) ProtoMessage()               {}
 func (*DeleteEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
+type AtomicRenameEntryRequest struct {
+	OldDirectory string `protobuf:"bytes,1,opt,name=old_directory,json=oldDirectory" json:"old_directory,omitempty"`
+	OldName      string `protobuf:"bytes,2,opt,name=old_name,json=oldName" json:"old_name,omitempty"`
+	NewDirectory string `protobuf:"bytes,3,opt,name=new_directory,json=newDirectory" json:"new_directory,omitempty"`
+	NewName      string `protobuf:"bytes,4,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+}
+
+func (m *AtomicRenameEntryRequest) Reset()                    { *m = AtomicRenameEntryRequest{} }
+func (m *AtomicRenameEntryRequest) String() string            { return proto.CompactTextString(m) }
+func (*AtomicRenameEntryRequest) ProtoMessage()               {}
+func (*AtomicRenameEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *AtomicRenameEntryRequest) GetOldDirectory() string {
+	if m != nil {
+		return m.OldDirectory
+	}
+	return ""
+}
+
+func (m *AtomicRenameEntryRequest) GetOldName() string {
+	if m != nil {
+		return m.OldName
+	}
+	return ""
+}
+
+func (m *AtomicRenameEntryRequest) GetNewDirectory() string {
+	if m != nil {
+		return m.NewDirectory
+	}
+	return ""
+}
+
+func (m *AtomicRenameEntryRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type AtomicRenameEntryResponse struct {
+}
+
+func (m *AtomicRenameEntryResponse) Reset()                    { *m = AtomicRenameEntryResponse{} }
+func (m *AtomicRenameEntryResponse) String() string            { return proto.CompactTextString(m) }
+func (*AtomicRenameEntryResponse) ProtoMessage()               {}
+func (*AtomicRenameEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+type CreateHardLinkRequest struct {
+	OldDirectory string `protobuf:"bytes,1,opt,name=old_directory,json=oldDirectory" json:"old_directory,omitempty"`
+	OldName      string `protobuf:"bytes,2,opt,name=old_name,json=oldName" json:"old_name,omitempty"`
+	NewDirectory string `protobuf:"bytes,3,opt,name=new_directory,json=newDirectory" json:"new_directory,omitempty"`
+	NewName      string `protobuf:"bytes,4,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+}
+
+func (m *CreateHardLinkRequest) Reset()                    { *m = CreateHardLinkRequest{} }
+func (m *CreateHardLinkRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateHardLinkRequest) ProtoMessage()               {}
+func (*CreateHardLinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *CreateHardLinkRequest) GetOldDirectory() string {
+	if m != nil {
+		return m.OldDirectory
+	}
+	return ""
+}
+
+func (m *CreateHardLinkRequest) GetOldName() string {
+	if m != nil {
+		return m.OldName
+	}
+	return ""
+}
+
+func (m *CreateHardLinkRequest) GetNewDirectory() string {
+	if m != nil {
+		return m.NewDirectory
+	}
+	return ""
+}
+
+func (m *CreateHardLinkRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type CreateHardLinkResponse struct {
+	Entry *Entry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+}
+
+func (m *CreateHardLinkResponse) Reset()                    { *m = CreateHardLinkResponse{} }
+func (m *CreateHardLinkResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateHardLinkResponse) ProtoMessage()               {}
+func (*CreateHardLinkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *CreateHardLinkResponse) GetEntry() *Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
 type AssignVolumeRequest struct {
 	Count       int32  `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
 	Collection  string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
@@ -532,7 +667,7 @@ type AssignVolumeRequest struct {
 func (m *AssignVolumeRequest) Reset()                    { *m = AssignVolumeRequest{} }
 func (m *AssignVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*AssignVolumeRequest) ProtoMessage()               {}
-func (*AssignVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*AssignVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *AssignVolumeRequest) GetCount() int32 {
 	if m != nil {
@@ -580,7 +715,7 @@ type AssignVolumeResponse struct {
 func (m *AssignVolumeResponse) Reset()                    { *m = AssignVolumeResponse{} }
 func (m *AssignVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*AssignVolumeResponse) ProtoMessage()               {}
-func (*AssignVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*AssignVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *AssignVolumeResponse) GetFileId() string {
 	if m != nil {
@@ -624,7 +759,7 @@ type LookupVolumeRequest struct {
 func (m *LookupVolumeRequest) Reset()                    { *m = LookupVolumeRequest{} }
 func (m *LookupVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeRequest) ProtoMessage()               {}
-func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *LookupVolumeRequest) GetVolumeIds() []string {
 	if m != nil {
@@ -640,7 +775,7 @@ type Locations struct {
 func (m *Locations) Reset()                    { *m = Locations{} }
 func (m *Locations) String() string            { return proto.CompactTextString(m) }
 func (*Locations) ProtoMessage()               {}
-func (*Locations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*Locations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *Locations) GetLocations() []*Location {
 	if m != nil {
@@ -657,7 +792,7 @@ type Location struct {
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Location) GetUrl() string {
 	if m != nil {
@@ -680,7 +815,7 @@ type LookupVolumeResponse struct {
 func (m *LookupVolumeResponse) Reset()                    { *m = LookupVolumeResponse{} }
 func (m *LookupVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse) ProtoMessage()               {}
-func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *LookupVolumeResponse) GetLocationsMap() map[string]*Locations {
 	if m != nil {
@@ -696,7 +831,7 @@ type DeleteCollectionRequest struct {
 func (m *DeleteCollectionRequest) Reset()                    { *m = DeleteCollectionRequest{} }
 func (m *DeleteCollectionRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCollectionRequest) ProtoMessage()               {}
-func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *DeleteCollectionRequest) GetCollection() string {
 	if m != nil {
@@ -711,7 +846,7 @@ type DeleteCollectionResponse struct {
 func (m *DeleteCollectionResponse) Reset()                    { *m = DeleteCollectionResponse{} }
 func (m *DeleteCollectionResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCollectionResponse) ProtoMessage()               {}
-func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 type StatisticsRequest struct {
 	Replication string `protobuf:"bytes,1,opt,name=replication" json:"replication,omitempty"`
@@ -722,7 +857,7 @@ type StatisticsRequest struct {
 func (m *StatisticsRequest) Reset()                    { *m = StatisticsRequest{} }
 func (m *StatisticsRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsRequest) ProtoMessage()               {}
-func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *StatisticsRequest) GetReplication() string {
 	if m != nil {
@@ -757,7 +892,7 @@ type StatisticsResponse struct {
 func (m *StatisticsResponse) Reset()                    { *m = StatisticsResponse{} }
 func (m *StatisticsResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsResponse) ProtoMessage()               {}
-func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *StatisticsResponse) GetReplication() string {
 	if m != nil {
@@ -801,6 +936,70 @@ func (m *StatisticsResponse) GetFileCount() uint64 {
 	return 0
 }
 
+type SubscribeMetadataRequest struct {
+	ClientName string `protobuf:"bytes,1,opt,name=client_name,json=clientName" json:"client_name,omitempty"`
+	PathPrefix string `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix" json:"path_prefix,omitempty"`
+	SinceNs    int64  `protobuf:"varint,3,opt,name=since_ns,json=sinceNs" json:"since_ns,omitempty"`
+}
+
+func (m *SubscribeMetadataRequest) Reset()                    { *m = SubscribeMetadataRequest{} }
+func (m *SubscribeMetadataRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeMetadataRequest) ProtoMessage()               {}
+func (*SubscribeMetadataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *SubscribeMetadataRequest) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *SubscribeMetadataRequest) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
+}
+
+func (m *SubscribeMetadataRequest) GetSinceNs() int64 {
+	if m != nil {
+		return m.SinceNs
+	}
+	return 0
+}
+
+type SubscribeMetadataResponse struct {
+	Directory         string             `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	EventNotification *EventNotification `protobuf:"bytes,2,opt,name=event_notification,json=eventNotification" json:"event_notification,omitempty"`
+	TsNs              int64              `protobuf:"varint,3,opt,name=ts_ns,json=tsNs" json:"ts_ns,omitempty"`
+}
+
+func (m *SubscribeMetadataResponse) Reset()                    { *m = SubscribeMetadataResponse{} }
+func (m *SubscribeMetadataResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeMetadataResponse) ProtoMessage()               {}
+func (*SubscribeMetadataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *SubscribeMetadataResponse) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *SubscribeMetadataResponse) GetEventNotification() *EventNotification {
+	if m != nil {
+		return m.EventNotification
+	}
+	return nil
+}
+
+func (m *SubscribeMetadataResponse) GetTsNs() int64 {
+	if m != nil {
+		return m.TsNs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*LookupDirectoryEntryRequest)(nil), "filer_pb.LookupDirectoryEntryRequest")
 	proto.RegisterType((*LookupDirectoryEntryResponse)(nil), "filer_pb.LookupDirectoryEntryResponse")
@@ -816,6 +1015,10 @@ func init() {
 	proto.RegisterType((*UpdateEntryResponse)(nil), "filer_pb.UpdateEntryResponse")
 	proto.RegisterType((*DeleteEntryRequest)(nil), "filer_pb.DeleteEntryRequest")
 	proto.RegisterType((*DeleteEntryResponse)(nil), "filer_pb.DeleteEntryResponse")
+	proto.RegisterType((*AtomicRenameEntryRequest)(nil), "filer_pb.AtomicRenameEntryRequest")
+	proto.RegisterType((*AtomicRenameEntryResponse)(nil), "filer_pb.AtomicRenameEntryResponse")
+	proto.RegisterType((*CreateHardLinkRequest)(nil), "filer_pb.CreateHardLinkRequest")
+	proto.RegisterType((*CreateHardLinkResponse)(nil), "filer_pb.CreateHardLinkResponse")
 	proto.RegisterType((*AssignVolumeRequest)(nil), "filer_pb.AssignVolumeRequest")
 	proto.RegisterType((*AssignVolumeResponse)(nil), "filer_pb.AssignVolumeResponse")
 	proto.RegisterType((*LookupVolumeRequest)(nil), "filer_pb.LookupVolumeRequest")
@@ -826,6 +1029,8 @@ func init() {
 	proto.RegisterType((*DeleteCollectionResponse)(nil), "filer_pb.DeleteCollectionResponse")
 	proto.RegisterType((*StatisticsRequest)(nil), "filer_pb.StatisticsRequest")
 	proto.RegisterType((*StatisticsResponse)(nil), "filer_pb.StatisticsResponse")
+	proto.RegisterType((*SubscribeMetadataRequest)(nil), "filer_pb.SubscribeMetadataRequest")
+	proto.RegisterType((*SubscribeMetadataResponse)(nil), "filer_pb.SubscribeMetadataResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -840,14 +1045,17 @@ const _ = grpc.SupportPackageIsVersion4
 
 type SeaweedFilerClient interface {
 	LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error)
-	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (SeaweedFiler_ListEntriesClient, error)
 	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error)
 	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error)
 	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error)
+	AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error)
+	CreateHardLink(ctx context.Context, in *CreateHardLinkRequest, opts ...grpc.CallOption) (*CreateHardLinkResponse, error)
 	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
 	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
 	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
 	Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error)
+	SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error)
 }
 
 type seaweedFilerClient struct {
@@ -867,13 +1075,36 @@ func (c *seaweedFilerClient) LookupDirectoryEntry(ctx context.Context, in *Looku
 	return out, nil
 }
 
-func (c *seaweedFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
-	out := new(ListEntriesResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/ListEntries", in, out, c.cc, opts...)
+func (c *seaweedFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (SeaweedFiler_ListEntriesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SeaweedFiler_serviceDesc.Streams[0], c.cc, "/filer_pb.SeaweedFiler/ListEntries", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &seaweedFilerListEntriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SeaweedFiler_ListEntriesClient interface {
+	Recv() (*ListEntriesResponse, error)
+	grpc.ClientStream
+}
+
+type seaweedFilerListEntriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedFilerListEntriesClient) Recv() (*ListEntriesResponse, error) {
+	m := new(ListEntriesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (c *seaweedFilerClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
@@ -903,6 +1134,24 @@ func (c *seaweedFilerClient) DeleteEntry(ctx context.Context, in *DeleteEntryReq
 	return out, nil
 }
 
+func (c *seaweedFilerClient) AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error) {
+	out := new(AtomicRenameEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AtomicRenameEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) CreateHardLink(ctx context.Context, in *CreateHardLinkRequest, opts ...grpc.CallOption) (*CreateHardLinkResponse, error) {
+	out := new(CreateHardLinkResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/CreateHardLink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *seaweedFilerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
 	out := new(AssignVolumeResponse)
 	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AssignVolume", in, out, c.cc, opts...)
@@ -939,18 +1188,53 @@ func (c *seaweedFilerClient) Statistics(ctx context.Context, in *StatisticsReque
 	return out, nil
 }
 
+func (c *seaweedFilerClient) SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SeaweedFiler_serviceDesc.Streams[1], c.cc, "/filer_pb.SeaweedFiler/SubscribeMetadata", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedFilerSubscribeMetadataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SeaweedFiler_SubscribeMetadataClient interface {
+	Recv() (*SubscribeMetadataResponse, error)
+	grpc.ClientStream
+}
+
+type seaweedFilerSubscribeMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedFilerSubscribeMetadataClient) Recv() (*SubscribeMetadataResponse, error) {
+	m := new(SubscribeMetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for SeaweedFiler service
 
 type SeaweedFilerServer interface {
 	LookupDirectoryEntry(context.Context, *LookupDirectoryEntryRequest) (*LookupDirectoryEntryResponse, error)
-	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
+	ListEntries(*ListEntriesRequest, SeaweedFiler_ListEntriesServer) error
 	CreateEntry(context.Context, *CreateEntryRequest) (*CreateEntryResponse, error)
 	UpdateEntry(context.Context, *UpdateEntryRequest) (*UpdateEntryResponse, error)
 	DeleteEntry(context.Context, *DeleteEntryRequest) (*DeleteEntryResponse, error)
+	AtomicRenameEntry(context.Context, *AtomicRenameEntryRequest) (*AtomicRenameEntryResponse, error)
+	CreateHardLink(context.Context, *CreateHardLinkRequest) (*CreateHardLinkResponse, error)
 	AssignVolume(context.Context, *AssignVolumeRequest) (*AssignVolumeResponse, error)
 	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
 	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
 	Statistics(context.Context, *StatisticsRequest) (*StatisticsResponse, error)
+	SubscribeMetadata(*SubscribeMetadataRequest, SeaweedFiler_SubscribeMetadataServer) error
 }
 
 func RegisterSeaweedFilerServer(s *grpc.Server, srv SeaweedFilerServer) {
@@ -975,22 +1259,25 @@ func _SeaweedFiler_LookupDirectoryEntry_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SeaweedFiler_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListEntriesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SeaweedFilerServer).ListEntries(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/filer_pb.SeaweedFiler/ListEntries",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SeaweedFilerServer).ListEntries(ctx, req.(*ListEntriesRequest))
+func _SeaweedFiler_ListEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(SeaweedFilerServer).ListEntries(m, &seaweedFilerListEntriesServer{stream})
+}
+
+type SeaweedFiler_ListEntriesServer interface {
+	Send(*ListEntriesResponse) error
+	grpc.ServerStream
+}
+
+type seaweedFilerListEntriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *seaweedFilerListEntriesServer) Send(m *ListEntriesResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
 func _SeaweedFiler_CreateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -1047,6 +1334,42 @@ func _SeaweedFiler_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_AtomicRenameEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AtomicRenameEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).AtomicRenameEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/AtomicRenameEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).AtomicRenameEntry(ctx, req.(*AtomicRenameEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_CreateHardLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHardLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).CreateHardLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/CreateHardLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).CreateHardLink(ctx, req.(*CreateHardLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SeaweedFiler_AssignVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignVolumeRequest)
 	if err := dec(in); err != nil {
@@ -1119,6 +1442,27 @@ func _SeaweedFiler_Statistics_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_SubscribeMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMetadataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeaweedFilerServer).SubscribeMetadata(m, &seaweedFilerSubscribeMetadataServer{stream})
+}
+
+type SeaweedFiler_SubscribeMetadataServer interface {
+	Send(*SubscribeMetadataResponse) error
+	grpc.ServerStream
+}
+
+type seaweedFilerSubscribeMetadataServer struct {
+	grpc.ServerStream
+}
+
+func (x *seaweedFilerSubscribeMetadataServer) Send(m *SubscribeMetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "filer_pb.SeaweedFiler",
 	HandlerType: (*SeaweedFilerServer)(nil),
@@ -1127,10 +1471,6 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LookupDirectoryEntry",
 			Handler:    _SeaweedFiler_LookupDirectoryEntry_Handler,
 		},
-		{
-			MethodName: "ListEntries",
-			Handler:    _SeaweedFiler_ListEntries_Handler,
-		},
 		{
 			MethodName: "CreateEntry",
 			Handler:    _SeaweedFiler_CreateEntry_Handler,
@@ -1143,6 +1483,14 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntry",
 			Handler:    _SeaweedFiler_DeleteEntry_Handler,
 		},
+		{
+			MethodName: "AtomicRenameEntry",
+			Handler:    _SeaweedFiler_AtomicRenameEntry_Handler,
+		},
+		{
+			MethodName: "CreateHardLink",
+			Handler:    _SeaweedFiler_CreateHardLink_Handler,
+		},
 		{
 			MethodName: "AssignVolume",
 			Handler:    _SeaweedFiler_AssignVolume_Handler,
@@ -1160,94 +1508,124 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			Handler:    _SeaweedFiler_Statistics_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListEntries",
+			Handler:       _SeaweedFiler_ListEntries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMetadata",
+			Handler:       _SeaweedFiler_SubscribeMetadata_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "filer.proto",
 }
 
 func init() { proto.RegisterFile("filer.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1301 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0x4d, 0x8f, 0xd3, 0xc6,
-	0x1b, 0xc7, 0x79, 0x23, 0x7e, 0x92, 0xf0, 0xdf, 0x9d, 0xec, 0xbf, 0x58, 0x61, 0x97, 0x06, 0xb7,
-	0x54, 0x8b, 0x8a, 0x22, 0x44, 0x7b, 0x80, 0xa2, 0x4a, 0x85, 0x65, 0x91, 0x90, 0x16, 0xa8, 0xbc,
-	0x50, 0xa9, 0xea, 0xc1, 0xf2, 0xda, 0x93, 0x30, 0x5a, 0xc7, 0x4e, 0x3d, 0xe3, 0x05, 0xfa, 0x11,
-	0x7a, 0xe9, 0xa5, 0xd7, 0x1e, 0x7a, 0xea, 0xb7, 0xe8, 0xa5, 0xdf, 0xa7, 0xf7, 0xde, 0xaa, 0x79,
-	0x66, 0xec, 0x8c, 0x63, 0x2f, 0xb4, 0xaa, 0xb8, 0xcd, 0xfc, 0x9e, 0xf7, 0x67, 0x9e, 0x17, 0x1b,
-	0x06, 0x73, 0x16, 0xd3, 0x6c, 0xb6, 0xca, 0x52, 0x91, 0x92, 0x3e, 0x5e, 0xfc, 0xd5, 0x89, 0xfb,
-	0x0c, 0xae, 0x1c, 0xa5, 0xe9, 0x69, 0xbe, 0x7a, 0xc8, 0x32, 0x1a, 0x8a, 0x34, 0x7b, 0x73, 0x98,
-	0x88, 0xec, 0x8d, 0x47, 0xbf, 0xcf, 0x29, 0x17, 0x64, 0x17, 0xec, 0xa8, 0x20, 0x38, 0xd6, 0xd4,
-	0xda, 0xb7, 0xbd, 0x35, 0x40, 0x08, 0x74, 0x92, 0x60, 0x49, 0x9d, 0x16, 0x12, 0xf0, 0xec, 0x1e,
-	0xc2, 0x6e, 0xb3, 0x42, 0xbe, 0x4a, 0x13, 0x4e, 0xc9, 0x75, 0xe8, 0x52, 0x09, 0xa0, 0xb6, 0xc1,
-	0xed, 0xff, 0xcd, 0x0a, 0x57, 0x66, 0x8a, 0x4f, 0x51, 0xdd, 0xdf, 0x2d, 0x20, 0x47, 0x8c, 0x0b,
-	0x09, 0x32, 0xca, 0xff, 0x99, 0x3f, 0x1f, 0x40, 0x6f, 0x95, 0xd1, 0x39, 0x7b, 0xad, 0x3d, 0xd2,
-	0x37, 0x72, 0x13, 0xb6, 0xb9, 0x08, 0x32, 0xf1, 0x28, 0x4b, 0x97, 0x8f, 0x58, 0x4c, 0x9f, 0x4a,
-	0xa7, 0xdb, 0xc8, 0x52, 0x27, 0x90, 0x19, 0x10, 0x96, 0x84, 0x71, 0xce, 0xd9, 0x19, 0x3d, 0x2e,
-	0xa8, 0x4e, 0x67, 0x6a, 0xed, 0xf7, 0xbd, 0x06, 0x0a, 0xd9, 0x81, 0x6e, 0xcc, 0x96, 0x4c, 0x38,
-	0xdd, 0xa9, 0xb5, 0x3f, 0xf2, 0xd4, 0xc5, 0xfd, 0x0a, 0xc6, 0x15, 0xff, 0x75, 0xf8, 0x37, 0xe0,
-	0x22, 0x55, 0x90, 0x63, 0x4d, 0xdb, 0x4d, 0x09, 0x28, 0xe8, 0xee, 0x2f, 0x2d, 0xe8, 0x22, 0x54,
-	0xe6, 0xd9, 0x5a, 0xe7, 0x99, 0x5c, 0x83, 0x21, 0xe3, 0xfe, 0x3a, 0x19, 0x2d, 0xf4, 0x6f, 0xc0,
-	0x78, 0x99, 0x77, 0xf2, 0x29, 0xf4, 0xc2, 0x97, 0x79, 0x72, 0xca, 0x9d, 0x36, 0x9a, 0x1a, 0xaf,
-	0x4d, 0xc9, 0x60, 0x0f, 0x24, 0xcd, 0xd3, 0x2c, 0xe4, 0x0e, 0x40, 0x20, 0x44, 0xc6, 0x4e, 0x72,
-	0x41, 0x39, 0x46, 0x3b, 0xb8, 0xed, 0x18, 0x02, 0x39, 0xa7, 0xf7, 0x4b, 0xba, 0x67, 0xf0, 0x92,
-	0xbb, 0xd0, 0xa7, 0xaf, 0x05, 0x4d, 0x22, 0x1a, 0x39, 0x5d, 0x34, 0xb4, 0xb7, 0x11, 0xd3, 0xec,
-	0x50, 0xd3, 0x55, 0x84, 0x25, 0xfb, 0xe4, 0x1e, 0x8c, 0x2a, 0x24, 0xb2, 0x05, 0xed, 0x53, 0x5a,
-	0xbc, 0xac, 0x3c, 0xca, 0xec, 0x9e, 0x05, 0x71, 0xae, 0x8a, 0x6c, 0xe8, 0xa9, 0xcb, 0x17, 0xad,
-	0x3b, 0x96, 0xfb, 0xb3, 0x05, 0xdb, 0x87, 0x67, 0x34, 0x11, 0x4f, 0x53, 0xc1, 0xe6, 0x2c, 0x0c,
-	0x04, 0x4b, 0x13, 0x72, 0x13, 0xec, 0x34, 0x8e, 0xfc, 0xb7, 0xd6, 0x58, 0x3f, 0x8d, 0xb5, 0xbd,
-	0x9b, 0x60, 0x27, 0xf4, 0x95, 0xe6, 0x6e, 0x9d, 0xc3, 0x9d, 0xd0, 0x57, 0x8a, 0xfb, 0x23, 0x18,
-	0x45, 0x34, 0xa6, 0x82, 0xfa, 0x65, 0x5e, 0x65, 0xd2, 0x87, 0x0a, 0xc4, 0x7c, 0x72, 0xf7, 0x57,
-	0x0b, 0xec, 0x32, 0xbd, 0xe4, 0x32, 0x5c, 0x94, 0xea, 0x7c, 0x16, 0xe9, 0xa0, 0x7a, 0xf2, 0xfa,
-	0x38, 0x92, 0xb5, 0x9a, 0xce, 0xe7, 0x9c, 0x0a, 0x34, 0xdb, 0xf6, 0xf4, 0x4d, 0xbe, 0x35, 0x67,
-	0x3f, 0xa8, 0xf2, 0xec, 0x78, 0x78, 0x96, 0x39, 0x58, 0x0a, 0xb6, 0xa4, 0xf8, 0x2c, 0x6d, 0x4f,
-	0x5d, 0xc8, 0x18, 0xba, 0xd4, 0x17, 0xc1, 0x02, 0xeb, 0xce, 0xf6, 0x3a, 0xf4, 0x79, 0xb0, 0x20,
-	0x1f, 0xc3, 0x25, 0x9e, 0xe6, 0x59, 0x48, 0xfd, 0xc2, 0x6c, 0x0f, 0xa9, 0x43, 0x85, 0x3e, 0x42,
-	0xe3, 0xee, 0x9f, 0x2d, 0xb8, 0x54, 0x7d, 0x51, 0x72, 0x05, 0x6c, 0x94, 0x40, 0xe3, 0x16, 0x1a,
-	0xc7, 0x29, 0x71, 0x5c, 0x71, 0xa0, 0x65, 0x3a, 0x50, 0x88, 0x2c, 0xd3, 0x48, 0xf9, 0x3b, 0x52,
-	0x22, 0x4f, 0xd2, 0x88, 0xca, 0x97, 0xcc, 0x59, 0x84, 0x1e, 0x8f, 0x3c, 0x79, 0x94, 0xc8, 0x82,
-	0x45, 0xba, 0x4b, 0xe4, 0x51, 0xe6, 0x20, 0xcc, 0x50, 0x6f, 0x4f, 0xe5, 0x40, 0xdd, 0x64, 0x0e,
-	0x96, 0x12, 0xbd, 0xa8, 0x02, 0x93, 0x67, 0x32, 0x85, 0x41, 0x46, 0x57, 0xb1, 0x7e, 0x66, 0xa7,
-	0x8f, 0x24, 0x13, 0x22, 0x57, 0x01, 0xc2, 0x34, 0x8e, 0x69, 0x88, 0x0c, 0x36, 0x32, 0x18, 0x88,
-	0x7c, 0x0a, 0x21, 0x62, 0x9f, 0xd3, 0xd0, 0x81, 0xa9, 0xb5, 0xdf, 0xf5, 0x7a, 0x42, 0xc4, 0xc7,
-	0x34, 0x94, 0x71, 0xe4, 0x9c, 0x66, 0x3e, 0xf6, 0xd8, 0x00, 0xe5, 0xfa, 0x12, 0xc0, 0x69, 0xb0,
-	0x07, 0xb0, 0xc8, 0xd2, 0x7c, 0xa5, 0xa8, 0xc3, 0x69, 0x5b, 0x8e, 0x1c, 0x44, 0x90, 0x7c, 0x1d,
-	0x2e, 0xf1, 0x37, 0xcb, 0x98, 0x25, 0xa7, 0xbe, 0x08, 0xb2, 0x05, 0x15, 0xce, 0x08, 0x15, 0x8c,
-	0x34, 0xfa, 0x1c, 0x41, 0xf7, 0x5b, 0x20, 0x07, 0x19, 0x0d, 0x04, 0xfd, 0x17, 0xd3, 0xb5, 0x9c,
-	0x94, 0xad, 0xb7, 0x4e, 0xca, 0xff, 0xc3, 0xb8, 0xa2, 0x5a, 0x0d, 0x1a, 0x69, 0xf1, 0xc5, 0x2a,
-	0x7a, 0x5f, 0x16, 0x2b, 0xaa, 0xb5, 0xc5, 0x9f, 0x2c, 0x20, 0x0f, 0xb1, 0x13, 0xfe, 0xdb, 0x0a,
-	0x91, 0x35, 0x2c, 0x47, 0x9b, 0xea, 0xb4, 0x28, 0x10, 0x81, 0x1e, 0xbe, 0x43, 0xc6, 0x95, 0xfe,
-	0x87, 0x81, 0x08, 0xf4, 0x00, 0xcc, 0x68, 0x98, 0x67, 0x72, 0x1e, 0x63, 0x5d, 0xe1, 0x00, 0xf4,
-	0x0a, 0x48, 0x3a, 0x5a, 0x71, 0x48, 0x3b, 0xfa, 0x9b, 0x05, 0xe3, 0xfb, 0x9c, 0xb3, 0x45, 0xf2,
-	0x4d, 0x1a, 0xe7, 0x4b, 0x5a, 0x78, 0xba, 0x03, 0xdd, 0x30, 0xcd, 0x13, 0x81, 0x5e, 0x76, 0x3d,
-	0x75, 0xd9, 0x28, 0xab, 0x56, 0xad, 0xac, 0x36, 0x0a, 0xb3, 0x5d, 0x2f, 0x4c, 0xa3, 0xf0, 0x3a,
-	0x95, 0xc2, 0xfb, 0x10, 0x06, 0x32, 0x3c, 0x3f, 0xa4, 0x89, 0xa0, 0x99, 0xee, 0x63, 0x90, 0xd0,
-	0x01, 0x22, 0xee, 0x8f, 0x16, 0xec, 0x54, 0x3d, 0xd5, 0x6b, 0xe4, 0xdc, 0xb1, 0x22, 0xdb, 0x2e,
-	0x8b, 0xb5, 0x9b, 0xf2, 0x28, 0x0b, 0x78, 0x95, 0x9f, 0xc4, 0x2c, 0xf4, 0x25, 0x41, 0xb9, 0x67,
-	0x2b, 0xe4, 0x45, 0x16, 0xaf, 0x83, 0xee, 0x98, 0x41, 0x13, 0xe8, 0x04, 0xb9, 0x78, 0x59, 0x8c,
-	0x16, 0x79, 0x76, 0x3f, 0x87, 0xb1, 0xda, 0xec, 0xd5, 0xac, 0xed, 0x01, 0x9c, 0x21, 0xe0, 0xb3,
-	0x48, 0x2d, 0x35, 0xdb, 0xb3, 0x15, 0xf2, 0x38, 0xe2, 0xee, 0x97, 0x60, 0x1f, 0xa5, 0x2a, 0x11,
-	0x9c, 0xdc, 0x02, 0x3b, 0x2e, 0x2e, 0x7a, 0xff, 0x91, 0x75, 0x91, 0x15, 0x7c, 0xde, 0x9a, 0xc9,
-	0xbd, 0x07, 0xfd, 0x02, 0x2e, 0x62, 0xb3, 0xce, 0x8b, 0xad, 0xb5, 0x11, 0x9b, 0xfb, 0x87, 0x05,
-	0x3b, 0x55, 0x97, 0x75, 0xfa, 0x5e, 0xc0, 0xa8, 0x34, 0xe1, 0x2f, 0x83, 0x95, 0xf6, 0xe5, 0x96,
-	0xe9, 0x4b, 0x5d, 0xac, 0x74, 0x90, 0x3f, 0x09, 0x56, 0xaa, 0xa4, 0x86, 0xb1, 0x01, 0x4d, 0x9e,
-	0xc3, 0x76, 0x8d, 0xa5, 0x61, 0xa5, 0xdd, 0x30, 0x57, 0x5a, 0x65, 0x2d, 0x97, 0xd2, 0xe6, 0x9e,
-	0xbb, 0x0b, 0x97, 0x55, 0x15, 0x1f, 0x94, 0x45, 0x57, 0xe4, 0xbe, 0x5a, 0x9b, 0xd6, 0x66, 0x6d,
-	0xba, 0x13, 0x70, 0xea, 0xa2, 0xba, 0x0b, 0x16, 0xb0, 0x7d, 0x2c, 0x02, 0xc1, 0xb8, 0x60, 0x61,
-	0xf9, 0x7d, 0xb5, 0x51, 0xcc, 0xd6, 0xbb, 0xa6, 0x6c, 0xbd, 0x1d, 0xb6, 0xa0, 0x2d, 0x44, 0x51,
-	0x67, 0xf2, 0x28, 0x5f, 0x81, 0x98, 0x96, 0xf4, 0x1b, 0xbc, 0x07, 0x53, 0xb2, 0x1e, 0x44, 0x2a,
-	0x82, 0x58, 0x6d, 0xb1, 0x0e, 0x6e, 0x31, 0x1b, 0x11, 0x5c, 0x63, 0x6a, 0xd0, 0x47, 0x8a, 0xda,
-	0x55, 0x3b, 0x4e, 0x02, 0x48, 0xdc, 0x03, 0xc0, 0x96, 0x52, 0xdd, 0xd0, 0x53, 0xb2, 0x12, 0x39,
-	0x90, 0xc0, 0xed, 0xbf, 0xba, 0x30, 0x3c, 0xa6, 0xc1, 0x2b, 0x4a, 0x23, 0xb9, 0x44, 0x33, 0xb2,
-	0x28, 0x6a, 0xab, 0xfa, 0xa1, 0x4b, 0xae, 0x6f, 0x16, 0x51, 0xe3, 0x97, 0xf5, 0xe4, 0x93, 0x77,
-	0xb1, 0xe9, 0x67, 0xba, 0x40, 0x8e, 0x60, 0x60, 0x7c, 0x49, 0x92, 0x5d, 0x43, 0xb0, 0xf6, 0x81,
-	0x3c, 0xd9, 0x3b, 0x87, 0x6a, 0x6a, 0x33, 0xd6, 0x85, 0xa9, 0xad, 0xbe, 0xa0, 0x4c, 0x6d, 0x4d,
-	0x3b, 0x06, 0xb5, 0x19, 0xab, 0xc0, 0xd4, 0x56, 0x5f, 0x3e, 0xa6, 0xb6, 0xa6, 0xfd, 0x81, 0xda,
-	0x8c, 0x79, 0x6d, 0x6a, 0xab, 0xef, 0x15, 0x53, 0x5b, 0xd3, 0x90, 0xbf, 0x40, 0x9e, 0xc1, 0xd0,
-	0x9c, 0x9d, 0xc4, 0x10, 0x68, 0x98, 0xfe, 0x93, 0xab, 0xe7, 0x91, 0x4d, 0x85, 0xe6, 0x58, 0x30,
-	0x15, 0x36, 0x0c, 0x46, 0x53, 0x61, 0xd3, 0x34, 0x71, 0x2f, 0x90, 0xef, 0x60, 0x6b, 0xb3, 0x3d,
-	0xc9, 0xb5, 0xcd, 0xb0, 0x6a, 0x5d, 0x3f, 0x71, 0xdf, 0xc6, 0x52, 0x2a, 0x7f, 0x0c, 0xb0, 0xee,
-	0x3a, 0x72, 0x65, 0x2d, 0x53, 0xeb, 0xfa, 0xc9, 0x6e, 0x33, 0xb1, 0x50, 0xf5, 0xe0, 0x2a, 0x6c,
-	0x71, 0x55, 0xfa, 0x73, 0x3e, 0x0b, 0x63, 0x46, 0x13, 0xf1, 0x00, 0xb0, 0x0b, 0xbe, 0x96, 0xbf,
-	0x93, 0x27, 0x3d, 0xfc, 0xab, 0xfc, 0xec, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8c, 0x6d, 0xf7,
-	0x42, 0x64, 0x0e, 0x00, 0x00,
+	// 1612 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4f, 0x6f, 0xdb, 0x46,
+	0x16, 0x5f, 0xea, 0x9f, 0xa5, 0x27, 0xc9, 0xb1, 0xc6, 0x4e, 0xc2, 0xd0, 0x96, 0xad, 0xd0, 0x9b,
+	0x5d, 0xef, 0x6e, 0x60, 0x04, 0xde, 0x3d, 0x24, 0x9b, 0x16, 0x45, 0xe2, 0x38, 0xa8, 0x0b, 0xc7,
+	0x09, 0xe8, 0xb8, 0x40, 0x51, 0xa0, 0x2c, 0x4d, 0x8e, 0xe5, 0x81, 0x29, 0x52, 0xe5, 0x0c, 0xed,
+	0xa4, 0xc7, 0x1e, 0x7b, 0xe9, 0x25, 0x40, 0x81, 0xde, 0x7a, 0xea, 0xb7, 0xe8, 0xa5, 0x1f, 0xa7,
+	0x40, 0x3f, 0x43, 0x31, 0x33, 0xfc, 0x33, 0x24, 0x25, 0xa7, 0x41, 0x11, 0xa0, 0x37, 0xce, 0xfb,
+	0x37, 0xbf, 0x79, 0xf3, 0xe6, 0xf7, 0x9e, 0x04, 0xdd, 0x53, 0xe2, 0xe3, 0x68, 0x7b, 0x1a, 0x85,
+	0x2c, 0x44, 0x6d, 0xb1, 0xb0, 0xa7, 0x27, 0xe6, 0x73, 0x58, 0x3d, 0x08, 0xc3, 0xf3, 0x78, 0xfa,
+	0x84, 0x44, 0xd8, 0x65, 0x61, 0xf4, 0x7a, 0x2f, 0x60, 0xd1, 0x6b, 0x0b, 0x7f, 0x15, 0x63, 0xca,
+	0xd0, 0x1a, 0x74, 0xbc, 0x54, 0xa1, 0x6b, 0x23, 0x6d, 0xab, 0x63, 0xe5, 0x02, 0x84, 0xa0, 0x11,
+	0x38, 0x13, 0xac, 0xd7, 0x84, 0x42, 0x7c, 0x9b, 0x7b, 0xb0, 0x36, 0x3b, 0x20, 0x9d, 0x86, 0x01,
+	0xc5, 0xe8, 0x0e, 0x34, 0x71, 0xc0, 0x92, 0x68, 0xdd, 0x9d, 0x6b, 0xdb, 0x29, 0x94, 0x6d, 0x69,
+	0x27, 0xb5, 0xe6, 0xcf, 0x1a, 0xa0, 0x03, 0x42, 0x19, 0x17, 0x12, 0x4c, 0xff, 0x18, 0x9e, 0x1b,
+	0xd0, 0x9a, 0x46, 0xf8, 0x94, 0xbc, 0x4a, 0x10, 0x25, 0x2b, 0x74, 0x17, 0x06, 0x94, 0x39, 0x11,
+	0x7b, 0x1a, 0x85, 0x93, 0xa7, 0xc4, 0xc7, 0x87, 0x1c, 0x74, 0x5d, 0x98, 0x54, 0x15, 0x68, 0x1b,
+	0x10, 0x09, 0x5c, 0x3f, 0xa6, 0xe4, 0x02, 0x1f, 0xa5, 0x5a, 0xbd, 0x31, 0xd2, 0xb6, 0xda, 0xd6,
+	0x0c, 0x0d, 0x5a, 0x81, 0xa6, 0x4f, 0x26, 0x84, 0xe9, 0xcd, 0x91, 0xb6, 0xd5, 0xb7, 0xe4, 0xc2,
+	0xfc, 0x00, 0x96, 0x0b, 0xf8, 0xdf, 0xed, 0xf8, 0xbf, 0xd6, 0xa0, 0x29, 0x04, 0x59, 0x8e, 0xb5,
+	0x3c, 0xc7, 0xe8, 0x36, 0xf4, 0x08, 0xb5, 0xf3, 0x44, 0xd4, 0x04, 0xb6, 0x2e, 0xa1, 0x59, 0xce,
+	0xd1, 0x7f, 0xa0, 0xe5, 0x9e, 0xc5, 0xc1, 0x39, 0xd5, 0xeb, 0xa3, 0xfa, 0x56, 0x77, 0x67, 0x39,
+	0xdf, 0x88, 0x1f, 0x74, 0x97, 0xeb, 0xac, 0xc4, 0x04, 0xdd, 0x07, 0x70, 0x18, 0x8b, 0xc8, 0x49,
+	0xcc, 0x30, 0x15, 0x27, 0xed, 0xee, 0xe8, 0x8a, 0x43, 0x4c, 0xf1, 0xa3, 0x4c, 0x6f, 0x29, 0xb6,
+	0xe8, 0x01, 0xb4, 0xf1, 0x2b, 0x86, 0x03, 0x0f, 0x7b, 0x7a, 0x53, 0x6c, 0x34, 0x2c, 0x9d, 0x68,
+	0x7b, 0x2f, 0xd1, 0xcb, 0xf3, 0x65, 0xe6, 0x68, 0x04, 0xbd, 0x33, 0x27, 0xf2, 0x6c, 0x9f, 0x04,
+	0xe7, 0x36, 0xf1, 0xf4, 0xd6, 0x48, 0xdb, 0xea, 0x59, 0xc0, 0x65, 0x07, 0x24, 0x38, 0xdf, 0xf7,
+	0xd0, 0xbf, 0x61, 0x90, 0x5b, 0xb8, 0x61, 0x1c, 0x30, 0x1c, 0xe9, 0x0b, 0x23, 0x6d, 0xab, 0x69,
+	0x5d, 0x4b, 0xcd, 0x76, 0xa5, 0xd8, 0x78, 0x08, 0xfd, 0xc2, 0x46, 0x68, 0x09, 0xea, 0xe7, 0x38,
+	0xad, 0x11, 0xfe, 0xc9, 0xef, 0xe9, 0xc2, 0xf1, 0x63, 0x59, 0xae, 0x3d, 0x4b, 0x2e, 0xfe, 0x5f,
+	0xbb, 0xaf, 0x99, 0x6f, 0x34, 0x18, 0xec, 0x5d, 0xe0, 0x80, 0x1d, 0x86, 0x8c, 0x9c, 0x12, 0xd7,
+	0x61, 0x24, 0x0c, 0xd0, 0x5d, 0xe8, 0x84, 0xbe, 0x67, 0x5f, 0x79, 0x5d, 0xed, 0xd0, 0x4f, 0xf6,
+	0xbb, 0x0b, 0x9d, 0x00, 0x5f, 0x26, 0xd6, 0xb5, 0x39, 0xd6, 0x01, 0xbe, 0x94, 0xd6, 0x9b, 0xd0,
+	0xf7, 0xb0, 0x8f, 0x19, 0xb6, 0xb3, 0x5b, 0xe2, 0x57, 0xd8, 0x93, 0x42, 0x71, 0x3b, 0xd4, 0xfc,
+	0x51, 0x83, 0x4e, 0x76, 0x59, 0xe8, 0x26, 0x2c, 0xf0, 0x70, 0x3c, 0x55, 0xf2, 0x50, 0x2d, 0xbe,
+	0xdc, 0xf7, 0x78, 0xd5, 0x87, 0xa7, 0xa7, 0x14, 0x33, 0xb1, 0x6d, 0xdd, 0x4a, 0x56, 0xbc, 0x72,
+	0x28, 0xf9, 0x5a, 0x16, 0x7a, 0xc3, 0x12, 0xdf, 0x3c, 0x07, 0x13, 0x46, 0x26, 0x58, 0x5c, 0x72,
+	0xdd, 0x92, 0x0b, 0xb4, 0x0c, 0x4d, 0x6c, 0x33, 0x67, 0x2c, 0x2a, 0xb8, 0x63, 0x35, 0xf0, 0x4b,
+	0x67, 0x8c, 0xfe, 0x0e, 0x8b, 0x34, 0x8c, 0x23, 0x17, 0xdb, 0xe9, 0xb6, 0x2d, 0xa1, 0xed, 0x49,
+	0xe9, 0x53, 0xb1, 0xb9, 0xf9, 0x5b, 0x0d, 0x16, 0x8b, 0xf5, 0x81, 0x56, 0xa1, 0x23, 0x3c, 0xc4,
+	0xe6, 0x9a, 0xd8, 0x5c, 0xf0, 0xcd, 0x51, 0x01, 0x40, 0x4d, 0x05, 0x90, 0xba, 0x4c, 0x42, 0x4f,
+	0xe2, 0xed, 0x4b, 0x97, 0x67, 0xa1, 0x87, 0xf9, 0x4d, 0xc6, 0xc4, 0x13, 0x88, 0xfb, 0x16, 0xff,
+	0xe4, 0x92, 0x31, 0xf1, 0x92, 0xf7, 0xc6, 0x3f, 0x79, 0x0e, 0xdc, 0x48, 0xc4, 0x6d, 0xc9, 0x1c,
+	0xc8, 0x15, 0xcf, 0xc1, 0x84, 0x4b, 0x17, 0xe4, 0xc1, 0xf8, 0x37, 0x1a, 0x41, 0x37, 0xc2, 0x53,
+	0x3f, 0xb9, 0x66, 0xbd, 0x2d, 0x54, 0xaa, 0x08, 0xad, 0x03, 0xb8, 0xa1, 0xef, 0x63, 0x57, 0x18,
+	0x74, 0x84, 0x81, 0x22, 0xe1, 0x57, 0xc1, 0x98, 0x6f, 0x53, 0xec, 0xea, 0x20, 0xca, 0xb1, 0xc5,
+	0x98, 0x7f, 0x84, 0x5d, 0x7e, 0x8e, 0x98, 0xe2, 0xc8, 0x16, 0x2f, 0xb6, 0x2b, 0xfc, 0xda, 0x5c,
+	0x20, 0x78, 0x65, 0x08, 0x30, 0x8e, 0xc2, 0x78, 0x2a, 0xb5, 0xbd, 0x51, 0x9d, 0x93, 0x97, 0x90,
+	0x08, 0xf5, 0x1d, 0x58, 0xa4, 0xaf, 0x27, 0xa2, 0xd6, 0x99, 0x13, 0x8d, 0x31, 0xd3, 0xfb, 0x22,
+	0x40, 0x3f, 0x91, 0xbe, 0x14, 0x42, 0xf3, 0x33, 0x40, 0xbb, 0x11, 0x76, 0x18, 0x7e, 0x07, 0x9e,
+	0xce, 0x48, 0xa7, 0x76, 0x25, 0xe9, 0x5c, 0x87, 0xe5, 0x42, 0x68, 0x49, 0x59, 0xe6, 0x37, 0x1a,
+	0xa0, 0xe3, 0xa9, 0xf7, 0x3e, 0xb6, 0x44, 0xff, 0x84, 0x6b, 0xb1, 0x08, 0x6d, 0x67, 0x34, 0x22,
+	0x5f, 0xc2, 0xa2, 0x14, 0xa7, 0x6f, 0x9a, 0x63, 0x2b, 0x60, 0x48, 0xb0, 0x7d, 0xa7, 0x01, 0x7a,
+	0x22, 0xde, 0xcc, 0x9f, 0x6b, 0x5b, 0xbc, 0xda, 0x39, 0xa5, 0xca, 0x37, 0xe9, 0x39, 0xcc, 0x49,
+	0x08, 0xbf, 0x47, 0xa8, 0x8c, 0xff, 0xc4, 0x61, 0x4e, 0x42, 0xbc, 0x11, 0x76, 0xe3, 0x88, 0xf7,
+	0x00, 0xbd, 0x99, 0x12, 0xaf, 0x95, 0x8a, 0x38, 0xd0, 0x02, 0xa0, 0x04, 0xe8, 0x0f, 0x1a, 0xe8,
+	0x8f, 0x58, 0x38, 0x21, 0xae, 0x85, 0xf9, 0x86, 0x05, 0xb8, 0x9b, 0xd0, 0xe7, 0x4c, 0x53, 0x86,
+	0xdc, 0x0b, 0x7d, 0x2f, 0x67, 0xf4, 0x5b, 0xc0, 0xc9, 0xc6, 0x56, 0x90, 0x2f, 0x84, 0xbe, 0x27,
+	0x4a, 0x67, 0x13, 0xfa, 0x9c, 0x7b, 0x72, 0x7f, 0xd9, 0xdb, 0x7a, 0x01, 0xbe, 0x2c, 0xf8, 0x73,
+	0x23, 0xe1, 0xdf, 0x90, 0xfe, 0x01, 0xbe, 0xe4, 0xfe, 0xe6, 0x2a, 0xdc, 0x9a, 0x81, 0x2d, 0x41,
+	0xfe, 0xbd, 0x06, 0xd7, 0x65, 0x59, 0x7c, 0x9c, 0x70, 0xee, 0x5f, 0x05, 0xf6, 0x47, 0x70, 0xa3,
+	0x0c, 0xec, 0xdd, 0xba, 0xec, 0x4f, 0x1a, 0x2c, 0x3f, 0xa2, 0x94, 0x8c, 0x83, 0x4f, 0x43, 0x3f,
+	0x9e, 0xe0, 0xf4, 0x60, 0x2b, 0xd0, 0x14, 0xed, 0x46, 0xb8, 0x37, 0x2d, 0xb9, 0x28, 0xb1, 0x42,
+	0xad, 0xc2, 0x0a, 0x25, 0x5e, 0xa9, 0x57, 0x79, 0x45, 0xe1, 0x8d, 0x46, 0x81, 0x37, 0x36, 0xa0,
+	0xcb, 0x6b, 0xce, 0x76, 0xb1, 0xe8, 0x71, 0x92, 0x86, 0x81, 0x8b, 0x76, 0x85, 0xc4, 0xfc, 0x56,
+	0x83, 0x95, 0x22, 0xd2, 0xe4, 0xa4, 0x73, 0xbb, 0x02, 0x67, 0xcd, 0xc8, 0x4f, 0x60, 0xf2, 0x4f,
+	0xce, 0x3f, 0xd3, 0xf8, 0xc4, 0x27, 0xae, 0xcd, 0x15, 0x12, 0x5e, 0x47, 0x4a, 0x8e, 0x23, 0x3f,
+	0x3f, 0x74, 0x43, 0x3d, 0x34, 0x82, 0x86, 0x13, 0xb3, 0xb3, 0xb4, 0x33, 0xf0, 0x6f, 0xf3, 0x7f,
+	0xb0, 0x2c, 0x47, 0xbc, 0x62, 0xd6, 0x86, 0x00, 0x17, 0x42, 0x60, 0x13, 0x8f, 0xea, 0x9a, 0xe4,
+	0x37, 0x29, 0xd9, 0xf7, 0xa8, 0xf9, 0x21, 0x74, 0x0e, 0x42, 0x99, 0x08, 0x8a, 0xee, 0x41, 0xc7,
+	0x4f, 0x17, 0xc2, 0xb4, 0xbb, 0x83, 0xf2, 0x4b, 0x4a, 0xed, 0xac, 0xdc, 0xc8, 0x7c, 0x08, 0xed,
+	0x54, 0x9c, 0x9e, 0x4d, 0x9b, 0x77, 0xb6, 0x5a, 0xe9, 0x6c, 0xe6, 0x2f, 0x1a, 0xac, 0x14, 0x21,
+	0x27, 0xe9, 0x3b, 0x86, 0x7e, 0xb6, 0x85, 0x3d, 0x71, 0xa6, 0x09, 0x96, 0x7b, 0x2a, 0x96, 0xaa,
+	0x5b, 0x06, 0x90, 0x3e, 0x73, 0xa6, 0xb2, 0xa2, 0x7a, 0xbe, 0x22, 0x32, 0x5e, 0xc2, 0xa0, 0x62,
+	0x32, 0x63, 0x22, 0xf9, 0x97, 0x3a, 0x91, 0x14, 0x66, 0xb4, 0xcc, 0x5b, 0x1d, 0x53, 0x1e, 0xc0,
+	0x4d, 0x49, 0x2d, 0xbb, 0x59, 0xd1, 0xa5, 0xb9, 0x2f, 0xd6, 0xa6, 0x56, 0xae, 0x4d, 0xd3, 0x00,
+	0xbd, 0xea, 0x9a, 0x3c, 0xf0, 0x31, 0x0c, 0x8e, 0x98, 0xc3, 0x08, 0x65, 0xc4, 0xcd, 0x06, 0xed,
+	0x52, 0x31, 0x6b, 0x6f, 0x6b, 0x92, 0xd5, 0xe7, 0xb0, 0x04, 0x75, 0xc6, 0xd2, 0x3a, 0xe3, 0x9f,
+	0xfc, 0x16, 0x90, 0xba, 0x53, 0x72, 0x07, 0xef, 0x61, 0x2b, 0x5e, 0x0f, 0x2c, 0x64, 0x8e, 0x2f,
+	0x87, 0x90, 0x86, 0x18, 0x42, 0x3a, 0x42, 0x22, 0xa6, 0x10, 0xd9, 0xa7, 0x3d, 0xa9, 0x6d, 0xca,
+	0x11, 0x85, 0x0b, 0x84, 0x72, 0x08, 0x20, 0x9e, 0x94, 0x7c, 0x0d, 0x2d, 0xe9, 0xcb, 0x25, 0x62,
+	0xd6, 0x34, 0x2f, 0x41, 0x3f, 0x8a, 0x4f, 0xa8, 0x1b, 0x91, 0x13, 0xfc, 0x0c, 0x33, 0x87, 0xbf,
+	0xd2, 0x34, 0x6b, 0x1b, 0xd0, 0x75, 0x7d, 0x82, 0x03, 0x66, 0x2b, 0x33, 0x3b, 0x48, 0x91, 0xa0,
+	0xbc, 0x0d, 0xe8, 0x4e, 0x1d, 0x76, 0x66, 0x17, 0x7e, 0xa6, 0x00, 0x17, 0xbd, 0x10, 0x12, 0x4e,
+	0x77, 0x94, 0x04, 0x2e, 0xb6, 0x03, 0x39, 0x13, 0xd6, 0xad, 0x05, 0xb1, 0x3e, 0xa4, 0xbc, 0x85,
+	0xdc, 0x9a, 0xb1, 0x73, 0x92, 0xc5, 0xab, 0x5b, 0xde, 0x27, 0x80, 0xf0, 0x85, 0xc0, 0xa5, 0x4c,
+	0xb8, 0x49, 0xd9, 0xad, 0x2a, 0xec, 0x58, 0x1e, 0x82, 0xad, 0x01, 0x2e, 0x8b, 0xf8, 0xb4, 0xc8,
+	0x68, 0x8e, 0xaf, 0xc1, 0xe8, 0x21, 0xdd, 0x79, 0xd3, 0x86, 0xde, 0x11, 0x76, 0x2e, 0x31, 0xf6,
+	0xf8, 0x64, 0x18, 0xa1, 0x71, 0xfa, 0xe2, 0x8a, 0xbf, 0x03, 0xd1, 0x9d, 0xf2, 0xd3, 0x9a, 0xf9,
+	0xc3, 0xd3, 0xf8, 0xc7, 0xdb, 0xcc, 0x92, 0xe2, 0xfd, 0x1b, 0x3a, 0x84, 0xae, 0xf2, 0x43, 0x0b,
+	0xad, 0x29, 0x8e, 0x95, 0xdf, 0x8f, 0xc6, 0x70, 0x8e, 0x36, 0x8d, 0x76, 0x4f, 0x43, 0x07, 0xd0,
+	0x55, 0xa6, 0x20, 0x35, 0x5e, 0x75, 0xee, 0x32, 0x86, 0x73, 0xb4, 0x19, 0xba, 0x03, 0xe8, 0x2a,
+	0x73, 0x8b, 0x1a, 0xad, 0x3a, 0x52, 0x19, 0xc3, 0x39, 0x5a, 0x35, 0x9a, 0x32, 0x5c, 0xa8, 0xd1,
+	0xaa, 0x43, 0x90, 0x31, 0x9c, 0xa3, 0xcd, 0xa2, 0x7d, 0x01, 0x83, 0x4a, 0xdb, 0x47, 0x66, 0xee,
+	0x35, 0x6f, 0x5e, 0x31, 0x36, 0xaf, 0xb4, 0xc9, 0xe2, 0x1f, 0xc3, 0x62, 0xb1, 0x3f, 0xa3, 0x8d,
+	0x72, 0xba, 0x4a, 0x23, 0x85, 0x31, 0x9a, 0x6f, 0x90, 0x85, 0x7d, 0x0e, 0x3d, 0xb5, 0x15, 0x22,
+	0xe5, 0x9c, 0x33, 0x9a, 0xb9, 0xb1, 0x3e, 0x4f, 0xad, 0x06, 0x54, 0x59, 0x5e, 0x0d, 0x38, 0xa3,
+	0xcf, 0x19, 0xeb, 0xf3, 0xd4, 0x59, 0xc0, 0xcf, 0x61, 0xa9, 0xcc, 0xb6, 0xe8, 0x76, 0xf9, 0x36,
+	0x2a, 0x24, 0x6e, 0x98, 0x57, 0x99, 0x64, 0xc1, 0xf7, 0x01, 0x72, 0x12, 0x45, 0xca, 0xe3, 0xad,
+	0x90, 0xb8, 0xb1, 0x36, 0x5b, 0x99, 0x85, 0xfa, 0x12, 0x06, 0x15, 0x42, 0x51, 0x0b, 0x60, 0x1e,
+	0xcf, 0x19, 0x9b, 0x57, 0xda, 0xe4, 0x8f, 0xe9, 0xf1, 0x3a, 0x2c, 0x51, 0xc9, 0x0a, 0xa7, 0x74,
+	0x5b, 0xf2, 0xe0, 0x63, 0x10, 0x04, 0xf1, 0x22, 0x0a, 0x59, 0x78, 0xd2, 0x12, 0xff, 0x47, 0xfd,
+	0xf7, 0xf7, 0x01, 0x00, 0x26, 0x04, 0x68, 0xc0, 0x9e, 0x12, 0x00, 0x00,
 }
@@ -0,0 +1,272 @@
+// Code generated by protoc-gen-go.
+// source: filer_raft.proto
+// DO NOT EDIT!
+
+/*
+Package filer_raft_pb is a generated protocol buffer package.
+
+It is generated from these files:
+	filer_raft.proto
+
+It has these top-level messages:
+	ApplyCommandRequest
+	ApplyCommandResponse
+	ReadSnapshotDumpRequest
+	ReadSnapshotDumpResponse
+*/
+package filer_raft_pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ApplyCommandRequest struct {
+	CommandName string `protobuf:"bytes,1,opt,name=command_name,json=commandName" json:"command_name,omitempty"`
+	Command     []byte `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+}
+
+func (m *ApplyCommandRequest) Reset()                    { *m = ApplyCommandRequest{} }
+func (m *ApplyCommandRequest) String() string            { return proto.CompactTextString(m) }
+func (*ApplyCommandRequest) ProtoMessage()               {}
+func (*ApplyCommandRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ApplyCommandRequest) GetCommandName() string {
+	if m != nil {
+		return m.CommandName
+	}
+	return ""
+}
+
+func (m *ApplyCommandRequest) GetCommand() []byte {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+type ApplyCommandResponse struct {
+	CommitIndex uint64 `protobuf:"varint,1,opt,name=commit_index,json=commitIndex" json:"commit_index,omitempty"`
+}
+
+func (m *ApplyCommandResponse) Reset()                    { *m = ApplyCommandResponse{} }
+func (m *ApplyCommandResponse) String() string            { return proto.CompactTextString(m) }
+func (*ApplyCommandResponse) ProtoMessage()               {}
+func (*ApplyCommandResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ApplyCommandResponse) GetCommitIndex() uint64 {
+	if m != nil {
+		return m.CommitIndex
+	}
+	return 0
+}
+
+type ReadSnapshotDumpRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ReadSnapshotDumpRequest) Reset()                    { *m = ReadSnapshotDumpRequest{} }
+func (m *ReadSnapshotDumpRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadSnapshotDumpRequest) ProtoMessage()               {}
+func (*ReadSnapshotDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ReadSnapshotDumpRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ReadSnapshotDumpResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *ReadSnapshotDumpResponse) Reset()                    { *m = ReadSnapshotDumpResponse{} }
+func (m *ReadSnapshotDumpResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadSnapshotDumpResponse) ProtoMessage()               {}
+func (*ReadSnapshotDumpResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ReadSnapshotDumpResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ApplyCommandRequest)(nil), "filer_raft_pb.ApplyCommandRequest")
+	proto.RegisterType((*ApplyCommandResponse)(nil), "filer_raft_pb.ApplyCommandResponse")
+	proto.RegisterType((*ReadSnapshotDumpRequest)(nil), "filer_raft_pb.ReadSnapshotDumpRequest")
+	proto.RegisterType((*ReadSnapshotDumpResponse)(nil), "filer_raft_pb.ReadSnapshotDumpResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for FilerRaftStore service
+
+type FilerRaftStoreClient interface {
+	ApplyCommand(ctx context.Context, in *ApplyCommandRequest, opts ...grpc.CallOption) (*ApplyCommandResponse, error)
+	ReadSnapshotDump(ctx context.Context, in *ReadSnapshotDumpRequest, opts ...grpc.CallOption) (FilerRaftStore_ReadSnapshotDumpClient, error)
+}
+
+type filerRaftStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFilerRaftStoreClient(cc *grpc.ClientConn) FilerRaftStoreClient {
+	return &filerRaftStoreClient{cc}
+}
+
+func (c *filerRaftStoreClient) ApplyCommand(ctx context.Context, in *ApplyCommandRequest, opts ...grpc.CallOption) (*ApplyCommandResponse, error) {
+	out := new(ApplyCommandResponse)
+	err := grpc.Invoke(ctx, "/filer_raft_pb.FilerRaftStore/ApplyCommand", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filerRaftStoreClient) ReadSnapshotDump(ctx context.Context, in *ReadSnapshotDumpRequest, opts ...grpc.CallOption) (FilerRaftStore_ReadSnapshotDumpClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_FilerRaftStore_serviceDesc.Streams[0], c.cc, "/filer_raft_pb.FilerRaftStore/ReadSnapshotDump", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &filerRaftStoreReadSnapshotDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FilerRaftStore_ReadSnapshotDumpClient interface {
+	Recv() (*ReadSnapshotDumpResponse, error)
+	grpc.ClientStream
+}
+
+type filerRaftStoreReadSnapshotDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *filerRaftStoreReadSnapshotDumpClient) Recv() (*ReadSnapshotDumpResponse, error) {
+	m := new(ReadSnapshotDumpResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for FilerRaftStore service
+
+type FilerRaftStoreServer interface {
+	ApplyCommand(context.Context, *ApplyCommandRequest) (*ApplyCommandResponse, error)
+	ReadSnapshotDump(*ReadSnapshotDumpRequest, FilerRaftStore_ReadSnapshotDumpServer) error
+}
+
+func RegisterFilerRaftStoreServer(s *grpc.Server, srv FilerRaftStoreServer) {
+	s.RegisterService(&_FilerRaftStore_serviceDesc, srv)
+}
+
+func _FilerRaftStore_ApplyCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilerRaftStoreServer).ApplyCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_raft_pb.FilerRaftStore/ApplyCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilerRaftStoreServer).ApplyCommand(ctx, req.(*ApplyCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilerRaftStore_ReadSnapshotDump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadSnapshotDumpRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FilerRaftStoreServer).ReadSnapshotDump(m, &filerRaftStoreReadSnapshotDumpServer{stream})
+}
+
+type FilerRaftStore_ReadSnapshotDumpServer interface {
+	Send(*ReadSnapshotDumpResponse) error
+	grpc.ServerStream
+}
+
+type filerRaftStoreReadSnapshotDumpServer struct {
+	grpc.ServerStream
+}
+
+func (x *filerRaftStoreReadSnapshotDumpServer) Send(m *ReadSnapshotDumpResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _FilerRaftStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "filer_raft_pb.FilerRaftStore",
+	HandlerType: (*FilerRaftStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ApplyCommand",
+			Handler:    _FilerRaftStore_ApplyCommand_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadSnapshotDump",
+			Handler:       _FilerRaftStore_ReadSnapshotDump_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "filer_raft.proto",
+}
+
+func init() { proto.RegisterFile("filer_raft.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 258 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x51, 0x4d, 0x4b, 0xc3, 0x40,
+	0x10, 0x75, 0x25, 0x28, 0x8e, 0x51, 0xca, 0x2a, 0x18, 0x7a, 0xaa, 0x2b, 0x68, 0x2f, 0x06, 0xd1,
+	0x93, 0x47, 0x51, 0x04, 0x2f, 0x1e, 0xb6, 0x27, 0x4f, 0x61, 0x6a, 0x26, 0x1a, 0xe8, 0x7e, 0x98,
+	0x4c, 0x41, 0xff, 0xa7, 0x3f, 0x48, 0xba, 0x49, 0xb0, 0x8d, 0x1f, 0xbd, 0xcd, 0x3c, 0xde, 0xbe,
+	0xf7, 0xe6, 0x2d, 0x0c, 0x8a, 0x72, 0x46, 0x55, 0x56, 0x61, 0xc1, 0xa9, 0xaf, 0x1c, 0x3b, 0xb9,
+	0xf7, 0x8d, 0x64, 0x7e, 0xaa, 0x34, 0x1c, 0xdc, 0x78, 0x3f, 0xfb, 0xb8, 0x75, 0xc6, 0xa0, 0xcd,
+	0x35, 0xbd, 0xcd, 0xa9, 0x66, 0x79, 0x0c, 0xf1, 0x73, 0x83, 0x64, 0x16, 0x0d, 0x25, 0x62, 0x24,
+	0xc6, 0x3b, 0x7a, 0xb7, 0xc5, 0x1e, 0xd1, 0x90, 0x4c, 0x60, 0xbb, 0x5d, 0x93, 0xcd, 0x91, 0x18,
+	0xc7, 0xba, 0x5b, 0xd5, 0x35, 0x1c, 0xae, 0x6a, 0xd6, 0xde, 0xd9, 0x9a, 0x3a, 0xd1, 0x92, 0xb3,
+	0xd2, 0xe6, 0xf4, 0x1e, 0x44, 0xa3, 0x46, 0xb4, 0xe4, 0x87, 0x05, 0xa4, 0xce, 0xe1, 0x48, 0x13,
+	0xe6, 0x13, 0x8b, 0xbe, 0x7e, 0x75, 0x7c, 0x37, 0x37, 0xbe, 0x8b, 0x24, 0x21, 0x5a, 0x8a, 0x12,
+	0x66, 0x95, 0x42, 0xf2, 0x93, 0xde, 0xba, 0x49, 0x88, 0x72, 0x64, 0x0c, 0xfc, 0x58, 0x87, 0xf9,
+	0xf2, 0x53, 0xc0, 0xfe, 0xfd, 0xe2, 0x7e, 0x8d, 0x05, 0x4f, 0xd8, 0x55, 0x24, 0x9f, 0x20, 0x5e,
+	0x0e, 0x2b, 0x55, 0xba, 0x52, 0x50, 0xfa, 0x4b, 0x3b, 0xc3, 0x93, 0x7f, 0x39, 0x8d, 0xbf, 0xda,
+	0x90, 0x2f, 0x30, 0xe8, 0xa7, 0x93, 0xa7, 0xbd, 0xa7, 0x7f, 0x5c, 0x3b, 0x3c, 0x5b, 0xcb, 0xeb,
+	0x6c, 0x2e, 0xc4, 0x74, 0x2b, 0x7c, 0xed, 0xd5, 0xd7, 0x00, 0x32, 0x0a, 0x12, 0xea, 0xee, 0x01,
+	0x00, 0x00,
+}
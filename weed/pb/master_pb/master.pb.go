@@ -12,6 +12,7 @@ It has these top-level messages:
 	Heartbeat
 	HeartbeatResponse
 	VolumeInformationMessage
+	VolumeEcShardInformationMessage
 	Empty
 	SuperBlockExtra
 	ClientListenRequest
@@ -23,6 +24,21 @@ It has these top-level messages:
 	AssignResponse
 	StatisticsRequest
 	StatisticsResponse
+	LookupEcVolumeRequest
+	LookupEcVolumeResponse
+	Collection
+	CollectionListRequest
+	CollectionListResponse
+	CollectionDeleteRequest
+	CollectionDeleteResponse
+	DataNodeInfo
+	RackInfo
+	DataCenterInfo
+	TopologyInfo
+	VolumeListRequest
+	VolumeListResponse
+	VacuumVolumeRequest
+	VacuumVolumeResponse
 */
 package master_pb
 
@@ -57,8 +73,12 @@ type Heartbeat struct {
 	AdminPort      uint32                      `protobuf:"varint,8,opt,name=admin_port,json=adminPort" json:"admin_port,omitempty"`
 	Volumes        []*VolumeInformationMessage `protobuf:"bytes,9,rep,name=volumes" json:"volumes,omitempty"`
 	// delta volume ids
-	NewVids     []uint32 `protobuf:"varint,10,rep,packed,name=new_vids,json=newVids" json:"new_vids,omitempty"`
-	DeletedVids []uint32 `protobuf:"varint,11,rep,packed,name=deleted_vids,json=deletedVids" json:"deleted_vids,omitempty"`
+	NewVids     []uint32                           `protobuf:"varint,10,rep,packed,name=new_vids,json=newVids" json:"new_vids,omitempty"`
+	DeletedVids []uint32                           `protobuf:"varint,11,rep,packed,name=deleted_vids,json=deletedVids" json:"deleted_vids,omitempty"`
+	EcShards    []*VolumeEcShardInformationMessage `protobuf:"bytes,12,rep,name=ec_shards,json=ecShards" json:"ec_shards,omitempty"`
+	// delta ec shards
+	NewEcShards     []*VolumeEcShardInformationMessage `protobuf:"bytes,13,rep,name=new_ec_shards,json=newEcShards" json:"new_ec_shards,omitempty"`
+	DeletedEcShards []*VolumeEcShardInformationMessage `protobuf:"bytes,14,rep,name=deleted_ec_shards,json=deletedEcShards" json:"deleted_ec_shards,omitempty"`
 }
 
 func (m *Heartbeat) Reset()                    { *m = Heartbeat{} }
@@ -143,6 +163,27 @@ func (m *Heartbeat) GetDeletedVids() []uint32 {
 	return nil
 }
 
+func (m *Heartbeat) GetEcShards() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.EcShards
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetNewEcShards() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.NewEcShards
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetDeletedEcShards() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.DeletedEcShards
+	}
+	return nil
+}
+
 type HeartbeatResponse struct {
 	VolumeSizeLimit uint64 `protobuf:"varint,1,opt,name=volumeSizeLimit" json:"volumeSizeLimit,omitempty"`
 	Leader          string `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
@@ -168,16 +209,17 @@ func (m *HeartbeatResponse) GetLeader() string {
 }
 
 type VolumeInformationMessage struct {
-	Id               uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Size             uint64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
-	Collection       string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
-	FileCount        uint64 `protobuf:"varint,4,opt,name=file_count,json=fileCount" json:"file_count,omitempty"`
-	DeleteCount      uint64 `protobuf:"varint,5,opt,name=delete_count,json=deleteCount" json:"delete_count,omitempty"`
-	DeletedByteCount uint64 `protobuf:"varint,6,opt,name=deleted_byte_count,json=deletedByteCount" json:"deleted_byte_count,omitempty"`
-	ReadOnly         bool   `protobuf:"varint,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
-	ReplicaPlacement uint32 `protobuf:"varint,8,opt,name=replica_placement,json=replicaPlacement" json:"replica_placement,omitempty"`
-	Version          uint32 `protobuf:"varint,9,opt,name=version" json:"version,omitempty"`
-	Ttl              uint32 `protobuf:"varint,10,opt,name=ttl" json:"ttl,omitempty"`
+	Id                   uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Size                 uint64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	Collection           string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
+	FileCount            uint64 `protobuf:"varint,4,opt,name=file_count,json=fileCount" json:"file_count,omitempty"`
+	DeleteCount          uint64 `protobuf:"varint,5,opt,name=delete_count,json=deleteCount" json:"delete_count,omitempty"`
+	DeletedByteCount     uint64 `protobuf:"varint,6,opt,name=deleted_byte_count,json=deletedByteCount" json:"deleted_byte_count,omitempty"`
+	ReadOnly             bool   `protobuf:"varint,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+	ReplicaPlacement     uint32 `protobuf:"varint,8,opt,name=replica_placement,json=replicaPlacement" json:"replica_placement,omitempty"`
+	Version              uint32 `protobuf:"varint,9,opt,name=version" json:"version,omitempty"`
+	Ttl                  uint32 `protobuf:"varint,10,opt,name=ttl" json:"ttl,omitempty"`
+	CorruptedNeedleCount uint32 `protobuf:"varint,11,opt,name=corrupted_needle_count,json=corruptedNeedleCount" json:"corrupted_needle_count,omitempty"`
 }
 
 func (m *VolumeInformationMessage) Reset()                    { *m = VolumeInformationMessage{} }
@@ -255,13 +297,54 @@ func (m *VolumeInformationMessage) GetTtl() uint32 {
 	return 0
 }
 
+func (m *VolumeInformationMessage) GetCorruptedNeedleCount() uint32 {
+	if m != nil {
+		return m.CorruptedNeedleCount
+	}
+	return 0
+}
+
+type VolumeEcShardInformationMessage struct {
+	Id          uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Collection  string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	EcIndexBits uint32 `protobuf:"varint,3,opt,name=ec_index_bits,json=ecIndexBits" json:"ec_index_bits,omitempty"`
+}
+
+func (m *VolumeEcShardInformationMessage) Reset()         { *m = VolumeEcShardInformationMessage{} }
+func (m *VolumeEcShardInformationMessage) String() string { return proto.CompactTextString(m) }
+func (*VolumeEcShardInformationMessage) ProtoMessage()    {}
+func (*VolumeEcShardInformationMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3}
+}
+
+func (m *VolumeEcShardInformationMessage) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *VolumeEcShardInformationMessage) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeEcShardInformationMessage) GetEcIndexBits() uint32 {
+	if m != nil {
+		return m.EcIndexBits
+	}
+	return 0
+}
+
 type Empty struct {
 }
 
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 type SuperBlockExtra struct {
 	ErasureCoding *SuperBlockExtra_ErasureCoding `protobuf:"bytes,1,opt,name=erasure_coding,json=erasureCoding" json:"erasure_coding,omitempty"`
@@ -270,7 +353,7 @@ type SuperBlockExtra struct {
 func (m *SuperBlockExtra) Reset()                    { *m = SuperBlockExtra{} }
 func (m *SuperBlockExtra) String() string            { return proto.CompactTextString(m) }
 func (*SuperBlockExtra) ProtoMessage()               {}
-func (*SuperBlockExtra) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*SuperBlockExtra) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *SuperBlockExtra) GetErasureCoding() *SuperBlockExtra_ErasureCoding {
 	if m != nil {
@@ -289,7 +372,7 @@ func (m *SuperBlockExtra_ErasureCoding) Reset()         { *m = SuperBlockExtra_E
 func (m *SuperBlockExtra_ErasureCoding) String() string { return proto.CompactTextString(m) }
 func (*SuperBlockExtra_ErasureCoding) ProtoMessage()    {}
 func (*SuperBlockExtra_ErasureCoding) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{4, 0}
+	return fileDescriptor0, []int{5, 0}
 }
 
 func (m *SuperBlockExtra_ErasureCoding) GetData() uint32 {
@@ -320,7 +403,7 @@ type ClientListenRequest struct {
 func (m *ClientListenRequest) Reset()                    { *m = ClientListenRequest{} }
 func (m *ClientListenRequest) String() string            { return proto.CompactTextString(m) }
 func (*ClientListenRequest) ProtoMessage()               {}
-func (*ClientListenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*ClientListenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *ClientListenRequest) GetName() string {
 	if m != nil {
@@ -339,7 +422,7 @@ type VolumeLocation struct {
 func (m *VolumeLocation) Reset()                    { *m = VolumeLocation{} }
 func (m *VolumeLocation) String() string            { return proto.CompactTextString(m) }
 func (*VolumeLocation) ProtoMessage()               {}
-func (*VolumeLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*VolumeLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *VolumeLocation) GetUrl() string {
 	if m != nil {
@@ -377,7 +460,7 @@ type LookupVolumeRequest struct {
 func (m *LookupVolumeRequest) Reset()                    { *m = LookupVolumeRequest{} }
 func (m *LookupVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeRequest) ProtoMessage()               {}
-func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *LookupVolumeRequest) GetVolumeIds() []string {
 	if m != nil {
@@ -400,7 +483,7 @@ type LookupVolumeResponse struct {
 func (m *LookupVolumeResponse) Reset()                    { *m = LookupVolumeResponse{} }
 func (m *LookupVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse) ProtoMessage()               {}
-func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *LookupVolumeResponse) GetVolumeIdLocations() []*LookupVolumeResponse_VolumeIdLocation {
 	if m != nil {
@@ -419,7 +502,7 @@ func (m *LookupVolumeResponse_VolumeIdLocation) Reset()         { *m = LookupVol
 func (m *LookupVolumeResponse_VolumeIdLocation) String() string { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse_VolumeIdLocation) ProtoMessage()    {}
 func (*LookupVolumeResponse_VolumeIdLocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{8, 0}
+	return fileDescriptor0, []int{9, 0}
 }
 
 func (m *LookupVolumeResponse_VolumeIdLocation) GetVolumeId() string {
@@ -451,7 +534,7 @@ type Location struct {
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *Location) GetUrl() string {
 	if m != nil {
@@ -480,7 +563,7 @@ type AssignRequest struct {
 func (m *AssignRequest) Reset()                    { *m = AssignRequest{} }
 func (m *AssignRequest) String() string            { return proto.CompactTextString(m) }
 func (*AssignRequest) ProtoMessage()               {}
-func (*AssignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*AssignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *AssignRequest) GetCount() uint64 {
 	if m != nil {
@@ -543,7 +626,7 @@ type AssignResponse struct {
 func (m *AssignResponse) Reset()                    { *m = AssignResponse{} }
 func (m *AssignResponse) String() string            { return proto.CompactTextString(m) }
 func (*AssignResponse) ProtoMessage()               {}
-func (*AssignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*AssignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *AssignResponse) GetFid() string {
 	if m != nil {
@@ -596,7 +679,7 @@ type StatisticsRequest struct {
 func (m *StatisticsRequest) Reset()                    { *m = StatisticsRequest{} }
 func (m *StatisticsRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsRequest) ProtoMessage()               {}
-func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *StatisticsRequest) GetReplication() string {
 	if m != nil {
@@ -631,7 +714,7 @@ type StatisticsResponse struct {
 func (m *StatisticsResponse) Reset()                    { *m = StatisticsResponse{} }
 func (m *StatisticsResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsResponse) ProtoMessage()               {}
-func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *StatisticsResponse) GetReplication() string {
 	if m != nil {
@@ -675,10 +758,432 @@ func (m *StatisticsResponse) GetFileCount() uint64 {
 	return 0
 }
 
+type LookupEcVolumeRequest struct {
+	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+}
+
+func (m *LookupEcVolumeRequest) Reset()                    { *m = LookupEcVolumeRequest{} }
+func (m *LookupEcVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*LookupEcVolumeRequest) ProtoMessage()               {}
+func (*LookupEcVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *LookupEcVolumeRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+type LookupEcVolumeResponse struct {
+	VolumeId         uint32                                      `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	ShardIdLocations []*LookupEcVolumeResponse_EcShardIdLocation `protobuf:"bytes,2,rep,name=shard_id_locations,json=shardIdLocations" json:"shard_id_locations,omitempty"`
+}
+
+func (m *LookupEcVolumeResponse) Reset()                    { *m = LookupEcVolumeResponse{} }
+func (m *LookupEcVolumeResponse) String() string            { return proto.CompactTextString(m) }
+func (*LookupEcVolumeResponse) ProtoMessage()               {}
+func (*LookupEcVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *LookupEcVolumeResponse) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *LookupEcVolumeResponse) GetShardIdLocations() []*LookupEcVolumeResponse_EcShardIdLocation {
+	if m != nil {
+		return m.ShardIdLocations
+	}
+	return nil
+}
+
+type LookupEcVolumeResponse_EcShardIdLocation struct {
+	ShardId   uint32      `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Locations []*Location `protobuf:"bytes,2,rep,name=locations" json:"locations,omitempty"`
+}
+
+func (m *LookupEcVolumeResponse_EcShardIdLocation) Reset() {
+	*m = LookupEcVolumeResponse_EcShardIdLocation{}
+}
+func (m *LookupEcVolumeResponse_EcShardIdLocation) String() string { return proto.CompactTextString(m) }
+func (*LookupEcVolumeResponse_EcShardIdLocation) ProtoMessage()    {}
+func (*LookupEcVolumeResponse_EcShardIdLocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{16, 0}
+}
+
+func (m *LookupEcVolumeResponse_EcShardIdLocation) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *LookupEcVolumeResponse_EcShardIdLocation) GetLocations() []*Location {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+type Collection struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *Collection) Reset()                    { *m = Collection{} }
+func (m *Collection) String() string            { return proto.CompactTextString(m) }
+func (*Collection) ProtoMessage()               {}
+func (*Collection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *Collection) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CollectionListRequest struct {
+}
+
+func (m *CollectionListRequest) Reset()                    { *m = CollectionListRequest{} }
+func (m *CollectionListRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollectionListRequest) ProtoMessage()               {}
+func (*CollectionListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type CollectionListResponse struct {
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections" json:"collections,omitempty"`
+}
+
+func (m *CollectionListResponse) Reset()                    { *m = CollectionListResponse{} }
+func (m *CollectionListResponse) String() string            { return proto.CompactTextString(m) }
+func (*CollectionListResponse) ProtoMessage()               {}
+func (*CollectionListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *CollectionListResponse) GetCollections() []*Collection {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+type CollectionDeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *CollectionDeleteRequest) Reset()                    { *m = CollectionDeleteRequest{} }
+func (m *CollectionDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollectionDeleteRequest) ProtoMessage()               {}
+func (*CollectionDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *CollectionDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CollectionDeleteResponse struct {
+}
+
+func (m *CollectionDeleteResponse) Reset()                    { *m = CollectionDeleteResponse{} }
+func (m *CollectionDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*CollectionDeleteResponse) ProtoMessage()               {}
+func (*CollectionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+// volume related
+type DataNodeInfo struct {
+	Id                string                             `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64                             `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64                             `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64                             `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64                             `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	VolumeInfos       []*VolumeInformationMessage        `protobuf:"bytes,6,rep,name=volume_infos,json=volumeInfos" json:"volume_infos,omitempty"`
+	EcShardInfos      []*VolumeEcShardInformationMessage `protobuf:"bytes,7,rep,name=ec_shard_infos,json=ecShardInfos" json:"ec_shard_infos,omitempty"`
+}
+
+func (m *DataNodeInfo) Reset()                    { *m = DataNodeInfo{} }
+func (m *DataNodeInfo) String() string            { return proto.CompactTextString(m) }
+func (*DataNodeInfo) ProtoMessage()               {}
+func (*DataNodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *DataNodeInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DataNodeInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *DataNodeInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *DataNodeInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
+}
+
+func (m *DataNodeInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
+	}
+	return 0
+}
+
+func (m *DataNodeInfo) GetVolumeInfos() []*VolumeInformationMessage {
+	if m != nil {
+		return m.VolumeInfos
+	}
+	return nil
+}
+
+func (m *DataNodeInfo) GetEcShardInfos() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.EcShardInfos
+	}
+	return nil
+}
+
+type RackInfo struct {
+	Id                string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64          `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64          `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64          `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64          `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	DataNodeInfos     []*DataNodeInfo `protobuf:"bytes,6,rep,name=data_node_infos,json=dataNodeInfos" json:"data_node_infos,omitempty"`
+}
+
+func (m *RackInfo) Reset()                    { *m = RackInfo{} }
+func (m *RackInfo) String() string            { return proto.CompactTextString(m) }
+func (*RackInfo) ProtoMessage()               {}
+func (*RackInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *RackInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RackInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *RackInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *RackInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
+}
+
+func (m *RackInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
+	}
+	return 0
+}
+
+func (m *RackInfo) GetDataNodeInfos() []*DataNodeInfo {
+	if m != nil {
+		return m.DataNodeInfos
+	}
+	return nil
+}
+
+type DataCenterInfo struct {
+	Id                string      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64      `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64      `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64      `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64      `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	RackInfos         []*RackInfo `protobuf:"bytes,6,rep,name=rack_infos,json=rackInfos" json:"rack_infos,omitempty"`
+}
+
+func (m *DataCenterInfo) Reset()                    { *m = DataCenterInfo{} }
+func (m *DataCenterInfo) String() string            { return proto.CompactTextString(m) }
+func (*DataCenterInfo) ProtoMessage()               {}
+func (*DataCenterInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *DataCenterInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DataCenterInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *DataCenterInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *DataCenterInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
+}
+
+func (m *DataCenterInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
+	}
+	return 0
+}
+
+func (m *DataCenterInfo) GetRackInfos() []*RackInfo {
+	if m != nil {
+		return m.RackInfos
+	}
+	return nil
+}
+
+type TopologyInfo struct {
+	Id                string            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64            `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64            `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64            `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64            `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	DataCenterInfos   []*DataCenterInfo `protobuf:"bytes,6,rep,name=data_center_infos,json=dataCenterInfos" json:"data_center_infos,omitempty"`
+}
+
+func (m *TopologyInfo) Reset()                    { *m = TopologyInfo{} }
+func (m *TopologyInfo) String() string            { return proto.CompactTextString(m) }
+func (*TopologyInfo) ProtoMessage()               {}
+func (*TopologyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *TopologyInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TopologyInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetDataCenterInfos() []*DataCenterInfo {
+	if m != nil {
+		return m.DataCenterInfos
+	}
+	return nil
+}
+
+type VolumeListRequest struct {
+}
+
+func (m *VolumeListRequest) Reset()                    { *m = VolumeListRequest{} }
+func (m *VolumeListRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeListRequest) ProtoMessage()               {}
+func (*VolumeListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+type VolumeListResponse struct {
+	TopologyInfo      *TopologyInfo `protobuf:"bytes,1,opt,name=topology_info,json=topologyInfo" json:"topology_info,omitempty"`
+	VolumeSizeLimitMb uint64        `protobuf:"varint,2,opt,name=volume_size_limit_mb,json=volumeSizeLimitMb" json:"volume_size_limit_mb,omitempty"`
+}
+
+func (m *VolumeListResponse) Reset()                    { *m = VolumeListResponse{} }
+func (m *VolumeListResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeListResponse) ProtoMessage()               {}
+func (*VolumeListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *VolumeListResponse) GetTopologyInfo() *TopologyInfo {
+	if m != nil {
+		return m.TopologyInfo
+	}
+	return nil
+}
+
+func (m *VolumeListResponse) GetVolumeSizeLimitMb() uint64 {
+	if m != nil {
+		return m.VolumeSizeLimitMb
+	}
+	return 0
+}
+
+type VacuumVolumeRequest struct {
+	GarbageThreshold float32 `protobuf:"fixed32,1,opt,name=garbage_threshold,json=garbageThreshold" json:"garbage_threshold,omitempty"`
+}
+
+func (m *VacuumVolumeRequest) Reset()                    { *m = VacuumVolumeRequest{} }
+func (m *VacuumVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*VacuumVolumeRequest) ProtoMessage()               {}
+func (*VacuumVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *VacuumVolumeRequest) GetGarbageThreshold() float32 {
+	if m != nil {
+		return m.GarbageThreshold
+	}
+	return 0
+}
+
+type VacuumVolumeResponse struct {
+}
+
+func (m *VacuumVolumeResponse) Reset()                    { *m = VacuumVolumeResponse{} }
+func (m *VacuumVolumeResponse) String() string            { return proto.CompactTextString(m) }
+func (*VacuumVolumeResponse) ProtoMessage()               {}
+func (*VacuumVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
 func init() {
 	proto.RegisterType((*Heartbeat)(nil), "master_pb.Heartbeat")
 	proto.RegisterType((*HeartbeatResponse)(nil), "master_pb.HeartbeatResponse")
 	proto.RegisterType((*VolumeInformationMessage)(nil), "master_pb.VolumeInformationMessage")
+	proto.RegisterType((*VolumeEcShardInformationMessage)(nil), "master_pb.VolumeEcShardInformationMessage")
 	proto.RegisterType((*Empty)(nil), "master_pb.Empty")
 	proto.RegisterType((*SuperBlockExtra)(nil), "master_pb.SuperBlockExtra")
 	proto.RegisterType((*SuperBlockExtra_ErasureCoding)(nil), "master_pb.SuperBlockExtra.ErasureCoding")
@@ -692,6 +1197,22 @@ func init() {
 	proto.RegisterType((*AssignResponse)(nil), "master_pb.AssignResponse")
 	proto.RegisterType((*StatisticsRequest)(nil), "master_pb.StatisticsRequest")
 	proto.RegisterType((*StatisticsResponse)(nil), "master_pb.StatisticsResponse")
+	proto.RegisterType((*LookupEcVolumeRequest)(nil), "master_pb.LookupEcVolumeRequest")
+	proto.RegisterType((*LookupEcVolumeResponse)(nil), "master_pb.LookupEcVolumeResponse")
+	proto.RegisterType((*LookupEcVolumeResponse_EcShardIdLocation)(nil), "master_pb.LookupEcVolumeResponse.EcShardIdLocation")
+	proto.RegisterType((*Collection)(nil), "master_pb.Collection")
+	proto.RegisterType((*CollectionListRequest)(nil), "master_pb.CollectionListRequest")
+	proto.RegisterType((*CollectionListResponse)(nil), "master_pb.CollectionListResponse")
+	proto.RegisterType((*CollectionDeleteRequest)(nil), "master_pb.CollectionDeleteRequest")
+	proto.RegisterType((*CollectionDeleteResponse)(nil), "master_pb.CollectionDeleteResponse")
+	proto.RegisterType((*DataNodeInfo)(nil), "master_pb.DataNodeInfo")
+	proto.RegisterType((*RackInfo)(nil), "master_pb.RackInfo")
+	proto.RegisterType((*DataCenterInfo)(nil), "master_pb.DataCenterInfo")
+	proto.RegisterType((*TopologyInfo)(nil), "master_pb.TopologyInfo")
+	proto.RegisterType((*VolumeListRequest)(nil), "master_pb.VolumeListRequest")
+	proto.RegisterType((*VolumeListResponse)(nil), "master_pb.VolumeListResponse")
+	proto.RegisterType((*VacuumVolumeRequest)(nil), "master_pb.VacuumVolumeRequest")
+	proto.RegisterType((*VacuumVolumeResponse)(nil), "master_pb.VacuumVolumeResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -710,6 +1231,11 @@ type SeaweedClient interface {
 	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
 	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
 	Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error)
+	LookupEcVolume(ctx context.Context, in *LookupEcVolumeRequest, opts ...grpc.CallOption) (*LookupEcVolumeResponse, error)
+	CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error)
+	CollectionDelete(ctx context.Context, in *CollectionDeleteRequest, opts ...grpc.CallOption) (*CollectionDeleteResponse, error)
+	VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error)
+	VacuumVolume(ctx context.Context, in *VacuumVolumeRequest, opts ...grpc.CallOption) (*VacuumVolumeResponse, error)
 }
 
 type seaweedClient struct {
@@ -809,6 +1335,51 @@ func (c *seaweedClient) Statistics(ctx context.Context, in *StatisticsRequest, o
 	return out, nil
 }
 
+func (c *seaweedClient) LookupEcVolume(ctx context.Context, in *LookupEcVolumeRequest, opts ...grpc.CallOption) (*LookupEcVolumeResponse, error) {
+	out := new(LookupEcVolumeResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/LookupEcVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error) {
+	out := new(CollectionListResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/CollectionList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) CollectionDelete(ctx context.Context, in *CollectionDeleteRequest, opts ...grpc.CallOption) (*CollectionDeleteResponse, error) {
+	out := new(CollectionDeleteResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/CollectionDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error) {
+	out := new(VolumeListResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VolumeList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VacuumVolume(ctx context.Context, in *VacuumVolumeRequest, opts ...grpc.CallOption) (*VacuumVolumeResponse, error) {
+	out := new(VacuumVolumeResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VacuumVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Seaweed service
 
 type SeaweedServer interface {
@@ -817,6 +1388,11 @@ type SeaweedServer interface {
 	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
 	Assign(context.Context, *AssignRequest) (*AssignResponse, error)
 	Statistics(context.Context, *StatisticsRequest) (*StatisticsResponse, error)
+	LookupEcVolume(context.Context, *LookupEcVolumeRequest) (*LookupEcVolumeResponse, error)
+	CollectionList(context.Context, *CollectionListRequest) (*CollectionListResponse, error)
+	CollectionDelete(context.Context, *CollectionDeleteRequest) (*CollectionDeleteResponse, error)
+	VolumeList(context.Context, *VolumeListRequest) (*VolumeListResponse, error)
+	VacuumVolume(context.Context, *VacuumVolumeRequest) (*VacuumVolumeResponse, error)
 }
 
 func RegisterSeaweedServer(s *grpc.Server, srv SeaweedServer) {
@@ -929,6 +1505,96 @@ func _Seaweed_Statistics_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Seaweed_LookupEcVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupEcVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).LookupEcVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/LookupEcVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).LookupEcVolume(ctx, req.(*LookupEcVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_CollectionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).CollectionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/CollectionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).CollectionList(ctx, req.(*CollectionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_CollectionDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).CollectionDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/CollectionDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).CollectionDelete(ctx, req.(*CollectionDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VolumeList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VolumeList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VolumeList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VolumeList(ctx, req.(*VolumeListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VacuumVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VacuumVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VacuumVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VacuumVolume(ctx, req.(*VacuumVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Seaweed_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "master_pb.Seaweed",
 	HandlerType: (*SeaweedServer)(nil),
@@ -945,6 +1611,26 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Statistics",
 			Handler:    _Seaweed_Statistics_Handler,
 		},
+		{
+			MethodName: "LookupEcVolume",
+			Handler:    _Seaweed_LookupEcVolume_Handler,
+		},
+		{
+			MethodName: "CollectionList",
+			Handler:    _Seaweed_CollectionList_Handler,
+		},
+		{
+			MethodName: "CollectionDelete",
+			Handler:    _Seaweed_CollectionDelete_Handler,
+		},
+		{
+			MethodName: "VolumeList",
+			Handler:    _Seaweed_VolumeList_Handler,
+		},
+		{
+			MethodName: "VacuumVolume",
+			Handler:    _Seaweed_VacuumVolume_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -966,71 +1652,111 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("master.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1056 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xe4, 0x44,
-	0x10, 0x8e, 0x3d, 0xbf, 0xae, 0xc9, 0x64, 0x27, 0x9d, 0x08, 0x79, 0x67, 0xd9, 0xdd, 0xc1, 0x5c,
-	0x06, 0x81, 0xa2, 0x25, 0x1c, 0x11, 0x42, 0x6c, 0x14, 0x44, 0x94, 0xc0, 0x06, 0x87, 0xdd, 0x03,
-	0x17, 0xd3, 0xb1, 0x2b, 0xa1, 0x15, 0xff, 0xe1, 0x6e, 0x27, 0x33, 0x7b, 0xe1, 0xc8, 0x03, 0xf0,
-	0x3e, 0x5c, 0xe0, 0xc6, 0xa3, 0x70, 0xe3, 0x09, 0x50, 0xff, 0xd8, 0xf1, 0x38, 0x19, 0x22, 0x21,
-	0x71, 0xeb, 0xfe, 0xba, 0xba, 0xab, 0xfa, 0xfb, 0xaa, 0xaa, 0x1b, 0x36, 0x13, 0xca, 0x05, 0x16,
-	0x7b, 0x79, 0x91, 0x89, 0x8c, 0x38, 0x7a, 0x16, 0xe4, 0xe7, 0xde, 0x5f, 0x36, 0x38, 0x5f, 0x21,
-	0x2d, 0xc4, 0x39, 0x52, 0x41, 0xb6, 0xc0, 0x66, 0xb9, 0x6b, 0xcd, 0xac, 0xb9, 0xe3, 0xdb, 0x2c,
-	0x27, 0x04, 0xba, 0x79, 0x56, 0x08, 0xd7, 0x9e, 0x59, 0xf3, 0xb1, 0xaf, 0xc6, 0xe4, 0x29, 0x40,
-	0x5e, 0x9e, 0xc7, 0x2c, 0x0c, 0xca, 0x22, 0x76, 0x3b, 0xca, 0xd6, 0xd1, 0xc8, 0xeb, 0x22, 0x26,
-	0x73, 0x98, 0x24, 0x74, 0x11, 0x5c, 0x67, 0x71, 0x99, 0x60, 0x10, 0x66, 0x65, 0x2a, 0xdc, 0xae,
-	0xda, 0xbe, 0x95, 0xd0, 0xc5, 0x1b, 0x05, 0x1f, 0x48, 0x94, 0xcc, 0x64, 0x54, 0x8b, 0xe0, 0x82,
-	0xc5, 0x18, 0x5c, 0xe1, 0xd2, 0xed, 0xcd, 0xac, 0x79, 0xd7, 0x87, 0x84, 0x2e, 0xbe, 0x64, 0x31,
-	0x1e, 0xe3, 0x92, 0x3c, 0x87, 0x51, 0x44, 0x05, 0x0d, 0x42, 0x4c, 0x05, 0x16, 0x6e, 0x5f, 0xf9,
-	0x02, 0x09, 0x1d, 0x28, 0x44, 0xc6, 0x57, 0xd0, 0xf0, 0xca, 0x1d, 0xa8, 0x15, 0x35, 0x96, 0xf1,
-	0xd1, 0x28, 0x61, 0x69, 0xa0, 0x22, 0x1f, 0x2a, 0xd7, 0x8e, 0x42, 0x4e, 0x65, 0xf8, 0x9f, 0xc1,
-	0x40, 0xc7, 0xc6, 0x5d, 0x67, 0xd6, 0x99, 0x8f, 0xf6, 0xdf, 0xdf, 0xab, 0xd9, 0xd8, 0xd3, 0xe1,
-	0x1d, 0xa5, 0x17, 0x59, 0x91, 0x50, 0xc1, 0xb2, 0xf4, 0x6b, 0xe4, 0x9c, 0x5e, 0xa2, 0x5f, 0xed,
-	0x21, 0x8f, 0x61, 0x98, 0xe2, 0x4d, 0x70, 0xcd, 0x22, 0xee, 0xc2, 0xac, 0x33, 0x1f, 0xfb, 0x83,
-	0x14, 0x6f, 0xde, 0xb0, 0x88, 0x93, 0xf7, 0x60, 0x33, 0xc2, 0x18, 0x05, 0x46, 0x7a, 0x79, 0xa4,
-	0x96, 0x47, 0x06, 0x93, 0x26, 0xde, 0x6b, 0xd8, 0xae, 0xc9, 0xf6, 0x91, 0xe7, 0x59, 0xca, 0x91,
-	0xcc, 0xe1, 0x91, 0x3e, 0xfd, 0x8c, 0xbd, 0xc5, 0x13, 0x96, 0x30, 0xa1, 0x14, 0xe8, 0xfa, 0x6d,
-	0x98, 0xbc, 0x03, 0xfd, 0x18, 0x69, 0x84, 0x85, 0xa1, 0xdd, 0xcc, 0xbc, 0x3f, 0x6c, 0x70, 0xd7,
-	0x85, 0xae, 0x34, 0x8d, 0xd4, 0x89, 0x63, 0xdf, 0x66, 0x91, 0xe4, 0x8c, 0xb3, 0xb7, 0xa8, 0x34,
-	0xed, 0xfa, 0x6a, 0x4c, 0x9e, 0x01, 0x84, 0x59, 0x1c, 0x63, 0x28, 0x37, 0x9a, 0xc3, 0x1b, 0x88,
-	0xe4, 0x54, 0xc9, 0x74, 0x2b, 0x67, 0xd7, 0x77, 0x24, 0xa2, 0x95, 0xac, 0x6f, 0x6e, 0x0c, 0xb4,
-	0x92, 0xe6, 0xe6, 0xda, 0xe4, 0x23, 0x20, 0x15, 0x39, 0xe7, 0xcb, 0xda, 0xb0, 0xaf, 0x0c, 0x27,
-	0x66, 0xe5, 0xe5, 0xb2, 0xb2, 0x7e, 0x02, 0x4e, 0x81, 0x34, 0x0a, 0xb2, 0x34, 0x5e, 0x2a, 0x71,
-	0x87, 0xfe, 0x50, 0x02, 0xaf, 0xd2, 0x78, 0x49, 0x3e, 0x84, 0xed, 0x02, 0xf3, 0x98, 0x85, 0x34,
-	0xc8, 0x63, 0x1a, 0x62, 0x82, 0x69, 0xa5, 0xf3, 0xc4, 0x2c, 0x9c, 0x56, 0x38, 0x71, 0x61, 0x70,
-	0x8d, 0x05, 0x97, 0xd7, 0x72, 0x94, 0x49, 0x35, 0x25, 0x13, 0xe8, 0x08, 0x11, 0xbb, 0xa0, 0x50,
-	0x39, 0xf4, 0x06, 0xd0, 0x3b, 0x4c, 0x72, 0xb1, 0xf4, 0x7e, 0xb3, 0xe0, 0xd1, 0x59, 0x99, 0x63,
-	0xf1, 0x32, 0xce, 0xc2, 0xab, 0xc3, 0x85, 0x28, 0x28, 0x79, 0x05, 0x5b, 0x58, 0x50, 0x5e, 0x16,
-	0x32, 0xf6, 0x88, 0xa5, 0x97, 0x8a, 0xd2, 0xd1, 0xfe, 0xbc, 0x91, 0x3e, 0xad, 0x3d, 0x7b, 0x87,
-	0x7a, 0xc3, 0x81, 0xb2, 0xf7, 0xc7, 0xd8, 0x9c, 0x4e, 0xbf, 0x87, 0xf1, 0xca, 0xba, 0x14, 0x46,
-	0xa6, 0xb6, 0x91, 0x4a, 0x8d, 0xa5, 0xe2, 0x39, 0x2d, 0x98, 0x58, 0x9a, 0x12, 0x34, 0x33, 0x29,
-	0x88, 0xa9, 0x30, 0x99, 0x69, 0x1d, 0x95, 0x69, 0x8e, 0x46, 0x8e, 0x22, 0xee, 0x7d, 0x00, 0x3b,
-	0x07, 0x31, 0xc3, 0x54, 0x9c, 0x30, 0x2e, 0x30, 0xf5, 0xf1, 0xa7, 0x12, 0xb9, 0x90, 0x1e, 0x52,
-	0x9a, 0xa0, 0x29, 0x70, 0x35, 0xf6, 0x7e, 0x86, 0x2d, 0x9d, 0x3a, 0x27, 0x59, 0xa8, 0xf2, 0x46,
-	0x12, 0x23, 0x2b, 0x5b, 0x1b, 0xc9, 0x61, 0xab, 0xe4, 0xed, 0x76, 0xc9, 0x37, 0x6b, 0xa2, 0xf3,
-	0xef, 0x35, 0xd1, 0xbd, 0x5b, 0x13, 0xdf, 0xc1, 0xce, 0x49, 0x96, 0x5d, 0x95, 0xb9, 0x0e, 0xa3,
-	0x8a, 0x75, 0xf5, 0x86, 0xd6, 0xac, 0x23, 0x7d, 0xd6, 0x37, 0x6c, 0x65, 0xac, 0xdd, 0xce, 0x58,
-	0xef, 0x6f, 0x0b, 0x76, 0x57, 0x8f, 0x35, 0xd5, 0xf6, 0x03, 0xec, 0xd4, 0xe7, 0x06, 0xb1, 0xb9,
-	0xb3, 0x76, 0x30, 0xda, 0x7f, 0xd1, 0x10, 0xf3, 0xbe, 0xdd, 0x55, 0x83, 0x88, 0x2a, 0xb2, 0xfc,
-	0xed, 0xeb, 0x16, 0xc2, 0xa7, 0x0b, 0x98, 0xb4, 0xcd, 0x64, 0x42, 0xd7, 0x5e, 0x0d, 0xb3, 0xc3,
-	0x6a, 0x27, 0xf9, 0x18, 0x9c, 0xdb, 0x40, 0x6c, 0x15, 0xc8, 0xce, 0x4a, 0x20, 0xc6, 0xd7, 0xad,
-	0x15, 0xd9, 0x85, 0x1e, 0x16, 0x45, 0x56, 0x35, 0x02, 0x3d, 0xf1, 0x3e, 0x85, 0xe1, 0x7f, 0x56,
-	0xd1, 0xfb, 0xd3, 0x82, 0xf1, 0x17, 0x9c, 0xb3, 0xcb, 0x3a, 0x5d, 0x76, 0xa1, 0xa7, 0xcb, 0x54,
-	0xb7, 0x23, 0x3d, 0x21, 0x33, 0x18, 0x99, 0x2a, 0x6b, 0x50, 0xdf, 0x84, 0x1e, 0xec, 0x26, 0xa6,
-	0xf2, 0xba, 0x3a, 0x34, 0x21, 0xe2, 0x76, 0xa3, 0xef, 0xad, 0x6d, 0xf4, 0xfd, 0x46, 0xa3, 0x7f,
-	0x02, 0x8e, 0xda, 0x94, 0x66, 0x11, 0x9a, 0x17, 0x60, 0x28, 0x81, 0x6f, 0xb2, 0x08, 0xbd, 0x5f,
-	0x2d, 0xd8, 0xaa, 0x6e, 0x63, 0x94, 0x9f, 0x40, 0xe7, 0xa2, 0x66, 0x5f, 0x0e, 0x2b, 0x8e, 0xec,
-	0x75, 0x1c, 0xdd, 0x79, 0xdc, 0x6a, 0x46, 0xba, 0x4d, 0x46, 0x6a, 0x31, 0x7a, 0x0d, 0x31, 0x64,
-	0xc8, 0xb4, 0x14, 0x3f, 0x56, 0x21, 0xcb, 0xb1, 0x77, 0x09, 0xdb, 0x67, 0x82, 0x0a, 0xc6, 0x05,
-	0x0b, 0x79, 0x45, 0x73, 0x8b, 0x50, 0xeb, 0x21, 0x42, 0xed, 0x75, 0x84, 0x76, 0x6a, 0x42, 0xbd,
-	0xdf, 0x2d, 0x20, 0x4d, 0x4f, 0x86, 0x82, 0xff, 0xc1, 0x95, 0xa4, 0x4c, 0x64, 0x82, 0xc6, 0x81,
-	0x7a, 0x55, 0xcc, 0xdb, 0xa0, 0x10, 0xf9, 0x70, 0x49, 0x95, 0x4a, 0x8e, 0x91, 0x5e, 0xd5, 0x0f,
-	0xc3, 0x50, 0x02, 0x6a, 0x71, 0xf5, 0x5d, 0xe9, 0xb7, 0xde, 0x95, 0xfd, 0x5f, 0x3a, 0x30, 0x38,
-	0x43, 0x7a, 0x83, 0x18, 0x91, 0x23, 0x18, 0x9f, 0x61, 0x1a, 0xdd, 0xfe, 0x55, 0x76, 0x1b, 0x25,
-	0x52, 0xa3, 0xd3, 0x77, 0xef, 0x43, 0xab, 0xfb, 0x7b, 0x1b, 0x73, 0xeb, 0x85, 0x45, 0x4e, 0x61,
-	0x7c, 0x8c, 0x98, 0x1f, 0x64, 0x69, 0x8a, 0xa1, 0xc0, 0x88, 0x3c, 0x6b, 0x6c, 0xba, 0xa7, 0x6f,
-	0x4e, 0x1f, 0xdf, 0xf9, 0x22, 0x54, 0x65, 0x66, 0x4e, 0xfc, 0x16, 0x36, 0x9b, 0xed, 0x62, 0xe5,
-	0xc0, 0x7b, 0x9a, 0xdb, 0xf4, 0xf9, 0x03, 0x7d, 0xc6, 0xdb, 0x20, 0x9f, 0x43, 0x5f, 0xe7, 0x2f,
-	0x71, 0x1b, 0xc6, 0x2b, 0x05, 0xba, 0x12, 0xd7, 0x6a, 0xb2, 0x7b, 0x1b, 0xe4, 0x18, 0xe0, 0x36,
-	0x03, 0x48, 0x93, 0x97, 0x3b, 0x29, 0x38, 0x7d, 0xba, 0x66, 0xb5, 0x3a, 0xec, 0xbc, 0xaf, 0x3e,
-	0x8e, 0x9f, 0xfc, 0x13, 0x00, 0x00, 0xff, 0xff, 0x25, 0xfc, 0xb7, 0x54, 0x48, 0x0a, 0x00, 0x00,
+	// 1681 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x4f, 0x73, 0xdc, 0x4a,
+	0x11, 0x7f, 0xda, 0x5d, 0xef, 0x9f, 0xde, 0x3f, 0xde, 0x1d, 0x3b, 0x8e, 0xbc, 0x8f, 0x3c, 0xaf,
+	0xf5, 0x2e, 0xcb, 0x7b, 0x60, 0x82, 0x93, 0x2a, 0x0e, 0x40, 0xa5, 0xb0, 0xe3, 0x80, 0x2b, 0x4e,
+	0xe2, 0xc8, 0x89, 0xa9, 0xa2, 0x8a, 0x12, 0xb3, 0xd2, 0xd8, 0x56, 0x59, 0x2b, 0x09, 0xcd, 0xac,
+	0xed, 0xcd, 0x85, 0x03, 0x9c, 0xb9, 0xf0, 0x65, 0x38, 0x71, 0xe1, 0xc8, 0x57, 0xe0, 0xc2, 0x57,
+	0xe0, 0x08, 0x45, 0x15, 0x35, 0x7f, 0x24, 0x8d, 0xb4, 0x6b, 0x9b, 0xa4, 0x8a, 0x43, 0x6e, 0x9a,
+	0xee, 0x9e, 0x9e, 0x9e, 0xdf, 0xf4, 0xfc, 0xba, 0x47, 0xd0, 0x99, 0x62, 0xca, 0x48, 0xb2, 0x13,
+	0x27, 0x11, 0x8b, 0x50, 0x4b, 0x8e, 0x9c, 0x78, 0x62, 0xfd, 0xbd, 0x06, 0xad, 0x5f, 0x10, 0x9c,
+	0xb0, 0x09, 0xc1, 0x0c, 0xf5, 0xa0, 0xe2, 0xc7, 0xa6, 0x31, 0x32, 0xc6, 0x2d, 0xbb, 0xe2, 0xc7,
+	0x08, 0x41, 0x2d, 0x8e, 0x12, 0x66, 0x56, 0x46, 0xc6, 0xb8, 0x6b, 0x8b, 0x6f, 0xf4, 0x08, 0x20,
+	0x9e, 0x4d, 0x02, 0xdf, 0x75, 0x66, 0x49, 0x60, 0x56, 0x85, 0x6d, 0x4b, 0x4a, 0xde, 0x27, 0x01,
+	0x1a, 0x43, 0x7f, 0x8a, 0x6f, 0x9c, 0xab, 0x28, 0x98, 0x4d, 0x89, 0xe3, 0x46, 0xb3, 0x90, 0x99,
+	0x35, 0x31, 0xbd, 0x37, 0xc5, 0x37, 0xa7, 0x42, 0xbc, 0xcf, 0xa5, 0x68, 0xc4, 0xa3, 0xba, 0x71,
+	0xce, 0xfc, 0x80, 0x38, 0x97, 0x64, 0x6e, 0xae, 0x8c, 0x8c, 0x71, 0xcd, 0x86, 0x29, 0xbe, 0x79,
+	0xe1, 0x07, 0xe4, 0x25, 0x99, 0xa3, 0x2d, 0x68, 0x7b, 0x98, 0x61, 0xc7, 0x25, 0x21, 0x23, 0x89,
+	0x59, 0x17, 0x6b, 0x01, 0x17, 0xed, 0x0b, 0x09, 0x8f, 0x2f, 0xc1, 0xee, 0xa5, 0xd9, 0x10, 0x1a,
+	0xf1, 0xcd, 0xe3, 0xc3, 0xde, 0xd4, 0x0f, 0x1d, 0x11, 0x79, 0x53, 0x2c, 0xdd, 0x12, 0x92, 0x63,
+	0x1e, 0xfe, 0x4f, 0xa1, 0x21, 0x63, 0xa3, 0x66, 0x6b, 0x54, 0x1d, 0xb7, 0x77, 0xbf, 0xde, 0xc9,
+	0xd0, 0xd8, 0x91, 0xe1, 0x1d, 0x86, 0x67, 0x51, 0x32, 0xc5, 0xcc, 0x8f, 0xc2, 0x57, 0x84, 0x52,
+	0x7c, 0x4e, 0xec, 0x74, 0x0e, 0xda, 0x84, 0x66, 0x48, 0xae, 0x9d, 0x2b, 0xdf, 0xa3, 0x26, 0x8c,
+	0xaa, 0xe3, 0xae, 0xdd, 0x08, 0xc9, 0xf5, 0xa9, 0xef, 0x51, 0xb4, 0x0d, 0x1d, 0x8f, 0x04, 0x84,
+	0x11, 0x4f, 0xaa, 0xdb, 0x42, 0xdd, 0x56, 0x32, 0x61, 0xf2, 0x73, 0x68, 0x11, 0xd7, 0xa1, 0x17,
+	0x38, 0xf1, 0xa8, 0xd9, 0x11, 0xcb, 0x7f, 0xb3, 0xb0, 0xfc, 0x81, 0x7b, 0xc2, 0x0d, 0x96, 0x44,
+	0xd1, 0x24, 0x52, 0x45, 0xd1, 0x6b, 0xe8, 0xf2, 0x30, 0x72, 0x67, 0xdd, 0x8f, 0x76, 0xd6, 0x0e,
+	0xc9, 0xf5, 0x41, 0xea, 0xef, 0x14, 0x06, 0x69, 0xec, 0xb9, 0xcf, 0xde, 0x47, 0xfb, 0x5c, 0x55,
+	0x4e, 0x52, 0xbf, 0xd6, 0x7b, 0x18, 0x64, 0xd9, 0x65, 0x13, 0x1a, 0x47, 0x21, 0x25, 0x68, 0x0c,
+	0xab, 0x12, 0xce, 0x13, 0xff, 0x03, 0x39, 0xf2, 0xa7, 0x3e, 0x13, 0x29, 0x57, 0xb3, 0xcb, 0x62,
+	0xb4, 0x01, 0xf5, 0x80, 0x60, 0x8f, 0x24, 0x2a, 0xcf, 0xd4, 0xc8, 0xfa, 0x57, 0x05, 0xcc, 0xdb,
+	0xce, 0x4a, 0x24, 0xb1, 0x27, 0x3c, 0x76, 0xed, 0x8a, 0xef, 0xf1, 0x24, 0xa1, 0xfe, 0x07, 0x22,
+	0x92, 0xb8, 0x66, 0x8b, 0x6f, 0xf4, 0x15, 0x80, 0x1b, 0x05, 0x01, 0x71, 0xf9, 0x44, 0xe5, 0x5c,
+	0x93, 0xf0, 0x24, 0x12, 0x79, 0x99, 0xe7, 0x6f, 0xcd, 0x6e, 0x71, 0x89, 0x4c, 0xdd, 0xec, 0xa8,
+	0x95, 0x81, 0x4c, 0x5d, 0x75, 0xd4, 0xd2, 0xe4, 0x7b, 0x80, 0x52, 0x44, 0x27, 0xf3, 0xcc, 0xb0,
+	0x2e, 0x0c, 0xfb, 0x4a, 0xb3, 0x37, 0x4f, 0xad, 0xbf, 0x84, 0x56, 0x42, 0xb0, 0xe7, 0x44, 0x61,
+	0x30, 0x17, 0xd9, 0xdc, 0xb4, 0x9b, 0x5c, 0xf0, 0x26, 0x0c, 0xe6, 0xe8, 0x5b, 0x18, 0x24, 0x24,
+	0x0e, 0x7c, 0x17, 0x3b, 0x71, 0x80, 0x5d, 0x32, 0x25, 0x61, 0x9a, 0xd8, 0x7d, 0xa5, 0x38, 0x4e,
+	0xe5, 0xc8, 0x84, 0xc6, 0x15, 0x49, 0x28, 0xdf, 0x56, 0x4b, 0x98, 0xa4, 0x43, 0xd4, 0x87, 0x2a,
+	0x63, 0x81, 0x09, 0x42, 0xca, 0x3f, 0xd1, 0x53, 0xd8, 0x70, 0xa3, 0x24, 0x99, 0xc5, 0x3c, 0xca,
+	0x90, 0x10, 0x2f, 0xdb, 0x71, 0x5b, 0x18, 0xad, 0x67, 0xda, 0xd7, 0x42, 0x29, 0x62, 0xb5, 0x66,
+	0xb0, 0x75, 0x4f, 0x1e, 0x2c, 0x1c, 0x41, 0x11, 0xee, 0xca, 0x02, 0xdc, 0x16, 0x74, 0x89, 0xeb,
+	0xf8, 0xa1, 0x47, 0x6e, 0x9c, 0x89, 0xcf, 0xa8, 0x38, 0x91, 0xae, 0xdd, 0x26, 0xee, 0x21, 0x97,
+	0xed, 0xf9, 0x8c, 0x5a, 0x0d, 0x58, 0x39, 0x98, 0xc6, 0x6c, 0x6e, 0xfd, 0xc5, 0x80, 0xd5, 0x93,
+	0x59, 0x4c, 0x92, 0xbd, 0x20, 0x72, 0x2f, 0x0f, 0x6e, 0x58, 0x82, 0xd1, 0x1b, 0xe8, 0x91, 0x04,
+	0xd3, 0x59, 0xc2, 0x37, 0xe0, 0xf9, 0xe1, 0xb9, 0x58, 0xbc, 0xbd, 0x3b, 0xd6, 0x92, 0xb7, 0x34,
+	0x67, 0xe7, 0x40, 0x4e, 0xd8, 0x17, 0xf6, 0x76, 0x97, 0xe8, 0xc3, 0xe1, 0xaf, 0xa0, 0x5b, 0xd0,
+	0xf3, 0x2c, 0xe2, 0xc4, 0xa3, 0x36, 0x25, 0xbe, 0x79, 0x7a, 0xc6, 0x38, 0xf1, 0xd9, 0x5c, 0x11,
+	0xa4, 0x1a, 0xf1, 0xec, 0x51, 0xfc, 0xc7, 0x79, 0xa0, 0x2a, 0x78, 0xa0, 0x25, 0x25, 0x87, 0x1e,
+	0xb5, 0xbe, 0x0b, 0x6b, 0xfb, 0x81, 0x4f, 0x42, 0x76, 0xe4, 0x53, 0x46, 0x42, 0x9b, 0xfc, 0x76,
+	0x46, 0x28, 0xe3, 0x2b, 0x84, 0x78, 0x4a, 0x14, 0xfd, 0x8a, 0x6f, 0xeb, 0x77, 0xd0, 0x93, 0x58,
+	0x1f, 0x45, 0x2e, 0x66, 0xea, 0x14, 0x39, 0xef, 0x4a, 0x23, 0xfe, 0x59, 0x22, 0xe4, 0x4a, 0x99,
+	0x90, 0x75, 0xc6, 0xaa, 0xde, 0xcd, 0x58, 0xb5, 0x05, 0xc6, 0xb2, 0xde, 0xc1, 0xda, 0x51, 0x14,
+	0x5d, 0xce, 0x62, 0x19, 0x46, 0x1a, 0x6b, 0x71, 0x87, 0xc6, 0xa8, 0xca, 0xd7, 0xcc, 0x76, 0x78,
+	0xdf, 0x79, 0x5b, 0xff, 0x34, 0x60, 0xbd, 0xe8, 0x56, 0x51, 0xc3, 0x6f, 0x60, 0x2d, 0xf3, 0xeb,
+	0x04, 0x6a, 0xcf, 0x72, 0x81, 0xf6, 0xee, 0x63, 0xed, 0x30, 0x97, 0xcd, 0x4e, 0xe9, 0xdb, 0x4b,
+	0xc1, 0xb2, 0x07, 0x57, 0x25, 0x09, 0x1d, 0xde, 0x40, 0xbf, 0x6c, 0xc6, 0x6f, 0x5f, 0xb6, 0xaa,
+	0x42, 0xb6, 0x99, 0xce, 0x44, 0x3f, 0x84, 0x56, 0x1e, 0x48, 0x45, 0x04, 0xb2, 0x56, 0x08, 0x44,
+	0xad, 0x95, 0x5b, 0xa1, 0x75, 0x58, 0x21, 0x49, 0x12, 0xa5, 0xac, 0x25, 0x07, 0xd6, 0x8f, 0xa1,
+	0xf9, 0xc9, 0xa7, 0x68, 0xfd, 0xcd, 0x80, 0xee, 0xcf, 0x28, 0xf5, 0xcf, 0xb3, 0x74, 0x59, 0x87,
+	0x15, 0x79, 0x57, 0x25, 0x77, 0xca, 0x01, 0x1a, 0x41, 0x5b, 0x51, 0x82, 0x06, 0xbd, 0x2e, 0xba,
+	0x97, 0xfa, 0x14, 0x4d, 0xd4, 0x64, 0x68, 0x9c, 0x26, 0x4a, 0x65, 0x78, 0xe5, 0xd6, 0x32, 0x5c,
+	0xd7, 0xca, 0xf0, 0x97, 0xd0, 0x12, 0x93, 0xc2, 0xc8, 0x23, 0xaa, 0x3e, 0x37, 0xb9, 0xe0, 0x75,
+	0xe4, 0x11, 0xeb, 0x4f, 0x06, 0xf4, 0xd2, 0xdd, 0xa8, 0x93, 0xef, 0x43, 0xf5, 0x2c, 0x43, 0x9f,
+	0x7f, 0xa6, 0x18, 0x55, 0x6e, 0xc3, 0x68, 0xa1, 0xf5, 0xc8, 0x10, 0xa9, 0xe9, 0x88, 0x64, 0x87,
+	0xb1, 0xa2, 0x1d, 0x06, 0x0f, 0x19, 0xcf, 0xd8, 0x45, 0x1a, 0x32, 0xff, 0xb6, 0xce, 0x61, 0x70,
+	0xc2, 0x30, 0xf3, 0x29, 0xf3, 0x5d, 0x9a, 0xc2, 0x5c, 0x02, 0xd4, 0xb8, 0x0f, 0xd0, 0xca, 0x6d,
+	0x80, 0x56, 0x33, 0x40, 0xad, 0xbf, 0x1a, 0x80, 0xf4, 0x95, 0x14, 0x04, 0xff, 0x87, 0xa5, 0x38,
+	0x64, 0x2c, 0x62, 0x38, 0x70, 0x44, 0x09, 0x54, 0x85, 0x4c, 0x48, 0x78, 0x95, 0xe5, 0xa7, 0x34,
+	0xa3, 0xc4, 0x93, 0x5a, 0x59, 0xc5, 0x9a, 0x5c, 0x20, 0x94, 0xc5, 0x22, 0x58, 0x2f, 0x15, 0x41,
+	0xeb, 0x29, 0x3c, 0x90, 0xb7, 0xf0, 0xc0, 0x2d, 0x92, 0xc3, 0xc2, 0x75, 0xea, 0xe6, 0xd7, 0xc9,
+	0xfa, 0xb7, 0x01, 0x1b, 0xe5, 0x69, 0x6a, 0xff, 0x77, 0xcd, 0x43, 0x18, 0x90, 0x68, 0x4b, 0x8a,
+	0xc4, 0x20, 0xef, 0xe3, 0x93, 0x05, 0x62, 0x28, 0xfb, 0xde, 0x49, 0x6b, 0x55, 0xce, 0x0d, 0x7d,
+	0x5a, 0x14, 0xd0, 0x21, 0x86, 0xc1, 0x82, 0x19, 0xa7, 0xcf, 0x74, 0x5d, 0x15, 0x53, 0x43, 0x4d,
+	0xfc, 0x04, 0x66, 0xb0, 0x46, 0x00, 0xfb, 0xf9, 0x71, 0x2d, 0x63, 0xfc, 0x87, 0xf0, 0x20, 0xb7,
+	0xe0, 0x05, 0x42, 0xa1, 0x6a, 0xbd, 0x85, 0x8d, 0xb2, 0x42, 0xe1, 0xf6, 0x23, 0x68, 0xe7, 0x39,
+	0x90, 0x92, 0xe5, 0x03, 0x2d, 0x92, 0x7c, 0x9e, 0xad, 0x5b, 0x5a, 0xdf, 0x87, 0x87, 0xb9, 0xea,
+	0xb9, 0x60, 0xfd, 0xbb, 0x8a, 0xd1, 0x10, 0xcc, 0x45, 0x73, 0x19, 0x83, 0xf5, 0x8f, 0x0a, 0x74,
+	0x9e, 0xab, 0xeb, 0xcd, 0x1b, 0x02, 0xad, 0x05, 0x68, 0x89, 0x16, 0x60, 0x1b, 0x3a, 0x85, 0x37,
+	0x81, 0xec, 0xc6, 0xda, 0x57, 0xda, 0x83, 0x60, 0xd9, 0xd3, 0xa1, 0x2a, 0xcc, 0xca, 0x4f, 0x87,
+	0x6f, 0x60, 0x70, 0x96, 0x10, 0xb2, 0xf8, 0xca, 0xa8, 0xd9, 0xab, 0x5c, 0xa1, 0xdb, 0xee, 0xc0,
+	0x1a, 0x76, 0x99, 0x7f, 0x55, 0xb2, 0x96, 0xc9, 0x3e, 0x90, 0x2a, 0xdd, 0xfe, 0x45, 0x16, 0xa8,
+	0x1f, 0x9e, 0x45, 0xd4, 0xac, 0xff, 0xef, 0xaf, 0x84, 0xf6, 0x55, 0xa6, 0xa1, 0xe8, 0x18, 0x7a,
+	0x69, 0x2b, 0xad, 0x3c, 0x35, 0x3e, 0xba, 0x9f, 0xee, 0x90, 0x5c, 0x45, 0xad, 0x3f, 0x54, 0xa0,
+	0x69, 0x63, 0xf7, 0xf2, 0xf3, 0xc6, 0xf7, 0x19, 0xac, 0x66, 0x85, 0xa1, 0x00, 0xf1, 0x43, 0x0d,
+	0x18, 0x3d, 0x95, 0xec, 0xae, 0xa7, 0x8d, 0xa8, 0xf5, 0x1f, 0x03, 0x7a, 0xcf, 0xb3, 0xe2, 0xf3,
+	0x79, 0x83, 0xb1, 0x0b, 0xc0, 0xab, 0x65, 0x01, 0x07, 0x9d, 0x43, 0xd2, 0xe3, 0xb6, 0x5b, 0x89,
+	0xfa, 0xa2, 0xd6, 0x1f, 0x2b, 0xd0, 0x79, 0x17, 0xc5, 0x51, 0x10, 0x9d, 0xcf, 0x3f, 0xef, 0xdd,
+	0x1f, 0xc0, 0x40, 0x6b, 0x2c, 0x0a, 0x20, 0x6c, 0x96, 0x92, 0x21, 0x3f, 0x6c, 0x7b, 0xd5, 0x2b,
+	0x8c, 0xa9, 0xb5, 0x06, 0x03, 0xd5, 0x24, 0x6b, 0x74, 0xf9, 0x7b, 0x03, 0x90, 0x2e, 0x55, 0x5c,
+	0xf9, 0x13, 0xe8, 0x32, 0x85, 0x9d, 0x58, 0x4f, 0xbd, 0x13, 0xf4, 0xdc, 0xd3, 0xb1, 0xb5, 0x3b,
+	0x4c, 0x1b, 0xa1, 0x1f, 0xc0, 0xba, 0xda, 0x19, 0x2f, 0x98, 0x4e, 0xc0, 0x1f, 0xa9, 0xce, 0x74,
+	0xa2, 0x10, 0x1e, 0x94, 0x9e, 0xaf, 0xaf, 0x26, 0xd6, 0x1e, 0xac, 0x9d, 0x62, 0x77, 0x36, 0x9b,
+	0x16, 0x2b, 0xe4, 0xb7, 0x30, 0x38, 0xc7, 0xc9, 0x04, 0x9f, 0x13, 0x87, 0x5d, 0x24, 0x84, 0x5e,
+	0x44, 0x81, 0x3c, 0xc0, 0x8a, 0xdd, 0x57, 0x8a, 0x77, 0xa9, 0xdc, 0xda, 0x80, 0xf5, 0xa2, 0x0f,
+	0xb9, 0x95, 0xdd, 0x3f, 0xd7, 0xa1, 0x71, 0x42, 0xf0, 0x35, 0x21, 0x1e, 0x3a, 0x84, 0xee, 0x09,
+	0x09, 0xbd, 0xfc, 0x4f, 0xce, 0xba, 0xb6, 0xa1, 0x4c, 0x3a, 0xfc, 0xce, 0x32, 0x69, 0xc6, 0xe1,
+	0x5f, 0x8c, 0x8d, 0xc7, 0x06, 0x3a, 0x86, 0xee, 0x4b, 0x42, 0xe2, 0xfd, 0x28, 0x0c, 0x89, 0xcb,
+	0x88, 0x87, 0xbe, 0xd2, 0x2b, 0xc9, 0xe2, 0xbb, 0x65, 0xb8, 0xb9, 0x40, 0x68, 0x69, 0xe5, 0x53,
+	0x1e, 0xdf, 0x42, 0x47, 0x6f, 0xd7, 0x0b, 0x0e, 0x97, 0x3c, 0x2e, 0x86, 0x5b, 0xf7, 0xf4, 0xf9,
+	0xd6, 0x17, 0xe8, 0x19, 0xd4, 0x65, 0xff, 0x88, 0x4c, 0xcd, 0xb8, 0xd0, 0x20, 0x0f, 0x37, 0x97,
+	0x68, 0x32, 0x07, 0x2f, 0x01, 0xf2, 0x0e, 0x0c, 0xe9, 0xb8, 0x2c, 0xb4, 0x80, 0xc3, 0x47, 0xb7,
+	0x68, 0x33, 0x67, 0xbf, 0x84, 0x5e, 0xb1, 0xed, 0x40, 0xa3, 0x3b, 0x3a, 0x12, 0xe9, 0x74, 0xfb,
+	0xde, 0x9e, 0x45, 0x3a, 0x2e, 0xd6, 0xfc, 0x82, 0xe3, 0xa5, 0x7d, 0xc2, 0x70, 0xfb, 0x0e, 0x8b,
+	0xcc, 0xf1, 0xaf, 0xa1, 0x5f, 0x2e, 0xe5, 0xc8, 0x5a, 0x3a, 0xb1, 0xd0, 0x16, 0x0c, 0xbf, 0xbe,
+	0xd3, 0x46, 0x47, 0x37, 0xbf, 0x7b, 0x05, 0x74, 0x17, 0x2e, 0xea, 0xf0, 0xd1, 0x2d, 0xda, 0xcc,
+	0xd9, 0x5b, 0xe8, 0xe8, 0xf9, 0x5f, 0x48, 0x9f, 0x25, 0x97, 0x6b, 0xb8, 0x75, 0xab, 0x3e, 0x75,
+	0x39, 0xa9, 0x8b, 0xff, 0xa0, 0x4f, 0xfe, 0x3b, 0x00, 0xfe, 0x9e, 0xd3, 0x64, 0x17, 0x15, 0x00,
+	0x00,
 }
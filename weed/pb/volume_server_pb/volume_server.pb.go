@@ -31,12 +31,47 @@ It has these top-level messages:
 	VolumeSyncIndexResponse
 	VolumeSyncDataRequest
 	VolumeSyncDataResponse
+	VolumeSynchronizeRequest
+	VolumeSynchronizeResponse
 	VolumeMountRequest
 	VolumeMountResponse
 	VolumeUnmountRequest
 	VolumeUnmountResponse
 	VolumeDeleteRequest
 	VolumeDeleteResponse
+	VolumeMarkReadonlyRequest
+	VolumeMarkReadonlyResponse
+	VolumeMarkWritableRequest
+	VolumeMarkWritableResponse
+	VolumeCopyRequest
+	VolumeCopyResponse
+	CopyFileRequest
+	CopyFileResponse
+	VolumeEcShardsGenerateRequest
+	VolumeEcShardsGenerateResponse
+	VolumeEcShardsRebuildRequest
+	VolumeEcShardsRebuildResponse
+	VolumeEcShardsCopyRequest
+	VolumeEcShardsCopyResponse
+	VolumeEcShardsDeleteRequest
+	VolumeEcShardsDeleteResponse
+	VolumeEcShardsMountRequest
+	VolumeEcShardsMountResponse
+	VolumeEcShardsUnmountRequest
+	VolumeEcShardsUnmountResponse
+	VolumeEcShardReadRequest
+	VolumeEcShardReadResponse
+	VolumeTierMoveDatToRemoteRequest
+	VolumeTierMoveDatToRemoteResponse
+	VolumeTierMoveDatFromRemoteRequest
+	VolumeTierMoveDatFromRemoteResponse
+	VolumeScrubRequest
+	VolumeScrubResponse
+	VolumeScrubResult
+	ReadNeedleBlobRequest
+	ReadNeedleBlobResponse
+	VolumeRepairRequest
+	VolumeRepairResponse
 	VolumeUiPageRequest
 	VolumeUiPageResponse
 	DiskStatus
@@ -360,6 +395,7 @@ type VolumeSyncStatusResponse struct {
 	TailOffset      uint64 `protobuf:"varint,6,opt,name=tail_offset,json=tailOffset" json:"tail_offset,omitempty"`
 	CompactRevision uint32 `protobuf:"varint,7,opt,name=compact_revision,json=compactRevision" json:"compact_revision,omitempty"`
 	IdxFileSize     uint64 `protobuf:"varint,8,opt,name=idx_file_size,json=idxFileSize" json:"idx_file_size,omitempty"`
+	ReadOnly        bool   `protobuf:"varint,9,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
 }
 
 func (m *VolumeSyncStatusResponse) Reset()                    { *m = VolumeSyncStatusResponse{} }
@@ -416,6 +452,13 @@ func (m *VolumeSyncStatusResponse) GetIdxFileSize() uint64 {
 	return 0
 }
 
+func (m *VolumeSyncStatusResponse) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 type VolumeSyncIndexRequest struct {
 	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
 }
@@ -512,6 +555,38 @@ func (m *VolumeSyncDataResponse) GetFileContent() []byte {
 	return nil
 }
 
+type VolumeSynchronizeRequest struct {
+	VolumdId       uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	SourceDataNode string `protobuf:"bytes,2,opt,name=source_data_node,json=sourceDataNode" json:"source_data_node,omitempty"`
+}
+
+func (m *VolumeSynchronizeRequest) Reset()                    { *m = VolumeSynchronizeRequest{} }
+func (m *VolumeSynchronizeRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeSynchronizeRequest) ProtoMessage()               {}
+func (*VolumeSynchronizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *VolumeSynchronizeRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeSynchronizeRequest) GetSourceDataNode() string {
+	if m != nil {
+		return m.SourceDataNode
+	}
+	return ""
+}
+
+type VolumeSynchronizeResponse struct {
+}
+
+func (m *VolumeSynchronizeResponse) Reset()                    { *m = VolumeSynchronizeResponse{} }
+func (m *VolumeSynchronizeResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeSynchronizeResponse) ProtoMessage()               {}
+func (*VolumeSynchronizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
 type VolumeMountRequest struct {
 	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
 }
@@ -519,7 +594,7 @@ type VolumeMountRequest struct {
 func (m *VolumeMountRequest) Reset()                    { *m = VolumeMountRequest{} }
 func (m *VolumeMountRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeMountRequest) ProtoMessage()               {}
-func (*VolumeMountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*VolumeMountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *VolumeMountRequest) GetVolumdId() uint32 {
 	if m != nil {
@@ -534,7 +609,7 @@ type VolumeMountResponse struct {
 func (m *VolumeMountResponse) Reset()                    { *m = VolumeMountResponse{} }
 func (m *VolumeMountResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeMountResponse) ProtoMessage()               {}
-func (*VolumeMountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*VolumeMountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 type VolumeUnmountRequest struct {
 	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
@@ -543,7 +618,7 @@ type VolumeUnmountRequest struct {
 func (m *VolumeUnmountRequest) Reset()                    { *m = VolumeUnmountRequest{} }
 func (m *VolumeUnmountRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeUnmountRequest) ProtoMessage()               {}
-func (*VolumeUnmountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*VolumeUnmountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *VolumeUnmountRequest) GetVolumdId() uint32 {
 	if m != nil {
@@ -558,7 +633,7 @@ type VolumeUnmountResponse struct {
 func (m *VolumeUnmountResponse) Reset()                    { *m = VolumeUnmountResponse{} }
 func (m *VolumeUnmountResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeUnmountResponse) ProtoMessage()               {}
-func (*VolumeUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*VolumeUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 type VolumeDeleteRequest struct {
 	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
@@ -567,7 +642,7 @@ type VolumeDeleteRequest struct {
 func (m *VolumeDeleteRequest) Reset()                    { *m = VolumeDeleteRequest{} }
 func (m *VolumeDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeDeleteRequest) ProtoMessage()               {}
-func (*VolumeDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*VolumeDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *VolumeDeleteRequest) GetVolumdId() uint32 {
 	if m != nil {
@@ -582,276 +657,1280 @@ type VolumeDeleteResponse struct {
 func (m *VolumeDeleteResponse) Reset()                    { *m = VolumeDeleteResponse{} }
 func (m *VolumeDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeDeleteResponse) ProtoMessage()               {}
-func (*VolumeDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*VolumeDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
-type VolumeUiPageRequest struct {
+type VolumeMarkReadonlyRequest struct {
+	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
 }
 
-func (m *VolumeUiPageRequest) Reset()                    { *m = VolumeUiPageRequest{} }
-func (m *VolumeUiPageRequest) String() string            { return proto.CompactTextString(m) }
-func (*VolumeUiPageRequest) ProtoMessage()               {}
-func (*VolumeUiPageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (m *VolumeMarkReadonlyRequest) Reset()                    { *m = VolumeMarkReadonlyRequest{} }
+func (m *VolumeMarkReadonlyRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMarkReadonlyRequest) ProtoMessage()               {}
+func (*VolumeMarkReadonlyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
-type VolumeUiPageResponse struct {
+func (m *VolumeMarkReadonlyRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
 }
 
-func (m *VolumeUiPageResponse) Reset()                    { *m = VolumeUiPageResponse{} }
-func (m *VolumeUiPageResponse) String() string            { return proto.CompactTextString(m) }
-func (*VolumeUiPageResponse) ProtoMessage()               {}
-func (*VolumeUiPageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
-
-type DiskStatus struct {
-	Dir  string `protobuf:"bytes,1,opt,name=dir" json:"dir,omitempty"`
-	All  uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
-	Used uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
-	Free uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
+type VolumeMarkReadonlyResponse struct {
 }
 
-func (m *DiskStatus) Reset()                    { *m = DiskStatus{} }
-func (m *DiskStatus) String() string            { return proto.CompactTextString(m) }
-func (*DiskStatus) ProtoMessage()               {}
-func (*DiskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (m *VolumeMarkReadonlyResponse) Reset()                    { *m = VolumeMarkReadonlyResponse{} }
+func (m *VolumeMarkReadonlyResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMarkReadonlyResponse) ProtoMessage()               {}
+func (*VolumeMarkReadonlyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
-func (m *DiskStatus) GetDir() string {
-	if m != nil {
-		return m.Dir
-	}
-	return ""
+type VolumeMarkWritableRequest struct {
+	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
 }
 
-func (m *DiskStatus) GetAll() uint64 {
+func (m *VolumeMarkWritableRequest) Reset()                    { *m = VolumeMarkWritableRequest{} }
+func (m *VolumeMarkWritableRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMarkWritableRequest) ProtoMessage()               {}
+func (*VolumeMarkWritableRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *VolumeMarkWritableRequest) GetVolumdId() uint32 {
 	if m != nil {
-		return m.All
+		return m.VolumdId
 	}
 	return 0
 }
 
-func (m *DiskStatus) GetUsed() uint64 {
+type VolumeMarkWritableResponse struct {
+}
+
+func (m *VolumeMarkWritableResponse) Reset()                    { *m = VolumeMarkWritableResponse{} }
+func (m *VolumeMarkWritableResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMarkWritableResponse) ProtoMessage()               {}
+func (*VolumeMarkWritableResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type VolumeCopyRequest struct {
+	VolumdId       uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	SourceDataNode string `protobuf:"bytes,2,opt,name=source_data_node,json=sourceDataNode" json:"source_data_node,omitempty"`
+}
+
+func (m *VolumeCopyRequest) Reset()                    { *m = VolumeCopyRequest{} }
+func (m *VolumeCopyRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeCopyRequest) ProtoMessage()               {}
+func (*VolumeCopyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *VolumeCopyRequest) GetVolumdId() uint32 {
 	if m != nil {
-		return m.Used
+		return m.VolumdId
 	}
 	return 0
 }
 
-func (m *DiskStatus) GetFree() uint64 {
+func (m *VolumeCopyRequest) GetSourceDataNode() string {
 	if m != nil {
-		return m.Free
+		return m.SourceDataNode
 	}
-	return 0
+	return ""
 }
 
-type MemStatus struct {
-	Goroutines int32  `protobuf:"varint,1,opt,name=goroutines" json:"goroutines,omitempty"`
-	All        uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
-	Used       uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
-	Free       uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
-	Self       uint64 `protobuf:"varint,5,opt,name=self" json:"self,omitempty"`
-	Heap       uint64 `protobuf:"varint,6,opt,name=heap" json:"heap,omitempty"`
-	Stack      uint64 `protobuf:"varint,7,opt,name=stack" json:"stack,omitempty"`
+type VolumeCopyResponse struct {
 }
 
-func (m *MemStatus) Reset()                    { *m = MemStatus{} }
-func (m *MemStatus) String() string            { return proto.CompactTextString(m) }
-func (*MemStatus) ProtoMessage()               {}
-func (*MemStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (m *VolumeCopyResponse) Reset()                    { *m = VolumeCopyResponse{} }
+func (m *VolumeCopyResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeCopyResponse) ProtoMessage()               {}
+func (*VolumeCopyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
-func (m *MemStatus) GetGoroutines() int32 {
+type CopyFileRequest struct {
+	VolumdId   uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Ext        string `protobuf:"bytes,2,opt,name=ext" json:"ext,omitempty"`
+	Collection string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
+	IsEcVolume bool   `protobuf:"varint,4,opt,name=is_ec_volume,json=isEcVolume" json:"is_ec_volume,omitempty"`
+	StopOffset uint64 `protobuf:"varint,5,opt,name=stop_offset,json=stopOffset" json:"stop_offset,omitempty"`
+}
+
+func (m *CopyFileRequest) Reset()                    { *m = CopyFileRequest{} }
+func (m *CopyFileRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyFileRequest) ProtoMessage()               {}
+func (*CopyFileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *CopyFileRequest) GetVolumdId() uint32 {
 	if m != nil {
-		return m.Goroutines
+		return m.VolumdId
 	}
 	return 0
 }
 
-func (m *MemStatus) GetAll() uint64 {
+func (m *CopyFileRequest) GetExt() string {
 	if m != nil {
-		return m.All
+		return m.Ext
 	}
-	return 0
+	return ""
 }
 
-func (m *MemStatus) GetUsed() uint64 {
+func (m *CopyFileRequest) GetCollection() string {
 	if m != nil {
-		return m.Used
+		return m.Collection
 	}
-	return 0
+	return ""
 }
 
-func (m *MemStatus) GetFree() uint64 {
+func (m *CopyFileRequest) GetIsEcVolume() bool {
 	if m != nil {
-		return m.Free
+		return m.IsEcVolume
 	}
-	return 0
+	return false
 }
 
-func (m *MemStatus) GetSelf() uint64 {
+func (m *CopyFileRequest) GetStopOffset() uint64 {
 	if m != nil {
-		return m.Self
+		return m.StopOffset
 	}
 	return 0
 }
 
-func (m *MemStatus) GetHeap() uint64 {
+type CopyFileResponse struct {
+	FileContent []byte `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+}
+
+func (m *CopyFileResponse) Reset()                    { *m = CopyFileResponse{} }
+func (m *CopyFileResponse) String() string            { return proto.CompactTextString(m) }
+func (*CopyFileResponse) ProtoMessage()               {}
+func (*CopyFileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *CopyFileResponse) GetFileContent() []byte {
 	if m != nil {
-		return m.Heap
+		return m.FileContent
 	}
-	return 0
+	return nil
 }
 
-func (m *MemStatus) GetStack() uint64 {
+type VolumeEcShardsGenerateRequest struct {
+	VolumdId   uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
+
+func (m *VolumeEcShardsGenerateRequest) Reset()                    { *m = VolumeEcShardsGenerateRequest{} }
+func (m *VolumeEcShardsGenerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsGenerateRequest) ProtoMessage()               {}
+func (*VolumeEcShardsGenerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *VolumeEcShardsGenerateRequest) GetVolumdId() uint32 {
 	if m != nil {
-		return m.Stack
+		return m.VolumdId
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*BatchDeleteRequest)(nil), "volume_server_pb.BatchDeleteRequest")
-	proto.RegisterType((*BatchDeleteResponse)(nil), "volume_server_pb.BatchDeleteResponse")
-	proto.RegisterType((*DeleteResult)(nil), "volume_server_pb.DeleteResult")
-	proto.RegisterType((*Empty)(nil), "volume_server_pb.Empty")
-	proto.RegisterType((*VacuumVolumeCheckRequest)(nil), "volume_server_pb.VacuumVolumeCheckRequest")
-	proto.RegisterType((*VacuumVolumeCheckResponse)(nil), "volume_server_pb.VacuumVolumeCheckResponse")
-	proto.RegisterType((*VacuumVolumeCompactRequest)(nil), "volume_server_pb.VacuumVolumeCompactRequest")
-	proto.RegisterType((*VacuumVolumeCompactResponse)(nil), "volume_server_pb.VacuumVolumeCompactResponse")
-	proto.RegisterType((*VacuumVolumeCommitRequest)(nil), "volume_server_pb.VacuumVolumeCommitRequest")
-	proto.RegisterType((*VacuumVolumeCommitResponse)(nil), "volume_server_pb.VacuumVolumeCommitResponse")
-	proto.RegisterType((*VacuumVolumeCleanupRequest)(nil), "volume_server_pb.VacuumVolumeCleanupRequest")
-	proto.RegisterType((*VacuumVolumeCleanupResponse)(nil), "volume_server_pb.VacuumVolumeCleanupResponse")
-	proto.RegisterType((*DeleteCollectionRequest)(nil), "volume_server_pb.DeleteCollectionRequest")
-	proto.RegisterType((*DeleteCollectionResponse)(nil), "volume_server_pb.DeleteCollectionResponse")
-	proto.RegisterType((*AssignVolumeRequest)(nil), "volume_server_pb.AssignVolumeRequest")
-	proto.RegisterType((*AssignVolumeResponse)(nil), "volume_server_pb.AssignVolumeResponse")
-	proto.RegisterType((*VolumeSyncStatusRequest)(nil), "volume_server_pb.VolumeSyncStatusRequest")
-	proto.RegisterType((*VolumeSyncStatusResponse)(nil), "volume_server_pb.VolumeSyncStatusResponse")
-	proto.RegisterType((*VolumeSyncIndexRequest)(nil), "volume_server_pb.VolumeSyncIndexRequest")
-	proto.RegisterType((*VolumeSyncIndexResponse)(nil), "volume_server_pb.VolumeSyncIndexResponse")
-	proto.RegisterType((*VolumeSyncDataRequest)(nil), "volume_server_pb.VolumeSyncDataRequest")
-	proto.RegisterType((*VolumeSyncDataResponse)(nil), "volume_server_pb.VolumeSyncDataResponse")
-	proto.RegisterType((*VolumeMountRequest)(nil), "volume_server_pb.VolumeMountRequest")
-	proto.RegisterType((*VolumeMountResponse)(nil), "volume_server_pb.VolumeMountResponse")
-	proto.RegisterType((*VolumeUnmountRequest)(nil), "volume_server_pb.VolumeUnmountRequest")
-	proto.RegisterType((*VolumeUnmountResponse)(nil), "volume_server_pb.VolumeUnmountResponse")
-	proto.RegisterType((*VolumeDeleteRequest)(nil), "volume_server_pb.VolumeDeleteRequest")
-	proto.RegisterType((*VolumeDeleteResponse)(nil), "volume_server_pb.VolumeDeleteResponse")
-	proto.RegisterType((*VolumeUiPageRequest)(nil), "volume_server_pb.VolumeUiPageRequest")
-	proto.RegisterType((*VolumeUiPageResponse)(nil), "volume_server_pb.VolumeUiPageResponse")
-	proto.RegisterType((*DiskStatus)(nil), "volume_server_pb.DiskStatus")
-	proto.RegisterType((*MemStatus)(nil), "volume_server_pb.MemStatus")
+func (m *VolumeEcShardsGenerateRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type VolumeEcShardsGenerateResponse struct {
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *VolumeEcShardsGenerateResponse) Reset()         { *m = VolumeEcShardsGenerateResponse{} }
+func (m *VolumeEcShardsGenerateResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeEcShardsGenerateResponse) ProtoMessage()    {}
+func (*VolumeEcShardsGenerateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{39}
+}
 
-// Client API for VolumeServer service
+type VolumeEcShardsRebuildRequest struct {
+	VolumdId   uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
 
-type VolumeServerClient interface {
-	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
-	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
-	VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error)
-	VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error)
-	VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error)
-	VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error)
-	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
-	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
-	VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error)
-	VolumeSyncIndex(ctx context.Context, in *VolumeSyncIndexRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncIndexClient, error)
-	VolumeSyncData(ctx context.Context, in *VolumeSyncDataRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncDataClient, error)
-	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
-	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
-	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error)
+func (m *VolumeEcShardsRebuildRequest) Reset()                    { *m = VolumeEcShardsRebuildRequest{} }
+func (m *VolumeEcShardsRebuildRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsRebuildRequest) ProtoMessage()               {}
+func (*VolumeEcShardsRebuildRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *VolumeEcShardsRebuildRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
 }
 
-type volumeServerClient struct {
-	cc *grpc.ClientConn
+func (m *VolumeEcShardsRebuildRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
 }
 
-func NewVolumeServerClient(cc *grpc.ClientConn) VolumeServerClient {
-	return &volumeServerClient{cc}
+type VolumeEcShardsRebuildResponse struct {
+	RebuiltShardIds []uint32 `protobuf:"varint,1,rep,packed,name=rebuilt_shard_ids,json=rebuiltShardIds" json:"rebuilt_shard_ids,omitempty"`
 }
 
-func (c *volumeServerClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
-	out := new(BatchDeleteResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/BatchDelete", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsRebuildResponse) Reset()                    { *m = VolumeEcShardsRebuildResponse{} }
+func (m *VolumeEcShardsRebuildResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsRebuildResponse) ProtoMessage()               {}
+func (*VolumeEcShardsRebuildResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *VolumeEcShardsRebuildResponse) GetRebuiltShardIds() []uint32 {
+	if m != nil {
+		return m.RebuiltShardIds
 	}
-	return out, nil
+	return nil
 }
 
-func (c *volumeServerClient) VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error) {
-	out := new(VacuumVolumeCheckResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCheck", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type VolumeEcShardsCopyRequest struct {
+	VolumdId       uint32   `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection     string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ShardIds       []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+	CopyEcxFile    bool     `protobuf:"varint,4,opt,name=copy_ecx_file,json=copyEcxFile" json:"copy_ecx_file,omitempty"`
+	SourceDataNode string   `protobuf:"bytes,5,opt,name=source_data_node,json=sourceDataNode" json:"source_data_node,omitempty"`
 }
 
-func (c *volumeServerClient) VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error) {
-	out := new(VacuumVolumeCompactResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCompact", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsCopyRequest) Reset()                    { *m = VolumeEcShardsCopyRequest{} }
+func (m *VolumeEcShardsCopyRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsCopyRequest) ProtoMessage()               {}
+func (*VolumeEcShardsCopyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *VolumeEcShardsCopyRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *volumeServerClient) VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error) {
-	out := new(VacuumVolumeCommitResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCommit", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsCopyRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
 	}
-	return out, nil
+	return ""
 }
 
-func (c *volumeServerClient) VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error) {
-	out := new(VacuumVolumeCleanupResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCleanup", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsCopyRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
 	}
-	return out, nil
+	return nil
 }
 
-func (c *volumeServerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
-	out := new(DeleteCollectionResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/DeleteCollection", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsCopyRequest) GetCopyEcxFile() bool {
+	if m != nil {
+		return m.CopyEcxFile
 	}
-	return out, nil
+	return false
 }
 
-func (c *volumeServerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
-	out := new(AssignVolumeResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/AssignVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsCopyRequest) GetSourceDataNode() string {
+	if m != nil {
+		return m.SourceDataNode
 	}
-	return out, nil
+	return ""
 }
 
-func (c *volumeServerClient) VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error) {
-	out := new(VolumeSyncStatusResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeSyncStatus", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type VolumeEcShardsCopyResponse struct {
 }
 
-func (c *volumeServerClient) VolumeSyncIndex(ctx context.Context, in *VolumeSyncIndexRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncIndexClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[0], c.cc, "/volume_server_pb.VolumeServer/VolumeSyncIndex", opts...)
-	if err != nil {
-		return nil, err
+func (m *VolumeEcShardsCopyResponse) Reset()                    { *m = VolumeEcShardsCopyResponse{} }
+func (m *VolumeEcShardsCopyResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsCopyResponse) ProtoMessage()               {}
+func (*VolumeEcShardsCopyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+type VolumeEcShardsDeleteRequest struct {
+	VolumdId   uint32   `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ShardIds   []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+}
+
+func (m *VolumeEcShardsDeleteRequest) Reset()                    { *m = VolumeEcShardsDeleteRequest{} }
+func (m *VolumeEcShardsDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsDeleteRequest) ProtoMessage()               {}
+func (*VolumeEcShardsDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *VolumeEcShardsDeleteRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardsDeleteRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeEcShardsDeleteRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
+
+type VolumeEcShardsDeleteResponse struct {
+}
+
+func (m *VolumeEcShardsDeleteResponse) Reset()                    { *m = VolumeEcShardsDeleteResponse{} }
+func (m *VolumeEcShardsDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsDeleteResponse) ProtoMessage()               {}
+func (*VolumeEcShardsDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+type VolumeEcShardsMountRequest struct {
+	VolumdId   uint32   `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ShardIds   []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+}
+
+func (m *VolumeEcShardsMountRequest) Reset()                    { *m = VolumeEcShardsMountRequest{} }
+func (m *VolumeEcShardsMountRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsMountRequest) ProtoMessage()               {}
+func (*VolumeEcShardsMountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *VolumeEcShardsMountRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardsMountRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeEcShardsMountRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
+
+type VolumeEcShardsMountResponse struct {
+}
+
+func (m *VolumeEcShardsMountResponse) Reset()                    { *m = VolumeEcShardsMountResponse{} }
+func (m *VolumeEcShardsMountResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsMountResponse) ProtoMessage()               {}
+func (*VolumeEcShardsMountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+type VolumeEcShardsUnmountRequest struct {
+	VolumdId uint32   `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	ShardIds []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+}
+
+func (m *VolumeEcShardsUnmountRequest) Reset()                    { *m = VolumeEcShardsUnmountRequest{} }
+func (m *VolumeEcShardsUnmountRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsUnmountRequest) ProtoMessage()               {}
+func (*VolumeEcShardsUnmountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *VolumeEcShardsUnmountRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardsUnmountRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
+
+type VolumeEcShardsUnmountResponse struct {
+}
+
+func (m *VolumeEcShardsUnmountResponse) Reset()                    { *m = VolumeEcShardsUnmountResponse{} }
+func (m *VolumeEcShardsUnmountResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsUnmountResponse) ProtoMessage()               {}
+func (*VolumeEcShardsUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+type VolumeEcShardReadRequest struct {
+	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	ShardId  uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Offset   int64  `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	Size     int64  `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *VolumeEcShardReadRequest) Reset()                    { *m = VolumeEcShardReadRequest{} }
+func (m *VolumeEcShardReadRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardReadRequest) ProtoMessage()               {}
+func (*VolumeEcShardReadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *VolumeEcShardReadRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardReadRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardReadRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *VolumeEcShardReadRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type VolumeEcShardReadResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *VolumeEcShardReadResponse) Reset()                    { *m = VolumeEcShardReadResponse{} }
+func (m *VolumeEcShardReadResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardReadResponse) ProtoMessage()               {}
+func (*VolumeEcShardReadResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *VolumeEcShardReadResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type VolumeTierMoveDatToRemoteRequest struct {
+	VolumdId   uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	Endpoint   string `protobuf:"bytes,3,opt,name=endpoint" json:"endpoint,omitempty"`
+	Region     string `protobuf:"bytes,4,opt,name=region" json:"region,omitempty"`
+	Bucket     string `protobuf:"bytes,5,opt,name=bucket" json:"bucket,omitempty"`
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) Reset()         { *m = VolumeTierMoveDatToRemoteRequest{} }
+func (m *VolumeTierMoveDatToRemoteRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatToRemoteRequest) ProtoMessage()    {}
+func (*VolumeTierMoveDatToRemoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{52}
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+type VolumeTierMoveDatToRemoteResponse struct {
+}
+
+func (m *VolumeTierMoveDatToRemoteResponse) Reset()         { *m = VolumeTierMoveDatToRemoteResponse{} }
+func (m *VolumeTierMoveDatToRemoteResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatToRemoteResponse) ProtoMessage()    {}
+func (*VolumeTierMoveDatToRemoteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{53}
+}
+
+type VolumeTierMoveDatFromRemoteRequest struct {
+	VolumdId   uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) Reset()         { *m = VolumeTierMoveDatFromRemoteRequest{} }
+func (m *VolumeTierMoveDatFromRemoteRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatFromRemoteRequest) ProtoMessage()    {}
+func (*VolumeTierMoveDatFromRemoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{54}
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+type VolumeTierMoveDatFromRemoteResponse struct {
+}
+
+func (m *VolumeTierMoveDatFromRemoteResponse) Reset()         { *m = VolumeTierMoveDatFromRemoteResponse{} }
+func (m *VolumeTierMoveDatFromRemoteResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatFromRemoteResponse) ProtoMessage()    {}
+func (*VolumeTierMoveDatFromRemoteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{55}
+}
+
+type VolumeScrubRequest struct {
+	VolumeIds []uint32 `protobuf:"varint,1,rep,packed,name=volume_ids,json=volumeIds" json:"volume_ids,omitempty"`
+	ScrubNow  bool     `protobuf:"varint,2,opt,name=scrub_now,json=scrubNow" json:"scrub_now,omitempty"`
+}
+
+func (m *VolumeScrubRequest) Reset()                    { *m = VolumeScrubRequest{} }
+func (m *VolumeScrubRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeScrubRequest) ProtoMessage()               {}
+func (*VolumeScrubRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *VolumeScrubRequest) GetVolumeIds() []uint32 {
+	if m != nil {
+		return m.VolumeIds
+	}
+	return nil
+}
+
+func (m *VolumeScrubRequest) GetScrubNow() bool {
+	if m != nil {
+		return m.ScrubNow
+	}
+	return false
+}
+
+type VolumeScrubResponse struct {
+	Results []*VolumeScrubResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *VolumeScrubResponse) Reset()                    { *m = VolumeScrubResponse{} }
+func (m *VolumeScrubResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeScrubResponse) ProtoMessage()               {}
+func (*VolumeScrubResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *VolumeScrubResponse) GetResults() []*VolumeScrubResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type VolumeScrubResult struct {
+	VolumeId           uint32   `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection         string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ScrubbedAtNs       int64    `protobuf:"varint,3,opt,name=scrubbed_at_ns,json=scrubbedAtNs" json:"scrubbed_at_ns,omitempty"`
+	NeedleCount        uint64   `protobuf:"varint,4,opt,name=needle_count,json=needleCount" json:"needle_count,omitempty"`
+	CorruptedNeedleIds []uint64 `protobuf:"varint,5,rep,packed,name=corrupted_needle_ids,json=corruptedNeedleIds" json:"corrupted_needle_ids,omitempty"`
+	Error              string   `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *VolumeScrubResult) Reset()                    { *m = VolumeScrubResult{} }
+func (m *VolumeScrubResult) String() string            { return proto.CompactTextString(m) }
+func (*VolumeScrubResult) ProtoMessage()               {}
+func (*VolumeScrubResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *VolumeScrubResult) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeScrubResult) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeScrubResult) GetScrubbedAtNs() int64 {
+	if m != nil {
+		return m.ScrubbedAtNs
+	}
+	return 0
+}
+
+func (m *VolumeScrubResult) GetNeedleCount() uint64 {
+	if m != nil {
+		return m.NeedleCount
+	}
+	return 0
+}
+
+func (m *VolumeScrubResult) GetCorruptedNeedleIds() []uint64 {
+	if m != nil {
+		return m.CorruptedNeedleIds
+	}
+	return nil
+}
+
+func (m *VolumeScrubResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ReadNeedleBlobRequest struct {
+	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+	NeedleId uint64 `protobuf:"varint,2,opt,name=needle_id,json=needleId" json:"needle_id,omitempty"`
+}
+
+func (m *ReadNeedleBlobRequest) Reset()                    { *m = ReadNeedleBlobRequest{} }
+func (m *ReadNeedleBlobRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadNeedleBlobRequest) ProtoMessage()               {}
+func (*ReadNeedleBlobRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *ReadNeedleBlobRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+func (m *ReadNeedleBlobRequest) GetNeedleId() uint64 {
+	if m != nil {
+		return m.NeedleId
+	}
+	return 0
+}
+
+type ReadNeedleBlobResponse struct {
+	NeedleBlob []byte `protobuf:"bytes,1,opt,name=needle_blob,json=needleBlob,proto3" json:"needle_blob,omitempty"`
+	Size       uint32 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	Version    uint32 `protobuf:"varint,3,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *ReadNeedleBlobResponse) Reset()                    { *m = ReadNeedleBlobResponse{} }
+func (m *ReadNeedleBlobResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadNeedleBlobResponse) ProtoMessage()               {}
+func (*ReadNeedleBlobResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ReadNeedleBlobResponse) GetNeedleBlob() []byte {
+	if m != nil {
+		return m.NeedleBlob
+	}
+	return nil
+}
+
+func (m *ReadNeedleBlobResponse) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ReadNeedleBlobResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type VolumeRepairRequest struct {
+	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+}
+
+func (m *VolumeRepairRequest) Reset()                    { *m = VolumeRepairRequest{} }
+func (m *VolumeRepairRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeRepairRequest) ProtoMessage()               {}
+func (*VolumeRepairRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *VolumeRepairRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+type VolumeRepairResponse struct {
+	NeedleCount       uint64   `protobuf:"varint,1,opt,name=needle_count,json=needleCount" json:"needle_count,omitempty"`
+	RepairedNeedleIds []uint64 `protobuf:"varint,2,rep,packed,name=repaired_needle_ids,json=repairedNeedleIds" json:"repaired_needle_ids,omitempty"`
+	FailedNeedleIds   []uint64 `protobuf:"varint,3,rep,packed,name=failed_needle_ids,json=failedNeedleIds" json:"failed_needle_ids,omitempty"`
+}
+
+func (m *VolumeRepairResponse) Reset()                    { *m = VolumeRepairResponse{} }
+func (m *VolumeRepairResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeRepairResponse) ProtoMessage()               {}
+func (*VolumeRepairResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *VolumeRepairResponse) GetNeedleCount() uint64 {
+	if m != nil {
+		return m.NeedleCount
+	}
+	return 0
+}
+
+func (m *VolumeRepairResponse) GetRepairedNeedleIds() []uint64 {
+	if m != nil {
+		return m.RepairedNeedleIds
+	}
+	return nil
+}
+
+func (m *VolumeRepairResponse) GetFailedNeedleIds() []uint64 {
+	if m != nil {
+		return m.FailedNeedleIds
+	}
+	return nil
+}
+
+type VolumeUiPageRequest struct {
+}
+
+func (m *VolumeUiPageRequest) Reset()                    { *m = VolumeUiPageRequest{} }
+func (m *VolumeUiPageRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeUiPageRequest) ProtoMessage()               {}
+func (*VolumeUiPageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+type VolumeUiPageResponse struct {
+}
+
+func (m *VolumeUiPageResponse) Reset()                    { *m = VolumeUiPageResponse{} }
+func (m *VolumeUiPageResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeUiPageResponse) ProtoMessage()               {}
+func (*VolumeUiPageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+type DiskStatus struct {
+	Dir  string `protobuf:"bytes,1,opt,name=dir" json:"dir,omitempty"`
+	All  uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
+	Used uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
+	Free uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
+}
+
+func (m *DiskStatus) Reset()                    { *m = DiskStatus{} }
+func (m *DiskStatus) String() string            { return proto.CompactTextString(m) }
+func (*DiskStatus) ProtoMessage()               {}
+func (*DiskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *DiskStatus) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+func (m *DiskStatus) GetAll() uint64 {
+	if m != nil {
+		return m.All
+	}
+	return 0
+}
+
+func (m *DiskStatus) GetUsed() uint64 {
+	if m != nil {
+		return m.Used
+	}
+	return 0
+}
+
+func (m *DiskStatus) GetFree() uint64 {
+	if m != nil {
+		return m.Free
+	}
+	return 0
+}
+
+type MemStatus struct {
+	Goroutines int32  `protobuf:"varint,1,opt,name=goroutines" json:"goroutines,omitempty"`
+	All        uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
+	Used       uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
+	Free       uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
+	Self       uint64 `protobuf:"varint,5,opt,name=self" json:"self,omitempty"`
+	Heap       uint64 `protobuf:"varint,6,opt,name=heap" json:"heap,omitempty"`
+	Stack      uint64 `protobuf:"varint,7,opt,name=stack" json:"stack,omitempty"`
+}
+
+func (m *MemStatus) Reset()                    { *m = MemStatus{} }
+func (m *MemStatus) String() string            { return proto.CompactTextString(m) }
+func (*MemStatus) ProtoMessage()               {}
+func (*MemStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *MemStatus) GetGoroutines() int32 {
+	if m != nil {
+		return m.Goroutines
+	}
+	return 0
+}
+
+func (m *MemStatus) GetAll() uint64 {
+	if m != nil {
+		return m.All
+	}
+	return 0
+}
+
+func (m *MemStatus) GetUsed() uint64 {
+	if m != nil {
+		return m.Used
+	}
+	return 0
+}
+
+func (m *MemStatus) GetFree() uint64 {
+	if m != nil {
+		return m.Free
+	}
+	return 0
+}
+
+func (m *MemStatus) GetSelf() uint64 {
+	if m != nil {
+		return m.Self
+	}
+	return 0
+}
+
+func (m *MemStatus) GetHeap() uint64 {
+	if m != nil {
+		return m.Heap
+	}
+	return 0
+}
+
+func (m *MemStatus) GetStack() uint64 {
+	if m != nil {
+		return m.Stack
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*BatchDeleteRequest)(nil), "volume_server_pb.BatchDeleteRequest")
+	proto.RegisterType((*BatchDeleteResponse)(nil), "volume_server_pb.BatchDeleteResponse")
+	proto.RegisterType((*DeleteResult)(nil), "volume_server_pb.DeleteResult")
+	proto.RegisterType((*Empty)(nil), "volume_server_pb.Empty")
+	proto.RegisterType((*VacuumVolumeCheckRequest)(nil), "volume_server_pb.VacuumVolumeCheckRequest")
+	proto.RegisterType((*VacuumVolumeCheckResponse)(nil), "volume_server_pb.VacuumVolumeCheckResponse")
+	proto.RegisterType((*VacuumVolumeCompactRequest)(nil), "volume_server_pb.VacuumVolumeCompactRequest")
+	proto.RegisterType((*VacuumVolumeCompactResponse)(nil), "volume_server_pb.VacuumVolumeCompactResponse")
+	proto.RegisterType((*VacuumVolumeCommitRequest)(nil), "volume_server_pb.VacuumVolumeCommitRequest")
+	proto.RegisterType((*VacuumVolumeCommitResponse)(nil), "volume_server_pb.VacuumVolumeCommitResponse")
+	proto.RegisterType((*VacuumVolumeCleanupRequest)(nil), "volume_server_pb.VacuumVolumeCleanupRequest")
+	proto.RegisterType((*VacuumVolumeCleanupResponse)(nil), "volume_server_pb.VacuumVolumeCleanupResponse")
+	proto.RegisterType((*DeleteCollectionRequest)(nil), "volume_server_pb.DeleteCollectionRequest")
+	proto.RegisterType((*DeleteCollectionResponse)(nil), "volume_server_pb.DeleteCollectionResponse")
+	proto.RegisterType((*AssignVolumeRequest)(nil), "volume_server_pb.AssignVolumeRequest")
+	proto.RegisterType((*AssignVolumeResponse)(nil), "volume_server_pb.AssignVolumeResponse")
+	proto.RegisterType((*VolumeSyncStatusRequest)(nil), "volume_server_pb.VolumeSyncStatusRequest")
+	proto.RegisterType((*VolumeSyncStatusResponse)(nil), "volume_server_pb.VolumeSyncStatusResponse")
+	proto.RegisterType((*VolumeSyncIndexRequest)(nil), "volume_server_pb.VolumeSyncIndexRequest")
+	proto.RegisterType((*VolumeSyncIndexResponse)(nil), "volume_server_pb.VolumeSyncIndexResponse")
+	proto.RegisterType((*VolumeSyncDataRequest)(nil), "volume_server_pb.VolumeSyncDataRequest")
+	proto.RegisterType((*VolumeSyncDataResponse)(nil), "volume_server_pb.VolumeSyncDataResponse")
+	proto.RegisterType((*VolumeSynchronizeRequest)(nil), "volume_server_pb.VolumeSynchronizeRequest")
+	proto.RegisterType((*VolumeSynchronizeResponse)(nil), "volume_server_pb.VolumeSynchronizeResponse")
+	proto.RegisterType((*VolumeMountRequest)(nil), "volume_server_pb.VolumeMountRequest")
+	proto.RegisterType((*VolumeMountResponse)(nil), "volume_server_pb.VolumeMountResponse")
+	proto.RegisterType((*VolumeUnmountRequest)(nil), "volume_server_pb.VolumeUnmountRequest")
+	proto.RegisterType((*VolumeUnmountResponse)(nil), "volume_server_pb.VolumeUnmountResponse")
+	proto.RegisterType((*VolumeDeleteRequest)(nil), "volume_server_pb.VolumeDeleteRequest")
+	proto.RegisterType((*VolumeDeleteResponse)(nil), "volume_server_pb.VolumeDeleteResponse")
+	proto.RegisterType((*VolumeMarkReadonlyRequest)(nil), "volume_server_pb.VolumeMarkReadonlyRequest")
+	proto.RegisterType((*VolumeMarkReadonlyResponse)(nil), "volume_server_pb.VolumeMarkReadonlyResponse")
+	proto.RegisterType((*VolumeMarkWritableRequest)(nil), "volume_server_pb.VolumeMarkWritableRequest")
+	proto.RegisterType((*VolumeMarkWritableResponse)(nil), "volume_server_pb.VolumeMarkWritableResponse")
+	proto.RegisterType((*VolumeCopyRequest)(nil), "volume_server_pb.VolumeCopyRequest")
+	proto.RegisterType((*VolumeCopyResponse)(nil), "volume_server_pb.VolumeCopyResponse")
+	proto.RegisterType((*CopyFileRequest)(nil), "volume_server_pb.CopyFileRequest")
+	proto.RegisterType((*CopyFileResponse)(nil), "volume_server_pb.CopyFileResponse")
+	proto.RegisterType((*VolumeEcShardsGenerateRequest)(nil), "volume_server_pb.VolumeEcShardsGenerateRequest")
+	proto.RegisterType((*VolumeEcShardsGenerateResponse)(nil), "volume_server_pb.VolumeEcShardsGenerateResponse")
+	proto.RegisterType((*VolumeEcShardsRebuildRequest)(nil), "volume_server_pb.VolumeEcShardsRebuildRequest")
+	proto.RegisterType((*VolumeEcShardsRebuildResponse)(nil), "volume_server_pb.VolumeEcShardsRebuildResponse")
+	proto.RegisterType((*VolumeEcShardsCopyRequest)(nil), "volume_server_pb.VolumeEcShardsCopyRequest")
+	proto.RegisterType((*VolumeEcShardsCopyResponse)(nil), "volume_server_pb.VolumeEcShardsCopyResponse")
+	proto.RegisterType((*VolumeEcShardsDeleteRequest)(nil), "volume_server_pb.VolumeEcShardsDeleteRequest")
+	proto.RegisterType((*VolumeEcShardsDeleteResponse)(nil), "volume_server_pb.VolumeEcShardsDeleteResponse")
+	proto.RegisterType((*VolumeEcShardsMountRequest)(nil), "volume_server_pb.VolumeEcShardsMountRequest")
+	proto.RegisterType((*VolumeEcShardsMountResponse)(nil), "volume_server_pb.VolumeEcShardsMountResponse")
+	proto.RegisterType((*VolumeEcShardsUnmountRequest)(nil), "volume_server_pb.VolumeEcShardsUnmountRequest")
+	proto.RegisterType((*VolumeEcShardsUnmountResponse)(nil), "volume_server_pb.VolumeEcShardsUnmountResponse")
+	proto.RegisterType((*VolumeEcShardReadRequest)(nil), "volume_server_pb.VolumeEcShardReadRequest")
+	proto.RegisterType((*VolumeEcShardReadResponse)(nil), "volume_server_pb.VolumeEcShardReadResponse")
+	proto.RegisterType((*VolumeTierMoveDatToRemoteRequest)(nil), "volume_server_pb.VolumeTierMoveDatToRemoteRequest")
+	proto.RegisterType((*VolumeTierMoveDatToRemoteResponse)(nil), "volume_server_pb.VolumeTierMoveDatToRemoteResponse")
+	proto.RegisterType((*VolumeTierMoveDatFromRemoteRequest)(nil), "volume_server_pb.VolumeTierMoveDatFromRemoteRequest")
+	proto.RegisterType((*VolumeTierMoveDatFromRemoteResponse)(nil), "volume_server_pb.VolumeTierMoveDatFromRemoteResponse")
+	proto.RegisterType((*VolumeScrubRequest)(nil), "volume_server_pb.VolumeScrubRequest")
+	proto.RegisterType((*VolumeScrubResponse)(nil), "volume_server_pb.VolumeScrubResponse")
+	proto.RegisterType((*VolumeScrubResult)(nil), "volume_server_pb.VolumeScrubResult")
+	proto.RegisterType((*ReadNeedleBlobRequest)(nil), "volume_server_pb.ReadNeedleBlobRequest")
+	proto.RegisterType((*ReadNeedleBlobResponse)(nil), "volume_server_pb.ReadNeedleBlobResponse")
+	proto.RegisterType((*VolumeRepairRequest)(nil), "volume_server_pb.VolumeRepairRequest")
+	proto.RegisterType((*VolumeRepairResponse)(nil), "volume_server_pb.VolumeRepairResponse")
+	proto.RegisterType((*VolumeUiPageRequest)(nil), "volume_server_pb.VolumeUiPageRequest")
+	proto.RegisterType((*VolumeUiPageResponse)(nil), "volume_server_pb.VolumeUiPageResponse")
+	proto.RegisterType((*DiskStatus)(nil), "volume_server_pb.DiskStatus")
+	proto.RegisterType((*MemStatus)(nil), "volume_server_pb.MemStatus")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for VolumeServer service
+
+type VolumeServerClient interface {
+	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
+	VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error)
+	VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error)
+	VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error)
+	VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
+	VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error)
+	VolumeSyncIndex(ctx context.Context, in *VolumeSyncIndexRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncIndexClient, error)
+	VolumeSyncData(ctx context.Context, in *VolumeSyncDataRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncDataClient, error)
+	VolumeSynchronize(ctx context.Context, in *VolumeSynchronizeRequest, opts ...grpc.CallOption) (*VolumeSynchronizeResponse, error)
+	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
+	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
+	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error)
+	VolumeMarkReadonly(ctx context.Context, in *VolumeMarkReadonlyRequest, opts ...grpc.CallOption) (*VolumeMarkReadonlyResponse, error)
+	VolumeMarkWritable(ctx context.Context, in *VolumeMarkWritableRequest, opts ...grpc.CallOption) (*VolumeMarkWritableResponse, error)
+	VolumeCopy(ctx context.Context, in *VolumeCopyRequest, opts ...grpc.CallOption) (*VolumeCopyResponse, error)
+	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (VolumeServer_CopyFileClient, error)
+	VolumeEcShardsGenerate(ctx context.Context, in *VolumeEcShardsGenerateRequest, opts ...grpc.CallOption) (*VolumeEcShardsGenerateResponse, error)
+	VolumeEcShardsRebuild(ctx context.Context, in *VolumeEcShardsRebuildRequest, opts ...grpc.CallOption) (*VolumeEcShardsRebuildResponse, error)
+	VolumeEcShardsCopy(ctx context.Context, in *VolumeEcShardsCopyRequest, opts ...grpc.CallOption) (*VolumeEcShardsCopyResponse, error)
+	VolumeEcShardsDelete(ctx context.Context, in *VolumeEcShardsDeleteRequest, opts ...grpc.CallOption) (*VolumeEcShardsDeleteResponse, error)
+	VolumeEcShardsMount(ctx context.Context, in *VolumeEcShardsMountRequest, opts ...grpc.CallOption) (*VolumeEcShardsMountResponse, error)
+	VolumeEcShardsUnmount(ctx context.Context, in *VolumeEcShardsUnmountRequest, opts ...grpc.CallOption) (*VolumeEcShardsUnmountResponse, error)
+	VolumeEcShardRead(ctx context.Context, in *VolumeEcShardReadRequest, opts ...grpc.CallOption) (VolumeServer_VolumeEcShardReadClient, error)
+	// tiered storage
+	VolumeTierMoveDatToRemote(ctx context.Context, in *VolumeTierMoveDatToRemoteRequest, opts ...grpc.CallOption) (*VolumeTierMoveDatToRemoteResponse, error)
+	VolumeTierMoveDatFromRemote(ctx context.Context, in *VolumeTierMoveDatFromRemoteRequest, opts ...grpc.CallOption) (*VolumeTierMoveDatFromRemoteResponse, error)
+	VolumeScrub(ctx context.Context, in *VolumeScrubRequest, opts ...grpc.CallOption) (*VolumeScrubResponse, error)
+	ReadNeedleBlob(ctx context.Context, in *ReadNeedleBlobRequest, opts ...grpc.CallOption) (*ReadNeedleBlobResponse, error)
+	VolumeRepair(ctx context.Context, in *VolumeRepairRequest, opts ...grpc.CallOption) (*VolumeRepairResponse, error)
+}
+
+type volumeServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewVolumeServerClient(cc *grpc.ClientConn) VolumeServerClient {
+	return &volumeServerClient{cc}
+}
+
+func (c *volumeServerClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
+	out := new(BatchDeleteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/BatchDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error) {
+	out := new(VacuumVolumeCheckResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCheck", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error) {
+	out := new(VacuumVolumeCompactResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCompact", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error) {
+	out := new(VacuumVolumeCommitResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCommit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error) {
+	out := new(VacuumVolumeCleanupResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCleanup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	out := new(DeleteCollectionResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/DeleteCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
+	out := new(AssignVolumeResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/AssignVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error) {
+	out := new(VolumeSyncStatusResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeSyncStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeSyncIndex(ctx context.Context, in *VolumeSyncIndexRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncIndexClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[0], c.cc, "/volume_server_pb.VolumeServer/VolumeSyncIndex", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerVolumeSyncIndexClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeServer_VolumeSyncIndexClient interface {
+	Recv() (*VolumeSyncIndexResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServerVolumeSyncIndexClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServerVolumeSyncIndexClient) Recv() (*VolumeSyncIndexResponse, error) {
+	m := new(VolumeSyncIndexResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *volumeServerClient) VolumeSyncData(ctx context.Context, in *VolumeSyncDataRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncDataClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[1], c.cc, "/volume_server_pb.VolumeServer/VolumeSyncData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerVolumeSyncDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeServer_VolumeSyncDataClient interface {
+	Recv() (*VolumeSyncDataResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServerVolumeSyncDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServerVolumeSyncDataClient) Recv() (*VolumeSyncDataResponse, error) {
+	m := new(VolumeSyncDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *volumeServerClient) VolumeSynchronize(ctx context.Context, in *VolumeSynchronizeRequest, opts ...grpc.CallOption) (*VolumeSynchronizeResponse, error) {
+	out := new(VolumeSynchronizeResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeSynchronize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error) {
+	out := new(VolumeMountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error) {
+	out := new(VolumeUnmountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeUnmount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error) {
+	out := new(VolumeDeleteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeMarkReadonly(ctx context.Context, in *VolumeMarkReadonlyRequest, opts ...grpc.CallOption) (*VolumeMarkReadonlyResponse, error) {
+	out := new(VolumeMarkReadonlyResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMarkReadonly", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeMarkWritable(ctx context.Context, in *VolumeMarkWritableRequest, opts ...grpc.CallOption) (*VolumeMarkWritableResponse, error) {
+	out := new(VolumeMarkWritableResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMarkWritable", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeCopy(ctx context.Context, in *VolumeCopyRequest, opts ...grpc.CallOption) (*VolumeCopyResponse, error) {
+	out := new(VolumeCopyResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeCopy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (VolumeServer_CopyFileClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[2], c.cc, "/volume_server_pb.VolumeServer/CopyFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerCopyFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeServer_CopyFileClient interface {
+	Recv() (*CopyFileResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServerCopyFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServerCopyFileClient) Recv() (*CopyFileResponse, error) {
+	m := new(CopyFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsGenerate(ctx context.Context, in *VolumeEcShardsGenerateRequest, opts ...grpc.CallOption) (*VolumeEcShardsGenerateResponse, error) {
+	out := new(VolumeEcShardsGenerateResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsGenerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsRebuild(ctx context.Context, in *VolumeEcShardsRebuildRequest, opts ...grpc.CallOption) (*VolumeEcShardsRebuildResponse, error) {
+	out := new(VolumeEcShardsRebuildResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsRebuild", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsCopy(ctx context.Context, in *VolumeEcShardsCopyRequest, opts ...grpc.CallOption) (*VolumeEcShardsCopyResponse, error) {
+	out := new(VolumeEcShardsCopyResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsCopy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsDelete(ctx context.Context, in *VolumeEcShardsDeleteRequest, opts ...grpc.CallOption) (*VolumeEcShardsDeleteResponse, error) {
+	out := new(VolumeEcShardsDeleteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	x := &volumeServerVolumeSyncIndexClient{stream}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsMount(ctx context.Context, in *VolumeEcShardsMountRequest, opts ...grpc.CallOption) (*VolumeEcShardsMountResponse, error) {
+	out := new(VolumeEcShardsMountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsMount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsUnmount(ctx context.Context, in *VolumeEcShardsUnmountRequest, opts ...grpc.CallOption) (*VolumeEcShardsUnmountResponse, error) {
+	out := new(VolumeEcShardsUnmountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsUnmount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardRead(ctx context.Context, in *VolumeEcShardReadRequest, opts ...grpc.CallOption) (VolumeServer_VolumeEcShardReadClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[3], c.cc, "/volume_server_pb.VolumeServer/VolumeEcShardRead", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerVolumeEcShardReadClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -861,341 +1940,657 @@ func (c *volumeServerClient) VolumeSyncIndex(ctx context.Context, in *VolumeSync
 	return x, nil
 }
 
-type VolumeServer_VolumeSyncIndexClient interface {
-	Recv() (*VolumeSyncIndexResponse, error)
+type VolumeServer_VolumeEcShardReadClient interface {
+	Recv() (*VolumeEcShardReadResponse, error)
 	grpc.ClientStream
 }
 
-type volumeServerVolumeSyncIndexClient struct {
+type volumeServerVolumeEcShardReadClient struct {
 	grpc.ClientStream
 }
 
-func (x *volumeServerVolumeSyncIndexClient) Recv() (*VolumeSyncIndexResponse, error) {
-	m := new(VolumeSyncIndexResponse)
+func (x *volumeServerVolumeEcShardReadClient) Recv() (*VolumeEcShardReadResponse, error) {
+	m := new(VolumeEcShardReadResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *volumeServerClient) VolumeSyncData(ctx context.Context, in *VolumeSyncDataRequest, opts ...grpc.CallOption) (VolumeServer_VolumeSyncDataClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[1], c.cc, "/volume_server_pb.VolumeServer/VolumeSyncData", opts...)
+func (c *volumeServerClient) VolumeTierMoveDatToRemote(ctx context.Context, in *VolumeTierMoveDatToRemoteRequest, opts ...grpc.CallOption) (*VolumeTierMoveDatToRemoteResponse, error) {
+	out := new(VolumeTierMoveDatToRemoteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeTierMoveDatToRemote", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &volumeServerVolumeSyncDataClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeTierMoveDatFromRemote(ctx context.Context, in *VolumeTierMoveDatFromRemoteRequest, opts ...grpc.CallOption) (*VolumeTierMoveDatFromRemoteResponse, error) {
+	out := new(VolumeTierMoveDatFromRemoteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeTierMoveDatFromRemote", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeScrub(ctx context.Context, in *VolumeScrubRequest, opts ...grpc.CallOption) (*VolumeScrubResponse, error) {
+	out := new(VolumeScrubResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeScrub", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-type VolumeServer_VolumeSyncDataClient interface {
-	Recv() (*VolumeSyncDataResponse, error)
-	grpc.ClientStream
+func (c *volumeServerClient) ReadNeedleBlob(ctx context.Context, in *ReadNeedleBlobRequest, opts ...grpc.CallOption) (*ReadNeedleBlobResponse, error) {
+	out := new(ReadNeedleBlobResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/ReadNeedleBlob", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeRepair(ctx context.Context, in *VolumeRepairRequest, opts ...grpc.CallOption) (*VolumeRepairResponse, error) {
+	out := new(VolumeRepairResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeRepair", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for VolumeServer service
+
+type VolumeServerServer interface {
+	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
+	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
+	VacuumVolumeCheck(context.Context, *VacuumVolumeCheckRequest) (*VacuumVolumeCheckResponse, error)
+	VacuumVolumeCompact(context.Context, *VacuumVolumeCompactRequest) (*VacuumVolumeCompactResponse, error)
+	VacuumVolumeCommit(context.Context, *VacuumVolumeCommitRequest) (*VacuumVolumeCommitResponse, error)
+	VacuumVolumeCleanup(context.Context, *VacuumVolumeCleanupRequest) (*VacuumVolumeCleanupResponse, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	AssignVolume(context.Context, *AssignVolumeRequest) (*AssignVolumeResponse, error)
+	VolumeSyncStatus(context.Context, *VolumeSyncStatusRequest) (*VolumeSyncStatusResponse, error)
+	VolumeSyncIndex(*VolumeSyncIndexRequest, VolumeServer_VolumeSyncIndexServer) error
+	VolumeSyncData(*VolumeSyncDataRequest, VolumeServer_VolumeSyncDataServer) error
+	VolumeSynchronize(context.Context, *VolumeSynchronizeRequest) (*VolumeSynchronizeResponse, error)
+	VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error)
+	VolumeUnmount(context.Context, *VolumeUnmountRequest) (*VolumeUnmountResponse, error)
+	VolumeDelete(context.Context, *VolumeDeleteRequest) (*VolumeDeleteResponse, error)
+	VolumeMarkReadonly(context.Context, *VolumeMarkReadonlyRequest) (*VolumeMarkReadonlyResponse, error)
+	VolumeMarkWritable(context.Context, *VolumeMarkWritableRequest) (*VolumeMarkWritableResponse, error)
+	VolumeCopy(context.Context, *VolumeCopyRequest) (*VolumeCopyResponse, error)
+	CopyFile(*CopyFileRequest, VolumeServer_CopyFileServer) error
+	VolumeEcShardsGenerate(context.Context, *VolumeEcShardsGenerateRequest) (*VolumeEcShardsGenerateResponse, error)
+	VolumeEcShardsRebuild(context.Context, *VolumeEcShardsRebuildRequest) (*VolumeEcShardsRebuildResponse, error)
+	VolumeEcShardsCopy(context.Context, *VolumeEcShardsCopyRequest) (*VolumeEcShardsCopyResponse, error)
+	VolumeEcShardsDelete(context.Context, *VolumeEcShardsDeleteRequest) (*VolumeEcShardsDeleteResponse, error)
+	VolumeEcShardsMount(context.Context, *VolumeEcShardsMountRequest) (*VolumeEcShardsMountResponse, error)
+	VolumeEcShardsUnmount(context.Context, *VolumeEcShardsUnmountRequest) (*VolumeEcShardsUnmountResponse, error)
+	VolumeEcShardRead(*VolumeEcShardReadRequest, VolumeServer_VolumeEcShardReadServer) error
+	// tiered storage
+	VolumeTierMoveDatToRemote(context.Context, *VolumeTierMoveDatToRemoteRequest) (*VolumeTierMoveDatToRemoteResponse, error)
+	VolumeTierMoveDatFromRemote(context.Context, *VolumeTierMoveDatFromRemoteRequest) (*VolumeTierMoveDatFromRemoteResponse, error)
+	VolumeScrub(context.Context, *VolumeScrubRequest) (*VolumeScrubResponse, error)
+	ReadNeedleBlob(context.Context, *ReadNeedleBlobRequest) (*ReadNeedleBlobResponse, error)
+	VolumeRepair(context.Context, *VolumeRepairRequest) (*VolumeRepairResponse, error)
+}
+
+func RegisterVolumeServerServer(s *grpc.Server, srv VolumeServerServer) {
+	s.RegisterService(&_VolumeServer_serviceDesc, srv)
+}
+
+func _VolumeServer_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/BatchDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VacuumVolumeCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumVolumeCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VacuumVolumeCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCheck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VacuumVolumeCheck(ctx, req.(*VacuumVolumeCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VacuumVolumeCompact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumVolumeCompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VacuumVolumeCompact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCompact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VacuumVolumeCompact(ctx, req.(*VacuumVolumeCompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VacuumVolumeCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumVolumeCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VacuumVolumeCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VacuumVolumeCommit(ctx, req.(*VacuumVolumeCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VacuumVolumeCleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumVolumeCleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VacuumVolumeCleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCleanup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VacuumVolumeCleanup(ctx, req.(*VacuumVolumeCleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/DeleteCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_AssignVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).AssignVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/AssignVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).AssignVolume(ctx, req.(*AssignVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeSyncStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeSyncStatus(ctx, req.(*VolumeSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeSyncIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeSyncIndexRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).VolumeSyncIndex(m, &volumeServerVolumeSyncIndexServer{stream})
+}
+
+type VolumeServer_VolumeSyncIndexServer interface {
+	Send(*VolumeSyncIndexResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerVolumeSyncIndexServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServerVolumeSyncIndexServer) Send(m *VolumeSyncIndexResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeServer_VolumeSyncData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeSyncDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).VolumeSyncData(m, &volumeServerVolumeSyncDataServer{stream})
+}
+
+type VolumeServer_VolumeSyncDataServer interface {
+	Send(*VolumeSyncDataResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerVolumeSyncDataServer struct {
+	grpc.ServerStream
 }
 
-type volumeServerVolumeSyncDataClient struct {
-	grpc.ClientStream
+func (x *volumeServerVolumeSyncDataServer) Send(m *VolumeSyncDataResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *volumeServerVolumeSyncDataClient) Recv() (*VolumeSyncDataResponse, error) {
-	m := new(VolumeSyncDataResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _VolumeServer_VolumeSynchronize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSynchronizeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeSynchronize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeSynchronize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeSynchronize(ctx, req.(*VolumeSynchronizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *volumeServerClient) VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error) {
-	out := new(VolumeMountResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMount", in, out, c.cc, opts...)
-	if err != nil {
+func _VolumeServer_VolumeMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeMount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeMount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeMount(ctx, req.(*VolumeMountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *volumeServerClient) VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error) {
-	out := new(VolumeUnmountResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeUnmount", in, out, c.cc, opts...)
-	if err != nil {
+func _VolumeServer_VolumeUnmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeUnmountRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeUnmount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeUnmount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeUnmount(ctx, req.(*VolumeUnmountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *volumeServerClient) VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error) {
-	out := new(VolumeDeleteResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeDelete", in, out, c.cc, opts...)
-	if err != nil {
+func _VolumeServer_VolumeDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeDeleteRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// Server API for VolumeServer service
-
-type VolumeServerServer interface {
-	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
-	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
-	VacuumVolumeCheck(context.Context, *VacuumVolumeCheckRequest) (*VacuumVolumeCheckResponse, error)
-	VacuumVolumeCompact(context.Context, *VacuumVolumeCompactRequest) (*VacuumVolumeCompactResponse, error)
-	VacuumVolumeCommit(context.Context, *VacuumVolumeCommitRequest) (*VacuumVolumeCommitResponse, error)
-	VacuumVolumeCleanup(context.Context, *VacuumVolumeCleanupRequest) (*VacuumVolumeCleanupResponse, error)
-	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
-	AssignVolume(context.Context, *AssignVolumeRequest) (*AssignVolumeResponse, error)
-	VolumeSyncStatus(context.Context, *VolumeSyncStatusRequest) (*VolumeSyncStatusResponse, error)
-	VolumeSyncIndex(*VolumeSyncIndexRequest, VolumeServer_VolumeSyncIndexServer) error
-	VolumeSyncData(*VolumeSyncDataRequest, VolumeServer_VolumeSyncDataServer) error
-	VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error)
-	VolumeUnmount(context.Context, *VolumeUnmountRequest) (*VolumeUnmountResponse, error)
-	VolumeDelete(context.Context, *VolumeDeleteRequest) (*VolumeDeleteResponse, error)
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeDelete(ctx, req.(*VolumeDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterVolumeServerServer(s *grpc.Server, srv VolumeServerServer) {
-	s.RegisterService(&_VolumeServer_serviceDesc, srv)
+func _VolumeServer_VolumeMarkReadonly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMarkReadonlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeMarkReadonly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeMarkReadonly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeMarkReadonly(ctx, req.(*VolumeMarkReadonlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BatchDeleteRequest)
+func _VolumeServer_VolumeMarkWritable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMarkWritableRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).BatchDelete(ctx, in)
+		return srv.(VolumeServerServer).VolumeMarkWritable(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/BatchDelete",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeMarkWritable",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+		return srv.(VolumeServerServer).VolumeMarkWritable(ctx, req.(*VolumeMarkWritableRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VacuumVolumeCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VacuumVolumeCheckRequest)
+func _VolumeServer_VolumeCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeCopyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VacuumVolumeCheck(ctx, in)
+		return srv.(VolumeServerServer).VolumeCopy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCheck",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeCopy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VacuumVolumeCheck(ctx, req.(*VacuumVolumeCheckRequest))
+		return srv.(VolumeServerServer).VolumeCopy(ctx, req.(*VolumeCopyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VacuumVolumeCompact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VacuumVolumeCompactRequest)
+func _VolumeServer_CopyFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).CopyFile(m, &volumeServerCopyFileServer{stream})
+}
+
+type VolumeServer_CopyFileServer interface {
+	Send(*CopyFileResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerCopyFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServerCopyFileServer) Send(m *CopyFileResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeServer_VolumeEcShardsGenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsGenerateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VacuumVolumeCompact(ctx, in)
+		return srv.(VolumeServerServer).VolumeEcShardsGenerate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCompact",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsGenerate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VacuumVolumeCompact(ctx, req.(*VacuumVolumeCompactRequest))
+		return srv.(VolumeServerServer).VolumeEcShardsGenerate(ctx, req.(*VolumeEcShardsGenerateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VacuumVolumeCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VacuumVolumeCommitRequest)
+func _VolumeServer_VolumeEcShardsRebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsRebuildRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VacuumVolumeCommit(ctx, in)
+		return srv.(VolumeServerServer).VolumeEcShardsRebuild(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCommit",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsRebuild",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VacuumVolumeCommit(ctx, req.(*VacuumVolumeCommitRequest))
+		return srv.(VolumeServerServer).VolumeEcShardsRebuild(ctx, req.(*VolumeEcShardsRebuildRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VacuumVolumeCleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VacuumVolumeCleanupRequest)
+func _VolumeServer_VolumeEcShardsCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsCopyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VacuumVolumeCleanup(ctx, in)
+		return srv.(VolumeServerServer).VolumeEcShardsCopy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VacuumVolumeCleanup",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsCopy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VacuumVolumeCleanup(ctx, req.(*VacuumVolumeCleanupRequest))
+		return srv.(VolumeServerServer).VolumeEcShardsCopy(ctx, req.(*VolumeEcShardsCopyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCollectionRequest)
+func _VolumeServer_VolumeEcShardsDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsDeleteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).DeleteCollection(ctx, in)
+		return srv.(VolumeServerServer).VolumeEcShardsDelete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/DeleteCollection",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsDelete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+		return srv.(VolumeServerServer).VolumeEcShardsDelete(ctx, req.(*VolumeEcShardsDeleteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_AssignVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AssignVolumeRequest)
+func _VolumeServer_VolumeEcShardsMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsMountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).AssignVolume(ctx, in)
+		return srv.(VolumeServerServer).VolumeEcShardsMount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/AssignVolume",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsMount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).AssignVolume(ctx, req.(*AssignVolumeRequest))
+		return srv.(VolumeServerServer).VolumeEcShardsMount(ctx, req.(*VolumeEcShardsMountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VolumeSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeSyncStatusRequest)
+func _VolumeServer_VolumeEcShardsUnmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsUnmountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VolumeSyncStatus(ctx, in)
+		return srv.(VolumeServerServer).VolumeEcShardsUnmount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VolumeSyncStatus",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsUnmount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VolumeSyncStatus(ctx, req.(*VolumeSyncStatusRequest))
+		return srv.(VolumeServerServer).VolumeEcShardsUnmount(ctx, req.(*VolumeEcShardsUnmountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VolumeSyncIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(VolumeSyncIndexRequest)
+func _VolumeServer_VolumeEcShardRead_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeEcShardReadRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(VolumeServerServer).VolumeSyncIndex(m, &volumeServerVolumeSyncIndexServer{stream})
+	return srv.(VolumeServerServer).VolumeEcShardRead(m, &volumeServerVolumeEcShardReadServer{stream})
 }
 
-type VolumeServer_VolumeSyncIndexServer interface {
-	Send(*VolumeSyncIndexResponse) error
+type VolumeServer_VolumeEcShardReadServer interface {
+	Send(*VolumeEcShardReadResponse) error
 	grpc.ServerStream
 }
 
-type volumeServerVolumeSyncIndexServer struct {
+type volumeServerVolumeEcShardReadServer struct {
 	grpc.ServerStream
 }
 
-func (x *volumeServerVolumeSyncIndexServer) Send(m *VolumeSyncIndexResponse) error {
+func (x *volumeServerVolumeEcShardReadServer) Send(m *VolumeEcShardReadResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _VolumeServer_VolumeSyncData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(VolumeSyncDataRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _VolumeServer_VolumeTierMoveDatToRemote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeTierMoveDatToRemoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(VolumeServerServer).VolumeSyncData(m, &volumeServerVolumeSyncDataServer{stream})
-}
-
-type VolumeServer_VolumeSyncDataServer interface {
-	Send(*VolumeSyncDataResponse) error
-	grpc.ServerStream
-}
-
-type volumeServerVolumeSyncDataServer struct {
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeTierMoveDatToRemote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeTierMoveDatToRemote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeTierMoveDatToRemote(ctx, req.(*VolumeTierMoveDatToRemoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *volumeServerVolumeSyncDataServer) Send(m *VolumeSyncDataResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _VolumeServer_VolumeTierMoveDatFromRemote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeTierMoveDatFromRemoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeTierMoveDatFromRemote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeTierMoveDatFromRemote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeTierMoveDatFromRemote(ctx, req.(*VolumeTierMoveDatFromRemoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VolumeMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeMountRequest)
+func _VolumeServer_VolumeScrub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeScrubRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VolumeMount(ctx, in)
+		return srv.(VolumeServerServer).VolumeScrub(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VolumeMount",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeScrub",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VolumeMount(ctx, req.(*VolumeMountRequest))
+		return srv.(VolumeServerServer).VolumeScrub(ctx, req.(*VolumeScrubRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VolumeUnmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeUnmountRequest)
+func _VolumeServer_ReadNeedleBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadNeedleBlobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VolumeUnmount(ctx, in)
+		return srv.(VolumeServerServer).ReadNeedleBlob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VolumeUnmount",
+		FullMethod: "/volume_server_pb.VolumeServer/ReadNeedleBlob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VolumeUnmount(ctx, req.(*VolumeUnmountRequest))
+		return srv.(VolumeServerServer).ReadNeedleBlob(ctx, req.(*ReadNeedleBlobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VolumeServer_VolumeDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeDeleteRequest)
+func _VolumeServer_VolumeRepair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeRepairRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VolumeServerServer).VolumeDelete(ctx, in)
+		return srv.(VolumeServerServer).VolumeRepair(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/volume_server_pb.VolumeServer/VolumeDelete",
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeRepair",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VolumeServerServer).VolumeDelete(ctx, req.(*VolumeDeleteRequest))
+		return srv.(VolumeServerServer).VolumeRepair(ctx, req.(*VolumeRepairRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1236,6 +2631,10 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeSyncStatus",
 			Handler:    _VolumeServer_VolumeSyncStatus_Handler,
 		},
+		{
+			MethodName: "VolumeSynchronize",
+			Handler:    _VolumeServer_VolumeSynchronize_Handler,
+		},
 		{
 			MethodName: "VolumeMount",
 			Handler:    _VolumeServer_VolumeMount_Handler,
@@ -1248,6 +2647,62 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeDelete",
 			Handler:    _VolumeServer_VolumeDelete_Handler,
 		},
+		{
+			MethodName: "VolumeMarkReadonly",
+			Handler:    _VolumeServer_VolumeMarkReadonly_Handler,
+		},
+		{
+			MethodName: "VolumeMarkWritable",
+			Handler:    _VolumeServer_VolumeMarkWritable_Handler,
+		},
+		{
+			MethodName: "VolumeCopy",
+			Handler:    _VolumeServer_VolumeCopy_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsGenerate",
+			Handler:    _VolumeServer_VolumeEcShardsGenerate_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsRebuild",
+			Handler:    _VolumeServer_VolumeEcShardsRebuild_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsCopy",
+			Handler:    _VolumeServer_VolumeEcShardsCopy_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsDelete",
+			Handler:    _VolumeServer_VolumeEcShardsDelete_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsMount",
+			Handler:    _VolumeServer_VolumeEcShardsMount_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsUnmount",
+			Handler:    _VolumeServer_VolumeEcShardsUnmount_Handler,
+		},
+		{
+			MethodName: "VolumeTierMoveDatToRemote",
+			Handler:    _VolumeServer_VolumeTierMoveDatToRemote_Handler,
+		},
+		{
+			MethodName: "VolumeTierMoveDatFromRemote",
+			Handler:    _VolumeServer_VolumeTierMoveDatFromRemote_Handler,
+		},
+		{
+			MethodName: "VolumeScrub",
+			Handler:    _VolumeServer_VolumeScrub_Handler,
+		},
+		{
+			MethodName: "ReadNeedleBlob",
+			Handler:    _VolumeServer_ReadNeedleBlob_Handler,
+		},
+		{
+			MethodName: "VolumeRepair",
+			Handler:    _VolumeServer_VolumeRepair_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1260,6 +2715,16 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _VolumeServer_VolumeSyncData_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CopyFile",
+			Handler:       _VolumeServer_CopyFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "VolumeEcShardRead",
+			Handler:       _VolumeServer_VolumeEcShardRead_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "volume_server.proto",
 }
@@ -1267,71 +2732,134 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("volume_server.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1044 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0x8e, 0x6a, 0x3b, 0x76, 0x8e, 0x6d, 0x6a, 0xd6, 0x69, 0xa2, 0xaa, 0x10, 0x8c, 0x80, 0xd4,
-	0x69, 0x43, 0x80, 0x74, 0x80, 0x32, 0xdc, 0x00, 0x09, 0x30, 0xb9, 0xe8, 0x94, 0xd9, 0x4c, 0x3b,
-	0xcc, 0xd0, 0x19, 0x8f, 0x22, 0xad, 0x9d, 0x25, 0xb2, 0xe4, 0x6a, 0x57, 0x99, 0x94, 0x37, 0xe1,
-	0x9a, 0x1b, 0x9e, 0x8e, 0x17, 0xe0, 0x86, 0xd9, 0x1f, 0xd9, 0xfa, 0x73, 0x24, 0xe0, 0x6e, 0xf7,
-	0xec, 0x39, 0xdf, 0xf9, 0xd9, 0xa3, 0xf3, 0xad, 0x60, 0x78, 0x1d, 0xfa, 0xf1, 0x9c, 0x4c, 0x18,
-	0x89, 0xae, 0x49, 0x74, 0xb4, 0x88, 0x42, 0x1e, 0xa2, 0x41, 0x46, 0x38, 0x59, 0x5c, 0xd8, 0x9f,
-	0x00, 0xfa, 0xce, 0xe1, 0xee, 0xe5, 0x29, 0xf1, 0x09, 0x27, 0x98, 0xbc, 0x8e, 0x09, 0xe3, 0xe8,
-	0x3e, 0x74, 0xa6, 0xd4, 0x27, 0x13, 0xea, 0x31, 0xd3, 0x18, 0x35, 0xc6, 0x5b, 0xb8, 0x2d, 0xf6,
-	0x67, 0x1e, 0xb3, 0x9f, 0xc3, 0x30, 0x63, 0xc0, 0x16, 0x61, 0xc0, 0x08, 0x7a, 0x0a, 0xed, 0x88,
-	0xb0, 0xd8, 0xe7, 0xca, 0xa0, 0x7b, 0xbc, 0x77, 0x94, 0xf7, 0x75, 0xb4, 0x34, 0x89, 0x7d, 0x8e,
-	0x13, 0x75, 0x9b, 0x42, 0x2f, 0x7d, 0x80, 0x76, 0xa1, 0xad, 0x7d, 0x9b, 0xc6, 0xc8, 0x18, 0x6f,
-	0xe1, 0x4d, 0xe5, 0x1a, 0xed, 0xc0, 0x26, 0xe3, 0x0e, 0x8f, 0x99, 0x79, 0x67, 0x64, 0x8c, 0x5b,
-	0x58, 0xef, 0xd0, 0x36, 0xb4, 0x48, 0x14, 0x85, 0x91, 0xd9, 0x90, 0xea, 0x6a, 0x83, 0x10, 0x34,
-	0x19, 0xfd, 0x8d, 0x98, 0xcd, 0x91, 0x31, 0xee, 0x63, 0xb9, 0xb6, 0xdb, 0xd0, 0xfa, 0x7e, 0xbe,
-	0xe0, 0x6f, 0xec, 0x2f, 0xc1, 0x7c, 0xe9, 0xb8, 0x71, 0x3c, 0x7f, 0x29, 0x63, 0x3c, 0xb9, 0x24,
-	0xee, 0x55, 0x92, 0xfb, 0x03, 0xd8, 0x92, 0x91, 0x7b, 0x49, 0x04, 0x7d, 0xdc, 0x51, 0x82, 0x33,
-	0xcf, 0xfe, 0x06, 0xee, 0x97, 0x18, 0xea, 0x1a, 0x7c, 0x00, 0xfd, 0x99, 0x13, 0x5d, 0x38, 0x33,
-	0x32, 0x89, 0x1c, 0x4e, 0x43, 0x69, 0x6d, 0xe0, 0x9e, 0x16, 0x62, 0x21, 0xb3, 0x7f, 0x01, 0x2b,
-	0x83, 0x10, 0xce, 0x17, 0x8e, 0xcb, 0xeb, 0x38, 0x47, 0x23, 0xe8, 0x2e, 0x22, 0xe2, 0xf8, 0x7e,
-	0xe8, 0x3a, 0x9c, 0xc8, 0x2a, 0x34, 0x70, 0x5a, 0x64, 0xbf, 0x0b, 0x0f, 0x4a, 0xc1, 0x55, 0x80,
-	0xf6, 0xd3, 0x5c, 0xf4, 0xe1, 0x7c, 0x4e, 0x6b, 0xb9, 0xb6, 0xdf, 0x29, 0x44, 0x2d, 0x2d, 0x35,
-	0xee, 0x57, 0xb9, 0x53, 0x9f, 0x38, 0x41, 0xbc, 0xa8, 0x05, 0x9c, 0x8f, 0x38, 0x31, 0x5d, 0x22,
-	0xef, 0xaa, 0xe6, 0x38, 0x09, 0x7d, 0x9f, 0xb8, 0x9c, 0x86, 0x41, 0x02, 0xbb, 0x07, 0xe0, 0x2e,
-	0x85, 0xba, 0x55, 0x52, 0x12, 0xdb, 0x02, 0xb3, 0x68, 0xaa, 0x61, 0xff, 0x34, 0x60, 0xf8, 0x2d,
-	0x63, 0x74, 0x16, 0x28, 0xb7, 0xb5, 0xca, 0x9f, 0x75, 0x78, 0x27, 0xef, 0x30, 0x7f, 0x3d, 0x8d,
-	0xc2, 0xf5, 0x08, 0x8d, 0x88, 0x2c, 0x7c, 0xea, 0x3a, 0x12, 0xa2, 0x29, 0x21, 0xd2, 0x22, 0x34,
-	0x80, 0x06, 0xe7, 0xbe, 0xd9, 0x92, 0x27, 0x62, 0x69, 0xef, 0xc0, 0x76, 0x36, 0x52, 0x9d, 0xc2,
-	0x17, 0xb0, 0xab, 0x24, 0xe7, 0x6f, 0x02, 0xf7, 0x5c, 0x7e, 0x09, 0xb5, 0x0a, 0xfe, 0xb7, 0x01,
-	0x66, 0xd1, 0x50, 0x77, 0xf0, 0xff, 0xcd, 0xff, 0xdf, 0x66, 0x87, 0xde, 0x83, 0x2e, 0x77, 0xa8,
-	0x3f, 0x09, 0xa7, 0x53, 0x46, 0xb8, 0xb9, 0x39, 0x32, 0xc6, 0x4d, 0x0c, 0x42, 0xf4, 0x5c, 0x4a,
-	0xd0, 0x01, 0x0c, 0x5c, 0xd5, 0xc5, 0x93, 0x88, 0x5c, 0x53, 0x26, 0x90, 0xdb, 0x32, 0xb0, 0xbb,
-	0x6e, 0xd2, 0xdd, 0x4a, 0x8c, 0x6c, 0xe8, 0x53, 0xef, 0x66, 0x22, 0x87, 0x87, 0xfc, 0xf4, 0x3b,
-	0x12, 0xad, 0x4b, 0xbd, 0x9b, 0x1f, 0xa8, 0x4f, 0xce, 0xc5, 0x04, 0xf8, 0x1c, 0x76, 0x56, 0xc9,
-	0x9f, 0x05, 0x1e, 0xb9, 0xa9, 0x55, 0xb4, 0x1f, 0xd3, 0xc5, 0xd6, 0x66, 0xba, 0x64, 0x87, 0x80,
-	0xa8, 0x10, 0x28, 0xbf, 0x6e, 0x18, 0x70, 0x12, 0x70, 0x09, 0xd0, 0xc3, 0x03, 0x79, 0x22, 0x9c,
-	0x9f, 0x28, 0xb9, 0xfd, 0xbb, 0x01, 0xf7, 0x56, 0x48, 0xa7, 0x0e, 0x77, 0x6a, 0xb5, 0x9e, 0x05,
-	0x9d, 0x65, 0xf6, 0x77, 0xd4, 0x59, 0xb2, 0x17, 0x63, 0x51, 0x57, 0xaf, 0x21, 0x4f, 0xf4, 0xae,
-	0x6c, 0x00, 0x0a, 0x27, 0x01, 0x21, 0x9e, 0x9a, 0xae, 0xea, 0x1a, 0x3a, 0x4a, 0x70, 0xe6, 0xd9,
-	0x5f, 0xa7, 0x6b, 0xa3, 0x42, 0xd3, 0x39, 0xbe, 0x0f, 0xbd, 0x92, 0xec, 0xba, 0xd3, 0x54, 0x62,
-	0x9f, 0x01, 0x52, 0xc6, 0xcf, 0xc2, 0x38, 0xa8, 0x37, 0x53, 0xee, 0xc1, 0x30, 0x63, 0xa2, 0x1b,
-	0xfb, 0x09, 0x6c, 0x2b, 0xf1, 0x8b, 0x60, 0x5e, 0x1b, 0x6b, 0x37, 0x29, 0xeb, 0xd2, 0x48, 0xa3,
-	0x1d, 0x27, 0x4e, 0xb2, 0x04, 0x77, 0x2b, 0xd8, 0x4e, 0x12, 0x41, 0x96, 0xe3, 0x56, 0x01, 0xbf,
-	0xa0, 0x3f, 0x89, 0x79, 0xae, 0xb0, 0x56, 0xea, 0x89, 0x58, 0xab, 0xff, 0x0c, 0x70, 0x4a, 0xd9,
-	0x95, 0xfa, 0xc4, 0x44, 0xef, 0x7b, 0x34, 0xd2, 0x73, 0x4a, 0x2c, 0x85, 0xc4, 0xf1, 0x7d, 0x79,
-	0x9f, 0x4d, 0x2c, 0x96, 0xe2, 0xca, 0x62, 0x46, 0x3c, 0x79, 0x91, 0x4d, 0x2c, 0xd7, 0x42, 0x36,
-	0x8d, 0x88, 0xba, 0xc6, 0x26, 0x96, 0x6b, 0xfb, 0x0f, 0x03, 0xb6, 0x9e, 0x91, 0xb9, 0x46, 0xde,
-	0x03, 0x98, 0x85, 0x51, 0x18, 0x73, 0x1a, 0x10, 0x26, 0x1d, 0xb4, 0x70, 0x4a, 0xf2, 0xdf, 0xfd,
-	0xc8, 0x16, 0x22, 0xfe, 0x54, 0x76, 0x4a, 0x13, 0xcb, 0xb5, 0x90, 0x5d, 0x12, 0x67, 0xa1, 0x3f,
-	0x55, 0xb9, 0x16, 0x0c, 0xcc, 0xb8, 0xe3, 0x5e, 0xc9, 0x2f, 0xb3, 0x89, 0xd5, 0xe6, 0xf8, 0x2f,
-	0x80, 0x9e, 0x6e, 0x28, 0xf9, 0x04, 0x40, 0xaf, 0xa0, 0x9b, 0x7a, 0x3a, 0xa0, 0x0f, 0x8b, 0x2f,
-	0x84, 0xe2, 0x53, 0xc4, 0xfa, 0xa8, 0x42, 0x4b, 0x17, 0x7b, 0x03, 0x05, 0xf0, 0x76, 0x81, 0x9a,
-	0xd1, 0xa3, 0xa2, 0xf5, 0x3a, 0xe2, 0xb7, 0x1e, 0xd7, 0xd2, 0x5d, 0xfa, 0xe3, 0x30, 0x2c, 0xe1,
-	0x5a, 0x74, 0x58, 0x81, 0x92, 0xe1, 0x7b, 0xeb, 0xe3, 0x9a, 0xda, 0x4b, 0xaf, 0xaf, 0x01, 0x15,
-	0x89, 0x18, 0x3d, 0xae, 0x84, 0x59, 0x11, 0xbd, 0x75, 0x58, 0x4f, 0x79, 0x6d, 0xa2, 0x8a, 0xa2,
-	0x2b, 0x13, 0xcd, 0x3c, 0x02, 0x2a, 0x13, 0xcd, 0xf1, 0xfe, 0x06, 0xba, 0x82, 0x41, 0x9e, 0xbe,
-	0xd1, 0xc1, 0xba, 0x37, 0x65, 0xe1, 0x75, 0x60, 0x3d, 0xaa, 0xa3, 0xba, 0x74, 0x36, 0x81, 0x5e,
-	0x9a, 0x64, 0x51, 0x49, 0xd3, 0x95, 0x3c, 0x17, 0xac, 0xfd, 0x2a, 0xb5, 0x74, 0x36, 0x79, 0xd2,
-	0x2d, 0xcb, 0x66, 0x0d, 0xa3, 0x97, 0x65, 0xb3, 0x8e, 0xc3, 0xed, 0x0d, 0xf4, 0x2b, 0xdc, 0xcd,
-	0xb1, 0x15, 0x1a, 0xdf, 0x06, 0x90, 0xe6, 0x41, 0xeb, 0xa0, 0x86, 0x66, 0xe2, 0xe9, 0x53, 0x03,
-	0xcd, 0xe0, 0xad, 0x2c, 0x69, 0xa0, 0x87, 0xb7, 0x01, 0xa4, 0x18, 0xcf, 0x1a, 0x57, 0x2b, 0xa6,
-	0x1c, 0xbd, 0x82, 0x6e, 0x8a, 0x2d, 0xca, 0x86, 0x47, 0x91, 0x7f, 0xca, 0x86, 0x47, 0x19, 0xe5,
-	0x6c, 0xa0, 0x0b, 0xe8, 0x67, 0xf8, 0x03, 0xed, 0xaf, 0xb3, 0xcc, 0xb2, 0x92, 0xf5, 0xb0, 0x52,
-	0x2f, 0xdd, 0x64, 0x69, 0x5a, 0x41, 0x6b, 0x83, 0xcb, 0x0e, 0xc0, 0xfd, 0x2a, 0xb5, 0xc4, 0xc1,
-	0xc5, 0xa6, 0xfc, 0xc9, 0x7b, 0xf2, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xdb, 0x3c, 0x6d, 0xd7,
-	0xfb, 0x0d, 0x00, 0x00,
+	// 2053 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x59, 0x6f, 0xdc, 0xc8,
+	0x11, 0x0e, 0x35, 0x3a, 0x46, 0x35, 0x92, 0x25, 0xb5, 0xae, 0x11, 0x65, 0xc9, 0x63, 0xfa, 0xd8,
+	0xb1, 0x2c, 0x4b, 0x8e, 0x1d, 0x27, 0x1b, 0x04, 0x01, 0xb2, 0x6b, 0x7b, 0x17, 0x46, 0x60, 0x7b,
+	0x43, 0xd9, 0x9b, 0x63, 0x0d, 0x10, 0x1c, 0xb2, 0x25, 0x31, 0xe2, 0x90, 0xb3, 0x64, 0x8f, 0x6c,
+	0x19, 0x48, 0x1e, 0xf3, 0x94, 0xd7, 0x00, 0xc9, 0x73, 0x5e, 0x02, 0xe4, 0x67, 0xe4, 0x97, 0xe4,
+	0x97, 0x24, 0xe8, 0x83, 0x47, 0xf3, 0x18, 0xb6, 0xd6, 0x7a, 0x1b, 0x56, 0x57, 0xd5, 0x57, 0x5d,
+	0x5d, 0x5d, 0xdd, 0xfd, 0x61, 0x60, 0xf5, 0x3c, 0xf4, 0xc7, 0x43, 0x6c, 0xc5, 0x38, 0x3a, 0xc7,
+	0xd1, 0xc1, 0x28, 0x0a, 0x49, 0x88, 0x96, 0x25, 0xa1, 0x35, 0x1a, 0x18, 0x87, 0x80, 0xbe, 0xb4,
+	0x89, 0x73, 0xfa, 0x0c, 0xfb, 0x98, 0x60, 0x13, 0x7f, 0x3f, 0xc6, 0x31, 0x41, 0x5b, 0xd0, 0x3e,
+	0xf6, 0x7c, 0x6c, 0x79, 0x6e, 0xdc, 0xd5, 0x7a, 0xad, 0xfe, 0xbc, 0x39, 0x47, 0xbf, 0x5f, 0xb8,
+	0xb1, 0xf1, 0x1a, 0x56, 0x25, 0x83, 0x78, 0x14, 0x06, 0x31, 0x46, 0x9f, 0xc3, 0x5c, 0x84, 0xe3,
+	0xb1, 0x4f, 0xb8, 0x41, 0xe7, 0xd1, 0xee, 0x41, 0x11, 0xeb, 0x20, 0x35, 0x19, 0xfb, 0xc4, 0x4c,
+	0xd4, 0x0d, 0x0f, 0x16, 0xf2, 0x03, 0x68, 0x13, 0xe6, 0x04, 0x76, 0x57, 0xeb, 0x69, 0xfd, 0x79,
+	0x73, 0x96, 0x43, 0xa3, 0x0d, 0x98, 0x8d, 0x89, 0x4d, 0xc6, 0x71, 0x77, 0xaa, 0xa7, 0xf5, 0x67,
+	0x4c, 0xf1, 0x85, 0xd6, 0x60, 0x06, 0x47, 0x51, 0x18, 0x75, 0x5b, 0x4c, 0x9d, 0x7f, 0x20, 0x04,
+	0xd3, 0xb1, 0xf7, 0x11, 0x77, 0xa7, 0x7b, 0x5a, 0x7f, 0xd1, 0x64, 0xbf, 0x8d, 0x39, 0x98, 0x79,
+	0x3e, 0x1c, 0x91, 0x0b, 0xe3, 0x67, 0xd0, 0xfd, 0xd6, 0x76, 0xc6, 0xe3, 0xe1, 0xb7, 0x2c, 0xc6,
+	0xa7, 0xa7, 0xd8, 0x39, 0x4b, 0xe6, 0xbe, 0x0d, 0xf3, 0x2c, 0x72, 0x37, 0x89, 0x60, 0xd1, 0x6c,
+	0x73, 0xc1, 0x0b, 0xd7, 0xf8, 0x15, 0x6c, 0x55, 0x18, 0x8a, 0x1c, 0xdc, 0x82, 0xc5, 0x13, 0x3b,
+	0x1a, 0xd8, 0x27, 0xd8, 0x8a, 0x6c, 0xe2, 0x85, 0xcc, 0x5a, 0x33, 0x17, 0x84, 0xd0, 0xa4, 0x32,
+	0xe3, 0x3b, 0xd0, 0x25, 0x0f, 0xe1, 0x70, 0x64, 0x3b, 0x44, 0x05, 0x1c, 0xf5, 0xa0, 0x33, 0x8a,
+	0xb0, 0xed, 0xfb, 0xa1, 0x63, 0x13, 0xcc, 0xb2, 0xd0, 0x32, 0xf3, 0x22, 0x63, 0x07, 0xb6, 0x2b,
+	0x9d, 0xf3, 0x00, 0x8d, 0xcf, 0x0b, 0xd1, 0x87, 0xc3, 0xa1, 0xa7, 0x04, 0x6d, 0x5c, 0x07, 0xbd,
+	0xca, 0x52, 0xf8, 0xfd, 0x79, 0x61, 0xd4, 0xc7, 0x76, 0x30, 0x1e, 0x29, 0x39, 0x2e, 0x46, 0x9c,
+	0x98, 0xa6, 0x9e, 0x37, 0x79, 0x71, 0x3c, 0x0d, 0x7d, 0x1f, 0x3b, 0xc4, 0x0b, 0x83, 0xc4, 0xed,
+	0x2e, 0x80, 0x93, 0x0a, 0x45, 0xa9, 0xe4, 0x24, 0x86, 0x0e, 0xdd, 0xb2, 0xa9, 0x70, 0xfb, 0x2f,
+	0x0d, 0x56, 0xbf, 0x88, 0x63, 0xef, 0x24, 0xe0, 0xb0, 0x4a, 0xe9, 0x97, 0x01, 0xa7, 0x8a, 0x80,
+	0xc5, 0xe5, 0x69, 0x95, 0x96, 0x87, 0x6a, 0x44, 0x78, 0xe4, 0x7b, 0x8e, 0xcd, 0x5c, 0x4c, 0x33,
+	0x17, 0x79, 0x11, 0x5a, 0x86, 0x16, 0x21, 0x7e, 0x77, 0x86, 0x8d, 0xd0, 0x9f, 0xc6, 0x06, 0xac,
+	0xc9, 0x91, 0x8a, 0x29, 0xfc, 0x14, 0x36, 0xb9, 0xe4, 0xe8, 0x22, 0x70, 0x8e, 0xd8, 0x4e, 0x50,
+	0x4a, 0xf8, 0xdf, 0xa7, 0xa0, 0x5b, 0x36, 0x14, 0x15, 0xfc, 0xa9, 0xf3, 0xbf, 0xec, 0xec, 0xd0,
+	0x0d, 0xe8, 0x10, 0xdb, 0xf3, 0xad, 0xf0, 0xf8, 0x38, 0xc6, 0xa4, 0x3b, 0xdb, 0xd3, 0xfa, 0xd3,
+	0x26, 0x50, 0xd1, 0x6b, 0x26, 0x41, 0xf7, 0x60, 0xd9, 0xe1, 0x55, 0x6c, 0x45, 0xf8, 0xdc, 0x8b,
+	0xa9, 0xe7, 0x39, 0x16, 0xd8, 0x92, 0x93, 0x54, 0x37, 0x17, 0x23, 0x03, 0x16, 0x3d, 0xf7, 0x83,
+	0xc5, 0x9a, 0x07, 0xdb, 0xfa, 0x6d, 0xe6, 0xad, 0xe3, 0xb9, 0x1f, 0xbe, 0xf2, 0x7c, 0x7c, 0xe4,
+	0x7d, 0x64, 0x13, 0x8c, 0xb0, 0xed, 0x5a, 0x61, 0xe0, 0x5f, 0x74, 0xe7, 0x7b, 0x5a, 0xbf, 0x6d,
+	0xb6, 0xa9, 0xe0, 0x75, 0xe0, 0x5f, 0x18, 0x4f, 0x60, 0x23, 0xcb, 0xcc, 0x8b, 0xc0, 0xc5, 0x1f,
+	0x94, 0x32, 0xfa, 0x35, 0x6c, 0x96, 0xcc, 0x44, 0x3e, 0xf7, 0x01, 0x79, 0x54, 0xc0, 0x83, 0x72,
+	0xc2, 0x80, 0xe0, 0x80, 0x30, 0x07, 0x0b, 0xe6, 0x32, 0x1b, 0xa1, 0x91, 0x3d, 0xe5, 0x72, 0xe3,
+	0x1f, 0x1a, 0xac, 0x67, 0x9e, 0x9e, 0xd9, 0xc4, 0x56, 0xaa, 0x4b, 0x1d, 0xda, 0x69, 0x6a, 0xa6,
+	0xf8, 0x58, 0xf2, 0x4d, 0x7b, 0xa6, 0x48, 0x6d, 0x8b, 0x8d, 0x88, 0xaf, 0xaa, 0xee, 0x48, 0x41,
+	0x02, 0x8c, 0x5d, 0xde, 0x7a, 0xf9, 0x1a, 0xb5, 0xb9, 0xe0, 0x85, 0x6b, 0xfc, 0x02, 0x36, 0x8a,
+	0xa1, 0x89, 0x39, 0xde, 0x84, 0x85, 0x8a, 0xd9, 0x75, 0x8e, 0x73, 0x13, 0xb3, 0xf3, 0x25, 0x77,
+	0x1a, 0x85, 0x81, 0xf7, 0x51, 0x6d, 0xcb, 0xf5, 0x61, 0x39, 0x0e, 0xc7, 0x91, 0x83, 0x2d, 0xd7,
+	0x26, 0xb6, 0x15, 0x84, 0x2e, 0x16, 0x85, 0x77, 0x8d, 0xcb, 0x69, 0x24, 0xaf, 0x42, 0x17, 0x1b,
+	0xdb, 0xb0, 0x55, 0x01, 0x21, 0xf6, 0xca, 0x8f, 0x01, 0xf1, 0xc1, 0x97, 0xe1, 0x38, 0x50, 0x6b,
+	0x78, 0xeb, 0xb0, 0x2a, 0x99, 0x08, 0x4f, 0x8f, 0x61, 0x8d, 0x8b, 0xdf, 0x06, 0x43, 0x65, 0x5f,
+	0x9b, 0xb0, 0x5e, 0x30, 0x12, 0xde, 0x1e, 0x25, 0x20, 0xf2, 0xe9, 0x3b, 0xd1, 0xd9, 0x06, 0xac,
+	0xc9, 0x36, 0xb9, 0xde, 0xce, 0x03, 0xb6, 0xa3, 0x33, 0x13, 0xdb, 0x2e, 0x2d, 0x71, 0xe5, 0xde,
+	0x5e, 0x61, 0x59, 0xe5, 0xf7, 0xb7, 0x91, 0x47, 0xec, 0x81, 0x8f, 0x2f, 0xef, 0x37, 0xb3, 0x14,
+	0x7e, 0xff, 0x00, 0x2b, 0xc9, 0x59, 0x32, 0xba, 0xb8, 0xe2, 0x62, 0x58, 0x03, 0x94, 0xf7, 0x2d,
+	0x10, 0xff, 0xa9, 0xc1, 0x12, 0x15, 0xd0, 0x2d, 0xa7, 0x04, 0xb8, 0x0c, 0x2d, 0xfc, 0x81, 0x08,
+	0x0c, 0xfa, 0xb3, 0xd0, 0x02, 0x5b, 0x15, 0x2d, 0x70, 0xc1, 0x8b, 0x2d, 0xec, 0x58, 0xcc, 0x07,
+	0xdf, 0x5e, 0x6d, 0x13, 0xbc, 0xf8, 0xb9, 0xc3, 0x03, 0xa2, 0x0d, 0x2f, 0x26, 0xe1, 0x28, 0x69,
+	0x78, 0x33, 0xbc, 0xe1, 0x51, 0x11, 0x6f, 0x78, 0xc6, 0x13, 0x58, 0xce, 0x82, 0x54, 0xdf, 0x62,
+	0xef, 0x60, 0x87, 0x23, 0x3c, 0x77, 0x8e, 0x4e, 0xed, 0xc8, 0x8d, 0xbf, 0xc6, 0x01, 0x8e, 0x6c,
+	0x72, 0x25, 0x47, 0x9b, 0xd1, 0x83, 0xdd, 0x3a, 0xef, 0x22, 0xb9, 0xdf, 0xc1, 0x75, 0x59, 0xc3,
+	0xc4, 0x83, 0xb1, 0xe7, 0xbb, 0x57, 0x02, 0xff, 0x6b, 0xd8, 0xa9, 0x71, 0x2e, 0x12, 0xb4, 0x07,
+	0x2b, 0x11, 0x13, 0x11, 0x2b, 0xa6, 0x0a, 0xe9, 0xc5, 0x75, 0xd1, 0x5c, 0x12, 0x03, 0xcc, 0x90,
+	0x5e, 0x60, 0xff, 0xa3, 0xc1, 0x96, 0xec, 0x4d, 0xb9, 0x02, 0x9b, 0x4e, 0xc0, 0x6d, 0x98, 0xcf,
+	0xe0, 0x5b, 0x0c, 0xbe, 0x1d, 0x0b, 0x5c, 0x7a, 0x3c, 0x39, 0xe1, 0xe8, 0xc2, 0xc2, 0x0e, 0x3f,
+	0x0e, 0x44, 0x71, 0x74, 0xa8, 0xf0, 0xb9, 0xc3, 0x0e, 0x82, 0xca, 0x12, 0x9f, 0xa9, 0x2c, 0xf1,
+	0x74, 0x73, 0xc9, 0x93, 0x10, 0xab, 0xf1, 0x1e, 0xb6, 0xe5, 0x51, 0xf5, 0x06, 0xf3, 0x49, 0x93,
+	0x34, 0x76, 0xe1, 0x7a, 0x35, 0xb0, 0x08, 0xec, 0xbc, 0x18, 0xb6, 0x72, 0x47, 0xfe, 0xb4, 0xb8,
+	0x76, 0x60, 0xbb, 0x12, 0x57, 0x84, 0xf5, 0xbb, 0x62, 0xd8, 0x97, 0x68, 0xef, 0x93, 0x81, 0x6f,
+	0xc0, 0x4e, 0x8d, 0x67, 0x01, 0xfd, 0x67, 0xe8, 0x4a, 0x0a, 0xb4, 0x01, 0x2b, 0xc1, 0x6e, 0x41,
+	0x3b, 0x81, 0x15, 0xc7, 0xfe, 0x9c, 0x40, 0x2d, 0x9c, 0xfa, 0xad, 0xca, 0x53, 0xbf, 0x25, 0xde,
+	0x44, 0x87, 0xb0, 0x55, 0x81, 0x2f, 0xf6, 0x15, 0x82, 0x69, 0x5a, 0x88, 0xa2, 0xe1, 0xb0, 0xdf,
+	0xc6, 0xbf, 0x35, 0xe8, 0x71, 0x8b, 0x37, 0x1e, 0x8e, 0x5e, 0x86, 0xe7, 0xb4, 0x28, 0xdf, 0x84,
+	0x26, 0x1e, 0x86, 0x57, 0x54, 0x61, 0x3a, 0xb4, 0x71, 0xe0, 0x8e, 0x42, 0x2f, 0x20, 0xa2, 0xc7,
+	0xa6, 0xdf, 0x74, 0x6a, 0x11, 0x3e, 0xc9, 0xee, 0x97, 0xe2, 0x8b, 0xca, 0x07, 0x63, 0xe7, 0x4c,
+	0xb4, 0xd4, 0x79, 0x53, 0x7c, 0x19, 0xb7, 0xe0, 0xe6, 0x84, 0x60, 0xc5, 0x1a, 0xd8, 0x60, 0x94,
+	0x94, 0xbe, 0x8a, 0xc2, 0xe1, 0xd5, 0xcd, 0xc9, 0xb8, 0x03, 0xb7, 0x26, 0x42, 0x88, 0x48, 0xbe,
+	0x49, 0x4e, 0xae, 0x23, 0x27, 0x1a, 0x0f, 0x12, 0xe4, 0x1d, 0x00, 0xf1, 0x98, 0xce, 0xfa, 0x1a,
+	0x8f, 0x85, 0x3e, 0xc9, 0x59, 0x01, 0x52, 0x75, 0x2b, 0x08, 0xdf, 0x33, 0xe8, 0xb6, 0xd9, 0x66,
+	0x82, 0x57, 0xe1, 0x7b, 0xe3, 0x0d, 0xac, 0x4a, 0x1e, 0xc5, 0xca, 0xfe, 0xb2, 0xf8, 0x5e, 0xbf,
+	0x55, 0x7e, 0xaf, 0xcb, 0x76, 0xd2, 0xa3, 0xfd, 0xbf, 0x1a, 0xac, 0x94, 0x86, 0xd3, 0x0c, 0xe1,
+	0x62, 0x86, 0xb0, 0xc2, 0xaa, 0xdf, 0x86, 0x6b, 0x2c, 0xe8, 0x01, 0x76, 0x2d, 0x9b, 0x58, 0x41,
+	0x2c, 0x8a, 0x77, 0x21, 0x91, 0x7e, 0x41, 0x5e, 0xc5, 0xf4, 0x28, 0x14, 0x97, 0x54, 0x87, 0x6e,
+	0x23, 0x56, 0x05, 0xd3, 0x66, 0x87, 0xcb, 0x9e, 0x52, 0x11, 0x7a, 0x08, 0x6b, 0x4e, 0x18, 0x45,
+	0xe3, 0x11, 0xc1, 0xae, 0x95, 0xde, 0x68, 0xe3, 0xee, 0x4c, 0xaf, 0xd5, 0x9f, 0x36, 0x51, 0x3a,
+	0xf6, 0x4a, 0xdc, 0x6d, 0x73, 0x0c, 0xc2, 0x6c, 0x8e, 0x41, 0x30, 0x7e, 0x03, 0xeb, 0x74, 0x33,
+	0x70, 0xb5, 0x2f, 0xfd, 0x70, 0xa0, 0xda, 0x0d, 0xb2, 0x5b, 0xf4, 0x14, 0x8b, 0x2e, 0xbb, 0x45,
+	0x9f, 0xc0, 0x46, 0xd1, 0xa5, 0x58, 0x8f, 0x1b, 0x20, 0xe6, 0x60, 0x0d, 0xfc, 0x70, 0x20, 0x36,
+	0x1c, 0x04, 0xa9, 0x62, 0xba, 0x77, 0xa7, 0x72, 0x37, 0xf6, 0x2e, 0xcc, 0x9d, 0xe3, 0x28, 0x4e,
+	0xee, 0x22, 0x8b, 0x66, 0xf2, 0x99, 0xdd, 0x2c, 0x4d, 0x3c, 0xb2, 0xbd, 0x48, 0xe9, 0xbe, 0xf6,
+	0x37, 0x0d, 0xd6, 0x64, 0xa3, 0xec, 0xfa, 0x21, 0xe5, 0x5c, 0x2b, 0xe7, 0xfc, 0x00, 0x56, 0x23,
+	0x66, 0x24, 0xa7, 0x7c, 0x8a, 0xa5, 0x7c, 0x25, 0x19, 0xca, 0x32, 0xbe, 0x07, 0x2b, 0xc7, 0xb6,
+	0xe7, 0xcb, 0xda, 0x2d, 0xa6, 0xbd, 0xc4, 0x07, 0x52, 0xdd, 0xec, 0x2a, 0xfe, 0xd6, 0xfb, 0x86,
+	0xd2, 0x28, 0x7c, 0x2e, 0xd9, 0x45, 0x38, 0x11, 0xa7, 0xbd, 0x1c, 0x9e, 0x79, 0xf1, 0x19, 0x7f,
+	0xd9, 0xd2, 0x3b, 0x9c, 0xeb, 0x45, 0x82, 0x1e, 0xa0, 0x3f, 0xa9, 0xc4, 0xf6, 0x7d, 0xb1, 0x34,
+	0xf4, 0x27, 0x4d, 0xed, 0x38, 0xc6, 0x2e, 0xcb, 0xe1, 0xb4, 0xc9, 0x7e, 0x53, 0xd9, 0x71, 0x84,
+	0xb1, 0xa8, 0x2f, 0xf6, 0x9b, 0x5e, 0x20, 0xe7, 0x5f, 0xe2, 0xa1, 0xf0, 0xbc, 0x0b, 0x70, 0x12,
+	0x46, 0xe1, 0x98, 0x78, 0x01, 0x8e, 0x19, 0xc0, 0x8c, 0x99, 0x93, 0xfc, 0x70, 0x1c, 0xb6, 0xd4,
+	0xd8, 0x3f, 0x16, 0x97, 0x43, 0xf6, 0x9b, 0xca, 0x4e, 0xb1, 0x3d, 0x12, 0x2f, 0x64, 0xf6, 0x9b,
+	0x96, 0x6d, 0x4c, 0x6c, 0xe7, 0x8c, 0x3d, 0x88, 0xa7, 0x4d, 0xfe, 0xf1, 0xe8, 0x7f, 0x5b, 0xb0,
+	0x20, 0xb6, 0x26, 0xdb, 0xc9, 0xe8, 0x1d, 0x74, 0x72, 0x8c, 0x1d, 0xba, 0x5d, 0xde, 0xe8, 0x65,
+	0x06, 0x50, 0xbf, 0xd3, 0xa0, 0x25, 0x92, 0xfd, 0x23, 0x14, 0xc0, 0x4a, 0x89, 0x11, 0x43, 0x7b,
+	0x65, 0xeb, 0x3a, 0xbe, 0x4d, 0xbf, 0xaf, 0xa4, 0x9b, 0xe2, 0x11, 0x58, 0xad, 0xa0, 0xb8, 0xd0,
+	0x7e, 0x83, 0x17, 0x89, 0x66, 0xd3, 0x1f, 0x28, 0x6a, 0xa7, 0xa8, 0xdf, 0x03, 0x2a, 0xf3, 0x5f,
+	0xe8, 0x7e, 0xa3, 0x9b, 0x8c, 0x5f, 0xd3, 0xf7, 0xd5, 0x94, 0x6b, 0x27, 0xca, 0x99, 0xb1, 0xc6,
+	0x89, 0x4a, 0xdc, 0x9b, 0xfe, 0x40, 0x51, 0x3b, 0x45, 0x3d, 0x83, 0xe5, 0x22, 0x6b, 0x86, 0xee,
+	0xd5, 0x51, 0xb9, 0x25, 0x52, 0x4e, 0xdf, 0x53, 0x51, 0x4d, 0xc1, 0x2c, 0x58, 0xc8, 0x73, 0x5b,
+	0xa8, 0xa2, 0xe8, 0x2a, 0x58, 0x3a, 0xfd, 0x6e, 0x93, 0x5a, 0x7e, 0x36, 0x45, 0xae, 0xab, 0x6a,
+	0x36, 0x35, 0x44, 0x9a, 0xbe, 0xa7, 0xa2, 0x9a, 0x82, 0xfd, 0x11, 0x96, 0x0a, 0x3c, 0x10, 0xea,
+	0x4f, 0x72, 0x90, 0x67, 0x98, 0xf4, 0x7b, 0x0a, 0x9a, 0x09, 0xd2, 0x43, 0x0d, 0x9d, 0xc0, 0x35,
+	0x99, 0x8e, 0x41, 0x9f, 0x4d, 0x72, 0x90, 0xe3, 0x92, 0xf4, 0x7e, 0xb3, 0x62, 0x0e, 0x28, 0x80,
+	0x95, 0x12, 0xaf, 0x82, 0x26, 0xe6, 0x45, 0xe6, 0x77, 0xf4, 0xfb, 0x4a, 0xba, 0x69, 0x12, 0xdf,
+	0x41, 0x27, 0xc7, 0xbb, 0x54, 0x35, 0xab, 0x32, 0x93, 0xa3, 0xdf, 0x69, 0xd0, 0x4a, 0xbd, 0x0f,
+	0x60, 0x51, 0x62, 0x62, 0xd0, 0xdd, 0x3a, 0x4b, 0xf9, 0x01, 0xa0, 0x7f, 0xd6, 0xa8, 0x97, 0x2f,
+	0xea, 0x3c, 0x41, 0x83, 0x6a, 0x83, 0x93, 0x1b, 0xee, 0xdd, 0x26, 0x35, 0xa9, 0x17, 0x95, 0xf8,
+	0x1a, 0x54, 0x9b, 0xe7, 0x0a, 0x3e, 0x48, 0xdf, 0x57, 0x53, 0xae, 0x86, 0x4c, 0xa8, 0x9c, 0xc9,
+	0x90, 0x05, 0xaa, 0x48, 0xdf, 0x57, 0x53, 0x4e, 0x21, 0x7f, 0x0f, 0x90, 0x71, 0x38, 0xa8, 0xf6,
+	0x76, 0x9a, 0x7b, 0xbb, 0xeb, 0xb7, 0x27, 0x2b, 0xa5, 0xae, 0xdf, 0x42, 0x3b, 0xa1, 0x58, 0xd0,
+	0xcd, 0xb2, 0x4d, 0x81, 0x23, 0xd2, 0x8d, 0x49, 0x2a, 0xb9, 0xad, 0xf2, 0x27, 0xd8, 0x90, 0x5e,
+	0x52, 0x29, 0x49, 0x82, 0x0e, 0xeb, 0x02, 0xab, 0x21, 0x6b, 0xf4, 0x87, 0xea, 0x06, 0xe9, 0xac,
+	0x3e, 0xc2, 0xba, 0xac, 0x23, 0x48, 0x12, 0x74, 0xd0, 0xe4, 0x4c, 0xa6, 0x6a, 0xf4, 0x43, 0x65,
+	0xfd, 0x72, 0x7d, 0xe4, 0xd9, 0x88, 0xfa, 0xfa, 0xa8, 0x20, 0x5e, 0xf4, 0x7d, 0x35, 0xe5, 0x14,
+	0xf2, 0x3d, 0xac, 0xc9, 0xe3, 0x62, 0xbb, 0x3d, 0x68, 0xf2, 0x23, 0x6f, 0xbb, 0x03, 0x55, 0x75,
+	0xe9, 0x5c, 0x2e, 0x53, 0x09, 0xa8, 0x31, 0x7e, 0xa9, 0x63, 0x3d, 0x50, 0xd4, 0xae, 0x5f, 0xdd,
+	0xa4, 0x83, 0x35, 0x4e, 0xa0, 0xd0, 0xc9, 0x0e, 0x95, 0xf5, 0x53, 0xec, 0x11, 0xac, 0x48, 0x2a,
+	0xb4, 0x41, 0xd4, 0x9f, 0x01, 0x65, 0x1e, 0x43, 0xbf, 0xaf, 0xa4, 0x9b, 0xdb, 0x4a, 0x7f, 0x49,
+	0x39, 0xba, 0x8a, 0x67, 0x3b, 0x7a, 0x54, 0xe7, 0xae, 0x9e, 0x90, 0xd0, 0x1f, 0x5f, 0xca, 0x26,
+	0x9d, 0xfa, 0x5f, 0x35, 0xd8, 0x2e, 0xe9, 0x65, 0xef, 0x76, 0xf4, 0x13, 0x05, 0xb7, 0x25, 0x26,
+	0x41, 0x7f, 0x72, 0x49, 0xab, 0xf2, 0xe9, 0xc8, 0x5e, 0xdd, 0xf5, 0xa7, 0x63, 0x9e, 0x3d, 0xd0,
+	0xef, 0x34, 0x68, 0xa5, 0xde, 0x31, 0x5c, 0x93, 0x5f, 0xa7, 0x55, 0x97, 0x8a, 0xca, 0x27, 0xb1,
+	0xde, 0x6f, 0x56, 0x2c, 0x1f, 0x90, 0xfc, 0x99, 0x59, 0x7f, 0x40, 0x4a, 0x6f, 0x57, 0xfd, 0x6e,
+	0x93, 0x5a, 0x02, 0x30, 0x98, 0x65, 0x7f, 0x76, 0x78, 0xfc, 0xff, 0x01, 0x00, 0x66, 0x53, 0x10,
+	0x5c, 0x03, 0x21, 0x00, 0x00,
 }
@@ -72,6 +72,7 @@ func (fs *FilerSink) DeleteEntry(key string, isDirectory, deleteIncludeChunks bo
 			Directory:    dir,
 			Name:         name,
 			IsDeleteData: deleteIncludeChunks,
+			IsRecursive:  isDirectory,
 		}
 
 		glog.V(1).Infof("delete entry: %v", request)
@@ -197,8 +198,9 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry, newEntry *filer_pb.Entry,
 	return true, fs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		request := &filer_pb.UpdateEntryRequest{
-			Directory: dir,
-			Entry:     existingEntry,
+			Directory:      dir,
+			Entry:          existingEntry,
+			UpdateExtended: true,
 		}
 
 		if _, err := client.UpdateEntry(ctx, request); err != nil {
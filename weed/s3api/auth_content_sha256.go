@@ -0,0 +1,56 @@
+package s3api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+)
+
+var errContentSha256Mismatch = errors.New("content sha256 does not match X-Amz-Content-Sha256")
+
+// contentSha256Reader hashes the signed payload while it is read,
+// and fails the last read if the payload is not the one the signature covers.
+type contentSha256Reader struct {
+	reader      io.ReadCloser
+	hash        hash.Hash
+	expectedSum []byte
+	err         error
+}
+
+func newContentSha256Reader(reader io.ReadCloser, hashedPayload string) (*contentSha256Reader, ErrorCode) {
+	expectedSum, err := hex.DecodeString(hashedPayload)
+	if err != nil || len(expectedSum) != sha256.Size {
+		return nil, ErrContentSHA256Mismatch
+	}
+	return &contentSha256Reader{
+		reader:      reader,
+		hash:        sha256.New(),
+		expectedSum: expectedSum,
+	}, ErrNone
+}
+
+func (r *contentSha256Reader) Read(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err = r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(r.hash.Sum(nil), r.expectedSum) {
+		r.err = errContentSha256Mismatch
+		err = r.err
+	}
+	return
+}
+
+func (r *contentSha256Reader) Close() error {
+	return r.reader.Close()
+}
+
+// isContentSha256Mismatch checks whether reading the request body failed because of a wrong payload
+func isContentSha256Mismatch(reader io.Reader) bool {
+	r, ok := reader.(*contentSha256Reader)
+	return ok && r.err == errContentSha256Mismatch
+}
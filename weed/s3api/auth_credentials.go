@@ -0,0 +1,270 @@
+package s3api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/gorilla/mux"
+)
+
+type Action string
+
+const (
+	ACTION_READ  = "Read"
+	ACTION_WRITE = "Write"
+	ACTION_LIST  = "List"
+	ACTION_ADMIN = "Admin"
+)
+
+// the identity with this name is used for requests without any signature
+const anonymousIdentityName = "anonymous"
+
+// S3ApiConfiguration is loaded from the json file given by "weed s3 -config", e.g.,
+//
+//	{
+//	  "identities": [
+//	    {"name": "anonymous", "actions": ["Read:public_bucket"]},
+//	    {
+//	      "name": "some_user",
+//	      "credentials": [{"accessKey": "some_access_key", "secretKey": "some_secret_key"}],
+//	      "actions": ["Read", "Write:bucket1", "List:bucket1"]
+//	    }
+//	  ]
+//	}
+//
+// An action without ":<bucket>" applies to all buckets. "Admin" allows all actions.
+type S3ApiConfiguration struct {
+	Identities []*Identity `json:"identities"`
+}
+
+type Identity struct {
+	Name        string        `json:"name"`
+	Credentials []*Credential `json:"credentials"`
+	Actions     []Action      `json:"actions"`
+}
+
+type Credential struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+type IdentityAccessManagement struct {
+	identities []*Identity
+	domain     string
+}
+
+func NewIdentityAccessManagement(fileName string, domain string) *IdentityAccessManagement {
+	iam := &IdentityAccessManagement{
+		domain: domain,
+	}
+	if fileName == "" {
+		return iam
+	}
+	if err := iam.loadS3ApiConfiguration(fileName); err != nil {
+		glog.Fatalf("fail to load config file %s: %v", fileName, err)
+	}
+	return iam
+}
+
+func (iam *IdentityAccessManagement) loadS3ApiConfiguration(fileName string) error {
+
+	content, readErr := ioutil.ReadFile(fileName)
+	if readErr != nil {
+		return readErr
+	}
+
+	s3ApiConfiguration := &S3ApiConfiguration{}
+	if err := json.Unmarshal(content, s3ApiConfiguration); err != nil {
+		return fmt.Errorf("unmarshal %s: %v", fileName, err)
+	}
+
+	for _, ident := range s3ApiConfiguration.Identities {
+		if ident.Name == "" {
+			return fmt.Errorf("identity without name in %s", fileName)
+		}
+		for _, cred := range ident.Credentials {
+			if cred.AccessKey == "" || cred.SecretKey == "" {
+				return fmt.Errorf("identity %s has empty access key or secret key", ident.Name)
+			}
+		}
+		for _, action := range ident.Actions {
+			if !isActionValid(action) {
+				return fmt.Errorf("identity %s has unknown action %s", ident.Name, action)
+			}
+		}
+	}
+	iam.identities = s3ApiConfiguration.Identities
+
+	glog.V(0).Infof("loaded %d identities from %s", len(iam.identities), fileName)
+	return nil
+}
+
+// isEnabled is false if no identities are configured, and then all requests are allowed
+func (iam *IdentityAccessManagement) isEnabled() bool {
+	return len(iam.identities) > 0
+}
+
+func (iam *IdentityAccessManagement) lookupByAccessKey(accessKey string) (identity *Identity, cred *Credential, found bool) {
+	for _, ident := range iam.identities {
+		for _, cred := range ident.Credentials {
+			if cred.AccessKey == accessKey {
+				return ident, cred, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+func (iam *IdentityAccessManagement) lookupAnonymous() (identity *Identity, found bool) {
+	for _, ident := range iam.identities {
+		if ident.Name == anonymousIdentityName {
+			return ident, true
+		}
+	}
+	return nil, false
+}
+
+// Auth verifies the request signature, and checks whether the identity can do the action on the bucket
+func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) http.HandlerFunc {
+
+	if !iam.isEnabled() {
+		return f
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		errCode := iam.authRequest(r, action)
+		if errCode == ErrNone {
+			f(w, r)
+			return
+		}
+		glog.V(1).Infof("deny %s %s from %s: %s", r.Method, r.URL.Path, r.RemoteAddr, getAPIError(errCode).Code)
+		writeErrorResponse(w, errCode, r.URL)
+	}
+}
+
+func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action) ErrorCode {
+	var identity *Identity
+	var errCode ErrorCode
+	var found bool
+	switch getRequestAuthType(r) {
+	case authTypeStreamingSigned:
+		if errCode = checkRequestTime(r); errCode != ErrNone {
+			return errCode
+		}
+		identity, _, _, _, _, errCode = iam.calculateSeedSignature(r)
+	case authTypeSignedV2:
+		if errCode = checkRequestTime(r); errCode != ErrNone {
+			return errCode
+		}
+		identity, errCode = iam.doesSignV2Match(r)
+	case authTypePresignedV2:
+		identity, errCode = iam.doesPresignV2SignatureMatch(r)
+	case authTypeSigned:
+		if errCode = checkRequestTime(r); errCode != ErrNone {
+			return errCode
+		}
+		hashedPayload := getContentSha256Cksum(r)
+		if identity, errCode = iam.doesSignatureMatch(hashedPayload, r); errCode == ErrNone {
+			errCode = verifyContentSha256(r, hashedPayload)
+		}
+	case authTypePresigned:
+		hashedPayload := getContentSha256Cksum(r)
+		if identity, errCode = iam.doesPresignedSignatureMatch(hashedPayload, r); errCode == ErrNone {
+			errCode = verifyContentSha256(r, hashedPayload)
+		}
+	case authTypeAnonymous:
+		if identity, found = iam.lookupAnonymous(); !found {
+			return ErrAccessDenied
+		}
+	case authTypePostPolicy, authTypeJWT:
+		return ErrNotImplemented
+	default:
+		return ErrAccessDenied
+	}
+	if errCode != ErrNone {
+		return errCode
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if !identity.canDo(action, bucket) {
+		return ErrAccessDenied
+	}
+	// a copy also reads the source object, which can be in another bucket
+	if copySource := r.Header.Get("X-Amz-Copy-Source"); copySource != "" {
+		srcBucket, _, _ := parseCopySource(copySource)
+		if !identity.canDo(ACTION_READ, srcBucket) {
+			return ErrAccessDenied
+		}
+	}
+	return ErrNone
+}
+
+// verifyContentSha256 makes reading the body fail, if the body is not the payload covered by the signature
+func verifyContentSha256(r *http.Request, hashedPayload string) ErrorCode {
+	if hashedPayload == unsignedPayload || r.Body == nil {
+		return ErrNone
+	}
+	body, errCode := newContentSha256Reader(r.Body, hashedPayload)
+	if errCode != ErrNone {
+		return errCode
+	}
+	r.Body = body
+	return ErrNone
+}
+
+func (identity *Identity) canDo(action Action, bucket string) bool {
+	for _, a := range identity.Actions {
+		if a == ACTION_ADMIN || a == action {
+			return true
+		}
+	}
+	if bucket == "" {
+		return false
+	}
+	for _, a := range identity.Actions {
+		if a == ACTION_ADMIN+":"+Action(bucket) || a == action+":"+Action(bucket) {
+			return true
+		}
+	}
+	return false
+}
+
+// the signed requests are only valid within maxSkewTime of the signing time, to limit replaying
+const maxSkewTime = 15 * time.Minute
+
+func checkRequestTime(r *http.Request) ErrorCode {
+	var t time.Time
+	var err error
+	if amzDate := r.Header.Get("X-Amz-Date"); amzDate != "" {
+		if t, err = time.Parse(iso8601Format, amzDate); err != nil {
+			// signature v2 may carry x-amz-date in http date format
+			if t, err = http.ParseTime(amzDate); err != nil {
+				return ErrMalformedDate
+			}
+		}
+	} else if date := r.Header.Get("Date"); date != "" {
+		if t, err = http.ParseTime(date); err != nil {
+			return ErrMalformedDate
+		}
+	} else {
+		return ErrMissingDateHeader
+	}
+	if delta := time.Since(t); delta > maxSkewTime || delta < -maxSkewTime {
+		return ErrRequestTimeTooSkewed
+	}
+	return ErrNone
+}
+
+func isActionValid(action Action) bool {
+	name := strings.SplitN(string(action), ":", 2)[0]
+	switch name {
+	case ACTION_READ, ACTION_WRITE, ACTION_LIST, ACTION_ADMIN:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package s3api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+)
+
+// copyChunks copies the [offset, offset+size) range of the source chunks into new file ids
+// in the collection. The data goes from the source volume servers to the new ones through
+// this s3 gateway, never through the client. The returned chunks start from offset 0.
+func (s3a *S3ApiServer) copyChunks(chunks []*filer_pb.FileChunk, offset int64, size int64, collection string) (copied []*filer_pb.FileChunk, err error) {
+
+	chunkViews := filer2.ViewFromChunks(chunks, offset, int(size))
+	if len(chunkViews) == 0 {
+		return nil, nil
+	}
+
+	var vids []string
+	for _, chunkView := range chunkViews {
+		vids = append(vids, volumeId(chunkView.FileId))
+	}
+
+	vid2Locations := make(map[string]*filer_pb.Locations)
+
+	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		glog.V(4).Infof("copy lookup volume id locations: %v", vids)
+		resp, err := client.LookupVolume(context.Background(), &filer_pb.LookupVolumeRequest{
+			VolumeIds: vids,
+		})
+		if err != nil {
+			return err
+		}
+
+		vid2Locations = resp.LocationsMap
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup volume ids %v: %v", vids, err)
+	}
+
+	for _, chunkView := range chunkViews {
+
+		locations := vid2Locations[volumeId(chunkView.FileId)]
+		if locations == nil || len(locations.Locations) == 0 {
+			s3a.deleteChunks(copied)
+			return nil, fmt.Errorf("failed to locate %s", chunkView.FileId)
+		}
+		srcUrl := fmt.Sprintf("http://%s/%s", locations.Locations[0].Url, chunkView.FileId)
+
+		chunk, copyErr := s3a.copyChunkView(srcUrl, chunkView, collection)
+		if copyErr != nil {
+			s3a.deleteChunks(copied)
+			return nil, copyErr
+		}
+		chunk.Offset = chunkView.LogicOffset - offset
+
+		copied = append(copied, chunk)
+	}
+
+	return copied, nil
+}
+
+func (s3a *S3ApiServer) copyChunkView(srcUrl string, chunkView *filer2.ChunkView, collection string) (*filer_pb.FileChunk, error) {
+
+	var fileId, host string
+	var auth security.EncodedJwt
+
+	if err := s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AssignVolumeRequest{
+			Count:      1,
+			Collection: collection,
+		}
+
+		resp, err := client.AssignVolume(context.Background(), request)
+		if err != nil {
+			glog.V(0).Infof("assign volume failure %v: %v", request, err)
+			return err
+		}
+
+		fileId, host, auth = resp.FileId, resp.Url, security.EncodedJwt(resp.Auth)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("assign volume: %v", err)
+	}
+
+	req, err := http.NewRequest("GET", srcUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", chunkView.Offset, chunkView.Offset+int64(chunkView.Size)-1))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", srcUrl, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("read %s: %s", srcUrl, resp.Status)
+	}
+
+	fileUrl := fmt.Sprintf("http://%s/%s", host, fileId)
+	uploadResult, err := operation.Upload(fileUrl, "", resp.Body, false, "application/octet-stream", nil, auth)
+	if err != nil {
+		glog.V(0).Infof("copy %s to %s: %v", srcUrl, fileUrl, err)
+		return nil, fmt.Errorf("upload data: %v", err)
+	}
+	if uploadResult.Error != "" {
+		glog.V(0).Infof("copy %s to %s: %v", srcUrl, fileUrl, uploadResult.Error)
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+
+	return &filer_pb.FileChunk{
+		FileId: fileId,
+		Size:   chunkView.Size,
+		Mtime:  time.Now().UnixNano(),
+		ETag:   uploadResult.ETag,
+	}, nil
+
+}
+
+// deleteChunks removes the chunks that are not referenced by any entry, e.g., from a failed copy
+func (s3a *S3ApiServer) deleteChunks(chunks []*filer_pb.FileChunk) {
+
+	if len(chunks) == 0 {
+		return
+	}
+
+	var fileIds []string
+	for _, chunk := range chunks {
+		fileIds = append(fileIds, chunk.FileId)
+	}
+
+	err := s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		lookupFunc := func(vids []string) (map[string]operation.LookupResult, error) {
+
+			m := make(map[string]operation.LookupResult)
+
+			resp, err := client.LookupVolume(context.Background(), &filer_pb.LookupVolumeRequest{
+				VolumeIds: vids,
+			})
+			if err != nil {
+				return m, err
+			}
+
+			for _, vid := range vids {
+				lr := operation.LookupResult{
+					VolumeId: vid,
+				}
+				if locations, found := resp.LocationsMap[vid]; found {
+					for _, loc := range locations.Locations {
+						lr.Locations = append(lr.Locations, operation.Location{
+							Url:       loc.Url,
+							PublicUrl: loc.PublicUrl,
+						})
+					}
+				}
+				m[vid] = lr
+			}
+
+			return m, nil
+		}
+
+		_, err := operation.DeleteFilesWithLookupVolumeId(s3a.option.GrpcDialOption, fileIds, lookupFunc)
+		return err
+	})
+
+	if err != nil {
+		glog.V(0).Infof("delete chunks %v: %v", fileIds, err)
+	}
+}
+
+func volumeId(fileId string) string {
+	lastCommaIndex := strings.LastIndex(fileId, ",")
+	if lastCommaIndex > 0 {
+		return fileId[:lastCommaIndex]
+	}
+	return fileId
+}
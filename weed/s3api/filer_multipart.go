@@ -2,7 +2,6 @@ package s3api
 
 import (
 	"fmt"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -19,7 +18,8 @@ type InitiateMultipartUploadResult struct {
 	s3.CreateMultipartUploadOutput
 }
 
-func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInput) (output *InitiateMultipartUploadResult, code ErrorCode) {
+// createMultipartUpload keeps the object metadata in the upload folder until the upload completes
+func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInput, extended map[string][]byte) (output *InitiateMultipartUploadResult, code ErrorCode) {
 	uploadId, _ := uuid.NewV4()
 	uploadIdString := uploadId.String()
 
@@ -27,7 +27,11 @@ func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInp
 		if entry.Extended == nil {
 			entry.Extended = make(map[string][]byte)
 		}
+		for k, v := range extended {
+			entry.Extended[k] = v
+		}
 		entry.Extended["key"] = []byte(*input.Key)
+		entry.Attributes.Mime = aws.StringValue(input.ContentType)
 	}); err != nil {
 		glog.Errorf("NewMultipartUpload error: %v", err)
 		return nil, ErrInternalError
@@ -46,12 +50,19 @@ func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInp
 
 type CompleteMultipartUploadResult struct {
 	s3.CompleteMultipartUploadOutput
+	VersionId string `xml:"-"` // sent in the x-amz-version-id header
 }
 
 func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploadInput) (output *CompleteMultipartUploadResult, code ErrorCode) {
 
 	uploadDirectory := s3a.genUploadsFolder(*input.Bucket) + "/" + *input.UploadId
 
+	uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(*input.Bucket), *input.UploadId)
+	if err != nil {
+		glog.Errorf("completeMultipartUpload %s %s error: %v", *input.Bucket, *input.UploadId, err)
+		return nil, ErrNoSuchUpload
+	}
+
 	entries, err := s3a.list(uploadDirectory, "", "", false, 0)
 	if err != nil {
 		glog.Errorf("completeMultipartUpload %s %s error: %v", *input.Bucket, *input.UploadId, err)
@@ -77,29 +88,42 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 		}
 	}
 
-	entryName := filepath.Base(*input.Key)
-	dirName := filepath.Dir(*input.Key)
-	if dirName == "." {
-		dirName = ""
+	write, code := s3a.beginVersionedWrite(*input.Bucket, *input.Key)
+	if code != ErrNone {
+		return nil, code
 	}
-	if strings.HasPrefix(dirName, "/") {
-		dirName = dirName[1:]
-	}
-	dirName = fmt.Sprintf("%s/%s/%s", s3a.option.BucketsPath, *input.Bucket, dirName)
+	// the chunks still belong to the parts if completing fails
+	write.keepChunks = true
 
-	err = s3a.mkFile(dirName, entryName, finalParts)
+	err = s3a.mkFile(write.dir, write.name, finalParts, func(entry *filer_pb.Entry) {
+		entry.Attributes.Mime = uploadEntry.Attributes.Mime
+		for k, v := range uploadEntry.Extended {
+			if filer2.IsExtendedHeader(k) {
+				if entry.Extended == nil {
+					entry.Extended = make(map[string][]byte)
+				}
+				entry.Extended[k] = v
+			}
+		}
+		setVersionId(entry, write.versionId)
+	})
 
 	if err != nil {
-		glog.Errorf("completeMultipartUpload %s/%s error: %v", dirName, entryName, err)
+		glog.Errorf("completeMultipartUpload %s/%s error: %v", write.dir, write.name, err)
+		write.complete(ErrInternalError)
 		return nil, ErrInternalError
 	}
+	if code = write.complete(ErrNone); code != ErrNone {
+		return nil, code
+	}
 
 	output = &CompleteMultipartUploadResult{
-		s3.CompleteMultipartUploadOutput{
+		CompleteMultipartUploadOutput: s3.CompleteMultipartUploadOutput{
 			Bucket: input.Bucket,
 			ETag:   aws.String("\"" + filer2.ETag(finalParts) + "\""),
 			Key:    input.Key,
 		},
+		VersionId: write.versionId,
 	}
 
 	if err = s3a.rm(s3a.genUploadsFolder(*input.Bucket), *input.UploadId, true, false, true); err != nil {
@@ -3,6 +3,7 @@ package s3api
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -43,7 +44,7 @@ func (s3a *S3ApiServer) mkdir(parentDirectoryPath string, dirName string, fn fun
 	})
 }
 
-func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk) error {
+func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk, fn func(entry *filer_pb.Entry)) error {
 	return s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		entry := &filer_pb.Entry{
@@ -59,6 +60,10 @@ func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chun
 			Chunks: chunks,
 		}
 
+		if fn != nil {
+			fn(entry)
+		}
+
 		request := &filer_pb.CreateEntryRequest{
 			Directory: parentDirectoryPath,
 			Entry:     entry,
@@ -73,6 +78,24 @@ func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chun
 	})
 }
 
+func (s3a *S3ApiServer) updateEntry(parentDirectoryPath string, entry *filer_pb.Entry) error {
+	return s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory:      parentDirectoryPath,
+			Entry:          entry,
+			UpdateExtended: true,
+		}
+
+		glog.V(1).Infof("update entry %s/%s", parentDirectoryPath, entry.Name)
+		if _, err := client.UpdateEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("update entry %s/%s: %v", parentDirectoryPath, entry.Name, err)
+		}
+
+		return nil
+	})
+}
+
 func (s3a *S3ApiServer) list(parentDirectoryPath, prefix, startFrom string, inclusive bool, limit int) (entries []*filer_pb.Entry, err error) {
 
 	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
@@ -86,14 +109,21 @@ func (s3a *S3ApiServer) list(parentDirectoryPath, prefix, startFrom string, incl
 		}
 
 		glog.V(4).Infof("read directory: %v", request)
-		resp, err := client.ListEntries(context.Background(), request)
+		stream, err := client.ListEntries(context.Background(), request)
 		if err != nil {
 			return fmt.Errorf("list dir %v: %v", parentDirectoryPath, err)
 		}
 
-		entries = resp.Entries
-
-		return nil
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr == io.EOF {
+				return nil
+			}
+			if recvErr != nil {
+				return fmt.Errorf("list dir %v: %v", parentDirectoryPath, recvErr)
+			}
+			entries = append(entries, resp.Entry)
+		}
 	})
 
 	return
@@ -123,6 +153,52 @@ func (s3a *S3ApiServer) rm(parentDirectoryPath string, entryName string, isDirec
 
 }
 
+func (s3a *S3ApiServer) rename(oldDirectoryPath, oldName, newDirectoryPath, newName string) error {
+
+	return s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDirectoryPath,
+			OldName:      oldName,
+			NewDirectory: newDirectoryPath,
+			NewName:      newName,
+		}
+
+		glog.V(1).Infof("rename entry %v/%v to %v/%v", oldDirectoryPath, oldName, newDirectoryPath, newName)
+		if _, err := client.AtomicRenameEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("rename entry %s/%s to %s/%s: %v", oldDirectoryPath, oldName, newDirectoryPath, newName, err)
+		}
+
+		return nil
+	})
+
+}
+
+func (s3a *S3ApiServer) getEntry(parentDirectoryPath string, entryName string) (entry *filer_pb.Entry, err error) {
+
+	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		ctx := context.Background()
+
+		request := &filer_pb.LookupDirectoryEntryRequest{
+			Directory: parentDirectoryPath,
+			Name:      entryName,
+		}
+
+		glog.V(4).Infof("get entry %v/%v: %v", parentDirectoryPath, entryName, request)
+		resp, err := client.LookupDirectoryEntry(ctx, request)
+		if err != nil {
+			return fmt.Errorf("get entry %s/%s: %v", parentDirectoryPath, entryName, err)
+		}
+
+		entry = resp.Entry
+
+		return nil
+	})
+
+	return
+}
+
 func (s3a *S3ApiServer) exists(parentDirectoryPath string, entryName string, isDirectory bool) (exists bool, err error) {
 
 	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
@@ -0,0 +1,636 @@
+package s3api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// The latest version of an object stays at the object path. The prior versions and the delete markers
+// are kept under the hidden "<bucket>/.versions/<object>/" folder, named by their version ids.
+// Moving a version renames the entry, the chunks are kept as is.
+// The changes to the versions of one object are serialized by this gateway, not across multiple gateways.
+const (
+	versioningEnabled     = "Enabled"
+	versioningSuspended   = "Suspended"
+	versioningExtendedKey = "s3.versioning"
+	versionsFolder        = ".versions"
+	uploadsFolder         = ".uploads"
+	stagingFolder         = ".staging"
+	nullVersionId         = "null"
+	versionIdHeader       = "X-Amz-Version-Id"
+	deleteMarkerHeader    = "X-Amz-Delete-Marker"
+)
+
+// isHiddenFolder tells the folders at the bucket root that are not objects
+func isHiddenFolder(name string) bool {
+	return name == versionsFolder || name == uploadsFolder || name == stagingFolder
+}
+
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), filer2.ErrNotFound.Error())
+}
+
+// objectLocks serializes the changes to the same object
+type objectLocks struct {
+	sync.Mutex
+	locks map[string]*objectLock
+}
+
+type objectLock struct {
+	sync.Mutex
+	refCount int
+}
+
+func (l *objectLocks) lock(key string) (unlock func()) {
+
+	l.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*objectLock)
+	}
+	ol, found := l.locks[key]
+	if !found {
+		ol = &objectLock{}
+		l.locks[key] = ol
+	}
+	ol.refCount++
+	l.Unlock()
+
+	ol.Lock()
+
+	return func() {
+		ol.Unlock()
+		l.Lock()
+		if ol.refCount--; ol.refCount == 0 {
+			delete(l.locks, key)
+		}
+		l.Unlock()
+	}
+}
+
+func (s3a *S3ApiServer) getBucketVersioning(bucket string) (status string, err error) {
+	entry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		return "", err
+	}
+	return string(entry.Extended[versioningExtendedKey]), nil
+}
+
+func (s3a *S3ApiServer) setBucketVersioning(bucket string, status string) error {
+	entry, err := s3a.getEntry(s3a.option.BucketsPath, bucket)
+	if err != nil {
+		return err
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[versioningExtendedKey] = []byte(status)
+	return s3a.updateEntry(s3a.option.BucketsPath, entry)
+}
+
+func (s3a *S3ApiServer) genVersionsFolder(bucket, object string) string {
+	return filepath.Join(s3a.option.BucketsPath, bucket, versionsFolder, object)
+}
+
+// newVersionId generates ids that sort from the latest to the oldest, same as the filer listing order
+func newVersionId() string {
+	return fmt.Sprintf("%016x%08x", math.MaxInt64-time.Now().UnixNano(), rand.Uint32())
+}
+
+func currentVersionId(entry *filer_pb.Entry) string {
+	if versionId := entry.Extended[versionIdHeader]; len(versionId) > 0 {
+		return string(versionId)
+	}
+	return nullVersionId
+}
+
+func isDeleteMarker(entry *filer_pb.Entry) bool {
+	return string(entry.Extended[deleteMarkerHeader]) == "true"
+}
+
+// bucketVersioning returns the versioning status of the bucket, or the error code to respond with
+func (s3a *S3ApiServer) bucketVersioning(bucket string) (status string, code ErrorCode) {
+	status, err := s3a.getBucketVersioning(bucket)
+	if isNotFound(err) {
+		return "", ErrNoSuchBucket
+	}
+	if err != nil {
+		glog.Errorf("get bucket %s versioning: %v", bucket, err)
+		return "", ErrInternalError
+	}
+	return status, ErrNone
+}
+
+func (s3a *S3ApiServer) genStagingFolder(bucket string) string {
+	return filepath.Join(s3a.option.BucketsPath, bucket, stagingFolder)
+}
+
+// versionedWrite writes a new object. In a versioned bucket, the new object is written aside into the hidden
+// "<bucket>/.staging/" folder first, and only replaces the current object once it is complete,
+// so a failed write leaves the current object and its versions as they were.
+type versionedWrite struct {
+	s3a            *S3ApiServer
+	bucket, object string
+	status         string
+	unlock         func()
+
+	// versionId is the version id of the new object, empty if the bucket is not versioned or the versioning is suspended
+	versionId string
+	// dir and name are where the new object is to be written
+	dir, name string
+	// keepChunks leaves the chunks alone if the write fails, for chunks that are still used by the multipart upload
+	keepChunks bool
+}
+
+// beginVersionedWrite starts writing a new object, and holds the object lock in a versioned bucket until it completes
+func (s3a *S3ApiServer) beginVersionedWrite(bucket, object string) (w *versionedWrite, code ErrorCode) {
+
+	status, code := s3a.bucketVersioning(bucket)
+	if code != ErrNone {
+		return nil, code
+	}
+
+	w = &versionedWrite{
+		s3a:    s3a,
+		bucket: bucket,
+		object: object,
+		status: status,
+		unlock: func() {},
+	}
+
+	// the folders are not versioned
+	if status == "" || strings.HasSuffix(object, "/") {
+		w.status = ""
+		w.dir, w.name = s3a.objectDirAndName(bucket, object)
+		return w, ErrNone
+	}
+
+	w.unlock = s3a.versionLocks.lock(bucket + object)
+	if status == versioningEnabled {
+		w.versionId = newVersionId()
+	}
+	w.dir, w.name = s3a.genStagingFolder(bucket), newVersionId()
+	return w, ErrNone
+}
+
+// uploadUrl is the filer url to upload the new object to
+func (w *versionedWrite) uploadUrl() string {
+	if w.status == "" {
+		return fmt.Sprintf("http://%s%s/%s%s?collection=%s", w.s3a.option.Filer, w.s3a.option.BucketsPath, w.bucket, w.object, w.bucket)
+	}
+	return fmt.Sprintf("http://%s%s/%s?collection=%s", w.s3a.option.Filer, w.dir, w.name, w.bucket)
+}
+
+// complete takes the error code of writing the new object. The new object then replaces the current object,
+// or is removed if it failed.
+func (w *versionedWrite) complete(code ErrorCode) ErrorCode {
+
+	defer w.unlock()
+
+	if w.status == "" {
+		return code
+	}
+
+	if code == ErrNone {
+		if err := w.s3a.promoteStagedObject(w.bucket, w.object, w.status, w.dir, w.name); err != nil {
+			glog.Errorf("write %s%s: %v", w.bucket, w.object, err)
+			code = ErrInternalError
+		}
+	}
+
+	if code != ErrNone {
+		if err := w.s3a.rm(w.dir, w.name, false, !w.keepChunks, false); err != nil && !isNotFound(err) {
+			glog.V(1).Infof("remove staged %s%s: %v", w.bucket, w.object, err)
+		}
+	}
+
+	return code
+}
+
+// promoteStagedObject moves the staged object to the object path, after keeping the current object as a prior version
+func (s3a *S3ApiServer) promoteStagedObject(bucket, object, status, stagedDir, stagedName string) error {
+
+	restore, err := s3a.keepCurrentVersion(bucket, object, status)
+	if err != nil {
+		return err
+	}
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	if err = s3a.rename(stagedDir, stagedName, dir, name); err != nil {
+		restore()
+		return err
+	}
+
+	if status == versioningSuspended {
+		s3a.removeNullVersion(bucket, object)
+	}
+	return nil
+}
+
+// keepCurrentVersion moves the current object into the versions folder before it is replaced,
+// and returns the function to move it back if the replacement fails.
+// With suspended versioning, the current "null" version is not kept, it is simply replaced.
+func (s3a *S3ApiServer) keepCurrentVersion(bucket, object, status string) (restore func(), err error) {
+
+	restore = func() {}
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	entry, err := s3a.getEntry(dir, name)
+	if isNotFound(err) {
+		return restore, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsDirectory || status == versioningSuspended && currentVersionId(entry) == nullVersionId {
+		return restore, nil
+	}
+
+	versionsDir, versionId := s3a.genVersionsFolder(bucket, object), currentVersionId(entry)
+	if err = s3a.rename(dir, name, versionsDir, versionId); err != nil {
+		return nil, fmt.Errorf("keep prior version: %v", err)
+	}
+
+	return func() {
+		if err := s3a.rename(versionsDir, versionId, dir, name); err != nil {
+			glog.Errorf("restore current version of %s%s: %v", bucket, object, err)
+		}
+	}, nil
+}
+
+// removeNullVersion removes the prior "null" version, after it is replaced by a new "null" version
+func (s3a *S3ApiServer) removeNullVersion(bucket, object string) {
+	if err := s3a.rm(s3a.genVersionsFolder(bucket, object), nullVersionId, false, true, false); err != nil && !isNotFound(err) {
+		glog.V(1).Infof("remove null version of %s%s: %v", bucket, object, err)
+	}
+}
+
+// createDeleteMarker deletes an object in a versioned bucket, by keeping the current object as a prior version
+// and adding a delete marker as the latest version
+func (s3a *S3ApiServer) createDeleteMarker(bucket, object, status string) (markerVersionId string, code ErrorCode) {
+
+	defer s3a.versionLocks.lock(bucket + object)()
+
+	markerVersionId = nullVersionId
+	if status == versioningEnabled {
+		markerVersionId = newVersionId()
+	}
+
+	restore, err := s3a.keepCurrentVersion(bucket, object, status)
+	if err != nil {
+		glog.Errorf("delete %s%s: %v", bucket, object, err)
+		return "", ErrInternalError
+	}
+
+	// a "null" delete marker replaces the prior "null" version
+	if err = s3a.mkFile(s3a.genVersionsFolder(bucket, object), markerVersionId, nil, func(entry *filer_pb.Entry) {
+		entry.Extended = map[string][]byte{
+			deleteMarkerHeader: []byte("true"),
+		}
+	}); err != nil {
+		glog.Errorf("create delete marker for %s%s: %v", bucket, object, err)
+		restore()
+		return "", ErrInternalError
+	}
+
+	if status == versioningSuspended {
+		// the current "null" version is replaced by the delete marker
+		dir, name := s3a.objectDirAndName(bucket, object)
+		if err = s3a.rm(dir, name, false, true, false); err != nil && !isNotFound(err) {
+			glog.Errorf("delete %s%s: %v", bucket, object, err)
+			return "", ErrInternalError
+		}
+	}
+
+	return markerVersionId, ErrNone
+}
+
+// getObjectVersion finds the entry of one object version, which is either the current object or a prior version
+func (s3a *S3ApiServer) getObjectVersion(bucket, object, versionId string) (dir string, entry *filer_pb.Entry, code ErrorCode) {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	if entry, err := s3a.getEntry(dir, name); err == nil && !entry.IsDirectory {
+		if versionId == "" || currentVersionId(entry) == versionId {
+			return dir, entry, ErrNone
+		}
+	}
+	if versionId == "" {
+		return "", nil, ErrNoSuchKey
+	}
+
+	dir = s3a.genVersionsFolder(bucket, object)
+	entry, err := s3a.getEntry(dir, versionId)
+	if err != nil || entry.IsDirectory {
+		return "", nil, ErrNoSuchVersion
+	}
+	return dir, entry, ErrNone
+}
+
+// deleteObjectVersion permanently deletes one version, and the prior version becomes the current object
+// if the current one is deleted
+func (s3a *S3ApiServer) deleteObjectVersion(bucket, object, versionId string) (deletedMarker bool, code ErrorCode) {
+
+	defer s3a.versionLocks.lock(bucket + object)()
+
+	dir, entry, code := s3a.getObjectVersion(bucket, object, versionId)
+	if code != ErrNone {
+		return false, code
+	}
+
+	if err := s3a.rm(dir, entry.Name, false, true, false); err != nil {
+		glog.Errorf("delete %s%s version %s: %v", bucket, object, versionId, err)
+		return false, ErrInternalError
+	}
+
+	if err := s3a.promoteLatestVersion(bucket, object); err != nil {
+		glog.Errorf("restore latest version of %s%s: %v", bucket, object, err)
+		return false, ErrInternalError
+	}
+
+	return isDeleteMarker(entry), ErrNone
+}
+
+// promoteLatestVersion moves the latest prior version back as the current object,
+// unless the current object exists or the latest version is a delete marker
+func (s3a *S3ApiServer) promoteLatestVersion(bucket, object string) error {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	if _, err := s3a.getEntry(dir, name); !isNotFound(err) {
+		return err
+	}
+
+	versions, err := s3a.listPriorVersions(bucket, object)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 || isDeleteMarker(versions[0]) {
+		return nil
+	}
+
+	return s3a.rename(s3a.genVersionsFolder(bucket, object), versions[0].Name, dir, name)
+}
+
+// listPriorVersions lists the prior versions of one object, from the latest to the oldest
+func (s3a *S3ApiServer) listPriorVersions(bucket, object string) (versions []*filer_pb.Entry, err error) {
+
+	entries, err := s3a.list(s3a.genVersionsFolder(bucket, object), "", "", false, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDirectory {
+			versions = append(versions, entry)
+		}
+	}
+
+	// the version ids are in time order, except the "null" version
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Attributes.Mtime > versions[j].Attributes.Mtime
+	})
+
+	return versions, nil
+}
+
+type ListVersionsResultOutput struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+	s3.ListObjectVersionsOutput
+	Versions      []*s3.ObjectVersion     `xml:"Version,omitempty"`
+	DeleteMarkers []*s3.DeleteMarkerEntry `xml:"DeleteMarker,omitempty"`
+}
+
+// listObjectVersions lists the versions of the objects with the prefix, in the key order of walking the directories,
+// and from the latest to the oldest version for each key.
+// The keys are listed in batches from the key marker, so only about maxKeys keys are read from the filer.
+func (s3a *S3ApiServer) listObjectVersions(bucket, prefix, keyMarker, versionIdMarker string, maxKeys int) (output *ListVersionsResultOutput, err error) {
+
+	bucketDir := filepath.Join(s3a.option.BucketsPath, bucket)
+	versionsDir := filepath.Join(bucketDir, versionsFolder)
+	dir, namePrefix := filepath.Split(prefix)
+
+	output = &ListVersionsResultOutput{
+		ListObjectVersionsOutput: s3.ListObjectVersionsOutput{
+			Name:            aws.String(bucket),
+			Prefix:          aws.String(prefix),
+			KeyMarker:       aws.String(keyMarker),
+			VersionIdMarker: aws.String(versionIdMarker),
+			MaxKeys:         aws.Int64(int64(maxKeys)),
+			IsTruncated:     aws.Bool(false),
+		},
+	}
+
+	// two more than maxKeys, so a truncated batch always ends after its start key
+	batchSize := maxKeys + 2
+
+	var counter int
+	var lastKey, lastVersionId string
+	startKey, inclusive := keyMarker, versionIdMarker != ""
+	for {
+
+		// the current objects, and the keys with prior versions
+		currentKeys, currentEntries, currentTruncated, err := s3a.listKeys(bucketDir, dir, namePrefix, startKey, batchSize, false)
+		if err != nil {
+			return nil, err
+		}
+		versionKeys, _, versionTruncated, err := s3a.listKeys(versionsDir, dir, namePrefix, startKey, batchSize, true)
+		if err != nil {
+			return nil, err
+		}
+
+		// beyond the last key of a truncated batch, the keys of the other batch may be incomplete
+		var endKey string
+		if currentTruncated {
+			endKey = currentKeys[len(currentKeys)-1]
+		}
+		if versionTruncated && (endKey == "" || compareKeys(versionKeys[len(versionKeys)-1], endKey) < 0) {
+			endKey = versionKeys[len(versionKeys)-1]
+		}
+
+		hasPriorVersions := make(map[string]bool)
+		for _, key := range versionKeys {
+			hasPriorVersions[key] = true
+		}
+
+		for _, key := range mergeKeys(currentKeys, versionKeys) {
+
+			if endKey != "" && compareKeys(key, endKey) > 0 {
+				break
+			}
+			if key == startKey && !inclusive {
+				continue
+			}
+
+			var versions []*filer_pb.Entry
+			if hasPriorVersions[key] {
+				if versions, err = s3a.listPriorVersions(bucket, key); err != nil {
+					return nil, err
+				}
+			}
+			current := currentEntries[key]
+			if current != nil {
+				versions = append([]*filer_pb.Entry{current}, versions...)
+			}
+
+			skipping := key == keyMarker && versionIdMarker != ""
+			for i, entry := range versions {
+
+				versionId := entry.Name
+				if entry == current {
+					versionId = currentVersionId(entry)
+				}
+				if skipping {
+					skipping = versionId != versionIdMarker
+					continue
+				}
+
+				if counter >= maxKeys {
+					output.IsTruncated = aws.Bool(true)
+					output.NextKeyMarker = aws.String(lastKey)
+					output.NextVersionIdMarker = aws.String(lastVersionId)
+					return output, nil
+				}
+				counter++
+				lastKey, lastVersionId = key, versionId
+
+				if isDeleteMarker(entry) {
+					output.DeleteMarkers = append(output.DeleteMarkers, &s3.DeleteMarkerEntry{
+						Key:          aws.String(key),
+						VersionId:    aws.String(versionId),
+						IsLatest:     aws.Bool(i == 0),
+						LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0)),
+					})
+					continue
+				}
+				output.Versions = append(output.Versions, &s3.ObjectVersion{
+					Key:          aws.String(key),
+					VersionId:    aws.String(versionId),
+					IsLatest:     aws.Bool(i == 0),
+					LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0)),
+					ETag:         aws.String("\"" + filer2.ETag(entry.Chunks) + "\""),
+					Size:         aws.Int64(int64(filer2.TotalSize(entry.Chunks))),
+					StorageClass: aws.String("STANDARD"),
+				})
+			}
+		}
+
+		if endKey == "" {
+			return output, nil
+		}
+		startKey, inclusive = endKey, false
+	}
+}
+
+// listKeys lists up to limit keys under "<rootDir>/<dir>", starting from the start key inclusively.
+// The keys are relative to the root directory. They are the file paths, with the file entries,
+// or with dirsAsKeys, the directory paths, as used in the versions folder.
+func (s3a *S3ApiServer) listKeys(rootDir, dir, namePrefix, startKey string, limit int, dirsAsKeys bool) (keys []string, entries map[string]*filer_pb.Entry, truncated bool, err error) {
+
+	entries = make(map[string]*filer_pb.Entry)
+
+	relativeStartKey := ""
+	if strings.HasPrefix(startKey, dir) {
+		relativeStartKey = startKey[len(dir):]
+	} else if compareKeys(startKey, dir) > 0 {
+		// all the keys under the directory are before the start key
+		return nil, entries, false, nil
+	}
+
+	completed, err := s3a.walkKeys(filepath.Join(rootDir, dir), dir, namePrefix, relativeStartKey, func(parentDir string, entry *filer_pb.Entry) bool {
+		return parentDir != rootDir || !isHiddenFolder(entry.Name)
+	}, func(key string, entry *filer_pb.Entry) bool {
+		if entry.IsDirectory != dirsAsKeys {
+			return true
+		}
+		keys = append(keys, key)
+		entries[key] = entry
+		return len(keys) < limit
+	})
+
+	return keys, entries, !completed, err
+}
+
+const walkKeysPageSize = 1024
+
+// walkKeys visits the entries under the directory recursively, in the order of the keys, which are the paths prefixed
+// by the key prefix. It starts from the start key inclusively, only walks into the directories accepted by visitDir,
+// and stops once fn returns false.
+func (s3a *S3ApiServer) walkKeys(dir, keyPrefix, namePrefix, startKey string, visitDir func(parentDir string, entry *filer_pb.Entry) bool, fn func(key string, entry *filer_pb.Entry) bool) (completed bool, err error) {
+
+	startName, startRest := startKey, ""
+	if i := strings.Index(startKey, "/"); i >= 0 {
+		startName, startRest = startKey[:i], startKey[i+1:]
+	}
+
+	inclusive := true
+	for {
+
+		entries, err := s3a.list(dir, namePrefix, startName, inclusive, walkKeysPageSize)
+		if err != nil {
+			return false, err
+		}
+
+		for _, entry := range entries {
+
+			key, subStartKey := keyPrefix+entry.Name, ""
+			if entry.Name == startName && startRest != "" {
+				// the start key is under this entry, which comes before the start key
+				if !entry.IsDirectory {
+					continue
+				}
+				subStartKey = startRest
+			} else if !fn(key, entry) {
+				return false, nil
+			}
+
+			if !entry.IsDirectory || visitDir != nil && !visitDir(dir, entry) {
+				continue
+			}
+			if completed, err = s3a.walkKeys(filepath.Join(dir, entry.Name), key+"/", "", subStartKey, visitDir, fn); err != nil || !completed {
+				return completed, err
+			}
+		}
+
+		if len(entries) < walkKeysPageSize {
+			return true, nil
+		}
+		startName, startRest, inclusive = entries[len(entries)-1].Name, "", false
+	}
+}
+
+// compareKeys compares the keys segment by segment, which is the order of walking the directories
+func compareKeys(a, b string) int {
+	aSegments, bSegments := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		if c := strings.Compare(aSegments[i], bSegments[i]); c != 0 {
+			return c
+		}
+	}
+	return len(aSegments) - len(bSegments)
+}
+
+// mergeKeys merges two sorted key lists, without duplicates
+func mergeKeys(a, b []string) (keys []string) {
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || len(a) > 0 && compareKeys(a[0], b[0]) < 0:
+			keys, a = append(keys, a[0]), a[1:]
+		case len(a) == 0 || compareKeys(a[0], b[0]) > 0:
+			keys, b = append(keys, b[0]), b[1:]
+		default:
+			keys, a, b = append(keys, a[0]), a[1:], b[1:]
+		}
+	}
+	return keys
+}
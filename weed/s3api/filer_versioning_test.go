@@ -0,0 +1,478 @@
+package s3api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"google.golang.org/grpc"
+)
+
+// memFiler serves the filer calls used by the s3 gateway from memory
+type memFiler struct {
+	filer_pb.SeaweedFilerServer
+	sync.Mutex
+	entries map[string]*filer_pb.Entry
+
+	// failLookup fails all the lookups, and failRenameFrom fails renaming the entries in the directory
+	failLookup     bool
+	failRenameFrom string
+}
+
+func (f *memFiler) mkdirAll(dir string) {
+	for dir != "/" {
+		if _, found := f.entries[dir]; !found {
+			f.entries[dir] = &filer_pb.Entry{Name: filepath.Base(dir), IsDirectory: true, Attributes: &filer_pb.FuseAttributes{}}
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func (f *memFiler) LookupDirectoryEntry(ctx context.Context, req *filer_pb.LookupDirectoryEntryRequest) (*filer_pb.LookupDirectoryEntryResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.failLookup {
+		return nil, fmt.Errorf("lookup %s/%s: store is unavailable", req.Directory, req.Name)
+	}
+	entry, found := f.entries[filepath.Join(req.Directory, req.Name)]
+	if !found {
+		return nil, fmt.Errorf("%s not found under %s: %v", req.Name, req.Directory, filer2.ErrNotFound)
+	}
+	return &filer_pb.LookupDirectoryEntryResponse{Entry: entry}, nil
+}
+
+func (f *memFiler) ListEntries(req *filer_pb.ListEntriesRequest, stream filer_pb.SeaweedFiler_ListEntriesServer) error {
+	f.Lock()
+	var names []string
+	for path, entry := range f.entries {
+		if filepath.Dir(path) == filepath.Clean(req.Directory) && strings.HasPrefix(entry.Name, req.Prefix) &&
+			(entry.Name > req.StartFromFileName || entry.Name == req.StartFromFileName && req.InclusiveStartFrom) {
+			names = append(names, entry.Name)
+		}
+	}
+	sort.Strings(names)
+	if req.Limit > 0 && len(names) > int(req.Limit) {
+		names = names[:req.Limit]
+	}
+	var entries []*filer_pb.Entry
+	for _, name := range names {
+		entries = append(entries, f.entries[filepath.Join(req.Directory, name)])
+	}
+	f.Unlock()
+
+	for _, entry := range entries {
+		if err := stream.Send(&filer_pb.ListEntriesResponse{Entry: entry}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *memFiler) CreateEntry(ctx context.Context, req *filer_pb.CreateEntryRequest) (*filer_pb.CreateEntryResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.mkdirAll(filepath.Clean(req.Directory))
+	f.entries[filepath.Join(req.Directory, req.Entry.Name)] = req.Entry
+	return &filer_pb.CreateEntryResponse{}, nil
+}
+
+func (f *memFiler) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntryRequest) (*filer_pb.UpdateEntryResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	path := filepath.Join(req.Directory, req.Entry.Name)
+	if _, found := f.entries[path]; !found {
+		return nil, fmt.Errorf("update %s: %v", path, filer2.ErrNotFound)
+	}
+	f.entries[path] = req.Entry
+	return &filer_pb.UpdateEntryResponse{}, nil
+}
+
+func (f *memFiler) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntryRequest) (*filer_pb.DeleteEntryResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	path := filepath.Join(req.Directory, req.Name)
+	if _, found := f.entries[path]; !found {
+		return nil, fmt.Errorf("delete %s: %v", path, filer2.ErrNotFound)
+	}
+	for p := range f.entries {
+		if strings.HasPrefix(p, path+"/") {
+			if !req.IsRecursive {
+				return nil, fmt.Errorf("delete %s: folder is not empty", path)
+			}
+			delete(f.entries, p)
+		}
+	}
+	delete(f.entries, path)
+	return &filer_pb.DeleteEntryResponse{}, nil
+}
+
+func (f *memFiler) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	oldPath, newPath := filepath.Join(req.OldDirectory, req.OldName), filepath.Join(req.NewDirectory, req.NewName)
+	if filepath.Clean(req.OldDirectory) == f.failRenameFrom {
+		return nil, fmt.Errorf("rename %s: store is unavailable", oldPath)
+	}
+	entry, found := f.entries[oldPath]
+	if !found {
+		return nil, fmt.Errorf("rename %s: %v", oldPath, filer2.ErrNotFound)
+	}
+	f.mkdirAll(filepath.Clean(req.NewDirectory))
+	for p, e := range f.entries {
+		if strings.HasPrefix(p, oldPath+"/") {
+			f.entries[newPath+strings.TrimPrefix(p, oldPath)] = e
+			delete(f.entries, p)
+		}
+	}
+	delete(f.entries, oldPath)
+	entry.Name = req.NewName
+	f.entries[newPath] = entry
+	return &filer_pb.AtomicRenameEntryResponse{}, nil
+}
+
+func newTestS3ApiServer(t *testing.T) (s3a *S3ApiServer, filer *memFiler, stop func()) {
+	filer = &memFiler{entries: make(map[string]*filer_pb.Entry)}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	filer_pb.RegisterSeaweedFilerServer(grpcServer, filer)
+	go grpcServer.Serve(listener)
+
+	s3a = &S3ApiServer{option: &S3ApiServerOption{
+		FilerGrpcAddress: listener.Addr().String(),
+		BucketsPath:      "/buckets",
+		GrpcDialOption:   grpc.WithInsecure(),
+	}}
+	return s3a, filer, grpcServer.Stop
+}
+
+func createTestBucket(t *testing.T, s3a *S3ApiServer, bucket, versioning string) {
+	if err := s3a.mkdir(s3a.option.BucketsPath, bucket, func(entry *filer_pb.Entry) {
+		entry.Extended = map[string][]byte{versioningExtendedKey: []byte(versioning)}
+	}); err != nil {
+		t.Fatalf("create bucket %s: %v", bucket, err)
+	}
+}
+
+const testContentKey = "X-Amz-Meta-Content"
+
+// putTestObject writes an object the same way as the object handlers, with the content kept in the metadata
+func putTestObject(t *testing.T, s3a *S3ApiServer, bucket, object, content string) (versionId string) {
+	write, code := s3a.beginVersionedWrite(bucket, object)
+	if code != ErrNone {
+		t.Fatalf("begin writing %s: %v", object, code)
+	}
+	if err := s3a.mkFile(write.dir, write.name, nil, func(entry *filer_pb.Entry) {
+		entry.Extended = map[string][]byte{testContentKey: []byte(content)}
+		setVersionId(entry, write.versionId)
+	}); err != nil {
+		write.complete(ErrInternalError)
+		t.Fatalf("write %s: %v", object, err)
+	}
+	if code = write.complete(ErrNone); code != ErrNone {
+		t.Fatalf("complete writing %s: %v", object, code)
+	}
+	return write.versionId
+}
+
+func getTestObject(s3a *S3ApiServer, bucket, object, versionId string) (content string, code ErrorCode) {
+	_, entry, code := s3a.getObjectVersion(bucket, object, versionId)
+	if code != ErrNone {
+		return "", code
+	}
+	return string(entry.Extended[testContentKey]), ErrNone
+}
+
+func TestVersionedObjects(t *testing.T) {
+	s3a, _, stop := newTestS3ApiServer(t)
+	defer stop()
+	createTestBucket(t, s3a, "bucket", versioningEnabled)
+
+	v1 := putTestObject(t, s3a, "bucket", "/dir/object", "one")
+	v2 := putTestObject(t, s3a, "bucket", "/dir/object", "two")
+	if v1 == "" || v2 == "" || v1 == v2 {
+		t.Fatalf("version ids %q and %q", v1, v2)
+	}
+
+	for versionId, expected := range map[string]string{"": "two", v1: "one", v2: "two"} {
+		if content, code := getTestObject(s3a, "bucket", "/dir/object", versionId); code != ErrNone || content != expected {
+			t.Errorf("get version %q: %q %v", versionId, content, code)
+		}
+	}
+
+	markerVersionId, code := s3a.createDeleteMarker("bucket", "/dir/object", versioningEnabled)
+	if code != ErrNone {
+		t.Fatalf("delete: %v", code)
+	}
+	if _, code = getTestObject(s3a, "bucket", "/dir/object", ""); code != ErrNoSuchKey {
+		t.Errorf("get deleted object: %v", code)
+	}
+	if content, code := getTestObject(s3a, "bucket", "/dir/object", v2); code != ErrNone || content != "two" {
+		t.Errorf("get version %s of the deleted object: %q %v", v2, content, code)
+	}
+
+	output, err := s3a.listObjectVersions("bucket", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(output.Versions) != 2 || len(output.DeleteMarkers) != 1 || !*output.DeleteMarkers[0].IsLatest || *output.DeleteMarkers[0].VersionId != markerVersionId {
+		t.Fatalf("list versions: %+v", output)
+	}
+	if *output.Versions[0].VersionId != v2 || *output.Versions[1].VersionId != v1 || *output.Versions[0].IsLatest {
+		t.Errorf("list versions: %+v", output.Versions)
+	}
+
+	deletedMarker, code := s3a.deleteObjectVersion("bucket", "/dir/object", markerVersionId)
+	if code != ErrNone || !deletedMarker {
+		t.Fatalf("delete the delete marker: %v %v", deletedMarker, code)
+	}
+	if content, code := getTestObject(s3a, "bucket", "/dir/object", ""); code != ErrNone || content != "two" {
+		t.Errorf("get the undeleted object: %q %v", content, code)
+	}
+}
+
+func TestSuspendedVersioning(t *testing.T) {
+	s3a, _, stop := newTestS3ApiServer(t)
+	defer stop()
+	createTestBucket(t, s3a, "bucket", versioningEnabled)
+
+	v1 := putTestObject(t, s3a, "bucket", "/object", "one")
+	if err := s3a.setBucketVersioning("bucket", versioningSuspended); err != nil {
+		t.Fatalf("suspend versioning: %v", err)
+	}
+	if versionId := putTestObject(t, s3a, "bucket", "/object", "two"); versionId != "" {
+		t.Fatalf("version id %q with suspended versioning", versionId)
+	}
+	putTestObject(t, s3a, "bucket", "/object", "three")
+
+	for versionId, expected := range map[string]string{"": "three", nullVersionId: "three", v1: "one"} {
+		if content, code := getTestObject(s3a, "bucket", "/object", versionId); code != ErrNone || content != expected {
+			t.Errorf("get version %q: %q %v", versionId, content, code)
+		}
+	}
+
+	if _, code := s3a.createDeleteMarker("bucket", "/object", versioningSuspended); code != ErrNone {
+		t.Fatalf("delete: %v", code)
+	}
+	entry, err := s3a.getEntry("/buckets/bucket", "object")
+	if !isNotFound(err) {
+		t.Errorf("the null version is not replaced by the delete marker: %v %v", entry, err)
+	}
+	output, err := s3a.listObjectVersions("bucket", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(output.Versions) != 1 || len(output.DeleteMarkers) != 1 || *output.DeleteMarkers[0].VersionId != nullVersionId {
+		t.Errorf("list versions: %+v", output)
+	}
+}
+
+func TestVersionedWriteFailures(t *testing.T) {
+	s3a, filer, stop := newTestS3ApiServer(t)
+	defer stop()
+	createTestBucket(t, s3a, "bucket", versioningEnabled)
+
+	v1 := putTestObject(t, s3a, "bucket", "/object", "one")
+
+	expectUnchanged := func(step string) {
+		if content, code := getTestObject(s3a, "bucket", "/object", ""); code != ErrNone || content != "one" {
+			t.Errorf("%s: current object %q %v", step, content, code)
+		}
+		if versions, err := s3a.listPriorVersions("bucket", "/object"); err != nil || len(versions) != 0 {
+			t.Errorf("%s: prior versions %v %v", step, versions, err)
+		}
+		if entries, err := s3a.list(s3a.genStagingFolder("bucket"), "", "", false, 0); err != nil || len(entries) != 0 {
+			t.Errorf("%s: staged objects %v %v", step, entries, err)
+		}
+	}
+
+	// the upload fails
+	write, code := s3a.beginVersionedWrite("bucket", "/object")
+	if code != ErrNone {
+		t.Fatalf("begin writing: %v", code)
+	}
+	if err := s3a.mkFile(write.dir, write.name, nil, nil); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if code = write.complete(ErrInvalidDigest); code != ErrInvalidDigest {
+		t.Errorf("failed upload: %v", code)
+	}
+	expectUnchanged("failed upload")
+
+	// the current object can not be kept as a prior version
+	filer.failRenameFrom = "/buckets/bucket"
+	write, _ = s3a.beginVersionedWrite("bucket", "/object")
+	s3a.mkFile(write.dir, write.name, nil, nil)
+	if code = write.complete(ErrNone); code != ErrInternalError {
+		t.Errorf("failed to keep the prior version: %v", code)
+	}
+	expectUnchanged("failed to keep the prior version")
+
+	// the new object can not be moved in place, after the current object is moved away
+	filer.failRenameFrom = s3a.genStagingFolder("bucket")
+	write, _ = s3a.beginVersionedWrite("bucket", "/object")
+	s3a.mkFile(write.dir, write.name, nil, nil)
+	if code = write.complete(ErrNone); code != ErrInternalError {
+		t.Errorf("failed to promote the new object: %v", code)
+	}
+	expectUnchanged("failed to promote the new object")
+	if content, code := getTestObject(s3a, "bucket", "/object", v1); code != ErrNone || content != "one" {
+		t.Errorf("get the restored version: %q %v", content, code)
+	}
+
+	// the delete marker can not be added after the current object
+	filer.failRenameFrom = "/buckets/bucket"
+	if _, code = s3a.createDeleteMarker("bucket", "/object", versioningEnabled); code != ErrInternalError {
+		t.Errorf("failed to delete: %v", code)
+	}
+	expectUnchanged("failed to delete")
+}
+
+func TestBucketVersioningErrors(t *testing.T) {
+	s3a, filer, stop := newTestS3ApiServer(t)
+	defer stop()
+	createTestBucket(t, s3a, "bucket", "")
+
+	if status, code := s3a.bucketVersioning("bucket"); code != ErrNone || status != "" {
+		t.Errorf("bucket versioning: %q %v", status, code)
+	}
+	if _, code := s3a.bucketVersioning("missing"); code != ErrNoSuchBucket {
+		t.Errorf("missing bucket versioning: %v", code)
+	}
+
+	filer.failLookup = true
+	if _, code := s3a.bucketVersioning("bucket"); code != ErrInternalError {
+		t.Errorf("bucket versioning with the filer failing: %v", code)
+	}
+	if _, code := s3a.beginVersionedWrite("bucket", "/object"); code != ErrInternalError {
+		t.Errorf("write with the filer failing: %v", code)
+	}
+}
+
+func TestConcurrentVersionedWrites(t *testing.T) {
+	s3a, _, stop := newTestS3ApiServer(t)
+	defer stop()
+	createTestBucket(t, s3a, "bucket", versioningEnabled)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			write, code := s3a.beginVersionedWrite("bucket", "/object")
+			if code != ErrNone {
+				t.Errorf("begin writing %d: %v", i, code)
+				return
+			}
+			if err := s3a.mkFile(write.dir, write.name, nil, func(entry *filer_pb.Entry) {
+				setVersionId(entry, write.versionId)
+			}); err != nil {
+				t.Errorf("write %d: %v", i, err)
+			}
+			if code = write.complete(ErrNone); code != ErrNone {
+				t.Errorf("complete writing %d: %v", i, code)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	output, err := s3a.listObjectVersions("bucket", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(output.Versions) != 8 {
+		t.Errorf("%d versions after 8 writes", len(output.Versions))
+	}
+}
+
+func TestListObjectVersionsPages(t *testing.T) {
+	s3a, _, stop := newTestS3ApiServer(t)
+	defer stop()
+	createTestBucket(t, s3a, "bucket", versioningEnabled)
+
+	for _, object := range []string{"/a/b", "/a/b", "/a-c", "/d", "/e/f/g", "/e/f/g", "/e/f/g"} {
+		putTestObject(t, s3a, "bucket", object, object)
+	}
+	if _, code := s3a.createDeleteMarker("bucket", "/d", versioningEnabled); code != ErrNone {
+		t.Fatalf("delete: %v", code)
+	}
+
+	listAll := func(prefix string, maxKeys int) (versions []string) {
+		keyMarker, versionIdMarker := "", ""
+		for {
+			output, err := s3a.listObjectVersions("bucket", prefix, keyMarker, versionIdMarker, maxKeys)
+			if err != nil {
+				t.Fatalf("list versions: %v", err)
+			}
+			if len(output.Versions)+len(output.DeleteMarkers) > maxKeys {
+				t.Fatalf("%d versions in a page of %d", len(output.Versions)+len(output.DeleteMarkers), maxKeys)
+			}
+			for _, version := range output.Versions {
+				versions = append(versions, *version.Key+" "+*version.VersionId)
+			}
+			for _, marker := range output.DeleteMarkers {
+				versions = append(versions, *marker.Key+" "+*marker.VersionId)
+			}
+			if !*output.IsTruncated {
+				return versions
+			}
+			keyMarker, versionIdMarker = *output.NextKeyMarker, *output.NextVersionIdMarker
+		}
+	}
+
+	all := listAll("", 1000)
+	if len(all) != 8 {
+		t.Fatalf("list versions: %v", all)
+	}
+	var keys []string
+	for _, version := range all {
+		keys = append(keys, strings.Fields(version)[0])
+	}
+	if strings.Join(keys, ",") != "a/b,a/b,a-c,d,d,e/f/g,e/f/g,e/f/g" {
+		t.Errorf("list versions order: %v", keys)
+	}
+
+	for _, maxKeys := range []int{1, 2, 3} {
+		paged := listAll("", maxKeys)
+		sort.Strings(paged)
+		expected := append([]string(nil), all...)
+		sort.Strings(expected)
+		if strings.Join(paged, ",") != strings.Join(expected, ",") {
+			t.Errorf("list versions by %d: %v", maxKeys, paged)
+		}
+	}
+
+	if prefixed := listAll("a", 1000); len(prefixed) != 3 {
+		t.Errorf("list versions with prefix: %v", prefixed)
+	}
+	if prefixed := listAll("e/f/", 1); len(prefixed) != 3 {
+		t.Errorf("list versions with directory prefix: %v", prefixed)
+	}
+}
+
+func TestCompareKeys(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"a", "a", 0},
+		{"a", "a/b", -1},
+		{"a/b", "a-c", -1},
+		{"a-c", "a/b", 1},
+		{"a/b/c", "a/c", -1},
+		{"", "a", -1},
+	} {
+		if c := compareKeys(tc.a, tc.b); c < 0 != (tc.expected < 0) || c > 0 != (tc.expected > 0) {
+			t.Errorf("compare %q and %q: %d", tc.a, tc.b, c)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package s3api
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"os"
@@ -130,3 +132,53 @@ func (s3a *S3ApiServer) HeadBucketHandler(w http.ResponseWriter, r *http.Request
 
 	writeSuccessResponseEmpty(w)
 }
+
+type VersioningConfigurationOutput struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+func (s3a *S3ApiServer) GetBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	status, errCode := s3a.bucketVersioning(bucket)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(VersioningConfigurationOutput{Status: status}))
+}
+
+func (s3a *S3ApiServer) PutBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	var config struct {
+		Status string
+	}
+	if err := xml.NewDecoder(io.LimitReader(r.Body, 64*1024)).Decode(&config); err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+	if config.Status != versioningEnabled && config.Status != versioningSuspended {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if _, errCode := s3a.bucketVersioning(bucket); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err := s3a.setBucketVersioning(bucket, config.Status); err != nil {
+		glog.Errorf("put bucket %s versioning %s: %v", bucket, config.Status, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
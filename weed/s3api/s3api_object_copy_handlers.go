@@ -0,0 +1,293 @@
+package s3api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/gorilla/mux"
+)
+
+type CopyPartResult struct {
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+}
+
+// CopyObjectHandler - Copy an object, the data is copied chunk by chunk between the volume servers
+func (s3a *S3ApiServer) CopyObjectHandler(w http.ResponseWriter, r *http.Request) {
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectCOPY.html
+
+	vars := mux.Vars(r)
+	dstBucket := vars["bucket"]
+	dstObject := getObject(vars)
+
+	srcBucket, srcObject, srcVersionId := parseCopySource(r.Header.Get("X-Amz-Copy-Source"))
+	if srcBucket == "" || srcObject == "/" {
+		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+		return
+	}
+
+	replaceMetadata, errCode := isReplaceMetadataDirective(r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	srcDir, srcEntry, errCode := s3a.getObjectVersion(srcBucket, srcObject, srcVersionId)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if isDeleteMarker(srcEntry) {
+		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+		return
+	}
+
+	if srcBucket == dstBucket && srcObject == dstObject && srcVersionId == "" {
+		// copying to itself only changes the metadata, the chunks stay the same
+		if !replaceMetadata {
+			writeErrorResponse(w, ErrInvalidCopyDest, r.URL)
+			return
+		}
+		srcEntry.Attributes.Mtime = time.Now().Unix()
+		replaceObjectMetadata(srcEntry, r.Header)
+		if err := s3a.updateEntry(srcDir, srcEntry); err != nil {
+			glog.Errorf("copy %s%s to itself: %v", srcBucket, srcObject, err)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+		writeSuccessResponseXML(w, encodeResponse(&CopyObjectResult{
+			ETag:         "\"" + filer2.ETag(srcEntry.Chunks) + "\"",
+			LastModified: time.Unix(srcEntry.Attributes.Mtime, 0).UTC(),
+		}))
+		return
+	}
+
+	dstChunks, err := s3a.copyChunks(srcEntry.Chunks, 0, int64(filer2.TotalSize(srcEntry.Chunks)), dstBucket)
+	if err != nil {
+		glog.Errorf("copy %s%s to %s%s: %v", srcBucket, srcObject, dstBucket, dstObject, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	write, errCode := s3a.beginVersionedWrite(dstBucket, dstObject)
+	if errCode != ErrNone {
+		s3a.deleteChunks(dstChunks)
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if err = s3a.mkFile(write.dir, write.name, dstChunks, func(entry *filer_pb.Entry) {
+		entry.Attributes.Collection = dstBucket
+		if replaceMetadata {
+			replaceObjectMetadata(entry, r.Header)
+		} else {
+			entry.Attributes.Mime = srcEntry.Attributes.Mime
+			entry.Extended = objectMetadata(srcEntry.Extended)
+		}
+		setVersionId(entry, write.versionId)
+	}); err != nil {
+		glog.Errorf("copy %s%s to %s%s: %v", srcBucket, srcObject, dstBucket, dstObject, err)
+		write.complete(ErrInternalError)
+		s3a.deleteChunks(dstChunks)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	if errCode = write.complete(ErrNone); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	if write.versionId != "" {
+		w.Header().Set(versionIdHeader, write.versionId)
+	}
+	writeSuccessResponseXML(w, encodeResponse(&CopyObjectResult{
+		ETag:         "\"" + filer2.ETag(dstChunks) + "\"",
+		LastModified: time.Now().UTC(),
+	}))
+
+}
+
+// CopyObjectPartHandler - Upload a part by copying a range of an existing object
+func (s3a *S3ApiServer) CopyObjectPartHandler(w http.ResponseWriter, r *http.Request) {
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/mpUploadUploadPartCopy.html
+
+	vars := mux.Vars(r)
+	dstBucket := vars["bucket"]
+
+	srcBucket, srcObject, srcVersionId := parseCopySource(r.Header.Get("X-Amz-Copy-Source"))
+	if srcBucket == "" || srcObject == "/" {
+		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+		return
+	}
+
+	uploadID := r.URL.Query().Get("uploadId")
+	exists, err := s3a.exists(s3a.genUploadsFolder(dstBucket), uploadID, true)
+	if !exists {
+		writeErrorResponse(w, ErrNoSuchUpload, r.URL)
+		return
+	}
+
+	partIDString := r.URL.Query().Get("partNumber")
+	partID, err := strconv.Atoi(partIDString)
+	if err != nil || partID < 1 {
+		writeErrorResponse(w, ErrInvalidPart, r.URL)
+		return
+	}
+	if partID > globalMaxPartID {
+		writeErrorResponse(w, ErrInvalidMaxParts, r.URL)
+		return
+	}
+
+	_, srcEntry, errCode := s3a.getObjectVersion(srcBucket, srcObject, srcVersionId)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if isDeleteMarker(srcEntry) {
+		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
+		return
+	}
+
+	offset, size, ok := parseCopySourceRange(r.Header.Get("X-Amz-Copy-Source-Range"), int64(filer2.TotalSize(srcEntry.Chunks)))
+	if !ok {
+		writeErrorResponse(w, ErrInvalidCopyPartRange, r.URL)
+		return
+	}
+
+	dstChunks, err := s3a.copyChunks(srcEntry.Chunks, offset, size, dstBucket)
+	if err != nil {
+		glog.Errorf("copy %s%s to upload %s part %d: %v", srcBucket, srcObject, uploadID, partID, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if err = s3a.mkFile(s3a.genUploadsFolder(dstBucket)+"/"+uploadID, fmt.Sprintf("%04d.part", partID-1), dstChunks, func(entry *filer_pb.Entry) {
+		entry.Attributes.Collection = dstBucket
+	}); err != nil {
+		glog.Errorf("copy %s%s to upload %s part %d: %v", srcBucket, srcObject, uploadID, partID, err)
+		s3a.deleteChunks(dstChunks)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(CopyPartResult{
+		ETag:         "\"" + filer2.ETag(dstChunks) + "\"",
+		LastModified: time.Now().UTC(),
+	}))
+
+}
+
+func (s3a *S3ApiServer) objectDirAndName(bucket, object string) (dir, name string) {
+	return filepath.Join(s3a.option.BucketsPath, bucket, filepath.Dir(object)), filepath.Base(object)
+}
+
+// parseCopySource parses the x-amz-copy-source header, in the form of "/bucket/object" or "bucket/object",
+// url encoded and optionally followed by "?versionId=..."
+func parseCopySource(copySource string) (bucket, object, versionId string) {
+	if i := strings.Index(copySource, "?"); i >= 0 {
+		if values, err := url.ParseQuery(copySource[i+1:]); err == nil {
+			versionId = values.Get("versionId")
+		}
+		copySource = copySource[:i]
+	}
+	if unescaped, err := url.QueryUnescape(copySource); err == nil {
+		copySource = unescaped
+	}
+	copySource = strings.TrimPrefix(copySource, "/")
+	// the source is authorized by its bucket name, so dot segments must not lead into another bucket
+	for _, segment := range strings.Split(copySource, "/") {
+		if isDotSegment(segment) {
+			return "", "/", versionId
+		}
+	}
+	parts := strings.SplitN(copySource, "/", 2)
+	if len(parts) < 2 {
+		return parts[0], "/", versionId
+	}
+	return parts[0], "/" + parts[1], versionId
+}
+
+// isDotSegment checks for "." and "..", also when they are still url encoded
+func isDotSegment(segment string) bool {
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		segment = unescaped
+	}
+	return segment == "." || segment == ".."
+}
+
+// parseCopySourceRange parses the x-amz-copy-source-range header "bytes=first-last",
+// and returns the whole object if the header is empty
+func parseCopySourceRange(rangeHeader string, objectSize int64) (offset, size int64, ok bool) {
+	if rangeHeader == "" {
+		return 0, objectSize, true
+	}
+	if !strings.HasPrefix(rangeHeader, "bytes=") {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(rangeHeader, "bytes="), "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	first, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	last, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if first < 0 || first > last || last >= objectSize {
+		return 0, 0, false
+	}
+	return first, last - first + 1, true
+}
+
+// isReplaceMetadataDirective checks x-amz-metadata-directive, which is either COPY by default or REPLACE
+func isReplaceMetadataDirective(h http.Header) (bool, ErrorCode) {
+	switch h.Get("X-Amz-Metadata-Directive") {
+	case "", "COPY":
+		return false, ErrNone
+	case "REPLACE":
+		return true, ErrNone
+	}
+	return false, ErrInvalidMetadataDirective
+}
+
+// replaceObjectMetadata sets the object metadata from the request headers, dropping the existing ones
+func replaceObjectMetadata(entry *filer_pb.Entry, h http.Header) {
+	versionId := entry.Extended[versionIdHeader]
+	entry.Attributes.Mime = h.Get("Content-Type")
+	entry.Extended = objectMetadata(filer2.ExtendedFromHeader(h))
+	setVersionId(entry, string(versionId))
+}
+
+// objectMetadata copies the object metadata, without the version id of the object
+func objectMetadata(extended map[string][]byte) map[string][]byte {
+	metadata := make(map[string][]byte)
+	for k, v := range extended {
+		if k != versionIdHeader && k != deleteMarkerHeader {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
+
+func setVersionId(entry *filer_pb.Entry, versionId string) {
+	if versionId == "" {
+		return
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[versionIdHeader] = []byte(versionId)
+}
@@ -0,0 +1,28 @@
+package s3api
+
+import (
+	"testing"
+)
+
+func TestParseCopySource(t *testing.T) {
+	for _, tc := range []struct {
+		copySource        string
+		bucket, object    string
+		expectedVersionId string
+	}{
+		{"/bucket/dir/object", "bucket", "/dir/object", ""},
+		{"bucket/dir%2Fobject%20name?versionId=v1", "bucket", "/dir/object name", "v1"},
+		{"bucket", "bucket", "/", ""},
+		{"allowed-bucket/../other-bucket/key", "", "/", ""},
+		{"allowed-bucket/%2E%2E/other-bucket/key", "", "/", ""},
+		{"allowed-bucket/%252e%252e/other-bucket/key", "", "/", ""},
+		{"allowed-bucket/dir/./key", "", "/", ""},
+		{"../other-bucket/key", "", "/", ""},
+		{"bucket/dir/..key", "bucket", "/dir/..key", ""},
+	} {
+		bucket, object, versionId := parseCopySource(tc.copySource)
+		if bucket != tc.bucket || object != tc.object || versionId != tc.expectedVersionId {
+			t.Errorf("parse %q: bucket %q object %q versionId %q", tc.copySource, bucket, object, versionId)
+		}
+	}
+}
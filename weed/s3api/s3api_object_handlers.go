@@ -21,6 +21,8 @@ var (
 func init() {
 	client = &http.Client{Transport: &http.Transport{
 		MaxIdleConnsPerHost: 1024,
+		// pass the content through as is, it may be stored with its own Content-Encoding
+		DisableCompression: true,
 	}}
 }
 
@@ -41,20 +43,31 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 	rAuthType := getRequestAuthType(r)
 	dataReader := r.Body
 	if rAuthType == authTypeStreamingSigned {
-		dataReader = newSignV4ChunkedReader(r)
+		var errCode ErrorCode
+		dataReader, errCode = s3a.iam.newSignV4ChunkedReader(r)
+		if errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
 	}
 
-	uploadUrl := fmt.Sprintf("http://%s%s/%s%s?collection=%s",
-		s3a.option.Filer, s3a.option.BucketsPath, bucket, object, bucket)
+	write, errCode := s3a.beginVersionedWrite(bucket, object)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
+	etag, errCode := s3a.putToFiler(r, write.uploadUrl(), dataReader, write.versionId)
 
-	if errCode != ErrNone {
+	if errCode = write.complete(errCode); errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
 	setEtag(w, etag)
+	if write.versionId != "" {
+		w.Header().Set(versionIdHeader, write.versionId)
+	}
 
 	writeSuccessResponseEmpty(w)
 }
@@ -70,8 +83,11 @@ func (s3a *S3ApiServer) GetObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	destUrl := fmt.Sprintf("http://%s%s/%s%s",
-		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
+	destUrl, errCode := s3a.objectUrl(w, bucket, object, r.URL.Query().Get("versionId"))
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
 
 	s3a.proxyToFiler(w, r, destUrl, passThroughResponse)
 
@@ -83,8 +99,11 @@ func (s3a *S3ApiServer) HeadObjectHandler(w http.ResponseWriter, r *http.Request
 	bucket := vars["bucket"]
 	object := getObject(vars)
 
-	destUrl := fmt.Sprintf("http://%s%s/%s%s",
-		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
+	destUrl, errCode := s3a.objectUrl(w, bucket, object, r.URL.Query().Get("versionId"))
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
 
 	s3a.proxyToFiler(w, r, destUrl, passThroughResponse)
 
@@ -96,6 +115,37 @@ func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Reque
 	bucket := vars["bucket"]
 	object := getObject(vars)
 
+	if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+		deletedMarker, errCode := s3a.deleteObjectVersion(bucket, object, versionId)
+		if errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+		w.Header().Set(versionIdHeader, versionId)
+		if deletedMarker {
+			w.Header().Set(deleteMarkerHeader, "true")
+		}
+		writeResponse(w, http.StatusNoContent, nil, mimeNone)
+		return
+	}
+
+	status, errCode := s3a.bucketVersioning(bucket)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+	if status != "" {
+		markerVersionId, errCode := s3a.createDeleteMarker(bucket, object, status)
+		if errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+		w.Header().Set(versionIdHeader, markerVersionId)
+		w.Header().Set(deleteMarkerHeader, "true")
+		writeResponse(w, http.StatusNoContent, nil, mimeNone)
+		return
+	}
+
 	destUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
 
@@ -114,6 +164,25 @@ func (s3a *S3ApiServer) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *h
 	writeErrorResponse(w, ErrNotImplemented, r.URL)
 }
 
+// objectUrl locates the current object, or one version of the object if the versionId is specified
+func (s3a *S3ApiServer) objectUrl(w http.ResponseWriter, bucket, object, versionId string) (destUrl string, code ErrorCode) {
+
+	if versionId == "" {
+		return fmt.Sprintf("http://%s%s/%s%s", s3a.option.Filer, s3a.option.BucketsPath, bucket, object), ErrNone
+	}
+
+	dir, entry, code := s3a.getObjectVersion(bucket, object, versionId)
+	if code != ErrNone {
+		return "", code
+	}
+	if isDeleteMarker(entry) {
+		w.Header().Set(deleteMarkerHeader, "true")
+		return "", ErrMethodNotAllowed
+	}
+
+	return fmt.Sprintf("http://%s%s/%s", s3a.option.Filer, dir, entry.Name), ErrNone
+}
+
 func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, destUrl string, responseFn func(proxyResonse *http.Response, w http.ResponseWriter)) {
 
 	glog.V(2).Infof("s3 proxying %s to %s", r.Method, destUrl)
@@ -155,7 +224,7 @@ func passThroughResponse(proxyResonse *http.Response, w http.ResponseWriter) {
 	io.Copy(w, proxyResonse.Body)
 }
 
-func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.ReadCloser) (etag string, code ErrorCode) {
+func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.ReadCloser, versionId string) (etag string, code ErrorCode) {
 
 	hash := md5.New()
 	var body io.Reader = io.TeeReader(dataReader, hash)
@@ -175,6 +244,17 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 			proxyReq.Header.Add(header, value)
 		}
 	}
+	// aws-chunked only describes the signed streaming payload, which is already decoded
+	if contentEncoding := removeAwsChunked(r.Header.Get("Content-Encoding")); contentEncoding != "" {
+		proxyReq.Header.Set("Content-Encoding", contentEncoding)
+	} else {
+		proxyReq.Header.Del("Content-Encoding")
+	}
+	// the version id is saved with the object by the filer
+	proxyReq.Header.Del(versionIdHeader)
+	if versionId != "" {
+		proxyReq.Header.Set(versionIdHeader, versionId)
+	}
 
 	resp, postErr := client.Do(proxyReq)
 
@@ -182,6 +262,9 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 
 	if postErr != nil {
 		glog.Errorf("post to filer: %v", postErr)
+		if isContentSha256Mismatch(dataReader) {
+			return "", ErrContentSHA256Mismatch
+		}
 		return "", ErrInternalError
 	}
 	defer resp.Body.Close()
@@ -207,6 +290,17 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 	return etag, ErrNone
 }
 
+func removeAwsChunked(contentEncoding string) string {
+	var encodings []string
+	for _, encoding := range strings.Split(contentEncoding, ",") {
+		encoding = strings.TrimSpace(encoding)
+		if encoding != "" && encoding != "aws-chunked" {
+			encodings = append(encodings, encoding)
+		}
+	}
+	return strings.Join(encodings, ",")
+}
+
 func setEtag(w http.ResponseWriter, etag string) {
 	if etag != "" {
 		if strings.HasPrefix(etag, "\"") {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/chrislusf/seaweedfs/weed/filer2"
 	"github.com/gorilla/mux"
 	"net/http"
 	"net/url"
@@ -26,9 +27,10 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 	object = vars["object"]
 
 	response, errCode := s3a.createMultipartUpload(&s3.CreateMultipartUploadInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(object),
-	})
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(object),
+		ContentType: aws.String(r.Header.Get("Content-Type")),
+	}, objectMetadata(filer2.ExtendedFromHeader(r.Header)))
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
@@ -63,6 +65,9 @@ func (s3a *S3ApiServer) CompleteMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if response.VersionId != "" {
+		w.Header().Set(versionIdHeader, response.VersionId)
+	}
 	writeSuccessResponseXML(w, encodeResponse(response))
 
 }
@@ -194,13 +199,18 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 
 	dataReader := r.Body
 	if rAuthType == authTypeStreamingSigned {
-		dataReader = newSignV4ChunkedReader(r)
+		var errCode ErrorCode
+		dataReader, errCode = s3a.iam.newSignV4ChunkedReader(r)
+		if errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
 	}
 
 	uploadUrl := fmt.Sprintf("http://%s%s/%s/%04d.part",
 		s3a.option.Filer, s3a.genUploadsFolder(bucket), uploadID, partID-1)
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
+	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, "")
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
@@ -2,10 +2,13 @@ package s3api
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"time"
 
@@ -21,6 +24,17 @@ const (
 	maxObjectListSizeLimit = 1000 // Limit number of objects in a listObjectsResponse.
 )
 
+type ListBucketResultContents struct {
+	s3.Object
+	UserMetadata []MetadataEntry `xml:"UserMetadata,omitempty"`
+}
+
+type ListBucketResultOutput struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	s3.ListObjectsOutput
+	Contents []*ListBucketResultContents `xml:"Contents,omitempty"`
+}
+
 func (s3a *S3ApiServer) ListObjectsV2Handler(w http.ResponseWriter, r *http.Request) {
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/v2-RESTBucketGET.html
@@ -85,7 +99,37 @@ func (s3a *S3ApiServer) ListObjectsV1Handler(w http.ResponseWriter, r *http.Requ
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
 
-func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys int, marker string) (response *s3.ListObjectsOutput, err error) {
+func (s3a *S3ApiServer) ListObjectVersionsHandler(w http.ResponseWriter, r *http.Request) {
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketGETVersion.html
+
+	// collect parameters
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	prefix, keyMarker, versionIdMarker, delimiter, maxKeys := getListObjectVersionsArgs(r.URL.Query())
+
+	if maxKeys < 0 {
+		writeErrorResponse(w, ErrInvalidMaxKeys, r.URL)
+		return
+	}
+	if delimiter != "" {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	response, err := s3a.listObjectVersions(bucket, prefix, keyMarker, versionIdMarker, maxKeys)
+
+	if err != nil {
+		glog.Errorf("list object versions %s: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys int, marker string) (response *ListBucketResultOutput, err error) {
 
 	// convert full path prefix into directory name and prefix for entry name
 	dir, prefix := filepath.Split(originalPrefix)
@@ -101,17 +145,32 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 			InclusiveStartFrom: false,
 		}
 
-		resp, err := client.ListEntries(context.Background(), request)
+		// stop the stream when not all the entries are received
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.ListEntries(ctx, request)
 		if err != nil {
 			return fmt.Errorf("list buckets: %v", err)
 		}
 
-		var contents []*s3.Object
+		var contents []*ListBucketResultContents
 		var commonPrefixes []*s3.CommonPrefix
 		var counter int
 		var lastEntryName string
 		var isTruncated bool
-		for _, entry := range resp.Entries {
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr == io.EOF {
+				break
+			}
+			if recvErr != nil {
+				return fmt.Errorf("list buckets: %v", recvErr)
+			}
+			entry := resp.Entry
+			if entry.IsDirectory && dir == "" && isHiddenFolder(entry.Name) {
+				continue
+			}
 			counter++
 			if counter > maxKeys {
 				isTruncated = true
@@ -123,30 +182,35 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 					Prefix: aws.String(fmt.Sprintf("%s%s/", dir, entry.Name)),
 				})
 			} else {
-				contents = append(contents, &s3.Object{
-					Key:          aws.String(fmt.Sprintf("%s%s", dir, entry.Name)),
-					LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0)),
-					ETag:         aws.String("\"" + filer2.ETag(entry.Chunks) + "\""),
-					Size:         aws.Int64(int64(filer2.TotalSize(entry.Chunks))),
-					Owner: &s3.Owner{
-						ID:          aws.String("bcaf161ca5fb16fd081034f"),
-						DisplayName: aws.String("webfile"),
+				contents = append(contents, &ListBucketResultContents{
+					Object: s3.Object{
+						Key:          aws.String(fmt.Sprintf("%s%s", dir, entry.Name)),
+						LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0)),
+						ETag:         aws.String("\"" + filer2.ETag(entry.Chunks) + "\""),
+						Size:         aws.Int64(int64(filer2.TotalSize(entry.Chunks))),
+						Owner: &s3.Owner{
+							ID:          aws.String("bcaf161ca5fb16fd081034f"),
+							DisplayName: aws.String("webfile"),
+						},
+						StorageClass: aws.String("STANDARD"),
 					},
-					StorageClass: aws.String("STANDARD"),
+					UserMetadata: userMetadataEntries(entry.Extended),
 				})
 			}
 		}
 
-		response = &s3.ListObjectsOutput{
-			Name:           aws.String(bucket),
-			Prefix:         aws.String(originalPrefix),
-			Marker:         aws.String(marker),
-			NextMarker:     aws.String(lastEntryName),
-			MaxKeys:        aws.Int64(int64(maxKeys)),
-			Delimiter:      aws.String("/"),
-			IsTruncated:    aws.Bool(isTruncated),
-			Contents:       contents,
-			CommonPrefixes: commonPrefixes,
+		response = &ListBucketResultOutput{
+			ListObjectsOutput: s3.ListObjectsOutput{
+				Name:           aws.String(bucket),
+				Prefix:         aws.String(originalPrefix),
+				Marker:         aws.String(marker),
+				NextMarker:     aws.String(lastEntryName),
+				MaxKeys:        aws.Int64(int64(maxKeys)),
+				Delimiter:      aws.String("/"),
+				IsTruncated:    aws.Bool(isTruncated),
+				CommonPrefixes: commonPrefixes,
+			},
+			Contents: contents,
 		}
 
 		glog.V(4).Infof("read directory: %v, found: %v", request, counter)
@@ -157,6 +221,19 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 	return
 }
 
+// userMetadataEntries lists the object metadata headers saved in the entry, sorted by name
+func userMetadataEntries(extended map[string][]byte) (entries []MetadataEntry) {
+	for name, value := range extended {
+		if filer2.IsExtendedHeader(name) && name != versionIdHeader {
+			entries = append(entries, MetadataEntry{Name: name, Value: string(value)})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return
+}
+
 func getListObjectsV2Args(values url.Values) (prefix, token, startAfter, delimiter string, fetchOwner bool, maxkeys int) {
 	prefix = values.Get("prefix")
 	token = values.Get("continuation-token")
@@ -182,3 +259,16 @@ func getListObjectsV1Args(values url.Values) (prefix, marker, delimiter string,
 	}
 	return
 }
+
+func getListObjectVersionsArgs(values url.Values) (prefix, keyMarker, versionIdMarker, delimiter string, maxkeys int) {
+	prefix = values.Get("prefix")
+	keyMarker = values.Get("key-marker")
+	versionIdMarker = values.Get("version-id-marker")
+	delimiter = values.Get("delimiter")
+	if values.Get("max-keys") != "" {
+		maxkeys, _ = strconv.Atoi(values.Get("max-keys"))
+	} else {
+		maxkeys = maxObjectListSizeLimit
+	}
+	return
+}
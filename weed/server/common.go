@@ -191,6 +191,17 @@ func statsMemoryHandler(w http.ResponseWriter, r *http.Request) {
 	writeJsonQuiet(w, r, http.StatusOK, m)
 }
 
+// ServeMetrics serves the Prometheus metrics at /metrics, for servers which can not reserve the path on their own port
+func ServeMetrics(address string) error {
+	listener, err := util.NewListener(address, 0)
+	if err != nil {
+		return err
+	}
+	metricsMux := http.NewServeMux()
+	metricsMux.HandleFunc("/metrics", stats.MetricsHandler)
+	return http.Serve(listener, metricsMux)
+}
+
 func handleStaticResources(defaultMux *http.ServeMux) {
 	defaultMux.Handle("/favicon.ico", http.FileServer(statikFS))
 	defaultMux.Handle("/seaweedfsstatic/", http.StripPrefix("/seaweedfsstatic", http.FileServer(statikFS)))
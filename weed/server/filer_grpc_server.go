@@ -6,7 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/filer2"
@@ -30,57 +29,42 @@ func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.L
 			IsDirectory: entry.IsDirectory(),
 			Attributes:  filer2.EntryAttributeToPb(entry),
 			Chunks:      entry.Chunks,
+			Extended:    entry.Extended,
+
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
 		},
 	}, nil
 }
 
-func (fs *FilerServer) ListEntries(ctx context.Context, req *filer_pb.ListEntriesRequest) (*filer_pb.ListEntriesResponse, error) {
+func (fs *FilerServer) ListEntries(req *filer_pb.ListEntriesRequest, stream filer_pb.SeaweedFiler_ListEntriesServer) error {
 
 	limit := int(req.Limit)
 	if limit == 0 {
 		limit = fs.option.DirListingLimit
 	}
 
-	resp := &filer_pb.ListEntriesResponse{}
-	lastFileName := req.StartFromFileName
-	includeLastFile := req.InclusiveStartFrom
-	for limit > 0 {
-		entries, err := fs.filer.ListDirectoryEntries(filer2.FullPath(req.Directory), lastFileName, includeLastFile, 1024)
-		if err != nil {
-			return nil, err
-		}
-		if len(entries) == 0 {
-			return resp, nil
-		}
-
-		includeLastFile = false
-
-		for _, entry := range entries {
-
-			lastFileName = entry.Name()
-
-			if req.Prefix != "" {
-				if !strings.HasPrefix(entry.Name(), req.Prefix) {
-					continue
-				}
-			}
-
-			resp.Entries = append(resp.Entries, &filer_pb.Entry{
+	var sendErr error
+	_, err := fs.filer.StreamListDirectoryEntries(filer2.FullPath(req.Directory), req.StartFromFileName, req.InclusiveStartFrom, limit, req.Prefix, func(entry *filer2.Entry) bool {
+		sendErr = stream.Send(&filer_pb.ListEntriesResponse{
+			Entry: &filer_pb.Entry{
 				Name:        entry.Name(),
 				IsDirectory: entry.IsDirectory(),
 				Chunks:      entry.Chunks,
 				Attributes:  filer2.EntryAttributeToPb(entry),
-			})
-			limit--
-		}
-
-		if len(resp.Entries) < 1024 {
-			break
-		}
+				Extended:    entry.Extended,
 
+				HardLinkId:      entry.HardLinkId,
+				HardLinkCounter: entry.HardLinkCounter,
+			},
+		})
+		return sendErr == nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return resp, nil
+	return sendErr
 }
 
 func (fs *FilerServer) LookupVolume(ctx context.Context, req *filer_pb.LookupVolumeRequest) (*filer_pb.LookupVolumeResponse, error) {
@@ -125,6 +109,8 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 		FullPath: fullpath,
 		Attr:     filer2.PbToEntryAttribute(req.Entry.Attributes),
 		Chunks:   chunks,
+		Extended: req.Entry.Extended,
+		// the hard link fields are only assigned by CreateHardLink, and kept when overwriting a link
 	})
 
 	if err == nil {
@@ -150,6 +136,15 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		FullPath: filer2.FullPath(filepath.Join(req.Directory, req.Entry.Name)),
 		Attr:     entry.Attr,
 		Chunks:   chunks,
+		Extended: entry.Extended,
+
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
+	}
+
+	// the clients not aware of the extended attributes must not wipe them
+	if len(req.Entry.Extended) > 0 || req.UpdateExtended {
+		newEntry.Extended = req.Entry.Extended
 	}
 
 	glog.V(3).Infof("updating %s: %+v, chunks %d: %v => %+v, chunks %d: %v",
@@ -190,6 +185,45 @@ func (fs *FilerServer) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntr
 	return &filer_pb.DeleteEntryResponse{}, err
 }
 
+func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
+
+	oldPath := filer2.FullPath(filepath.Join(req.OldDirectory, req.OldName))
+	newPath := filer2.FullPath(filepath.Join(req.NewDirectory, req.NewName))
+
+	glog.V(1).Infof("rename %s to %s", oldPath, newPath)
+
+	if err := fs.filer.AtomicRenameEntry(oldPath, newPath); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.AtomicRenameEntryResponse{}, nil
+}
+
+func (fs *FilerServer) CreateHardLink(ctx context.Context, req *filer_pb.CreateHardLinkRequest) (*filer_pb.CreateHardLinkResponse, error) {
+
+	oldPath := filer2.FullPath(filepath.Join(req.OldDirectory, req.OldName))
+	newPath := filer2.FullPath(filepath.Join(req.NewDirectory, req.NewName))
+
+	glog.V(1).Infof("link %s to %s", oldPath, newPath)
+
+	entry, err := fs.filer.CreateHardLink(oldPath, newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.CreateHardLinkResponse{
+		Entry: &filer_pb.Entry{
+			Name:            req.NewName,
+			IsDirectory:     entry.IsDirectory(),
+			Attributes:      filer2.EntryAttributeToPb(entry),
+			Chunks:          entry.Chunks,
+			Extended:        entry.Extended,
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
+		},
+	}, nil
+}
+
 func (fs *FilerServer) AssignVolume(ctx context.Context, req *filer_pb.AssignVolumeRequest) (resp *filer_pb.AssignVolumeResponse, err error) {
 
 	ttlStr := ""
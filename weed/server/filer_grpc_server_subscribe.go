@@ -0,0 +1,37 @@
+package weed_server
+
+import (
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) SubscribeMetadata(req *filer_pb.SubscribeMetadataRequest, stream filer_pb.SeaweedFiler_SubscribeMetadataServer) error {
+
+	glog.V(0).Infof("%s subscribes to metadata changes under %s since %v", req.ClientName, req.PathPrefix, time.Unix(0, req.SinceNs))
+	defer glog.V(0).Infof("%s unsubscribes from metadata changes under %s", req.ClientName, req.PathPrefix)
+
+	return fs.filer.MetaLog.Subscribe(stream.Context(), req.SinceNs, func(event *filer_pb.SubscribeMetadataResponse) error {
+		if !isEventUnderPath(event, req.PathPrefix) {
+			return nil
+		}
+		return stream.Send(event)
+	})
+}
+
+// isEventUnderPath checks whether either the old or the new entry of the event has the path prefix.
+// The entry names in the event notifications are the full paths.
+func isEventUnderPath(event *filer_pb.SubscribeMetadataResponse, pathPrefix string) bool {
+	if pathPrefix == "" || pathPrefix == "/" {
+		return true
+	}
+	if oldEntry := event.EventNotification.OldEntry; oldEntry != nil && strings.HasPrefix(oldEntry.Name, pathPrefix) {
+		return true
+	}
+	if newEntry := event.EventNotification.NewEntry; newEntry != nil && strings.HasPrefix(newEntry.Name, pathPrefix) {
+		return true
+	}
+	return false
+}
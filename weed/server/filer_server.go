@@ -11,6 +11,7 @@ import (
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/memdb"
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/mysql"
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/postgres"
+	_ "github.com/chrislusf/seaweedfs/weed/filer2/raft"
 	_ "github.com/chrislusf/seaweedfs/weed/filer2/redis"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/notification"
@@ -32,6 +33,7 @@ type FilerOption struct {
 	DirListingLimit    int
 	DataCenter         string
 	DefaultLevelDbDir  string
+	MetaLogDir         string
 }
 
 type FilerServer struct {
@@ -54,6 +56,10 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 
 	fs.filer = filer2.NewFiler(option.Masters, fs.grpcDialOption)
 
+	if fs.filer.MetaLog, err = filer2.NewMetaLog(option.MetaLogDir); err != nil {
+		return nil, err
+	}
+
 	go fs.filer.KeepConnectedToMaster()
 
 	v := viper.GetViper()
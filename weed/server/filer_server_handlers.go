@@ -2,19 +2,27 @@ package weed_server
 
 import (
 	"net/http"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/stats"
 )
 
 func (fs *FilerServer) filerHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case "GET":
+		defer stats.FilerRequests.Observe("get", time.Now())
 		fs.GetOrHeadHandler(w, r, true)
 	case "HEAD":
+		defer stats.FilerRequests.Observe("head", time.Now())
 		fs.GetOrHeadHandler(w, r, false)
 	case "DELETE":
+		defer stats.FilerRequests.Observe("delete", time.Now())
 		fs.DeleteHandler(w, r)
 	case "PUT":
+		defer stats.FilerRequests.Observe("post", time.Now())
 		fs.PostHandler(w, r)
 	case "POST":
+		defer stats.FilerRequests.Observe("post", time.Now())
 		fs.PostHandler(w, r)
 	}
 }
@@ -22,8 +30,10 @@ func (fs *FilerServer) filerHandler(w http.ResponseWriter, r *http.Request) {
 func (fs *FilerServer) readonlyFilerHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case "GET":
+		defer stats.FilerRequests.Observe("get", time.Now())
 		fs.GetOrHeadHandler(w, r, true)
 	case "HEAD":
+		defer stats.FilerRequests.Observe("head", time.Now())
 		fs.GetOrHeadHandler(w, r, false)
 	}
 }
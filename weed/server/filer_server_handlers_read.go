@@ -51,6 +51,11 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 	if r.Method == "HEAD" {
 		w.Header().Set("Content-Length", strconv.FormatInt(int64(filer2.TotalSize(entry.Chunks)), 10))
 		w.Header().Set("Last-Modified", entry.Attr.Mtime.Format(http.TimeFormat))
+		if entry.Mime != "" {
+			w.Header().Set("Content-Type", entry.Mime)
+		}
+		setEtag(w, filer2.ETag(entry.Chunks))
+		filer2.SetHeaderFromExtended(w.Header(), entry.Extended)
 		return
 	}
 
@@ -109,6 +114,7 @@ func (fs *FilerServer) handleSingleChunk(w http.ResponseWriter, r *http.Request,
 	for k, v := range resp.Header {
 		w.Header()[k] = v
 	}
+	filer2.SetHeaderFromExtended(w.Header(), entry.Extended)
 	w.WriteHeader(resp.StatusCode)
 	io.Copy(w, resp.Body)
 }
@@ -125,6 +131,7 @@ func (fs *FilerServer) handleMultipleChunks(w http.ResponseWriter, r *http.Reque
 		w.Header().Set("Content-Type", mimeType)
 	}
 	setEtag(w, filer2.ETag(entry.Chunks))
+	filer2.SetHeaderFromExtended(w.Header(), entry.Extended)
 
 	totalSize := int64(filer2.TotalSize(entry.Chunks))
 
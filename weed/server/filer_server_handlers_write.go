@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -67,6 +68,11 @@ func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request,
 func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := r.URL.Query()
+
+	if from := query.Get("mv.from"); from != "" {
+		fs.moveHandler(w, r, from)
+		return
+	}
 	replication := query.Get("replication")
 	if replication == "" {
 		replication = fs.option.DefaultReplication
@@ -191,6 +197,7 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 			Mtime:  time.Now().UnixNano(),
 			ETag:   etag,
 		}},
+		Extended: filer2.ExtendedFromHeader(r.Header),
 	}
 	// glog.V(4).Infof("saving %s => %+v", path, entry)
 	if db_err := fs.filer.CreateEntry(entry); db_err != nil {
@@ -212,6 +219,31 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 	writeJsonQuiet(w, r, http.StatusCreated, reply)
 }
 
+// curl -X POST "http://localhost:8888/path/to/new?mv.from=/path/to/old"
+// curl -X POST "http://localhost:8888/path/to/dir/?mv.from=/path/to/old"
+func (fs *FilerServer) moveHandler(w http.ResponseWriter, r *http.Request, from string) {
+
+	oldPath := filer2.FullPath(filepath.Clean("/" + from))
+	if _, err := fs.filer.FindEntry(oldPath); err != nil {
+		writeJsonError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	// move into the directory, keeping the name
+	newPath := filer2.FullPath(filepath.Clean(r.URL.Path))
+	if strings.HasSuffix(r.URL.Path, "/") {
+		newPath = filer2.NewFullPath(string(newPath), oldPath.Name())
+	}
+
+	if err := fs.filer.AtomicRenameEntry(oldPath, newPath); err != nil {
+		glog.V(1).Infof("move %s to %s: %v", oldPath, newPath, err)
+		writeJsonError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // curl -X DELETE http://localhost:8888/path/to
 // curl -X DELETE http://localhost:8888/path/to?recursive=true
 func (fs *FilerServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
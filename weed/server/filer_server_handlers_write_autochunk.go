@@ -164,7 +164,8 @@ func (fs *FilerServer) doAutoChunk(w http.ResponseWriter, r *http.Request, conte
 			Collection:  collection,
 			TtlSec:      int32(util.ParseInt(r.URL.Query().Get("ttl"), 0)),
 		},
-		Chunks: fileChunks,
+		Chunks:   fileChunks,
+		Extended: filer2.ExtendedFromHeader(r.Header),
 	}
 	if db_err := fs.filer.CreateEntry(entry); db_err != nil {
 		replyerr = db_err
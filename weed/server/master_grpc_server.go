@@ -30,6 +30,9 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 			for _, v := range dn.GetVolumes() {
 				message.DeletedVids = append(message.DeletedVids, uint32(v.Id))
 			}
+			for _, s := range dn.GetEcShards() {
+				message.DeletedVids = append(message.DeletedVids, uint32(s.VolumeId))
+			}
 
 			if len(message.DeletedVids) > 0 {
 				ms.clientChansLock.RLock()
@@ -80,6 +83,16 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 			// process delta volume ids if exists for fast volume id updates
 			message.NewVids = append(message.NewVids, heartbeat.NewVids...)
 			message.DeletedVids = append(message.DeletedVids, heartbeat.DeletedVids...)
+		} else if len(heartbeat.NewEcShards) > 0 || len(heartbeat.DeletedEcShards) > 0 {
+			// process delta ec shards if exists
+			newEcVids, deletedEcVids := t.IncrementalSyncDataNodeEcShards(heartbeat.NewEcShards, heartbeat.DeletedEcShards, dn)
+
+			for _, vid := range newEcVids {
+				message.NewVids = append(message.NewVids, uint32(vid))
+			}
+			for _, vid := range deletedEcVids {
+				message.DeletedVids = append(message.DeletedVids, uint32(vid))
+			}
 		} else {
 			// process heartbeat.Volumes
 			newVolumes, deletedVolumes := t.SyncDataNodeRegistration(heartbeat.Volumes, dn)
@@ -90,6 +103,16 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 			for _, v := range deletedVolumes {
 				message.DeletedVids = append(message.DeletedVids, uint32(v.Id))
 			}
+
+			// process heartbeat.EcShards
+			newEcVids, deletedEcVids := t.SyncDataNodeEcShards(heartbeat.EcShards, dn)
+
+			for _, vid := range newEcVids {
+				message.NewVids = append(message.NewVids, uint32(vid))
+			}
+			for _, vid := range deletedEcVids {
+				message.DeletedVids = append(message.DeletedVids, uint32(vid))
+			}
 		}
 
 		if len(message.NewVids) > 0 || len(message.DeletedVids) > 0 {
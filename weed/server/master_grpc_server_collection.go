@@ -0,0 +1,59 @@
+package weed_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/chrislusf/raft"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+)
+
+func (ms *MasterServer) CollectionList(ctx context.Context, req *master_pb.CollectionListRequest) (*master_pb.CollectionListResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	resp := &master_pb.CollectionListResponse{}
+	for _, name := range ms.Topo.ListCollections() {
+		resp.Collections = append(resp.Collections, &master_pb.Collection{
+			Name: name,
+		})
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) CollectionDelete(ctx context.Context, req *master_pb.CollectionDeleteRequest) (*master_pb.CollectionDeleteResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	resp := &master_pb.CollectionDeleteResponse{}
+
+	collection, ok := ms.Topo.FindCollection(req.Name)
+	if !ok {
+		return resp, nil
+	}
+
+	for _, server := range collection.ListVolumeServers() {
+		err := operation.WithVolumeServerClient(server.Url(), ms.grpcDialOpiton, func(client volume_server_pb.VolumeServerClient) error {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(5*time.Second))
+			defer cancel()
+
+			_, deleteErr := client.DeleteCollection(ctx, &volume_server_pb.DeleteCollectionRequest{
+				Collection: collection.Name,
+			})
+			return deleteErr
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	ms.Topo.DeleteCollection(req.Name)
+
+	return resp, nil
+}
@@ -126,3 +126,69 @@ func (ms *MasterServer) Statistics(ctx context.Context, req *master_pb.Statistic
 
 	return resp, nil
 }
+
+func (ms *MasterServer) LookupEcVolume(ctx context.Context, req *master_pb.LookupEcVolumeRequest) (*master_pb.LookupEcVolumeResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	resp := &master_pb.LookupEcVolumeResponse{}
+
+	ecLocations, found := ms.Topo.LookupEcShards(storage.VolumeId(req.VolumeId))
+
+	if !found {
+		return resp, fmt.Errorf("ec volume %d not found", req.VolumeId)
+	}
+
+	resp.VolumeId = req.VolumeId
+
+	for shardId, shardLocations := range ecLocations.Locations {
+		if len(shardLocations) == 0 {
+			continue
+		}
+		var locations []*master_pb.Location
+		for _, dn := range shardLocations {
+			locations = append(locations, &master_pb.Location{
+				Url:       dn.Url(),
+				PublicUrl: dn.PublicUrl,
+			})
+		}
+		resp.ShardIdLocations = append(resp.ShardIdLocations, &master_pb.LookupEcVolumeResponse_EcShardIdLocation{
+			ShardId:   uint32(shardId),
+			Locations: locations,
+		})
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) VolumeList(ctx context.Context, req *master_pb.VolumeListRequest) (*master_pb.VolumeListResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	resp := &master_pb.VolumeListResponse{
+		TopologyInfo:      ms.Topo.ToTopologyInfo(),
+		VolumeSizeLimitMb: uint64(ms.volumeSizeLimitMB),
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) VacuumVolume(ctx context.Context, req *master_pb.VacuumVolumeRequest) (*master_pb.VacuumVolumeResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	gcThreshold := ms.garbageThreshold
+	if req.GarbageThreshold > 0 {
+		gcThreshold = float64(req.GarbageThreshold)
+	}
+
+	ms.Topo.Vacuum(ms.grpcDialOpiton, gcThreshold, ms.preallocate)
+
+	return &master_pb.VacuumVolumeResponse{}, nil
+}
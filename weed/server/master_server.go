@@ -13,6 +13,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
 	"github.com/chrislusf/seaweedfs/weed/security"
 	"github.com/chrislusf/seaweedfs/weed/sequence"
+	"github.com/chrislusf/seaweedfs/weed/stats"
 	"github.com/chrislusf/seaweedfs/weed/topology"
 	"github.com/chrislusf/seaweedfs/weed/util"
 	"github.com/gorilla/mux"
@@ -79,18 +80,22 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 	handleStaticResources2(r)
 	r.HandleFunc("/", ms.uiStatusHandler)
 	r.HandleFunc("/ui/index.html", ms.uiStatusHandler)
-	r.HandleFunc("/dir/assign", ms.proxyToLeader(ms.guard.WhiteList(ms.dirAssignHandler)))
-	r.HandleFunc("/dir/lookup", ms.proxyToLeader(ms.guard.WhiteList(ms.dirLookupHandler)))
-	r.HandleFunc("/dir/status", ms.proxyToLeader(ms.guard.WhiteList(ms.dirStatusHandler)))
-	r.HandleFunc("/col/delete", ms.proxyToLeader(ms.guard.WhiteList(ms.collectionDeleteHandler)))
-	r.HandleFunc("/vol/grow", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeGrowHandler)))
-	r.HandleFunc("/vol/status", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeStatusHandler)))
-	r.HandleFunc("/vol/vacuum", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeVacuumHandler)))
-	r.HandleFunc("/submit", ms.guard.WhiteList(ms.submitFromMasterServerHandler))
+	r.HandleFunc("/dir/assign", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("assign", ms.dirAssignHandler))))
+	r.HandleFunc("/dir/lookup", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("lookup", ms.dirLookupHandler))))
+	r.HandleFunc("/dir/status", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("dirStatus", ms.dirStatusHandler))))
+	r.HandleFunc("/col/delete", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("collectionDelete", ms.collectionDeleteHandler))))
+	r.HandleFunc("/vol/grow", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("volumeGrow", ms.volumeGrowHandler))))
+	r.HandleFunc("/vol/status", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("volumeStatus", ms.volumeStatusHandler))))
+	r.HandleFunc("/vol/vacuum", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("volumeVacuum", ms.volumeVacuumHandler))))
+	r.HandleFunc("/vol/balance", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("volumeBalance", ms.volumeBalanceHandler))))
+	r.HandleFunc("/vol/ec/encode", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("volumeEcEncode", ms.volumeEcEncodeHandler))))
+	r.HandleFunc("/vol/ec/rebuild", ms.proxyToLeader(ms.guard.WhiteList(stats.MasterRequests.Instrument("volumeEcRebuild", ms.volumeEcRebuildHandler))))
+	r.HandleFunc("/submit", ms.guard.WhiteList(stats.MasterRequests.Instrument("submit", ms.submitFromMasterServerHandler)))
 	r.HandleFunc("/stats/health", ms.guard.WhiteList(statsHealthHandler))
 	r.HandleFunc("/stats/counter", ms.guard.WhiteList(statsCounterHandler))
 	r.HandleFunc("/stats/memory", ms.guard.WhiteList(statsMemoryHandler))
-	r.HandleFunc("/{fileId}", ms.proxyToLeader(ms.redirectHandler))
+	r.HandleFunc("/metrics", ms.guard.WhiteList(stats.MetricsHandler))
+	r.HandleFunc("/{fileId}", ms.proxyToLeader(stats.MasterRequests.Instrument("redirect", ms.redirectHandler)))
 
 	ms.Topo.StartRefreshWritableVolumes(ms.grpcDialOpiton, garbageThreshold, ms.preallocate)
 
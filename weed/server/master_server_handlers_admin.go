@@ -64,6 +64,52 @@ func (ms *MasterServer) volumeVacuumHandler(w http.ResponseWriter, r *http.Reque
 	ms.dirStatusHandler(w, r)
 }
 
+func (ms *MasterServer) volumeBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	collection := r.FormValue("collection")
+	if collection == "" {
+		collection = "*"
+	}
+	moves := ms.Topo.PlanVolumeBalance(collection)
+	if r.FormValue("apply") != "true" {
+		writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{"moves": moves})
+		return
+	}
+	for i, move := range moves {
+		if err := ms.Topo.MoveVolume(ms.grpcDialOpiton, move, ms.preallocate); err != nil {
+			writeJsonQuiet(w, r, http.StatusInternalServerError, map[string]interface{}{"moved": moves[:i], "error": err.Error()})
+			return
+		}
+	}
+	writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{"moved": moves})
+}
+
+func (ms *MasterServer) volumeEcEncodeHandler(w http.ResponseWriter, r *http.Request) {
+	vid, err := storage.NewVolumeId(r.FormValue("volumeId"))
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, fmt.Errorf("volumeId %s is not valid: %v", r.FormValue("volumeId"), err))
+		return
+	}
+	if err = ms.Topo.EcEncodeVolume(ms.grpcDialOpiton, r.FormValue("collection"), vid); err != nil {
+		writeJsonError(w, r, http.StatusNotAcceptable, err)
+		return
+	}
+	writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{"volumeId": vid})
+}
+
+func (ms *MasterServer) volumeEcRebuildHandler(w http.ResponseWriter, r *http.Request) {
+	vid, err := storage.NewVolumeId(r.FormValue("volumeId"))
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, fmt.Errorf("volumeId %s is not valid: %v", r.FormValue("volumeId"), err))
+		return
+	}
+	rebuiltShardIds, err := ms.Topo.EcRebuildVolume(ms.grpcDialOpiton, vid)
+	if err != nil {
+		writeJsonError(w, r, http.StatusNotAcceptable, err)
+		return
+	}
+	writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{"volumeId": vid, "rebuiltShardIds": rebuiltShardIds})
+}
+
 func (ms *MasterServer) volumeGrowHandler(w http.ResponseWriter, r *http.Request) {
 	count := 0
 	option, err := ms.getVolumeGrowOption(r)
@@ -93,6 +139,7 @@ func (ms *MasterServer) volumeStatusHandler(w http.ResponseWriter, r *http.Reque
 	m := make(map[string]interface{})
 	m["Version"] = util.VERSION
 	m["Volumes"] = ms.Topo.ToVolumeMap()
+	m["Replication"] = ms.Topo.ReplicationStatus()
 	writeJsonQuiet(w, r, http.StatusOK, m)
 }
 
@@ -94,3 +94,35 @@ func (vs *VolumeServer) VolumeDelete(ctx context.Context, req *volume_server_pb.
 	return resp, err
 
 }
+
+func (vs *VolumeServer) VolumeMarkReadonly(ctx context.Context, req *volume_server_pb.VolumeMarkReadonlyRequest) (*volume_server_pb.VolumeMarkReadonlyResponse, error) {
+
+	resp := &volume_server_pb.VolumeMarkReadonlyResponse{}
+
+	err := vs.store.MarkVolumeReadonly(storage.VolumeId(req.VolumdId))
+
+	if err != nil {
+		glog.Errorf("volume mark readonly %v: %v", req, err)
+	} else {
+		glog.V(2).Infof("volume mark readonly %v", req)
+	}
+
+	return resp, err
+
+}
+
+func (vs *VolumeServer) VolumeMarkWritable(ctx context.Context, req *volume_server_pb.VolumeMarkWritableRequest) (*volume_server_pb.VolumeMarkWritableResponse, error) {
+
+	resp := &volume_server_pb.VolumeMarkWritableResponse{}
+
+	err := vs.store.MarkVolumeWritable(storage.VolumeId(req.VolumdId))
+
+	if err != nil {
+		glog.Errorf("volume mark writable %v: %v", req, err)
+	} else {
+		glog.V(2).Infof("volume mark writable %v", req)
+	}
+
+	return resp, err
+
+}
@@ -0,0 +1,235 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+)
+
+const BufferSizeLimit = 1024 * 1024 * 2
+
+// VolumeCopy copies the .idx and .dat files of a volume from the source data node, and mounts the volume.
+// The new volume is reported to the master with the next heartbeat.
+func (vs *VolumeServer) VolumeCopy(ctx context.Context, req *volume_server_pb.VolumeCopyRequest) (*volume_server_pb.VolumeCopyResponse, error) {
+
+	vid := storage.VolumeId(req.VolumdId)
+
+	if v := vs.store.GetVolume(vid); v != nil {
+		return nil, fmt.Errorf("volume %d already exists", req.VolumdId)
+	}
+
+	location := vs.store.FindFreeLocation()
+	if location == nil {
+		return nil, fmt.Errorf("no space left")
+	}
+
+	// copy from the read-only source volume, so the copied .idx and .dat files are consistent
+	var volumeFileName string
+	err := operation.WithVolumeServerClient(req.SourceDataNode, vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+
+		syncStatus, statusErr := client.VolumeSyncStatus(ctx, &volume_server_pb.VolumeSyncStatusRequest{
+			VolumdId: req.VolumdId,
+		})
+		if statusErr != nil {
+			return fmt.Errorf("read volume %d status: %v", req.VolumdId, statusErr)
+		}
+		if !syncStatus.ReadOnly {
+			if _, markErr := client.VolumeMarkReadonly(ctx, &volume_server_pb.VolumeMarkReadonlyRequest{
+				VolumdId: req.VolumdId,
+			}); markErr != nil {
+				return fmt.Errorf("mark volume %d read-only: %v", req.VolumdId, markErr)
+			}
+			defer func() {
+				if _, markErr := client.VolumeMarkWritable(context.Background(), &volume_server_pb.VolumeMarkWritableRequest{
+					VolumdId: req.VolumdId,
+				}); markErr != nil {
+					glog.Errorf("mark volume %d on %s writable: %v", req.VolumdId, req.SourceDataNode, markErr)
+				}
+			}()
+			// the sizes including the writes done before becoming read-only
+			if syncStatus, statusErr = client.VolumeSyncStatus(ctx, &volume_server_pb.VolumeSyncStatusRequest{
+				VolumdId: req.VolumdId,
+			}); statusErr != nil {
+				return fmt.Errorf("read volume %d status: %v", req.VolumdId, statusErr)
+			}
+		}
+		volumeFileName = storage.VolumeFileName(syncStatus.Collection, location.Directory, vid)
+
+		// copy the .idx file last, so it does not point to needles missing in the copied .dat file
+		if copyErr := vs.doCopyFile(ctx, client, false, req.VolumdId, syncStatus.Collection, volumeFileName, ".dat", syncStatus.TailOffset); copyErr != nil {
+			return copyErr
+		}
+		if copyErr := vs.doCopyFile(ctx, client, false, req.VolumdId, syncStatus.Collection, volumeFileName, ".idx", syncStatus.IdxFileSize); copyErr != nil {
+			return copyErr
+		}
+
+		// compaction during copying rewrites the source files
+		afterStatus, statusErr := client.VolumeSyncStatus(ctx, &volume_server_pb.VolumeSyncStatusRequest{
+			VolumdId: req.VolumdId,
+		})
+		if statusErr != nil {
+			return fmt.Errorf("read volume %d status: %v", req.VolumdId, statusErr)
+		}
+		if afterStatus.CompactRevision != syncStatus.CompactRevision {
+			return fmt.Errorf("volume %d is compacted during copying", req.VolumdId)
+		}
+
+		if verifyErr := verifyCopiedFileSizes(volumeFileName, syncStatus); verifyErr != nil {
+			return verifyErr
+		}
+		return storage.CheckCopiedVolumeFiles(volumeFileName)
+	})
+	if err != nil {
+		if volumeFileName != "" {
+			os.Remove(volumeFileName + ".idx")
+			os.Remove(volumeFileName + ".dat")
+		}
+		glog.Errorf("volume copy %v: %v", req, err)
+		return nil, fmt.Errorf("VolumeCopy volume %d: %v", req.VolumdId, err)
+	}
+
+	if err = vs.store.MountVolume(vid); err != nil {
+		return nil, fmt.Errorf("failed to mount volume %d: %v", req.VolumdId, err)
+	}
+
+	glog.V(0).Infof("copied volume %d from %s", req.VolumdId, req.SourceDataNode)
+
+	return &volume_server_pb.VolumeCopyResponse{}, nil
+}
+
+func verifyCopiedFileSizes(volumeFileName string, syncStatus *volume_server_pb.VolumeSyncStatusResponse) error {
+	idxStat, err := os.Stat(volumeFileName + ".idx")
+	if err != nil {
+		return err
+	}
+	if uint64(idxStat.Size()) != syncStatus.IdxFileSize {
+		return fmt.Errorf("copied %s.idx size %d, expected %d", volumeFileName, idxStat.Size(), syncStatus.IdxFileSize)
+	}
+	datStat, err := os.Stat(volumeFileName + ".dat")
+	if err != nil {
+		return err
+	}
+	if uint64(datStat.Size()) != syncStatus.TailOffset {
+		return fmt.Errorf("copied %s.dat size %d, expected %d", volumeFileName, datStat.Size(), syncStatus.TailOffset)
+	}
+	return nil
+}
+
+func (vs *VolumeServer) doCopyFile(ctx context.Context, client volume_server_pb.VolumeServerClient, isEcVolume bool, vid uint32, collection string, baseFileName string, ext string, stopOffset uint64) error {
+
+	if !isEcVolume && stopOffset == 0 {
+		// nothing to copy, and 0 would mean the whole file
+		return writeEmptyFile(baseFileName + ext)
+	}
+
+	copyFileClient, err := client.CopyFile(ctx, &volume_server_pb.CopyFileRequest{
+		VolumdId:   vid,
+		Ext:        ext,
+		Collection: collection,
+		IsEcVolume: isEcVolume,
+		StopOffset: stopOffset,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start copying volume %d %s file: %v", vid, ext, err)
+	}
+
+	if err = writeToFile(copyFileClient, baseFileName+ext); err != nil {
+		return fmt.Errorf("failed to copy volume %d %s file: %v", vid, ext, err)
+	}
+
+	return nil
+}
+
+func writeEmptyFile(fileName string) error {
+	dst, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	return dst.Close()
+}
+
+// CopyFile client pulls the volume related file from the source server.
+func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream volume_server_pb.VolumeServer_CopyFileServer) error {
+
+	var fileName string
+	if !req.IsEcVolume {
+		v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+		if v == nil {
+			return fmt.Errorf("not found volume id %d", req.VolumdId)
+		}
+		fileName = v.FileName() + req.Ext
+	} else {
+		baseFileName, found := vs.store.FindEcVolumeBaseFileName(req.Collection, storage.VolumeId(req.VolumdId))
+		if !found {
+			return fmt.Errorf("not found ec volume id %d", req.VolumdId)
+		}
+		fileName = baseFileName + req.Ext
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, BufferSizeLimit)
+	var bytesToRead int64 = -1
+	if req.StopOffset > 0 {
+		bytesToRead = int64(req.StopOffset)
+	}
+	for bytesToRead != 0 {
+		bytesread, err := file.Read(buffer)
+		if bytesToRead > 0 && int64(bytesread) > bytesToRead {
+			bytesread = int(bytesToRead)
+		}
+		if bytesToRead > 0 {
+			bytesToRead -= int64(bytesread)
+		}
+		if bytesread > 0 {
+			if sendErr := stream.Send(&volume_server_pb.CopyFileResponse{
+				FileContent: buffer[:bytesread],
+			}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	glog.V(2).Infof("copy file %s", fileName)
+
+	return nil
+}
+
+func writeToFile(client volume_server_pb.VolumeServer_CopyFileClient, fileName string) error {
+	glog.V(4).Infof("writing to %s", fileName)
+	dst, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	for {
+		resp, receiveErr := client.Recv()
+		if receiveErr == io.EOF {
+			break
+		}
+		if receiveErr != nil {
+			return fmt.Errorf("receiving %s: %v", fileName, receiveErr)
+		}
+		if _, err = dst.Write(resp.FileContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
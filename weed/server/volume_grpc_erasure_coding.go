@@ -0,0 +1,240 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+)
+
+/*
+
+Steps to apply erasure coding to .dat .idx files
+0. client call VolumeMarkReadonly on all the replicas, so no writes land after the ec files are generated
+1. client call VolumeEcShardsGenerate to generate the .ecx and .ec00 ~ .ec13 files
+2. client ask master for possible servers to hold the ec files
+3. client call VolumeEcShardsCopy on above target servers to copy ec files from the source server
+4. client call VolumeEcShardsMount on the target servers, which report the new ec shards to the master
+5.   master stores vid -> [14][]*DataNode
+6. client checks master. If all 14 shards are ready, delete the original .dat, .idx files
+
+*/
+
+// VolumeEcShardsGenerate generates the .ecx and .ec00 ~ .ec13 files
+func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_server_pb.VolumeEcShardsGenerateRequest) (*volume_server_pb.VolumeEcShardsGenerateResponse, error) {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+	if v == nil {
+		return nil, fmt.Errorf("volume %d not found", req.VolumdId)
+	}
+	if v.Collection != req.Collection {
+		return nil, fmt.Errorf("existing collection:%v unexpected input: %v", v.Collection, req.Collection)
+	}
+	if !v.IsReadOnly() {
+		return nil, fmt.Errorf("volume %d is writable, mark it read-only first", req.VolumdId)
+	}
+
+	if err := v.GenerateEcFiles(); err != nil {
+		glog.Errorf("ec generate %v: %v", req, err)
+		return nil, err
+	}
+
+	glog.V(2).Infof("ec generate %v", req)
+
+	return &volume_server_pb.VolumeEcShardsGenerateResponse{}, nil
+}
+
+// VolumeEcShardsRebuild generates any of the missing .ec00 ~ .ec13 files
+func (vs *VolumeServer) VolumeEcShardsRebuild(ctx context.Context, req *volume_server_pb.VolumeEcShardsRebuildRequest) (*volume_server_pb.VolumeEcShardsRebuildResponse, error) {
+
+	baseFileName, found := vs.store.FindEcVolumeBaseFileName(req.Collection, storage.VolumeId(req.VolumdId))
+	if !found {
+		return nil, fmt.Errorf("ec volume %d not found", req.VolumdId)
+	}
+
+	rebuiltShardIds, err := erasure_coding.RebuildEcFiles(baseFileName)
+	if err != nil {
+		glog.Errorf("ec rebuild %v: %v", req, err)
+		return nil, fmt.Errorf("RebuildEcFiles %s: %v", baseFileName, err)
+	}
+
+	glog.V(2).Infof("ec rebuild %v: %v", req, rebuiltShardIds)
+
+	return &volume_server_pb.VolumeEcShardsRebuildResponse{
+		RebuiltShardIds: rebuiltShardIds,
+	}, nil
+}
+
+// VolumeEcShardsCopy copy the .ecx and some ec data slices
+func (vs *VolumeServer) VolumeEcShardsCopy(ctx context.Context, req *volume_server_pb.VolumeEcShardsCopyRequest) (*volume_server_pb.VolumeEcShardsCopyResponse, error) {
+
+	// put the shards next to the existing .ecx file if any
+	baseFileName, found := vs.store.FindEcVolumeBaseFileName(req.Collection, storage.VolumeId(req.VolumdId))
+	if !found {
+		location := vs.store.FindFreeLocation()
+		if location == nil {
+			return nil, fmt.Errorf("no space left")
+		}
+		baseFileName = storage.EcShardFileName(req.Collection, location.Directory, storage.VolumeId(req.VolumdId))
+	}
+
+	err := operation.WithVolumeServerClient(req.SourceDataNode, vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+
+		// copy ec data slices
+		for _, shardId := range req.ShardIds {
+			if err := vs.doCopyFile(ctx, client, true, req.VolumdId, req.Collection, baseFileName, erasure_coding.ToExt(int(shardId)), 0); err != nil {
+				return err
+			}
+		}
+
+		if !req.CopyEcxFile {
+			return nil
+		}
+
+		// copy ecx file
+		return vs.doCopyFile(ctx, client, true, req.VolumdId, req.Collection, baseFileName, ".ecx", 0)
+	})
+	if err != nil {
+		glog.Errorf("ec shards copy %v: %v", req, err)
+		return nil, fmt.Errorf("VolumeEcShardsCopy volume %d: %v", req.VolumdId, err)
+	}
+
+	glog.V(2).Infof("ec shards copy %v", req)
+
+	return &volume_server_pb.VolumeEcShardsCopyResponse{}, nil
+}
+
+// VolumeEcShardsDelete unmounts and deletes the ec shards, and the .ecx file if no shards are left
+func (vs *VolumeServer) VolumeEcShardsDelete(ctx context.Context, req *volume_server_pb.VolumeEcShardsDeleteRequest) (*volume_server_pb.VolumeEcShardsDeleteResponse, error) {
+
+	vid := storage.VolumeId(req.VolumdId)
+
+	for _, shardId := range req.ShardIds {
+		if err := vs.store.UnmountEcShards(vid, erasure_coding.ShardId(shardId)); err != nil {
+			glog.Errorf("ec shards delete %v: %v", req, err)
+			return nil, err
+		}
+	}
+
+	for _, location := range vs.store.Locations {
+		baseFileName := storage.EcShardFileName(req.Collection, location.Directory, vid)
+		for _, shardId := range req.ShardIds {
+			if err := os.Remove(baseFileName + erasure_coding.ToExt(int(shardId))); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+
+		hasEcxFile := false
+		if _, err := os.Stat(baseFileName + ".ecx"); err == nil {
+			hasEcxFile = true
+		}
+		hasShards := false
+		for shardId := 0; shardId < erasure_coding.TotalShardsCount; shardId++ {
+			if _, err := os.Stat(baseFileName + erasure_coding.ToExt(shardId)); err == nil {
+				hasShards = true
+				break
+			}
+		}
+		if hasEcxFile && !hasShards {
+			if err := os.Remove(baseFileName + ".ecx"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	glog.V(2).Infof("ec shards delete %v", req)
+
+	return &volume_server_pb.VolumeEcShardsDeleteResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsMount(ctx context.Context, req *volume_server_pb.VolumeEcShardsMountRequest) (*volume_server_pb.VolumeEcShardsMountResponse, error) {
+
+	for _, shardId := range req.ShardIds {
+		err := vs.store.MountEcShards(req.Collection, storage.VolumeId(req.VolumdId), erasure_coding.ShardId(shardId))
+
+		if err != nil {
+			glog.Errorf("ec shard mount %v: %v", req, err)
+			return nil, fmt.Errorf("mount %d.%d: %v", req.VolumdId, shardId, err)
+		}
+	}
+
+	glog.V(2).Infof("ec shard mount %v", req)
+
+	return &volume_server_pb.VolumeEcShardsMountResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsUnmount(ctx context.Context, req *volume_server_pb.VolumeEcShardsUnmountRequest) (*volume_server_pb.VolumeEcShardsUnmountResponse, error) {
+
+	for _, shardId := range req.ShardIds {
+		err := vs.store.UnmountEcShards(storage.VolumeId(req.VolumdId), erasure_coding.ShardId(shardId))
+
+		if err != nil {
+			glog.Errorf("ec shard unmount %v: %v", req, err)
+			return nil, fmt.Errorf("unmount %d.%d: %v", req.VolumdId, shardId, err)
+		}
+	}
+
+	glog.V(2).Infof("ec shard unmount %v", req)
+
+	return &volume_server_pb.VolumeEcShardsUnmountResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardRead(req *volume_server_pb.VolumeEcShardReadRequest, stream volume_server_pb.VolumeServer_VolumeEcShardReadServer) error {
+
+	ecVolume, found := vs.store.FindEcVolume(storage.VolumeId(req.VolumdId))
+	if !found {
+		return fmt.Errorf("not found ec volume id %d", req.VolumdId)
+	}
+	ecShard, found := ecVolume.FindEcVolumeShard(erasure_coding.ShardId(req.ShardId))
+	if !found {
+		return fmt.Errorf("not found ec shard %d.%d", req.VolumdId, req.ShardId)
+	}
+
+	bufSize := req.Size
+	if bufSize > BufferSizeLimit {
+		bufSize = BufferSizeLimit
+	}
+	buffer := make([]byte, bufSize)
+
+	startOffset, bytesToRead := req.Offset, req.Size
+
+	for bytesToRead > 0 {
+		bufferSize := bufSize
+		if bufferSize > bytesToRead {
+			bufferSize = bytesToRead
+		}
+		bytesread, err := ecShard.ReadAt(buffer[0:bufferSize], startOffset)
+
+		if bytesread > 0 {
+			if int64(bytesread) > bytesToRead {
+				bytesread = int(bytesToRead)
+			}
+			sendErr := stream.Send(&volume_server_pb.VolumeEcShardReadResponse{
+				Data: buffer[:bytesread],
+			})
+			if sendErr != nil {
+				glog.V(3).Infof("sending ec shard %d.%d: %v", req.VolumdId, req.ShardId, sendErr)
+				return sendErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			glog.V(3).Infof("read ec shard %d.%d: %v", req.VolumdId, req.ShardId, err)
+			return err
+		}
+
+		bytesToRead -= int64(bytesread)
+		startOffset += int64(bytesread)
+	}
+
+	return nil
+}
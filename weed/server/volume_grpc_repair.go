@@ -0,0 +1,215 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+// ReadNeedleBlob reads one needle as in the .dat file, for the other replicas to repair their corrupted copies.
+func (vs *VolumeServer) ReadNeedleBlob(ctx context.Context, req *volume_server_pb.ReadNeedleBlobRequest) (*volume_server_pb.ReadNeedleBlobResponse, error) {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+	if v == nil {
+		return nil, fmt.Errorf("not found volume id %d", req.VolumdId)
+	}
+
+	blob, size, err := v.ReadNeedleBlob(types.Uint64ToNeedleId(req.NeedleId))
+	if err != nil {
+		return nil, fmt.Errorf("read volume %d needle %d: %v", req.VolumdId, req.NeedleId, err)
+	}
+
+	return &volume_server_pb.ReadNeedleBlobResponse{
+		NeedleBlob: blob,
+		Size:       size,
+		Version:    uint32(v.Version()),
+	}, nil
+
+}
+
+// VolumeRepair scrubs the volume, and repairs the corrupted needles from the other replicas.
+func (vs *VolumeServer) VolumeRepair(ctx context.Context, req *volume_server_pb.VolumeRepairRequest) (*volume_server_pb.VolumeRepairResponse, error) {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+	if v == nil {
+		return nil, fmt.Errorf("not found volume id %d", req.VolumdId)
+	}
+
+	result, err := v.Scrub(vs.scrubBytesPerSecond)
+	if err != nil {
+		glog.Errorf("volume repair %v: %v", req, err)
+		return nil, err
+	}
+
+	resp := &volume_server_pb.VolumeRepairResponse{
+		NeedleCount: uint64(result.NeedleCount),
+	}
+	for _, key := range result.CorruptedNeedles {
+		if err := vs.repairNeedle(v, key); err != nil {
+			glog.Errorf("repair volume %d needle %d: %v", v.Id, key, err)
+			resp.FailedNeedleIds = append(resp.FailedNeedleIds, uint64(key))
+			continue
+		}
+		resp.RepairedNeedleIds = append(resp.RepairedNeedleIds, uint64(key))
+	}
+
+	glog.V(0).Infof("volume repair %v: %d repaired, %d failed", req, len(resp.RepairedNeedleIds), len(resp.FailedNeedleIds))
+
+	return resp, nil
+
+}
+
+func (vs *VolumeServer) repairNeedle(v *storage.Volume, key types.NeedleId) error {
+	resp, err := vs.readNeedleBlobFromReplicas(v.Id, key)
+	if err != nil {
+		return err
+	}
+	return v.RepairNeedle(key, resp.NeedleBlob, resp.Size, storage.Version(resp.Version))
+}
+
+const (
+	// at most this many corrupted needles are read from the other replicas at a time, the other reads fail
+	maxInlineRepairs = 4
+	// a needle failed to read from the other replicas is not tried again within this interval
+	inlineRepairRetryInterval = time.Minute
+	// the needles failed recently are forgotten once expired, after remembering this many
+	maxInlineRepairFailures = 1024
+)
+
+type inlineRepairKey struct {
+	volumeId storage.VolumeId
+	key      types.NeedleId
+}
+
+// inlineRepairLimiter limits the replica reads of the corrupted needles found while serving the reads
+type inlineRepairLimiter struct {
+	slots    chan struct{}
+	failedAt map[inlineRepairKey]time.Time
+	sync.Mutex
+}
+
+func newInlineRepairLimiter() *inlineRepairLimiter {
+	return &inlineRepairLimiter{
+		slots:    make(chan struct{}, maxInlineRepairs),
+		failedAt: make(map[inlineRepairKey]time.Time),
+	}
+}
+
+// acquire returns false if too many needles are being read from the other replicas, or the needle failed recently
+func (l *inlineRepairLimiter) acquire(k inlineRepairKey) bool {
+	l.Lock()
+	if failedAt, found := l.failedAt[k]; found && time.Since(failedAt) < inlineRepairRetryInterval {
+		l.Unlock()
+		return false
+	}
+	l.Unlock()
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *inlineRepairLimiter) release(k inlineRepairKey, failed bool) {
+	<-l.slots
+
+	l.Lock()
+	defer l.Unlock()
+	if !failed {
+		delete(l.failedAt, k)
+		return
+	}
+	if len(l.failedAt) >= maxInlineRepairFailures {
+		for failed, failedAt := range l.failedAt {
+			if time.Since(failedAt) >= inlineRepairRetryInterval {
+				delete(l.failedAt, failed)
+			}
+		}
+	}
+	l.failedAt[k] = time.Now()
+}
+
+// readCorruptedNeedle reads the needle from the other replicas, since the local copy is corrupted.
+// The local copy is also repaired, if the volume is writable.
+// The replica reads are limited by vs.inlineRepairs, and the locations of the replicas are cached by operation.Lookup.
+func (vs *VolumeServer) readCorruptedNeedle(volumeId storage.VolumeId, key types.NeedleId, n *storage.Needle) (count int, err error) {
+
+	v := vs.store.GetVolume(volumeId)
+	if v == nil {
+		return 0, fmt.Errorf("not found volume id %d", volumeId)
+	}
+
+	k := inlineRepairKey{volumeId: volumeId, key: key}
+	if !vs.inlineRepairs.acquire(k) {
+		return 0, storage.ErrorCRC
+	}
+	defer func() {
+		vs.inlineRepairs.release(k, err != nil && err != storage.ErrorNotFound)
+	}()
+
+	resp, err := vs.readNeedleBlobFromReplicas(volumeId, key)
+	if err != nil {
+		return 0, err
+	}
+	good, err := storage.ParseNeedleBlob(key, resp.NeedleBlob, resp.Size, storage.Version(resp.Version))
+	if err != nil {
+		return 0, err
+	}
+
+	if err = v.RepairNeedle(key, resp.NeedleBlob, resp.Size, storage.Version(resp.Version)); err != nil {
+		glog.V(0).Infof("repair volume %d needle %d: %v", volumeId, key, err)
+	} else {
+		glog.V(0).Infof("repaired volume %d needle %d", volumeId, key)
+	}
+
+	if good.IsExpired() {
+		// the same as reading the local copy
+		return -1, storage.ErrorNotFound
+	}
+	*n = *good
+	return len(n.Data), nil
+}
+
+// readNeedleBlobFromReplicas tries the other replicas one by one, until one returns a good copy of the needle
+func (vs *VolumeServer) readNeedleBlobFromReplicas(volumeId storage.VolumeId, key types.NeedleId) (*volume_server_pb.ReadNeedleBlobResponse, error) {
+
+	lookupResult, err := operation.Lookup(vs.GetMaster(), volumeId.String())
+	if err != nil {
+		return nil, fmt.Errorf("lookup volume %d: %v", volumeId, err)
+	}
+
+	selfUrl := vs.store.Ip + ":" + strconv.Itoa(vs.store.Port)
+	lastErr := fmt.Errorf("no other replicas of volume %d", volumeId)
+	for _, location := range lookupResult.Locations {
+		if location.Url == selfUrl {
+			continue
+		}
+		var resp *volume_server_pb.ReadNeedleBlobResponse
+		err := operation.WithVolumeServerClient(location.Url, vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+			var readErr error
+			resp, readErr = client.ReadNeedleBlob(context.Background(), &volume_server_pb.ReadNeedleBlobRequest{
+				VolumdId: uint32(volumeId),
+				NeedleId: types.NeedleIdToUint64(key),
+			})
+			return readErr
+		})
+		if err != nil {
+			lastErr = fmt.Errorf("read needle %d from %s: %v", key, location.Url, err)
+			glog.V(1).Infof("volume %d: %v", volumeId, lastErr)
+			continue
+		}
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,98 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+)
+
+const (
+	// how often to look for the volumes not scrubbed within the scrub interval
+	scrubCheckInterval = time.Hour
+	// the volumes without any saved scrubbing result are scrubbed soon after starting
+	scrubStartDelay = time.Minute
+)
+
+// VolumeScrub returns the results of the last background scrubbing, or scrubs the volumes right away.
+// The volumes moved to the remote storage are not scrubbed, and their results have the error.
+func (vs *VolumeServer) VolumeScrub(ctx context.Context, req *volume_server_pb.VolumeScrubRequest) (*volume_server_pb.VolumeScrubResponse, error) {
+
+	resp := &volume_server_pb.VolumeScrubResponse{}
+
+	var vids []storage.VolumeId
+	for _, vid := range req.VolumeIds {
+		vids = append(vids, storage.VolumeId(vid))
+	}
+	if len(vids) == 0 {
+		vids = vs.store.VolumeIds()
+	}
+
+	for _, vid := range vids {
+		v := vs.store.GetVolume(vid)
+		if v == nil {
+			return nil, fmt.Errorf("volume %d not found", vid)
+		}
+
+		result := &volume_server_pb.VolumeScrubResult{
+			VolumeId:   uint32(vid),
+			Collection: v.Collection,
+		}
+
+		scrubResult := v.LastScrubResult()
+		if req.ScrubNow {
+			if newResult, err := v.Scrub(vs.scrubBytesPerSecond); err != nil {
+				glog.Errorf("scrub volume %d: %v", vid, err)
+				result.Error = err.Error()
+			} else {
+				scrubResult = newResult
+			}
+		}
+
+		if scrubResult != nil {
+			result.ScrubbedAtNs = scrubResult.ScrubbedAt.UnixNano()
+			result.NeedleCount = uint64(scrubResult.NeedleCount)
+			for _, key := range scrubResult.CorruptedNeedles {
+				result.CorruptedNeedleIds = append(result.CorruptedNeedleIds, uint64(key))
+			}
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+
+}
+
+// loopScrubbingVolumes scrubs the local volumes one by one, each once within the scrub interval.
+// The volumes moved to the remote storage are skipped, since reading them all is costly.
+// The last results are saved with the volumes, so after restarting,
+// the volumes never scrubbed or not scrubbed within the interval are scrubbed first.
+func (vs *VolumeServer) loopScrubbingVolumes() {
+	time.Sleep(scrubStartDelay)
+	for {
+		vs.scrubDueVolumes()
+		time.Sleep(scrubCheckInterval)
+	}
+}
+
+func (vs *VolumeServer) scrubDueVolumes() {
+	for _, vid := range vs.store.VolumeIds() {
+		v := vs.store.GetVolume(vid)
+		if v == nil || v.IsRemote() {
+			continue
+		}
+		if last := v.LastScrubResult(); last != nil && time.Since(last.ScrubbedAt) < vs.scrubInterval {
+			continue
+		}
+		result, err := v.Scrub(vs.scrubBytesPerSecond)
+		if err != nil {
+			glog.Errorf("scrub volume %d: %v", vid, err)
+			continue
+		}
+		glog.V(1).Infof("scrubbed volume %d: %d needles, %d corrupted", vid, result.NeedleCount, len(result.CorruptedNeedles))
+	}
+}
@@ -66,7 +66,7 @@ func (vs *VolumeServer) VolumeSyncData(req *volume_server_pb.VolumeSyncDataReque
 		return fmt.Errorf("requested volume revision is %d, but current revision is %d", req.Revision, v.SuperBlock.CompactRevision)
 	}
 
-	content, err := storage.ReadNeedleBlob(v.DataFile(), int64(req.Offset)*types.NeedlePaddingSize, req.Size, v.Version())
+	content, err := storage.ReadNeedleBlob(v.DataReader(), int64(req.Offset)*types.NeedlePaddingSize, req.Size, v.Version())
 	if err != nil {
 		return fmt.Errorf("read offset:%d size:%d", req.Offset, req.Size)
 	}
@@ -99,3 +99,26 @@ func (vs *VolumeServer) VolumeSyncData(req *volume_server_pb.VolumeSyncDataReque
 	return nil
 
 }
+
+// VolumeSynchronize makes the local volume the same as the volume on the source data node
+func (vs *VolumeServer) VolumeSynchronize(ctx context.Context, req *volume_server_pb.VolumeSynchronizeRequest) (*volume_server_pb.VolumeSynchronizeResponse, error) {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+	if v == nil {
+		return nil, fmt.Errorf("not found volume id %d", req.VolumdId)
+	}
+
+	if err := v.Synchronize(req.SourceDataNode, vs.grpcDialOption); err != nil {
+		glog.Errorf("synchronize volume %d from %s: %v", req.VolumdId, req.SourceDataNode, err)
+		return nil, err
+	}
+
+	if err := v.VerifySynchronized(req.SourceDataNode, vs.grpcDialOption); err != nil {
+		glog.Errorf("verify volume %d with %s: %v", req.VolumdId, req.SourceDataNode, err)
+		return nil, err
+	}
+
+	glog.V(1).Infof("synchronized volume %d from %s", req.VolumdId, req.SourceDataNode)
+
+	return &volume_server_pb.VolumeSynchronizeResponse{}, nil
+}
@@ -0,0 +1,43 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+)
+
+// VolumeTierMoveDatToRemote uploads the .dat file of the volume to the S3 compatible storage, and removes the local .dat file.
+func (vs *VolumeServer) VolumeTierMoveDatToRemote(ctx context.Context, req *volume_server_pb.VolumeTierMoveDatToRemoteRequest) (*volume_server_pb.VolumeTierMoveDatToRemoteResponse, error) {
+
+	resp := &volume_server_pb.VolumeTierMoveDatToRemoteResponse{}
+
+	err := vs.store.MoveVolumeDatToRemote(storage.VolumeId(req.VolumdId), req.Collection, req.Endpoint, req.Region, req.Bucket)
+
+	if err != nil {
+		glog.Errorf("volume tier move dat to remote %v: %v", req, err)
+	} else {
+		glog.V(2).Infof("volume tier move dat to remote %v", req)
+	}
+
+	return resp, err
+
+}
+
+// VolumeTierMoveDatFromRemote downloads the .dat file of the volume back to the local disk, and deletes the remote copy.
+func (vs *VolumeServer) VolumeTierMoveDatFromRemote(ctx context.Context, req *volume_server_pb.VolumeTierMoveDatFromRemoteRequest) (*volume_server_pb.VolumeTierMoveDatFromRemoteResponse, error) {
+
+	resp := &volume_server_pb.VolumeTierMoveDatFromRemoteResponse{}
+
+	err := vs.store.MoveVolumeDatFromRemote(storage.VolumeId(req.VolumdId), req.Collection)
+
+	if err != nil {
+		glog.Errorf("volume tier move dat from remote %v: %v", req, err)
+	} else {
+		glog.V(2).Infof("volume tier move dat from remote %v", req)
+	}
+
+	return resp, err
+
+}
@@ -3,9 +3,11 @@ package weed_server
 import (
 	"google.golang.org/grpc"
 	"net/http"
+	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/stats"
 	"github.com/chrislusf/seaweedfs/weed/storage"
 	"github.com/spf13/viper"
 )
@@ -23,6 +25,10 @@ type VolumeServer struct {
 	needleMapKind     storage.NeedleMapType
 	FixJpgOrientation bool
 	ReadRedirect      bool
+
+	scrubInterval       time.Duration
+	scrubBytesPerSecond int64
+	inlineRepairs       *inlineRepairLimiter
 }
 
 func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
@@ -33,7 +39,9 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 	dataCenter string, rack string,
 	whiteList []string,
 	fixJpgOrientation bool,
-	readRedirect bool) *VolumeServer {
+	readRedirect bool,
+	scrubIntervalHours int, scrubMBps int,
+	fsyncMode storage.FsyncMode) *VolumeServer {
 
 	v := viper.GetViper()
 	signingKey := v.GetString("jwt.signing.key")
@@ -47,9 +55,13 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 		FixJpgOrientation: fixJpgOrientation,
 		ReadRedirect:      readRedirect,
 		grpcDialOption:    security.LoadClientTLS(viper.Sub("grpc"), "volume"),
+
+		scrubInterval:       time.Duration(scrubIntervalHours) * time.Hour,
+		scrubBytesPerSecond: int64(scrubMBps) * 1024 * 1024,
+		inlineRepairs:       newInlineRepairLimiter(),
 	}
 	vs.MasterNodes = masterNodes
-	vs.store = storage.NewStore(port, ip, publicUrl, folders, maxCounts, vs.needleMapKind)
+	vs.store = storage.NewStore(vs.grpcDialOption, port, ip, publicUrl, folders, maxCounts, vs.needleMapKind, fsyncMode)
 
 	vs.guard = security.NewGuard(whiteList, signingKey)
 
@@ -62,6 +74,7 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 		adminMux.HandleFunc("/stats/memory", vs.guard.WhiteList(statsMemoryHandler))
 		adminMux.HandleFunc("/stats/disk", vs.guard.WhiteList(vs.statsDiskHandler))
 	}
+	adminMux.HandleFunc("/metrics", vs.guard.WhiteList(stats.MetricsHandler))
 	adminMux.HandleFunc("/", vs.privateStoreHandler)
 	if publicMux != adminMux {
 		// separated admin and public port
@@ -71,6 +84,10 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 
 	go vs.heartbeat()
 
+	if vs.scrubInterval > 0 {
+		go vs.loopScrubbingVolumes()
+	}
+
 	return vs
 }
 
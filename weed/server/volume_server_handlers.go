@@ -2,6 +2,7 @@ package weed_server
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/security"
@@ -27,12 +28,15 @@ func (vs *VolumeServer) privateStoreHandler(w http.ResponseWriter, r *http.Reque
 	switch r.Method {
 	case "GET", "HEAD":
 		stats.ReadRequest()
+		defer stats.VolumeServerRequests.Observe("get", time.Now())
 		vs.GetOrHeadHandler(w, r)
 	case "DELETE":
 		stats.DeleteRequest()
+		defer stats.VolumeServerRequests.Observe("delete", time.Now())
 		vs.guard.WhiteList(vs.DeleteHandler)(w, r)
 	case "PUT", "POST":
 		stats.WriteRequest()
+		defer stats.VolumeServerRequests.Observe("post", time.Now())
 		vs.guard.WhiteList(vs.PostHandler)(w, r)
 	}
 }
@@ -41,9 +45,11 @@ func (vs *VolumeServer) publicReadOnlyHandler(w http.ResponseWriter, r *http.Req
 	switch r.Method {
 	case "GET":
 		stats.ReadRequest()
+		defer stats.VolumeServerRequests.Observe("get", time.Now())
 		vs.GetOrHeadHandler(w, r)
 	case "HEAD":
 		stats.ReadRequest()
+		defer stats.VolumeServerRequests.Observe("head", time.Now())
 		vs.GetOrHeadHandler(w, r)
 	}
 }
@@ -2,6 +2,7 @@ package weed_server
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"mime"
 	"mime/multipart"
@@ -40,7 +41,9 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	glog.V(4).Infoln("volume", volumeId, "reading", n)
-	if !vs.store.HasVolume(volumeId) {
+	hasVolume := vs.store.HasVolume(volumeId)
+	hasEcVolume := vs.store.HasEcVolume(volumeId)
+	if !hasVolume && !hasEcVolume {
 		if !vs.ReadRedirect {
 			glog.V(2).Infoln("volume is not local:", err, r.URL.Path)
 			w.WriteHeader(http.StatusNotFound)
@@ -65,7 +68,18 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	cookie := n.Cookie
-	count, e := vs.store.ReadVolumeNeedle(volumeId, n)
+	var count int
+	var e error
+	if hasVolume {
+		needleId := n.Id
+		count, e = vs.store.ReadVolumeNeedle(volumeId, n)
+		if e == storage.ErrorCRC {
+			glog.V(0).Infof("read %s: %v, reading from the other replicas", r.URL.Path, e)
+			count, e = vs.readCorruptedNeedle(volumeId, needleId, n)
+		}
+	} else {
+		count, e = vs.store.ReadEcShardNeedle(context.Background(), volumeId, n)
+	}
 	glog.V(4).Infoln("read bytes", count, "error", e)
 	if e != nil || count < 0 {
 		glog.V(0).Infof("read %s error: %v", r.URL.Path, e)
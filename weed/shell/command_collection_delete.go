@@ -0,0 +1,69 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+)
+
+func init() {
+	commands = append(commands, &commandCollectionDelete{})
+}
+
+type commandCollectionDelete struct {
+}
+
+func (c *commandCollectionDelete) Name() string {
+	return "collection.delete"
+}
+
+func (c *commandCollectionDelete) Help() string {
+	return `delete specified collection
+
+	collection.delete <collection_name>
+
+	This deletes all volumes of the collection on all volume servers. It can not be undone!
+
+`
+}
+
+func (c *commandCollectionDelete) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) == 0 {
+		return fmt.Errorf("missing collection name")
+	}
+
+	collectionName := args[0]
+
+	ctx := context.Background()
+	err = commandEnv.MasterClient.WithClient(func(client master_pb.SeaweedClient) error {
+		// the master does not report a missing collection, so look it up first
+		resp, err := client.CollectionList(ctx, &master_pb.CollectionListRequest{})
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, c := range resp.Collections {
+			if c.Name == collectionName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("collection %s is not found", collectionName)
+		}
+		_, err = client.CollectionDelete(ctx, &master_pb.CollectionDeleteRequest{
+			Name: collectionName,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "collection %s is deleted.\n", collectionName)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+)
+
+func init() {
+	commands = append(commands, &commandCollectionList{})
+}
+
+type commandCollectionList struct {
+}
+
+func (c *commandCollectionList) Name() string {
+	return "collection.list"
+}
+
+func (c *commandCollectionList) Help() string {
+	return "lists all collections"
+}
+
+func (c *commandCollectionList) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	var resp *master_pb.CollectionListResponse
+	ctx := context.Background()
+	err = commandEnv.MasterClient.WithClient(func(client master_pb.SeaweedClient) error {
+		resp, err = client.CollectionList(ctx, &master_pb.CollectionListRequest{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range resp.Collections {
+		fmt.Fprintf(writer, "collection:\"%s\"\n", c.Name)
+	}
+
+	fmt.Fprintf(writer, "Total %d collections.\n", len(resp.Collections))
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"google.golang.org/grpc"
+)
+
+// testMaster serves the collection requests from memory
+type testMaster struct {
+	master_pb.SeaweedServer
+	sync.Mutex
+	collections []string
+	deleted     []string
+}
+
+func (m *testMaster) KeepConnected(stream master_pb.Seaweed_KeepConnectedServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func (m *testMaster) CollectionList(ctx context.Context, req *master_pb.CollectionListRequest) (*master_pb.CollectionListResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	resp := &master_pb.CollectionListResponse{}
+	for _, name := range m.collections {
+		resp.Collections = append(resp.Collections, &master_pb.Collection{Name: name})
+	}
+	return resp, nil
+}
+
+// CollectionDelete succeeds for a missing collection, as the master does
+func (m *testMaster) CollectionDelete(ctx context.Context, req *master_pb.CollectionDeleteRequest) (*master_pb.CollectionDeleteResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	for i, name := range m.collections {
+		if name == req.Name {
+			m.collections = append(m.collections[:i], m.collections[i+1:]...)
+			m.deleted = append(m.deleted, name)
+			break
+		}
+	}
+	return &master_pb.CollectionDeleteResponse{}, nil
+}
+
+// startTestMaster returns a shell environment connected to the test master
+func startTestMaster(t *testing.T, collections ...string) (*testMaster, *CommandEnv, func()) {
+	// the clients dial the grpc port at the http port + 10000
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcPort := listener.Addr().(*net.TCPAddr).Port
+	if grpcPort <= 10000 {
+		listener.Close()
+		t.Skipf("grpc port %d has no http port", grpcPort)
+	}
+
+	master := &testMaster{collections: collections}
+	server := grpc.NewServer()
+	master_pb.RegisterSeaweedServer(server, master)
+	go server.Serve(listener)
+
+	masters := fmt.Sprintf("localhost:%d", grpcPort-10000)
+	commandEnv := NewCommandEnv(ShellOptions{
+		Masters:        &masters,
+		GrpcDialOption: grpc.WithInsecure(),
+	})
+	go commandEnv.MasterClient.KeepConnectedToMaster()
+	commandEnv.MasterClient.WaitUntilConnected()
+
+	return master, commandEnv, server.Stop
+}
+
+func TestCollectionList(t *testing.T) {
+	_, commandEnv, stop := startTestMaster(t, "pictures", "videos")
+	defer stop()
+
+	var output bytes.Buffer
+	if err := (&commandCollectionList{}).Do(nil, commandEnv, &output); err != nil {
+		t.Fatalf("collection.list: %v", err)
+	}
+	expected := "collection:\"pictures\"\ncollection:\"videos\"\nTotal 2 collections.\n"
+	if output.String() != expected {
+		t.Errorf("collection.list output %q, expected %q", output.String(), expected)
+	}
+}
+
+func TestCollectionDelete(t *testing.T) {
+	master, commandEnv, stop := startTestMaster(t, "pictures", "videos")
+	defer stop()
+
+	c := &commandCollectionDelete{}
+
+	var output bytes.Buffer
+	if err := c.Do(nil, commandEnv, &output); err == nil {
+		t.Errorf("collection.delete without a name succeeded")
+	}
+
+	output.Reset()
+	if err := c.Do([]string{"pictures"}, commandEnv, &output); err != nil {
+		t.Fatalf("collection.delete pictures: %v", err)
+	}
+	if output.String() != "collection pictures is deleted.\n" {
+		t.Errorf("collection.delete output %q", output.String())
+	}
+
+	output.Reset()
+	err := c.Do([]string{"pictures"}, commandEnv, &output)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("deleting a missing collection: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("deleting a missing collection printed %q", output.String())
+	}
+
+	master.Lock()
+	defer master.Unlock()
+	if strings.Join(master.deleted, ",") != "pictures" || strings.Join(master.collections, ",") != "videos" {
+		t.Errorf("deleted %v, left %v", master.deleted, master.collections)
+	}
+}
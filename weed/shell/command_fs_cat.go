@@ -0,0 +1,88 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	commands = append(commands, &commandFsCat{})
+}
+
+type commandFsCat struct {
+}
+
+func (c *commandFsCat) Name() string {
+	return "fs.cat"
+}
+
+func (c *commandFsCat) Help() string {
+	return `stream the file content on to the screen
+
+	fs.cat /dir/
+	fs.cat /dir/file_name
+	fs.cat http://<filer_server>:<port>/dir/file_name
+`
+}
+
+func (c *commandFsCat) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) == 0 {
+		return fmt.Errorf("missing file name")
+	}
+
+	filerServer, filerPort, path, err := commandEnv.parseUrl(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	if commandEnv.isDirectory(ctx, filerServer, filerPort, path) {
+		return fmt.Errorf("%s is a directory", path)
+	}
+
+	dir, name := filer2.FullPath(path).DirAndName()
+
+	return commandEnv.withFilerClient(ctx, filerServer, filerPort, func(client filer_pb.SeaweedFilerClient) error {
+
+		resp, err := client.LookupDirectoryEntry(ctx, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if err != nil {
+			return err
+		}
+
+		return writeContent(commandEnv, writer, resp.Entry.Chunks)
+
+	})
+
+}
+
+func writeContent(commandEnv *CommandEnv, writer io.Writer, chunks []*filer_pb.FileChunk) error {
+
+	chunkViews := filer2.ViewFromChunks(chunks, 0, int(filer2.TotalSize(chunks)))
+
+	for _, chunkView := range chunkViews {
+
+		urlString, err := commandEnv.MasterClient.LookupFileId(chunkView.FileId)
+		if err != nil {
+			return fmt.Errorf("lookup %s: %v", chunkView.FileId, err)
+		}
+
+		_, err = util.ReadUrlAsStream(urlString, chunkView.Offset, int(chunkView.Size), func(data []byte) {
+			writer.Write(data)
+		})
+		if err != nil {
+			return fmt.Errorf("read %s: %v", chunkView.FileId, err)
+		}
+	}
+
+	return nil
+}
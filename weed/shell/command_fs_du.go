@@ -0,0 +1,99 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	commands = append(commands, &commandFsDu{})
+}
+
+type commandFsDu struct {
+}
+
+func (c *commandFsDu) Name() string {
+	return "fs.du"
+}
+
+func (c *commandFsDu) Help() string {
+	return `show disk usage
+
+	fs.du http://<filer_server>:<port>/dir
+	fs.du http://<filer_server>:<port>/dir/file_name
+	fs.du http://<filer_server>:<port>/dir/file_prefix
+`
+}
+
+func (c *commandFsDu) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	input := ""
+	if len(args) > 0 {
+		input = args[0]
+	}
+
+	filerServer, filerPort, path, err := commandEnv.parseUrl(input)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	if commandEnv.isDirectory(ctx, filerServer, filerPort, path) {
+		path = path + "/"
+	}
+
+	dir, name := filer2.FullPath(path).DirAndName()
+
+	return commandEnv.withFilerClient(ctx, filerServer, filerPort, func(client filer_pb.SeaweedFilerClient) error {
+
+		_, _, err = duTraverseDirectory(ctx, writer, client, dir, name)
+		return err
+
+	})
+
+}
+
+func duTraverseDirectory(ctx context.Context, writer io.Writer, client filer_pb.SeaweedFilerClient, dir, name string) (blockCount uint64, byteCount uint64, err error) {
+
+	var traverseErr error
+	err = readDirectory(ctx, client, dir, name, func(entry *filer_pb.Entry) {
+		if traverseErr != nil {
+			return
+		}
+		entryPath := string(filer2.NewFullPath(dir, entry.Name))
+		if entry.IsDirectory {
+			numBlock, numByte, subErr := duTraverseDirectory(ctx, writer, client, entryPath, "")
+			if subErr != nil {
+				traverseErr = subErr
+				return
+			}
+			blockCount += numBlock
+			byteCount += numByte
+		} else {
+			fileBlockCount := uint64(len(entry.Chunks))
+			fileByteCount := filer2.TotalSize(entry.Chunks)
+			blockCount += fileBlockCount
+			byteCount += fileByteCount
+			if name != "" {
+				fmt.Fprintf(writer, "block:%4d\tbyte:%10d\t%s\n", fileBlockCount, fileByteCount, entryPath)
+			}
+		}
+	})
+	if err == nil {
+		err = traverseErr
+	}
+	if err != nil {
+		return
+	}
+
+	if name == "" {
+		fmt.Fprintf(writer, "block:%4d\tbyte:%10d\t%s\n", blockCount, byteCount, dir)
+	}
+
+	return
+}
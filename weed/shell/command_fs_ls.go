@@ -0,0 +1,149 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	commands = append(commands, &commandFsLs{})
+}
+
+type commandFsLs struct {
+}
+
+func (c *commandFsLs) Name() string {
+	return "fs.ls"
+}
+
+func (c *commandFsLs) Help() string {
+	return `list all files under a directory
+
+	fs.ls [-l] [-a] /dir/
+	fs.ls [-l] [-a] /dir/file_name
+	fs.ls [-l] [-a] /dir/file_prefix
+	fs.ls [-l] [-a] http://<filer_server>:<port>/dir/
+`
+}
+
+func (c *commandFsLs) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	var isLongFormat, showHidden bool
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			break
+		}
+		for _, t := range arg {
+			switch t {
+			case 'a':
+				showHidden = true
+			case 'l':
+				isLongFormat = true
+			}
+		}
+	}
+
+	input := ""
+	if len(args) > 0 && !strings.HasPrefix(args[len(args)-1], "-") {
+		input = args[len(args)-1]
+	}
+
+	filerServer, filerPort, path, err := commandEnv.parseUrl(input)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	if commandEnv.isDirectory(ctx, filerServer, filerPort, path) {
+		path = path + "/"
+	}
+
+	dir, name := filer2.FullPath(path).DirAndName()
+	entryCount := 0
+
+	err = commandEnv.withFilerClient(ctx, filerServer, filerPort, func(client filer_pb.SeaweedFilerClient) error {
+
+		return readDirectory(ctx, client, dir, name, func(entry *filer_pb.Entry) {
+
+			if !showHidden && strings.HasPrefix(entry.Name, ".") {
+				return
+			}
+
+			entryCount++
+
+			if isLongFormat {
+				fileMode := os.FileMode(entry.Attributes.FileMode)
+				userName, groupNames := entry.Attributes.UserName, entry.Attributes.GroupName
+				if userName == "" {
+					if user, userErr := user.LookupId(strconv.Itoa(int(entry.Attributes.Uid))); userErr == nil {
+						userName = user.Username
+					}
+				}
+				groupName := ""
+				if len(groupNames) > 0 {
+					groupName = groupNames[0]
+				}
+				if groupName == "" {
+					if group, groupErr := user.LookupGroupId(strconv.Itoa(int(entry.Attributes.Gid))); groupErr == nil {
+						groupName = group.Name
+					}
+				}
+
+				if dir == "/" {
+					// just for printing
+					dir = ""
+				}
+				fmt.Fprintf(writer, "%s %3d %s %s %6d %s %s/%s\n",
+					fileMode, len(entry.Chunks), userName, groupName,
+					filer2.TotalSize(entry.Chunks), time.Unix(entry.Attributes.Mtime, 0).Format(time.RFC3339),
+					dir, entry.Name)
+			} else {
+				fmt.Fprintf(writer, "%s\n", entry.Name)
+			}
+
+		})
+	})
+
+	if isLongFormat && err == nil {
+		fmt.Fprintf(writer, "total %d\n", entryCount)
+	}
+
+	return
+}
+
+// readDirectory streams all entries under the directory.
+// A non empty prefix only lists the entries whose name starts with it.
+func readDirectory(ctx context.Context, client filer_pb.SeaweedFilerClient, dir, prefix string, fn func(entry *filer_pb.Entry)) error {
+
+	stream, err := client.ListEntries(ctx, &filer_pb.ListEntriesRequest{
+		Directory: dir,
+		Prefix:    prefix,
+		Limit:     math.MaxInt32,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, recvErr := stream.Recv()
+		if recvErr == io.EOF {
+			return nil
+		}
+		if recvErr != nil {
+			return recvErr
+		}
+		fn(resp.Entry)
+	}
+
+}
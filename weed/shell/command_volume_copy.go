@@ -0,0 +1,65 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeCopy{})
+}
+
+type commandVolumeCopy struct {
+}
+
+func (c *commandVolumeCopy) Name() string {
+	return "volume.copy"
+}
+
+func (c *commandVolumeCopy) Help() string {
+	return `copy a volume from one volume server to another volume server
+
+	volume.copy <source volume server host:port> <target volume server host:port> <volume id>
+
+	This command copies the .idx and .dat files of a volume to the target volume server, and mounts it there.
+	The source volume is kept. The master learns the new copy from the next heartbeat of the target volume server.
+
+`
+}
+
+func (c *commandVolumeCopy) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 3 {
+		fmt.Fprintf(writer, "received args: %+v\n", args)
+		return fmt.Errorf("need 3 args of <source volume server host:port> <target volume server host:port> <volume id>")
+	}
+	sourceVolumeServer, targetVolumeServer, volumeIdString := args[0], args[1], args[2]
+
+	volumeId, err := storage.NewVolumeId(volumeIdString)
+	if err != nil {
+		return fmt.Errorf("wrong volume id format %s: %v", volumeIdString, err)
+	}
+
+	if sourceVolumeServer == targetVolumeServer {
+		return fmt.Errorf("source and target volume servers are the same!")
+	}
+
+	ctx := context.Background()
+	return copyVolume(ctx, commandEnv.option.GrpcDialOption, volumeId, sourceVolumeServer, targetVolumeServer)
+}
+
+func copyVolume(ctx context.Context, grpcDialOption grpc.DialOption, volumeId storage.VolumeId, sourceVolumeServer, targetVolumeServer string) (err error) {
+	return operation.WithVolumeServerClient(targetVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, copyErr := volumeServerClient.VolumeCopy(ctx, &volume_server_pb.VolumeCopyRequest{
+			VolumdId:       uint32(volumeId),
+			SourceDataNode: sourceVolumeServer,
+		})
+		return copyErr
+	})
+}
@@ -0,0 +1,60 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeDelete{})
+}
+
+type commandVolumeDelete struct {
+}
+
+func (c *commandVolumeDelete) Name() string {
+	return "volume.delete"
+}
+
+func (c *commandVolumeDelete) Help() string {
+	return `delete a live volume from one volume server
+
+	volume.delete <volume server host:port> <volume id>
+
+	This command deletes a volume from one volume server. It can not be undone!
+
+`
+}
+
+func (c *commandVolumeDelete) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 2 {
+		fmt.Fprintf(writer, "received args: %+v\n", args)
+		return fmt.Errorf("need 2 args of <volume server host:port> <volume id>")
+	}
+	sourceVolumeServer, volumeIdString := args[0], args[1]
+
+	volumeId, err := storage.NewVolumeId(volumeIdString)
+	if err != nil {
+		return fmt.Errorf("wrong volume id format %s: %v", volumeIdString, err)
+	}
+
+	ctx := context.Background()
+	return deleteVolume(ctx, commandEnv.option.GrpcDialOption, volumeId, sourceVolumeServer)
+
+}
+
+func deleteVolume(ctx context.Context, grpcDialOption grpc.DialOption, volumeId storage.VolumeId, sourceVolumeServer string) (err error) {
+	return operation.WithVolumeServerClient(sourceVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, deleteErr := volumeServerClient.VolumeDelete(ctx, &volume_server_pb.VolumeDeleteRequest{
+			VolumdId: uint32(volumeId),
+		})
+		return deleteErr
+	})
+}
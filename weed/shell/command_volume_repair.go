@@ -0,0 +1,68 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeRepair{})
+}
+
+type commandVolumeRepair struct {
+}
+
+func (c *commandVolumeRepair) Name() string {
+	return "volume.repair"
+}
+
+func (c *commandVolumeRepair) Help() string {
+	return `repair the corrupted needles of a volume from the other replicas
+
+	volume.repair <volume server host:port> <volume id>
+
+	This command verifies the CRC of all the needles of the volume on the volume server,
+	and replaces the corrupted ones with the good copies from the other replicas.
+	The volume needs to be writable.
+
+`
+}
+
+func (c *commandVolumeRepair) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 2 {
+		fmt.Fprintf(writer, "received args: %+v\n", args)
+		return fmt.Errorf("need 2 args of <volume server host:port> <volume id>")
+	}
+	volumeServer, volumeIdString := args[0], args[1]
+
+	volumeId, err := storage.NewVolumeId(volumeIdString)
+	if err != nil {
+		return fmt.Errorf("wrong volume id format %s: %v", volumeIdString, err)
+	}
+
+	ctx := context.Background()
+	return operation.WithVolumeServerClient(volumeServer, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		resp, repairErr := volumeServerClient.VolumeRepair(ctx, &volume_server_pb.VolumeRepairRequest{
+			VolumdId: uint32(volumeId),
+		})
+		if repairErr != nil {
+			return repairErr
+		}
+		fmt.Fprintf(writer, "volume %d: %d needles checked, %d repaired, %d failed\n",
+			volumeId, resp.NeedleCount, len(resp.RepairedNeedleIds), len(resp.FailedNeedleIds))
+		for _, key := range resp.RepairedNeedleIds {
+			fmt.Fprintf(writer, "  repaired needle %x\n", key)
+		}
+		for _, key := range resp.FailedNeedleIds {
+			fmt.Fprintf(writer, "  failed to repair needle %x\n", key)
+		}
+		return nil
+	})
+
+}
@@ -0,0 +1,126 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeTierUpload{})
+	commands = append(commands, &commandVolumeTierDownload{})
+}
+
+type commandVolumeTierUpload struct {
+}
+
+func (c *commandVolumeTierUpload) Name() string {
+	return "volume.tier.upload"
+}
+
+func (c *commandVolumeTierUpload) Help() string {
+	return `move the .dat file of a volume to a S3 compatible storage
+
+	volume.tier.upload <volume server host:port> <volume id> <bucket> [endpoint] [region]
+
+	This command moves the .dat file of a volume from one volume server to the bucket,
+	and the volume becomes read-only. The .idx file is kept on the volume server.
+	The endpoint defaults to the volume server -tier.s3.endpoint option, or else AWS S3,
+	and can be any S3 compatible storage, e.g., "weed s3" at http://localhost:8333 .
+	The volume server reads the credentials from its -tier.s3.accessKey and -tier.s3.secretKey options,
+	or else its AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables, or other default aws credential providers.
+
+`
+}
+
+func (c *commandVolumeTierUpload) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) < 3 || len(args) > 5 {
+		fmt.Fprintf(writer, "received args: %+v\n", args)
+		return fmt.Errorf("need args of <volume server host:port> <volume id> <bucket> [endpoint] [region]")
+	}
+	volumeServer, volumeIdString, bucket := args[0], args[1], args[2]
+	var endpoint, region string
+	if len(args) > 3 {
+		endpoint = args[3]
+	}
+	if len(args) > 4 {
+		region = args[4]
+	}
+
+	volumeId, err := storage.NewVolumeId(volumeIdString)
+	if err != nil {
+		return fmt.Errorf("wrong volume id format %s: %v", volumeIdString, err)
+	}
+
+	ctx := context.Background()
+	return operation.WithVolumeServerClient(volumeServer, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		syncStatus, statusErr := volumeServerClient.VolumeSyncStatus(ctx, &volume_server_pb.VolumeSyncStatusRequest{
+			VolumdId: uint32(volumeId),
+		})
+		if statusErr != nil {
+			return fmt.Errorf("read volume %d status: %v", volumeId, statusErr)
+		}
+		_, uploadErr := volumeServerClient.VolumeTierMoveDatToRemote(ctx, &volume_server_pb.VolumeTierMoveDatToRemoteRequest{
+			VolumdId:   uint32(volumeId),
+			Collection: syncStatus.Collection,
+			Endpoint:   endpoint,
+			Region:     region,
+			Bucket:     bucket,
+		})
+		return uploadErr
+	})
+
+}
+
+type commandVolumeTierDownload struct {
+}
+
+func (c *commandVolumeTierDownload) Name() string {
+	return "volume.tier.download"
+}
+
+func (c *commandVolumeTierDownload) Help() string {
+	return `move the .dat file of a volume back from the S3 compatible storage
+
+	volume.tier.download <volume server host:port> <volume id>
+
+	This command moves the .dat file of a volume, uploaded by volume.tier.upload, back to the volume server,
+	and deletes the remote copy.
+
+`
+}
+
+func (c *commandVolumeTierDownload) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) != 2 {
+		fmt.Fprintf(writer, "received args: %+v\n", args)
+		return fmt.Errorf("need 2 args of <volume server host:port> <volume id>")
+	}
+	volumeServer, volumeIdString := args[0], args[1]
+
+	volumeId, err := storage.NewVolumeId(volumeIdString)
+	if err != nil {
+		return fmt.Errorf("wrong volume id format %s: %v", volumeIdString, err)
+	}
+
+	ctx := context.Background()
+	return operation.WithVolumeServerClient(volumeServer, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		syncStatus, statusErr := volumeServerClient.VolumeSyncStatus(ctx, &volume_server_pb.VolumeSyncStatusRequest{
+			VolumdId: uint32(volumeId),
+		})
+		if statusErr != nil {
+			return fmt.Errorf("read volume %d status: %v", volumeId, statusErr)
+		}
+		_, downloadErr := volumeServerClient.VolumeTierMoveDatFromRemote(ctx, &volume_server_pb.VolumeTierMoveDatFromRemoteRequest{
+			VolumdId:   uint32(volumeId),
+			Collection: syncStatus.Collection,
+		})
+		return downloadErr
+	})
+
+}
@@ -0,0 +1,56 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+)
+
+func init() {
+	commands = append(commands, &commandVacuum{})
+}
+
+type commandVacuum struct {
+}
+
+func (c *commandVacuum) Name() string {
+	return "volume.vacuum"
+}
+
+func (c *commandVacuum) Help() string {
+	return `compact volumes if deleted entries are more than the limit
+
+	volume.vacuum [garbageThreshold]
+
+	The garbageThreshold defaults to the master's -garbageThreshold option, e.g. 0.3
+
+`
+}
+
+func (c *commandVacuum) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	var garbageThreshold float64
+	if len(args) > 0 {
+		if garbageThreshold, err = strconv.ParseFloat(args[0], 32); err != nil {
+			return fmt.Errorf("garbageThreshold %s is not a valid float number: %v", args[0], err)
+		}
+	}
+
+	ctx := context.Background()
+	err = commandEnv.MasterClient.WithClient(func(client master_pb.SeaweedClient) error {
+		_, err = client.VacuumVolume(ctx, &master_pb.VacuumVolumeRequest{
+			GarbageThreshold: float32(garbageThreshold),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "vacuum completed.\n")
+
+	return nil
+}
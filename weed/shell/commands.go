@@ -0,0 +1,102 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/filer2"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
+	"google.golang.org/grpc"
+)
+
+type ShellOptions struct {
+	Masters        *string
+	GrpcDialOption grpc.DialOption
+	// shell transient context
+	FilerHost string
+	FilerPort int64
+	Directory string
+}
+
+type CommandEnv struct {
+	env          map[string]string
+	MasterClient *wdclient.MasterClient
+	option       ShellOptions
+}
+
+type command interface {
+	Name() string
+	Help() string
+	Do([]string, *CommandEnv, io.Writer) error
+}
+
+var (
+	commands = []command{}
+)
+
+func NewCommandEnv(options ShellOptions) *CommandEnv {
+	return &CommandEnv{
+		env: make(map[string]string),
+		MasterClient: wdclient.NewMasterClient(context.Background(),
+			options.GrpcDialOption, "shell", strings.Split(*options.Masters, ",")),
+		option: options,
+	}
+}
+
+// parseUrl accepts either a full url like http://localhost:8888/some/path,
+// or a path, absolute or relative to the current directory, on the current filer
+func (ce *CommandEnv) parseUrl(input string) (filerServer string, filerPort int64, path string, err error) {
+	if strings.HasPrefix(input, "http") {
+		return util.ParseFilerUrl(input)
+	}
+	if !strings.HasPrefix(input, "/") {
+		input = filepath.ToSlash(filepath.Join(ce.option.Directory, input))
+	}
+	return ce.option.FilerHost, ce.option.FilerPort, input, err
+}
+
+func (ce *CommandEnv) isDirectory(ctx context.Context, filerServer string, filerPort int64, path string) bool {
+
+	return ce.checkDirectory(ctx, filerServer, filerPort, path) == nil
+}
+
+func (ce *CommandEnv) checkDirectory(ctx context.Context, filerServer string, filerPort int64, path string) error {
+
+	if path == "/" {
+		return nil
+	}
+	dir, name := filer2.FullPath(strings.TrimSuffix(path, "/")).DirAndName()
+
+	return ce.withFilerClient(ctx, filerServer, filerPort, func(client filer_pb.SeaweedFilerClient) error {
+
+		resp, err := client.LookupDirectoryEntry(ctx, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !resp.Entry.IsDirectory {
+			return fmt.Errorf("not a directory")
+		}
+
+		return nil
+	})
+
+}
+
+func (ce *CommandEnv) withFilerClient(ctx context.Context, filerServer string, filerPort int64, fn func(filer_pb.SeaweedFilerClient) error) error {
+
+	filerGrpcAddress := fmt.Sprintf("%s:%d", filerServer, filerPort+10000)
+	return util.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		return fn(client)
+	}, filerGrpcAddress, ce.option.GrpcDialOption)
+
+}
@@ -0,0 +1,101 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// RunShell reads commands from stdin and executes them until "exit" or EOF
+func RunShell(options ShellOptions) {
+
+	commandEnv := NewCommandEnv(options)
+	go commandEnv.MasterClient.KeepConnectedToMaster()
+	commandEnv.MasterClient.WaitUntilConnected()
+
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		fmt.Fprint(os.Stdout, "> ")
+
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			fmt.Fprintf(os.Stderr, "read input: %v\n", readErr)
+			return
+		}
+
+		exit, err := processEachCmd(line, commandEnv, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		if exit {
+			return
+		}
+
+		if readErr == io.EOF {
+			fmt.Fprintln(os.Stdout)
+			return
+		}
+	}
+}
+
+// processEachCmd runs one input line, and returns true if the shell should exit
+func processEachCmd(line string, commandEnv *CommandEnv, writer io.Writer) (exit bool, err error) {
+	cmds := strings.Fields(line)
+	if len(cmds) == 0 {
+		return false, nil
+	}
+	cmd := strings.ToLower(cmds[0])
+	if cmd == "exit" || cmd == "quit" {
+		return true, nil
+	}
+	if cmd == "help" || cmd == "?" {
+		printHelp(cmds[1:])
+		return false, nil
+	}
+	return false, executeCommand(cmd, cmds[1:], commandEnv, writer)
+}
+
+func executeCommand(cmd string, args []string, commandEnv *CommandEnv, writer io.Writer) error {
+	for _, c := range commands {
+		if c.Name() == cmd {
+			return c.Do(args, commandEnv, writer)
+		}
+	}
+	return fmt.Errorf("unknown command: %v", cmd)
+}
+
+func printGenericHelp() {
+	msg :=
+		`Type:	"help <command>" for help on <command>
+`
+	fmt.Print(msg)
+
+	sort.Slice(commands, func(i, j int) bool {
+		return strings.Compare(commands[i].Name(), commands[j].Name()) < 0
+	})
+	for _, c := range commands {
+		helpTexts := strings.SplitN(c.Help(), "\n", 2)
+		fmt.Printf("  %-30s\t# %s \n", c.Name(), helpTexts[0])
+	}
+}
+
+func printHelp(cmds []string) {
+	args := cmds
+	if len(args) == 0 {
+		printGenericHelp()
+	} else if len(args) > 1 {
+		fmt.Println()
+	} else {
+		cmd := strings.ToLower(args[0])
+
+		for _, c := range commands {
+			if c.Name() == cmd {
+				fmt.Printf("  %s\t# %s\n", c.Name(), c.Help())
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package shell
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testCommand records the arguments of its last run
+type testCommand struct {
+	args []string
+}
+
+func (c *testCommand) Name() string {
+	return "test.echo"
+}
+
+func (c *testCommand) Help() string {
+	return "prints the arguments"
+}
+
+func (c *testCommand) Do(args []string, commandEnv *CommandEnv, writer io.Writer) error {
+	c.args = args
+	_, err := io.WriteString(writer, strings.Join(args, ","))
+	return err
+}
+
+func TestCommandsRegistered(t *testing.T) {
+	expected := []string{
+		"collection.delete",
+		"collection.list",
+		"fs.cat",
+		"fs.cd",
+		"fs.du",
+		"fs.ls",
+		"fs.pwd",
+		"volume.copy",
+		"volume.delete",
+		"volume.list",
+		"volume.mount",
+		"volume.repair",
+		"volume.tier.download",
+		"volume.tier.upload",
+		"volume.unmount",
+		"volume.vacuum",
+	}
+
+	names := make(map[string]bool)
+	for _, c := range commands {
+		if names[c.Name()] {
+			t.Errorf("command %s is registered twice", c.Name())
+		}
+		names[c.Name()] = true
+		if strings.TrimSpace(c.Help()) == "" {
+			t.Errorf("command %s has no help", c.Name())
+		}
+		if c.Name() != strings.ToLower(c.Name()) {
+			t.Errorf("command %s can not be typed, the input is lower cased", c.Name())
+		}
+	}
+	for _, name := range expected {
+		if !names[name] {
+			t.Errorf("command %s is not registered", name)
+		}
+	}
+}
+
+func TestProcessEachCmd(t *testing.T) {
+	c := &testCommand{}
+	commands = append(commands, c)
+	defer func() {
+		commands = commands[:len(commands)-1]
+	}()
+
+	tests := []struct {
+		line   string
+		exit   bool
+		hasErr bool
+		args   []string
+		output string
+	}{
+		{line: "", args: nil},
+		{line: "  \t\n", args: nil},
+		{line: "exit\n", exit: true},
+		{line: "QUIT", exit: true},
+		{line: "test.echo\n", args: []string{}},
+		{line: "  Test.Echo  a   B\tc\n", args: []string{"a", "B", "c"}, output: "a,B,c"},
+		{line: "test.unknown a\n", hasErr: true},
+	}
+	for _, test := range tests {
+		c.args = nil
+		var output bytes.Buffer
+		exit, err := processEachCmd(test.line, &CommandEnv{}, &output)
+		if exit != test.exit {
+			t.Errorf("%q: exit %v, expected %v", test.line, exit, test.exit)
+		}
+		if (err != nil) != test.hasErr {
+			t.Errorf("%q: error %v", test.line, err)
+		}
+		if strings.Join(c.args, " ") != strings.Join(test.args, " ") || (c.args == nil) != (test.args == nil) {
+			t.Errorf("%q: arguments %q, expected %q", test.line, c.args, test.args)
+		}
+		if output.String() != test.output {
+			t.Errorf("%q: output %q, expected %q", test.line, output.String(), test.output)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package stats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Gather is the registry of all metrics, exposed at /metrics in the Prometheus exposition format
+var Gather = prometheus.NewRegistry()
+
+var (
+	MasterRequests       = newRequestMetrics("master")
+	VolumeServerRequests = newRequestMetrics("volumeServer")
+	FilerRequests        = newRequestMetrics("filer")
+
+	NetworkBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "network",
+			Name:      "bytes_total",
+			Help:      "Counter of bytes received and sent on the http connections.",
+		}, []string{"direction"})
+
+	MasterTopologyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "master",
+			Name:      "topology",
+			Help:      "Number of data centers, racks, data nodes, volume slots, volumes and ec shards in the topology.",
+		}, []string{"type"})
+
+	MasterCollectionVolumeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "master",
+			Name:      "collection_volumes",
+			Help:      "Number of volumes in each collection, and how many of them are writable.",
+		}, []string{"collection", "type"})
+
+	VolumeServerVolumeSizeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "volumeServer",
+			Name:      "volume_size_bytes",
+			Help:      "Size of each volume's .dat file.",
+		}, []string{"collection", "volume"})
+
+	VolumeServerVolumeGarbageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "volumeServer",
+			Name:      "volume_garbage_ratio",
+			Help:      "Ratio of deleted bytes to content bytes of each volume.",
+		}, []string{"collection", "volume"})
+
+	FilerStoreCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "filerStore",
+			Name:      "request_total",
+			Help:      "Counter of filer store operations.",
+		}, []string{"store", "type"})
+
+	FilerStoreHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "filerStore",
+			Name:      "request_seconds",
+			Help:      "Bucketed histogram of filer store operation latency.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 24),
+		}, []string{"store", "type"})
+)
+
+func init() {
+	Gather.MustRegister(prometheus.NewGoCollector())
+	Gather.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+	MasterRequests.register()
+	VolumeServerRequests.register()
+	FilerRequests.register()
+
+	Gather.MustRegister(NetworkBytesCounter)
+	Gather.MustRegister(MasterTopologyGauge)
+	Gather.MustRegister(MasterCollectionVolumeGauge)
+	Gather.MustRegister(VolumeServerVolumeSizeGauge)
+	Gather.MustRegister(VolumeServerVolumeGarbageGauge)
+	Gather.MustRegister(FilerStoreCounter)
+	Gather.MustRegister(FilerStoreHistogram)
+}
+
+// RequestMetrics counts the requests of one server, and observes their latency, by request type
+type RequestMetrics struct {
+	counter   *prometheus.CounterVec
+	histogram *prometheus.HistogramVec
+}
+
+func newRequestMetrics(subsystem string) *RequestMetrics {
+	return &RequestMetrics{
+		counter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "SeaweedFS",
+				Subsystem: subsystem,
+				Name:      "request_total",
+				Help:      "Counter of " + subsystem + " requests.",
+			}, []string{"type"}),
+		histogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "SeaweedFS",
+				Subsystem: subsystem,
+				Name:      "request_seconds",
+				Help:      "Bucketed histogram of " + subsystem + " request processing time.",
+				Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 24),
+			}, []string{"type"}),
+	}
+}
+
+func (m *RequestMetrics) register() {
+	Gather.MustRegister(m.counter)
+	Gather.MustRegister(m.histogram)
+}
+
+// Observe counts one request, which started at the given time, e.g., defer m.Observe("get", time.Now())
+func (m *RequestMetrics) Observe(requestType string, start time.Time) {
+	m.counter.WithLabelValues(requestType).Inc()
+	m.histogram.WithLabelValues(requestType).Observe(time.Since(start).Seconds())
+}
+
+// Instrument wraps the http handler to observe each request as the request type
+func (m *RequestMetrics) Instrument(requestType string, f func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer m.Observe(requestType, time.Now())
+		f(w, r)
+	}
+}
+
+// ObserveFilerStore counts one filer store operation, which started at the given time
+func ObserveFilerStore(store, requestType string, start time.Time) {
+	FilerStoreCounter.WithLabelValues(store, requestType).Inc()
+	FilerStoreHistogram.WithLabelValues(store, requestType).Observe(time.Since(start).Seconds())
+}
+
+var metricsHandler = promhttp.HandlerFor(Gather, promhttp.HandlerOpts{})
+
+// MetricsHandler writes all metrics in the Prometheus exposition format
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	metricsHandler.ServeHTTP(w, r)
+}
@@ -81,9 +81,11 @@ func DeleteRequest() {
 	Chan.DeleteRequests <- NewTimedValue(time.Now(), 1)
 }
 func BytesIn(val int64) {
+	NetworkBytesCounter.WithLabelValues("in").Add(float64(val))
 	Chan.BytesIn <- NewTimedValue(time.Now(), val)
 }
 func BytesOut(val int64) {
+	NetworkBytesCounter.WithLabelValues("out").Add(float64(val))
 	Chan.BytesOut <- NewTimedValue(time.Now(), val)
 }
 
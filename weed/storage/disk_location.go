@@ -16,33 +16,55 @@ type DiskLocation struct {
 	MaxVolumeCount int
 	volumes        map[VolumeId]*Volume
 	sync.RWMutex
+
+	// erasure coding
+	ecVolumes     map[VolumeId]*EcVolume
+	ecVolumesLock sync.RWMutex
 }
 
 func NewDiskLocation(dir string, maxVolumeCount int) *DiskLocation {
 	location := &DiskLocation{Directory: dir, MaxVolumeCount: maxVolumeCount}
 	location.volumes = make(map[VolumeId]*Volume)
+	location.ecVolumes = make(map[VolumeId]*EcVolume)
 	return location
 }
 
 func (l *DiskLocation) volumeIdFromPath(dir os.FileInfo) (VolumeId, string, error) {
 	name := dir.Name()
-	if !dir.IsDir() && strings.HasSuffix(name, ".dat") {
-		collection := ""
-		base := name[:len(name)-len(".dat")]
-		i := strings.LastIndex(base, "_")
-		if i > 0 {
-			collection, base = base[0:i], base[i+1:]
-		}
-		vol, err := NewVolumeId(base)
+	if base, ok := volumeBaseName(dir); ok {
+		collection, vol, err := parseCollectionVolumeId(base)
 		return vol, collection, err
 	}
 
 	return 0, "", fmt.Errorf("Path is not a volume: %s", name)
 }
 
+// volumeBaseName recognizes the volume by its local .dat file, or the .tier file if the .dat file is moved to the remote storage
+func volumeBaseName(dir os.FileInfo) (base string, ok bool) {
+	if dir.IsDir() {
+		return "", false
+	}
+	name := dir.Name()
+	for _, ext := range []string{".dat", ".tier"} {
+		if strings.HasSuffix(name, ext) {
+			return name[:len(name)-len(ext)], true
+		}
+	}
+	return "", false
+}
+
+func parseCollectionVolumeId(base string) (collection string, vid VolumeId, err error) {
+	i := strings.LastIndex(base, "_")
+	if i > 0 {
+		collection, base = base[0:i], base[i+1:]
+	}
+	vid, err = NewVolumeId(base)
+	return collection, vid, err
+}
+
 func (l *DiskLocation) loadExistingVolume(dir os.FileInfo, needleMapKind NeedleMapType, mutex *sync.RWMutex) {
 	name := dir.Name()
-	if !dir.IsDir() && strings.HasSuffix(name, ".dat") {
+	if _, ok := volumeBaseName(dir); ok {
 		vid, collection, err := l.volumeIdFromPath(dir)
 		if err == nil {
 			mutex.RLock()
@@ -104,6 +126,9 @@ func (l *DiskLocation) loadExistingVolumes(needleMapKind NeedleMapType) {
 	l.concurrentLoadingVolumes(needleMapKind, true)
 
 	glog.V(0).Infoln("Store started on dir:", l.Directory, "with", len(l.volumes), "volumes", "max", l.MaxVolumeCount)
+
+	l.loadAllEcShards()
+	glog.V(0).Infoln("Store started on dir:", l.Directory, "with", len(l.ecVolumes), "ec volumes")
 }
 
 func (l *DiskLocation) DeleteCollectionFromDiskLocation(collection string) (e error) {
@@ -202,5 +227,12 @@ func (l *DiskLocation) Close() {
 	for _, v := range l.volumes {
 		v.Close()
 	}
+
+	l.ecVolumesLock.Lock()
+	for _, ecVolume := range l.ecVolumes {
+		ecVolume.Close()
+	}
+	l.ecVolumesLock.Unlock()
+
 	return
 }
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+)
+
+var (
+	ecShardExtRegexp = regexp.MustCompile("^\\.ec[0-9][0-9]$")
+)
+
+func (l *DiskLocation) FindEcVolume(vid VolumeId) (*EcVolume, bool) {
+	l.ecVolumesLock.RLock()
+	defer l.ecVolumesLock.RUnlock()
+
+	ecVolume, ok := l.ecVolumes[vid]
+	return ecVolume, ok
+}
+
+func (l *DiskLocation) FindEcShard(vid VolumeId, shardId erasure_coding.ShardId) (*EcVolumeShard, bool) {
+	l.ecVolumesLock.RLock()
+	defer l.ecVolumesLock.RUnlock()
+
+	ecVolume, ok := l.ecVolumes[vid]
+	if !ok {
+		return nil, false
+	}
+	return ecVolume.FindEcVolumeShard(shardId)
+}
+
+func (l *DiskLocation) LoadEcShard(collection string, vid VolumeId, shardId erasure_coding.ShardId) (err error) {
+
+	ecVolumeShard, err := NewEcVolumeShard(l.Directory, collection, vid, shardId)
+	if err != nil {
+		return fmt.Errorf("failed to create ec shard %d.%d: %v", vid, shardId, err)
+	}
+
+	l.ecVolumesLock.Lock()
+	defer l.ecVolumesLock.Unlock()
+
+	ecVolume, found := l.ecVolumes[vid]
+	if !found {
+		ecVolume, err = NewEcVolume(l.Directory, collection, vid)
+		if err != nil {
+			ecVolumeShard.Close()
+			return fmt.Errorf("failed to create ec volume %d: %v", vid, err)
+		}
+		l.ecVolumes[vid] = ecVolume
+	}
+	if !ecVolume.AddEcVolumeShard(ecVolumeShard) {
+		ecVolumeShard.Close()
+	}
+
+	return nil
+}
+
+func (l *DiskLocation) UnloadEcShard(vid VolumeId, shardId erasure_coding.ShardId) bool {
+
+	l.ecVolumesLock.Lock()
+	defer l.ecVolumesLock.Unlock()
+
+	ecVolume, found := l.ecVolumes[vid]
+	if !found {
+		return false
+	}
+	ecVolumeShard, deleted := ecVolume.DeleteEcVolumeShard(shardId)
+	if !deleted {
+		return false
+	}
+	ecVolumeShard.Close()
+
+	if ecVolume.ShardCount() == 0 {
+		delete(l.ecVolumes, vid)
+		ecVolume.Close()
+	}
+
+	return true
+}
+
+func (l *DiskLocation) loadAllEcShards() {
+
+	fileInfos, err := ioutil.ReadDir(l.Directory)
+	if err != nil {
+		glog.V(0).Infof("load ec shards from %s: %v", l.Directory, err)
+		return
+	}
+
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+		name := fileInfo.Name()
+		ext := path.Ext(name)
+		if !ecShardExtRegexp.MatchString(ext) {
+			continue
+		}
+		collection, vid, err := parseCollectionVolumeId(name[:len(name)-len(ext)])
+		if err != nil {
+			continue
+		}
+		shardId, err := strconv.ParseInt(ext[len(".ec"):], 10, 64)
+		if err != nil || shardId >= erasure_coding.TotalShardsCount {
+			continue
+		}
+		if err = l.LoadEcShard(collection, vid, erasure_coding.ShardId(shardId)); err != nil {
+			glog.V(0).Infof("load ec shard %s/%s: %v", l.Directory, name, err)
+			continue
+		}
+		glog.V(0).Infof("loaded ec shard %s/%s", l.Directory, name)
+	}
+
+}
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+/*
+ * An EcVolume is a sealed volume converted into erasure coded shards.
+ * It is read only, and a volume server only keeps some of its shards locally.
+ */
+type EcVolume struct {
+	VolumeId    VolumeId
+	Collection  string
+	dir         string
+	ecxFile     *os.File
+	ecxFileSize int64
+
+	SuperBlock
+
+	Shards     []*EcVolumeShard
+	shardsLock sync.RWMutex
+
+	ShardLocations            map[erasure_coding.ShardId][]string
+	ShardLocationsRefreshTime time.Time
+	ShardLocationsLock        sync.RWMutex
+}
+
+type EcVolumeShard struct {
+	VolumeId    VolumeId
+	ShardId     erasure_coding.ShardId
+	Collection  string
+	dir         string
+	ecdFile     *os.File
+	ecdFileSize int64
+}
+
+func EcShardFileName(collection string, dir string, vid VolumeId) (fileName string) {
+	if collection == "" {
+		fileName = path.Join(dir, vid.String())
+	} else {
+		fileName = path.Join(dir, collection+"_"+vid.String())
+	}
+	return
+}
+
+func NewEcVolume(dir string, collection string, vid VolumeId) (ev *EcVolume, err error) {
+	ev = &EcVolume{dir: dir, Collection: collection, VolumeId: vid}
+
+	baseFileName := ev.FileName()
+	if ev.ecxFile, err = os.OpenFile(baseFileName+".ecx", os.O_RDONLY, 0644); err != nil {
+		return nil, fmt.Errorf("cannot open ec volume index %s.ecx: %v", baseFileName, err)
+	}
+	if ev.ecxFileSize, err = util.GetFileSize(ev.ecxFile); err != nil {
+		ev.ecxFile.Close()
+		return nil, fmt.Errorf("cannot stat ec volume index %s.ecx: %v", baseFileName, err)
+	}
+	if ev.SuperBlock, err = ReadSuperBlock(ev.ecxFile); err != nil {
+		ev.ecxFile.Close()
+		return nil, err
+	}
+	if ec := ev.erasureCoding(); ec == nil ||
+		ec.Data != erasure_coding.DataShardsCount || ec.Parity != erasure_coding.ParityShardsCount {
+		ev.ecxFile.Close()
+		return nil, fmt.Errorf("ec volume %s.ecx has unsupported erasure coding %+v", baseFileName, ec)
+	}
+
+	ev.ShardLocations = make(map[erasure_coding.ShardId][]string)
+
+	return
+}
+
+func (ev *EcVolume) erasureCoding() *master_pb.SuperBlockExtra_ErasureCoding {
+	if ev.Extra == nil {
+		return nil
+	}
+	return ev.Extra.ErasureCoding
+}
+
+func (ev *EcVolume) String() string {
+	return fmt.Sprintf("ec volume %d, dir:%s, Collection:%s, shards:%v", ev.VolumeId, ev.dir, ev.Collection, ev.ShardBits().ShardIds())
+}
+
+func (ev *EcVolume) FileName() string {
+	return EcShardFileName(ev.Collection, ev.dir, ev.VolumeId)
+}
+
+func (ev *EcVolume) AddEcVolumeShard(ecVolumeShard *EcVolumeShard) bool {
+	ev.shardsLock.Lock()
+	defer ev.shardsLock.Unlock()
+
+	for _, s := range ev.Shards {
+		if s.ShardId == ecVolumeShard.ShardId {
+			return false
+		}
+	}
+	ev.Shards = append(ev.Shards, ecVolumeShard)
+	sort.Slice(ev.Shards, func(i, j int) bool {
+		return ev.Shards[i].ShardId < ev.Shards[j].ShardId
+	})
+	return true
+}
+
+func (ev *EcVolume) DeleteEcVolumeShard(shardId erasure_coding.ShardId) (ecVolumeShard *EcVolumeShard, deleted bool) {
+	ev.shardsLock.Lock()
+	defer ev.shardsLock.Unlock()
+
+	foundPosition := -1
+	for i, s := range ev.Shards {
+		if s.ShardId == shardId {
+			foundPosition = i
+		}
+	}
+	if foundPosition < 0 {
+		return nil, false
+	}
+
+	ecVolumeShard = ev.Shards[foundPosition]
+	ev.Shards = append(ev.Shards[:foundPosition], ev.Shards[foundPosition+1:]...)
+	return ecVolumeShard, true
+}
+
+func (ev *EcVolume) FindEcVolumeShard(shardId erasure_coding.ShardId) (ecVolumeShard *EcVolumeShard, found bool) {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	for _, s := range ev.Shards {
+		if s.ShardId == shardId {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+func (ev *EcVolume) ShardCount() int {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	return len(ev.Shards)
+}
+
+func (ev *EcVolume) ShardBits() (b erasure_coding.ShardBits) {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	for _, s := range ev.Shards {
+		b = b.AddShardId(s.ShardId)
+	}
+	return
+}
+
+// ShardSize is the size of each shard file. All shards of one ec volume have the same size.
+func (ev *EcVolume) ShardSize() int64 {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	if len(ev.Shards) > 0 {
+		return ev.Shards[0].ecdFileSize
+	}
+	return 0
+}
+
+func (ev *EcVolume) ToVolumeEcShardInformationMessage() *master_pb.VolumeEcShardInformationMessage {
+	return &master_pb.VolumeEcShardInformationMessage{
+		Id:          uint32(ev.VolumeId),
+		Collection:  ev.Collection,
+		EcIndexBits: uint32(ev.ShardBits()),
+	}
+}
+
+func (ev *EcVolume) Close() {
+	ev.shardsLock.Lock()
+	defer ev.shardsLock.Unlock()
+
+	for _, s := range ev.Shards {
+		s.Close()
+	}
+	if ev.ecxFile != nil {
+		ev.ecxFile.Close()
+		ev.ecxFile = nil
+	}
+}
+
+// Destroy removes the index and all local shards of this ec volume
+func (ev *EcVolume) Destroy() {
+	ev.Close()
+
+	for _, s := range ev.Shards {
+		s.Destroy()
+	}
+	os.Remove(ev.FileName() + ".ecx")
+}
+
+// FindNeedleFromEcx binary searches the sorted .ecx file
+func (ev *EcVolume) FindNeedleFromEcx(needleId NeedleId) (offset Offset, size uint32, err error) {
+	headerSize := int64(ev.SuperBlock.BlockSize())
+	var l, h int64
+	h = (ev.ecxFileSize - headerSize) / NeedleEntrySize
+
+	buf := make([]byte, NeedleEntrySize)
+	for l < h {
+		m := (l + h) / 2
+		if _, err = ev.ecxFile.ReadAt(buf, headerSize+m*NeedleEntrySize); err != nil {
+			return 0, 0, fmt.Errorf("ecx file %d read at %d: %v", ev.ecxFileSize, headerSize+m*NeedleEntrySize, err)
+		}
+		key, entryOffset, entrySize := IdxFileEntry(buf)
+		if key == needleId {
+			return entryOffset, entrySize, nil
+		}
+		if key < needleId {
+			l = m + 1
+		} else {
+			h = m
+		}
+	}
+
+	return 0, 0, ErrorNotFound
+}
+
+// LocateEcShardNeedle finds the needle in the .ecx file, and maps its position in the original .dat file to shard intervals
+func (ev *EcVolume) LocateEcShardNeedle(n *Needle) (offset Offset, size uint32, intervals []erasure_coding.Interval, err error) {
+	offset, size, err = ev.FindNeedleFromEcx(n.Id)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	shardSize := ev.ShardSize()
+	if shardSize == 0 {
+		return 0, 0, nil, fmt.Errorf("ec volume %d has no local shards", ev.VolumeId)
+	}
+
+	intervals = erasure_coding.LocateData(erasure_coding.ErasureCodingLargeBlockSize, erasure_coding.ErasureCodingSmallBlockSize,
+		shardSize, int64(offset)*NeedlePaddingSize, int(getActualSize(size, ev.Version())))
+
+	return
+}
+
+func NewEcVolumeShard(dir string, collection string, vid VolumeId, shardId erasure_coding.ShardId) (v *EcVolumeShard, e error) {
+	v = &EcVolumeShard{dir: dir, Collection: collection, VolumeId: vid, ShardId: shardId}
+
+	shardFileName := v.FileName() + shardId.Ext()
+	if v.ecdFile, e = os.OpenFile(shardFileName, os.O_RDONLY, 0644); e != nil {
+		return nil, fmt.Errorf("cannot read ec volume shard %s: %v", shardFileName, e)
+	}
+	if v.ecdFileSize, e = util.GetFileSize(v.ecdFile); e != nil {
+		v.ecdFile.Close()
+		return nil, fmt.Errorf("cannot stat ec volume shard %s: %v", shardFileName, e)
+	}
+
+	return
+}
+
+func (shard *EcVolumeShard) String() string {
+	return fmt.Sprintf("ec shard %d.%d, dir:%s, Collection:%s", shard.VolumeId, shard.ShardId, shard.dir, shard.Collection)
+}
+
+func (shard *EcVolumeShard) FileName() string {
+	return EcShardFileName(shard.Collection, shard.dir, shard.VolumeId)
+}
+
+func (shard *EcVolumeShard) Size() int64 {
+	return shard.ecdFileSize
+}
+
+func (shard *EcVolumeShard) ReadAt(buf []byte, offset int64) (int, error) {
+	return shard.ecdFile.ReadAt(buf, offset)
+}
+
+func (shard *EcVolumeShard) Close() {
+	if shard.ecdFile != nil {
+		shard.ecdFile.Close()
+		shard.ecdFile = nil
+	}
+}
+
+func (shard *EcVolumeShard) Destroy() {
+	os.Remove(shard.FileName() + shard.ShardId.Ext())
+}
@@ -0,0 +1,270 @@
+package erasure_coding
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/klauspost/reedsolomon"
+)
+
+const (
+	DataShardsCount             = 10
+	ParityShardsCount           = 4
+	TotalShardsCount            = DataShardsCount + ParityShardsCount
+	ErasureCodingLargeBlockSize = 1024 * 1024 * 1024 // 1GB
+	ErasureCodingSmallBlockSize = 1024 * 1024        // 1MB
+
+	ecBufferSize = 256 * 1024
+)
+
+/*
+ * The .dat file is cut into rows of DataShardsCount blocks.
+ * Block i of each row goes to shard i, and the parity shards hold the
+ * Reed-Solomon parity of the row.
+ *
+ * Large blocks are used while the remaining data is more than one large row,
+ * small blocks for the rest, so that the tail of a volume does not waste space.
+ * The offsets inside each shard therefore are:
+ *   [large row 0][large row 1]...[large row n-1][small row 0][small row 1]...
+ */
+
+// WriteEcFiles generates .ec00 ~ .ec13 files from the .dat file
+func WriteEcFiles(baseFileName string) error {
+	return generateEcFiles(baseFileName, ecBufferSize, ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize)
+}
+
+// RebuildEcFiles generates the missing .ecNN files from the existing ones,
+// and returns the generated shard ids
+func RebuildEcFiles(baseFileName string) (generatedShardIds []uint32, err error) {
+	return generateMissingEcFiles(baseFileName, ecBufferSize)
+}
+
+func generateEcFiles(baseFileName string, bufferSize int, largeBlockSize int64, smallBlockSize int64) error {
+	file, err := os.OpenFile(baseFileName+".dat", os.O_RDONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open dat file: %v", err)
+	}
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat dat file: %v", err)
+	}
+	err = encodeDatFile(fi.Size(), baseFileName, bufferSize, largeBlockSize, file, smallBlockSize)
+	if err != nil {
+		return fmt.Errorf("encodeDatFile: %v", err)
+	}
+	return nil
+}
+
+func encodeDatFile(remainingSize int64, baseFileName string, bufferSize int, largeBlockSize int64, file *os.File, smallBlockSize int64) error {
+
+	var processedSize int64
+
+	enc, err := reedsolomon.New(DataShardsCount, ParityShardsCount)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %v", err)
+	}
+
+	buffers := make([][]byte, TotalShardsCount)
+	for i := range buffers {
+		buffers[i] = make([]byte, bufferSize)
+	}
+
+	outputs, err := openEcFiles(baseFileName, false)
+	defer closeEcFiles(outputs)
+	if err != nil {
+		return fmt.Errorf("failed to open ec files %s: %v", baseFileName, err)
+	}
+
+	for remainingSize > largeBlockSize*DataShardsCount {
+		err = encodeData(file, enc, processedSize, largeBlockSize, buffers, outputs)
+		if err != nil {
+			return fmt.Errorf("failed to encode large chunk data: %v", err)
+		}
+		remainingSize -= largeBlockSize * DataShardsCount
+		processedSize += largeBlockSize * DataShardsCount
+	}
+	for remainingSize > 0 {
+		err = encodeData(file, enc, processedSize, smallBlockSize, buffers, outputs)
+		if err != nil {
+			return fmt.Errorf("failed to encode small chunk data: %v", err)
+		}
+		remainingSize -= smallBlockSize * DataShardsCount
+		processedSize += smallBlockSize * DataShardsCount
+	}
+	return nil
+}
+
+func encodeData(file *os.File, enc reedsolomon.Encoder, startOffset, blockSize int64, buffers [][]byte, outputs []*os.File) error {
+
+	bufferSize := int64(len(buffers[0]))
+	if blockSize%bufferSize != 0 {
+		return fmt.Errorf("block size %d is not a multiple of buffer size %d", blockSize, bufferSize)
+	}
+	batchCount := blockSize / bufferSize
+
+	for b := int64(0); b < batchCount; b++ {
+		err := encodeDataOneBatch(file, enc, startOffset+b*bufferSize, blockSize, buffers, outputs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodeDataOneBatch(file *os.File, enc reedsolomon.Encoder, startOffset, blockSize int64, buffers [][]byte, outputs []*os.File) error {
+
+	// read data into buffers, padding zeros past the end of the file
+	for i := 0; i < DataShardsCount; i++ {
+		n, err := file.ReadAt(buffers[i], startOffset+blockSize*int64(i))
+		if err != nil && err != io.EOF {
+			return err
+		}
+		for t := n; t < len(buffers[i]); t++ {
+			buffers[i][t] = 0
+		}
+	}
+
+	err := enc.Encode(buffers)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < TotalShardsCount; i++ {
+		_, err := outputs[i].Write(buffers[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateMissingEcFiles(baseFileName string, bufferSize int) (generatedShardIds []uint32, err error) {
+
+	shardHasData := make([]bool, TotalShardsCount)
+	inputFiles := make([]*os.File, TotalShardsCount)
+	outputFiles := make([]*os.File, TotalShardsCount)
+	for shardId := 0; shardId < TotalShardsCount; shardId++ {
+		shardFileName := baseFileName + ToExt(shardId)
+		if _, statErr := os.Stat(shardFileName); statErr == nil {
+			shardHasData[shardId] = true
+			inputFiles[shardId], err = os.OpenFile(shardFileName, os.O_RDONLY, 0)
+			if err != nil {
+				return nil, err
+			}
+			defer inputFiles[shardId].Close()
+		} else {
+			outputFiles[shardId], err = os.OpenFile(shardFileName, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return nil, err
+			}
+			defer outputFiles[shardId].Close()
+			generatedShardIds = append(generatedShardIds, uint32(shardId))
+		}
+	}
+
+	err = rebuildEcFiles(shardHasData, inputFiles, outputFiles, bufferSize)
+	if err != nil {
+		return nil, fmt.Errorf("rebuildEcFiles: %v", err)
+	}
+	return
+}
+
+func rebuildEcFiles(shardHasData []bool, inputFiles []*os.File, outputFiles []*os.File, bufferSize int) error {
+
+	existingShardCount := 0
+	for _, hasData := range shardHasData {
+		if hasData {
+			existingShardCount++
+		}
+	}
+	if existingShardCount < DataShardsCount {
+		return fmt.Errorf("only %d shards exist, need at least %d", existingShardCount, DataShardsCount)
+	}
+
+	enc, err := reedsolomon.New(DataShardsCount, ParityShardsCount)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %v", err)
+	}
+
+	inputBuffers := make([][]byte, TotalShardsCount)
+	for i := range inputBuffers {
+		inputBuffers[i] = make([]byte, bufferSize)
+	}
+	shards := make([][]byte, TotalShardsCount)
+
+	var startOffset int64
+	for {
+
+		// read the input data from files
+		inputBufferDataSize := -1
+		for i := 0; i < TotalShardsCount; i++ {
+			if !shardHasData[i] {
+				shards[i] = inputBuffers[i][:0]
+				continue
+			}
+			n, err := inputFiles[i].ReadAt(inputBuffers[i], startOffset)
+			if err != nil && err != io.EOF {
+				return err
+			}
+			if inputBufferDataSize == -1 {
+				inputBufferDataSize = n
+			}
+			if inputBufferDataSize != n {
+				return fmt.Errorf("ec shard size expected %d actual %d", inputBufferDataSize, n)
+			}
+			shards[i] = inputBuffers[i][:n]
+		}
+		if inputBufferDataSize == 0 {
+			return nil
+		}
+
+		// reconstruct the missing shards
+		if err = enc.Reconstruct(shards); err != nil {
+			return fmt.Errorf("reconstruct: %v", err)
+		}
+
+		// write the data to output files
+		for i := 0; i < TotalShardsCount; i++ {
+			if shardHasData[i] {
+				continue
+			}
+			if _, err := outputFiles[i].WriteAt(shards[i], startOffset); err != nil {
+				return err
+			}
+		}
+		glog.V(4).Infof("rebuilt ec shards at offset %d size %d", startOffset, inputBufferDataSize)
+
+		startOffset += int64(inputBufferDataSize)
+	}
+
+}
+
+func openEcFiles(baseFileName string, forRead bool) (files []*os.File, err error) {
+	for i := 0; i < TotalShardsCount; i++ {
+		fname := baseFileName + ToExt(i)
+		openOption := os.O_TRUNC | os.O_CREATE | os.O_WRONLY
+		if forRead {
+			openOption = os.O_RDONLY
+		}
+		f, err := os.OpenFile(fname, openOption, 0644)
+		if err != nil {
+			return files, fmt.Errorf("failed to open file %s: %v", fname, err)
+		}
+		files = append(files, f)
+	}
+	return
+}
+
+func closeEcFiles(files []*os.File) {
+	for _, f := range files {
+		if f != nil {
+			f.Close()
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package erasure_coding
+
+// Interval is a continuous range of the .dat file that falls into one block
+type Interval struct {
+	BlockIndex          int
+	InnerBlockOffset    int64
+	Size                int
+	IsLargeBlock        bool
+	LargeBlockRowsCount int
+}
+
+// LocateData splits the range [offset, offset+size) of the original .dat file
+// into intervals, each of which lives in exactly one shard
+func LocateData(largeBlockLength, smallBlockLength int64, shardDatSize int64, offset int64, size int) (intervals []Interval) {
+	nLargeBlockRows := largeBlockRowsCount(largeBlockLength, shardDatSize)
+	blockIndex, isLargeBlock, innerBlockOffset := locateOffset(largeBlockLength, smallBlockLength, nLargeBlockRows, offset)
+
+	for size > 0 {
+		interval := Interval{
+			BlockIndex:          blockIndex,
+			InnerBlockOffset:    innerBlockOffset,
+			IsLargeBlock:        isLargeBlock,
+			LargeBlockRowsCount: int(nLargeBlockRows),
+		}
+
+		blockRemaining := largeBlockLength - innerBlockOffset
+		if !isLargeBlock {
+			blockRemaining = smallBlockLength - innerBlockOffset
+		}
+
+		if int64(size) <= blockRemaining {
+			interval.Size = size
+			intervals = append(intervals, interval)
+			return
+		}
+		interval.Size = int(blockRemaining)
+		intervals = append(intervals, interval)
+
+		size -= interval.Size
+		blockIndex += 1
+		if isLargeBlock && int64(blockIndex) == nLargeBlockRows*DataShardsCount {
+			isLargeBlock = false
+			blockIndex = 0
+		}
+		innerBlockOffset = 0
+	}
+	return
+}
+
+// largeBlockRowsCount derives the number of large rows from the size of any shard.
+// The encoder only uses large rows while strictly more than one large row remains,
+// so the small rows take at least one byte and at most largeBlockLength per shard.
+func largeBlockRowsCount(largeBlockLength int64, shardDatSize int64) int64 {
+	if shardDatSize <= 0 {
+		return 0
+	}
+	return (shardDatSize - 1) / largeBlockLength
+}
+
+func locateOffset(largeBlockLength, smallBlockLength int64, nLargeBlockRows int64, offset int64) (blockIndex int, isLargeBlock bool, innerBlockOffset int64) {
+	largeRowSize := largeBlockLength * DataShardsCount
+
+	if offset < nLargeBlockRows*largeRowSize {
+		isLargeBlock = true
+		blockIndex, innerBlockOffset = locateOffsetWithinBlocks(largeBlockLength, offset)
+		return
+	}
+
+	offset -= nLargeBlockRows * largeRowSize
+	blockIndex, innerBlockOffset = locateOffsetWithinBlocks(smallBlockLength, offset)
+	return
+}
+
+func locateOffsetWithinBlocks(blockLength int64, offset int64) (blockIndex int, innerBlockOffset int64) {
+	blockIndex = int(offset / blockLength)
+	innerBlockOffset = offset % blockLength
+	return
+}
+
+// ToShardIdAndOffset returns the shard holding this interval, and the offset inside the shard file
+func (interval Interval) ToShardIdAndOffset(largeBlockSize, smallBlockSize int64) (ShardId, int64) {
+	ecFileOffset := interval.InnerBlockOffset
+	rowIndex := interval.BlockIndex / DataShardsCount
+	if interval.IsLargeBlock {
+		ecFileOffset += int64(rowIndex) * largeBlockSize
+	} else {
+		ecFileOffset += int64(interval.LargeBlockRowsCount)*largeBlockSize + int64(rowIndex)*smallBlockSize
+	}
+	ecFileIndex := interval.BlockIndex % DataShardsCount
+	return ShardId(ecFileIndex), ecFileOffset
+}
@@ -0,0 +1,54 @@
+package erasure_coding
+
+import (
+	"fmt"
+)
+
+type ShardId uint8
+
+func ToExt(ecIndex int) string {
+	return fmt.Sprintf(".ec%02d", ecIndex)
+}
+
+func (shardId ShardId) Ext() string {
+	return ToExt(int(shardId))
+}
+
+// ShardBits is a bit set of shard ids, used to report the shards of one volume on one server
+type ShardBits uint32
+
+func (b ShardBits) AddShardId(id ShardId) ShardBits {
+	return b | (1 << id)
+}
+
+func (b ShardBits) RemoveShardId(id ShardId) ShardBits {
+	return b &^ (1 << id)
+}
+
+func (b ShardBits) HasShardId(id ShardId) bool {
+	return b&(1<<id) > 0
+}
+
+func (b ShardBits) ShardIds() (ret []ShardId) {
+	for i := ShardId(0); i < TotalShardsCount; i++ {
+		if b.HasShardId(i) {
+			ret = append(ret, i)
+		}
+	}
+	return
+}
+
+func (b ShardBits) ShardIdCount() (count int) {
+	for count = 0; b > 0; count++ {
+		b &= b - 1
+	}
+	return
+}
+
+func (b ShardBits) Plus(other ShardBits) ShardBits {
+	return b | other
+}
+
+func (b ShardBits) Minus(other ShardBits) ShardBits {
+	return b &^ other
+}
@@ -0,0 +1,94 @@
+package erasure_coding
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+)
+
+const (
+	largeBlockSize = 10000
+	smallBlockSize = 100
+	bufferSize     = 50
+)
+
+func TestEncodingDecoding(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ec")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	baseFileName := path.Join(dir, "1")
+	datData := make([]byte, 123457)
+	rand.Read(datData)
+	if err = ioutil.WriteFile(baseFileName+".dat", datData, 0644); err != nil {
+		t.Fatalf("write dat file: %v", err)
+	}
+
+	if err = generateEcFiles(baseFileName, bufferSize, largeBlockSize, smallBlockSize); err != nil {
+		t.Fatalf("generateEcFiles: %v", err)
+	}
+
+	shards := make([][]byte, TotalShardsCount)
+	for i := range shards {
+		if shards[i], err = ioutil.ReadFile(baseFileName + ToExt(i)); err != nil {
+			t.Fatalf("read shard %d: %v", i, err)
+		}
+	}
+	shardDatSize := int64(len(shards[0]))
+
+	for i := 0; i < 1000; i++ {
+		offset := rand.Int63n(int64(len(datData)))
+		size := rand.Intn(len(datData) - int(offset))
+		got := readFromShards(shards, shardDatSize, offset, size)
+		if !bytes.Equal(got, datData[offset:offset+int64(size)]) {
+			t.Fatalf("read offset %d size %d: data mismatch", offset, size)
+		}
+	}
+
+	for _, shardId := range []int{0, 3, 11, 13} {
+		os.Remove(baseFileName + ToExt(shardId))
+	}
+	generatedShardIds, err := generateMissingEcFiles(baseFileName, bufferSize)
+	if err != nil {
+		t.Fatalf("generateMissingEcFiles: %v", err)
+	}
+	if len(generatedShardIds) != 4 {
+		t.Fatalf("generated shards %v, expected 4", generatedShardIds)
+	}
+	for _, shardId := range generatedShardIds {
+		rebuilt, err := ioutil.ReadFile(baseFileName + ToExt(int(shardId)))
+		if err != nil {
+			t.Fatalf("read rebuilt shard %d: %v", shardId, err)
+		}
+		if !bytes.Equal(rebuilt, shards[shardId]) {
+			t.Fatalf("rebuilt shard %d does not match the original", shardId)
+		}
+	}
+}
+
+func readFromShards(shards [][]byte, shardDatSize int64, offset int64, size int) (data []byte) {
+	intervals := LocateData(largeBlockSize, smallBlockSize, shardDatSize, offset, size)
+	for _, interval := range intervals {
+		shardId, shardOffset := interval.ToShardIdAndOffset(largeBlockSize, smallBlockSize)
+		data = append(data, shards[shardId][shardOffset:shardOffset+int64(interval.Size)]...)
+	}
+	return
+}
+
+func TestShardBits(t *testing.T) {
+	var b ShardBits
+	b = b.AddShardId(1).AddShardId(13).AddShardId(5)
+	if b.ShardIdCount() != 3 || !b.HasShardId(13) || b.HasShardId(0) {
+		t.Fatalf("unexpected shard bits %b", b)
+	}
+	b = b.RemoveShardId(13)
+	ids := b.ShardIds()
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 5 {
+		t.Fatalf("unexpected shard ids %v", ids)
+	}
+}
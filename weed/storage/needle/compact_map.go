@@ -119,8 +119,11 @@ func (cs *CompactSection) binarySearchValues(key SectionalNeedleId) int {
 
 //This map assumes mostly inserting increasing keys
 //This map assumes mostly inserting increasing keys
+// CompactMap is safe for concurrent reads with one writer.
+// listLock guards the sorted list of sections, and each section guards its own entries.
 type CompactMap struct {
-	list []*CompactSection
+	listLock sync.RWMutex
+	list     []*CompactSection
 }
 
 func NewCompactMap() *CompactMap {
@@ -128,6 +131,7 @@ func NewCompactMap() *CompactMap {
 }
 
 func (cm *CompactMap) Set(key NeedleId, offset Offset, size uint32) (oldOffset Offset, oldSize uint32) {
+	cm.listLock.Lock()
 	x := cm.binarySearchCompactSection(key)
 	if x < 0 || (key-cm.list[x].start) > SectionalNeedleIdLimit {
 		// println(x, "adding to existing", len(cm.list), "sections, starting", key)
@@ -148,21 +152,31 @@ func (cm *CompactMap) Set(key NeedleId, offset Offset, size uint32) (oldOffset O
 		}
 	}
 	// println(key, "set to section[", x, "].start", cm.list[x].start)
-	return cm.list[x].Set(key, offset, size)
+	cs := cm.list[x]
+	cm.listLock.Unlock()
+	return cs.Set(key, offset, size)
 }
 func (cm *CompactMap) Delete(key NeedleId) uint32 {
+	cm.listLock.RLock()
 	x := cm.binarySearchCompactSection(key)
 	if x < 0 {
+		cm.listLock.RUnlock()
 		return uint32(0)
 	}
-	return cm.list[x].Delete(key)
+	cs := cm.list[x]
+	cm.listLock.RUnlock()
+	return cs.Delete(key)
 }
 func (cm *CompactMap) Get(key NeedleId) (*NeedleValue, bool) {
+	cm.listLock.RLock()
 	x := cm.binarySearchCompactSection(key)
 	if x < 0 {
+		cm.listLock.RUnlock()
 		return nil, false
 	}
-	return cm.list[x].Get(key)
+	cs := cm.list[x]
+	cm.listLock.RUnlock()
+	return cs.Get(key)
 }
 func (cm *CompactMap) binarySearchCompactSection(key NeedleId) int {
 	l, h := 0, len(cm.list)-1
@@ -192,6 +206,8 @@ func (cm *CompactMap) binarySearchCompactSection(key NeedleId) int {
 
 // Visit visits all entries or stop if any error when visiting
 func (cm *CompactMap) Visit(visit func(NeedleValue) error) error {
+	cm.listLock.RLock()
+	defer cm.listLock.RUnlock()
 	for _, cs := range cm.list {
 		cs.RLock()
 		for _, v := range cs.overflow {
@@ -2,6 +2,7 @@ package needle
 
 import (
 	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"sync"
 	"testing"
 )
 
@@ -38,6 +39,39 @@ func TestIssue52(t *testing.T) {
 	}
 }
 
+func TestConcurrentGet(t *testing.T) {
+	m := NewCompactMap()
+	top := NeedleId(1000 * SectionalNeedleIdLimit)
+	m.Set(top, 1, 1)
+
+	var wg sync.WaitGroup
+	done := make(chan bool)
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, ok := m.Get(top); !ok {
+					t.Error("key missing while adding sections")
+					return
+				}
+			}
+		}()
+	}
+
+	// each key starts a new section before the existing ones, shifting the section list
+	for i := 999; i > 0; i-- {
+		m.Set(NeedleId(uint64(i)*SectionalNeedleIdLimit), 1, 1)
+	}
+	close(done)
+	wg.Wait()
+}
+
 func TestCompactMap(t *testing.T) {
 	m := NewCompactMap()
 	for i := uint32(0); i < 100*batch; i += 2 {
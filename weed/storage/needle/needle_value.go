@@ -2,6 +2,7 @@ package needle
 
 import (
 	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
 	"github.com/google/btree"
 )
 
@@ -15,3 +16,11 @@ func (this NeedleValue) Less(than btree.Item) bool {
 	that := than.(NeedleValue)
 	return this.Key < that.Key
 }
+
+func (nv NeedleValue) ToBytes() []byte {
+	bytes := make([]byte, NeedleIdSize+OffsetSize+SizeSize)
+	NeedleIdToBytes(bytes[0:NeedleIdSize], nv.Key)
+	OffsetToBytes(bytes[NeedleIdSize:NeedleIdSize+OffsetSize], nv.Offset)
+	util.Uint32toBytes(bytes[NeedleIdSize+OffsetSize:NeedleIdSize+OffsetSize+SizeSize], nv.Size)
+	return bytes
+}
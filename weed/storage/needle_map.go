@@ -34,6 +34,7 @@ type NeedleMapper interface {
 	IndexFileSize() uint64
 	IndexFileContent() ([]byte, error)
 	IndexFileName() string
+	Sync() error
 }
 
 type baseNeedleMapper struct {
@@ -76,6 +77,11 @@ func (nm *baseNeedleMapper) appendToIndexFile(key NeedleId, offset Offset, size
 	_, err := nm.indexFile.Write(bytes)
 	return err
 }
+func (nm *baseNeedleMapper) Sync() error {
+	nm.indexFileAccessLock.Lock()
+	defer nm.indexFileAccessLock.Unlock()
+	return nm.indexFile.Sync()
+}
 func (nm *baseNeedleMapper) IndexFileContent() ([]byte, error) {
 	nm.indexFileAccessLock.Lock()
 	defer nm.indexFileAccessLock.Unlock()
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +13,9 @@ import (
 	"math"
 )
 
+// ErrorCRC tells the needle is corrupted on disk, and can be repaired from the other replicas
+var ErrorCRC = errors.New("CRC error! Data On Disk Corrupted")
+
 const (
 	FlagGzip                = 0x01
 	FlagHasName             = 0x02
@@ -42,6 +46,16 @@ func (n *Needle) Append(w *os.File, version Version) (offset uint64, size uint32
 		err = fmt.Errorf("Cannot Read Current Volume Position: %v", e)
 		return
 	}
+	var writeBytes bytes.Buffer
+	if size, actualSize, err = n.prepareWriteBuffer(version, &writeBytes); err != nil {
+		return
+	}
+	_, err = w.Write(writeBytes.Bytes())
+	return
+}
+
+// prepareWriteBuffer serializes the needle as appended to the .dat file
+func (n *Needle) prepareWriteBuffer(version Version, w *bytes.Buffer) (size uint32, actualSize int64, err error) {
 	switch version {
 	case Version1:
 		header := make([]byte, NeedleEntrySize+NeedlePaddingSize)
@@ -158,22 +172,27 @@ func (n *Needle) Append(w *os.File, version Version) (offset uint64, size uint32
 			_, err = w.Write(header[0 : NeedleChecksumSize+TimestampSize+padding])
 		}
 
-		return offset, n.DataSize, getActualSize(n.Size, version), err
+		return n.DataSize, getActualSize(n.Size, version), err
 	}
-	return 0, 0, 0, fmt.Errorf("Unsupported Version! (%d)", version)
+	return 0, 0, fmt.Errorf("Unsupported Version! (%d)", version)
 }
 
-func ReadNeedleBlob(r *os.File, offset int64, size uint32, version Version) (dataSlice []byte, err error) {
+func ReadNeedleBlob(r io.ReaderAt, offset int64, size uint32, version Version) (dataSlice []byte, err error) {
 	dataSlice = make([]byte, int(getActualSize(size, version)))
 	_, err = r.ReadAt(dataSlice, offset)
 	return dataSlice, err
 }
 
-func (n *Needle) ReadData(r *os.File, offset int64, size uint32, version Version) (err error) {
+func (n *Needle) ReadData(r io.ReaderAt, offset int64, size uint32, version Version) (err error) {
 	bytes, err := ReadNeedleBlob(r, offset, size, version)
 	if err != nil {
 		return err
 	}
+	return n.ReadBytes(bytes, offset, size, version)
+}
+
+// ReadBytes parses the needle from its serialized bytes, as returned by ReadNeedleBlob
+func (n *Needle) ReadBytes(bytes []byte, offset int64, size uint32, version Version) (err error) {
 	n.ParseNeedleHeader(bytes)
 	if n.Size != size {
 		return fmt.Errorf("File Entry Not Found. offset %d, Needle id %d expected size %d Memory %d", offset, n.Id, n.Size, size)
@@ -190,7 +209,7 @@ func (n *Needle) ReadData(r *os.File, offset int64, size uint32, version Version
 	checksum := util.BytesToUint32(bytes[NeedleEntrySize+size : NeedleEntrySize+size+NeedleChecksumSize])
 	newChecksum := NewCRC(n.Data)
 	if checksum != newChecksum.Value() {
-		return errors.New("CRC error! Data On Disk Corrupted")
+		return ErrorCRC
 	}
 	n.Checksum = newChecksum
 	if version == Version3 {
@@ -250,7 +269,7 @@ func (n *Needle) readNeedleDataVersion2(bytes []byte) {
 	}
 }
 
-func ReadNeedleHeader(r *os.File, version Version, offset int64) (n *Needle, bodyLength int64, err error) {
+func ReadNeedleHeader(r io.ReaderAt, version Version, offset int64) (n *Needle, bodyLength int64, err error) {
 	n = new(Needle)
 	if version == Version1 || version == Version2 || version == Version3 {
 		bytes := make([]byte, NeedleEntrySize)
@@ -289,7 +308,7 @@ func NeedleBodyLength(needleSize uint32, version Version) int64 {
 
 //n should be a needle already read the header
 //the input stream will read until next file entry
-func (n *Needle) ReadNeedleBody(r *os.File, version Version, offset int64, bodyLength int64) (err error) {
+func (n *Needle) ReadNeedleBody(r io.ReaderAt, version Version, offset int64, bodyLength int64) (err error) {
 	if bodyLength <= 0 {
 		return nil
 	}
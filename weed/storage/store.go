@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/stats"
 	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -15,6 +17,8 @@ const (
  * A VolumeServer contains one Store
  */
 type Store struct {
+	MasterAddress       string
+	grpcDialOption      grpc.DialOption
 	Ip                  string
 	Port                int
 	PublicUrl           string
@@ -25,8 +29,14 @@ type Store struct {
 	VolumeSizeLimit     uint64 //read from the master
 	Client              master_pb.Seaweed_SendHeartbeatClient
 	NeedleMapType       NeedleMapType
+	FsyncMode           FsyncMode
 	NewVolumeIdChan     chan VolumeId
 	DeletedVolumeIdChan chan VolumeId
+	NewEcShardsChan     chan master_pb.VolumeEcShardInformationMessage
+	DeletedEcShardsChan chan master_pb.VolumeEcShardInformationMessage
+
+	// the collections of the volumes with metrics, to remove the metrics of the volumes gone since the last heartbeat
+	volumeMetricCollections map[VolumeId]string
 }
 
 func (s *Store) String() (str string) {
@@ -34,8 +44,8 @@ func (s *Store) String() (str string) {
 	return
 }
 
-func NewStore(port int, ip, publicUrl string, dirnames []string, maxVolumeCounts []int, needleMapKind NeedleMapType) (s *Store) {
-	s = &Store{Port: port, Ip: ip, PublicUrl: publicUrl, NeedleMapType: needleMapKind}
+func NewStore(grpcDialOption grpc.DialOption, port int, ip, publicUrl string, dirnames []string, maxVolumeCounts []int, needleMapKind NeedleMapType, fsyncMode FsyncMode) (s *Store) {
+	s = &Store{grpcDialOption: grpcDialOption, Port: port, Ip: ip, PublicUrl: publicUrl, NeedleMapType: needleMapKind, FsyncMode: fsyncMode}
 	s.Locations = make([]*DiskLocation, 0)
 	for i := 0; i < len(dirnames); i++ {
 		location := NewDiskLocation(dirnames[i], maxVolumeCounts[i])
@@ -44,6 +54,9 @@ func NewStore(port int, ip, publicUrl string, dirnames []string, maxVolumeCounts
 	}
 	s.NewVolumeIdChan = make(chan VolumeId, 3)
 	s.DeletedVolumeIdChan = make(chan VolumeId, 3)
+
+	s.NewEcShardsChan = make(chan master_pb.VolumeEcShardInformationMessage, 3)
+	s.DeletedEcShardsChan = make(chan master_pb.VolumeEcShardInformationMessage, 3)
 	return
 }
 func (s *Store) AddVolume(volumeId VolumeId, collection string, needleMapKind NeedleMapType, replicaPlacement string, ttlString string, preallocate int64) error {
@@ -77,7 +90,7 @@ func (s *Store) findVolume(vid VolumeId) *Volume {
 	}
 	return nil
 }
-func (s *Store) findFreeLocation() (ret *DiskLocation) {
+func (s *Store) FindFreeLocation() (ret *DiskLocation) {
 	max := 0
 	for _, location := range s.Locations {
 		currentFreeCount := location.MaxVolumeCount - location.VolumesLen()
@@ -92,7 +105,7 @@ func (s *Store) addVolume(vid VolumeId, collection string, needleMapKind NeedleM
 	if s.findVolume(vid) != nil {
 		return fmt.Errorf("Volume Id %d already exists!", vid)
 	}
-	if location := s.findFreeLocation(); location != nil {
+	if location := s.FindFreeLocation(); location != nil {
 		glog.V(0).Infof("In dir %s adds volume:%v collection:%s replicaPlacement:%v ttl:%v",
 			location.Directory, vid, collection, replicaPlacement, ttl)
 		if volume, err := NewVolume(location.Directory, collection, vid, needleMapKind, replicaPlacement, ttl, preallocate); err == nil {
@@ -141,9 +154,11 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 	var volumeMessages []*master_pb.VolumeInformationMessage
 	maxVolumeCount := 0
 	var maxFileKey NeedleId
+	volumeMetricCollections := make(map[VolumeId]string)
 	for _, location := range s.Locations {
 		maxVolumeCount = maxVolumeCount + location.MaxVolumeCount
-		location.Lock()
+		var deleteVids []VolumeId
+		location.RLock()
 		for k, v := range location.volumes {
 			if maxFileKey < v.nm.MaxFileKey() {
 				maxFileKey = v.nm.MaxFileKey()
@@ -160,19 +175,38 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 					ReplicaPlacement: uint32(v.ReplicaPlacement.Byte()),
 					Version:          uint32(v.Version()),
 					Ttl:              v.Ttl.ToUint32(),
+
+					CorruptedNeedleCount: uint32(v.CorruptedNeedleCount()),
 				}
 				volumeMessages = append(volumeMessages, volumeMessage)
+				stats.VolumeServerVolumeSizeGauge.WithLabelValues(v.Collection, v.Id.String()).Set(float64(volumeMessage.Size))
+				stats.VolumeServerVolumeGarbageGauge.WithLabelValues(v.Collection, v.Id.String()).Set(v.garbageLevel())
+				volumeMetricCollections[v.Id] = v.Collection
 			} else {
 				if v.expiredLongEnough(MAX_TTL_VOLUME_REMOVAL_DELAY) {
-					location.deleteVolumeById(v.Id)
-					glog.V(0).Infoln("volume", v.Id, "is deleted.")
+					deleteVids = append(deleteVids, v.Id)
 				} else {
 					glog.V(0).Infoln("volume", v.Id, "is expired.")
 				}
 			}
 		}
-		location.Unlock()
+		location.RUnlock()
+		// only write lock the location to delete, so the reads are not blocked during the heartbeat
+		for _, vid := range deleteVids {
+			if err := location.DeleteVolume(vid); err != nil {
+				glog.V(0).Infof("delete expired volume %d: %v", vid, err)
+				continue
+			}
+			glog.V(0).Infoln("volume", vid, "is deleted.")
+		}
 	}
+	for vid, collection := range s.volumeMetricCollections {
+		if current, found := volumeMetricCollections[vid]; !found || current != collection {
+			stats.VolumeServerVolumeSizeGauge.DeleteLabelValues(collection, vid.String())
+			stats.VolumeServerVolumeGarbageGauge.DeleteLabelValues(collection, vid.String())
+		}
+	}
+	s.volumeMetricCollections = volumeMetricCollections
 
 	return &master_pb.Heartbeat{
 		Ip:             s.Ip,
@@ -183,6 +217,7 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 		DataCenter:     s.dataCenter,
 		Rack:           s.rack,
 		Volumes:        volumeMessages,
+		EcShards:       s.collectEcShardMessages(),
 	}
 
 }
@@ -200,7 +235,7 @@ func (s *Store) Write(i VolumeId, n *Needle) (size uint32, err error) {
 		}
 		// TODO: count needle size ahead
 		if MaxPossibleVolumeSize >= v.ContentSize()+uint64(size) {
-			_, size, err = v.writeNeedle(n)
+			_, size, err = v.writeNeedle(n, s.FsyncMode)
 		} else {
 			err = fmt.Errorf("Volume Size Limit %d Exceeded! Current size is %d", s.VolumeSizeLimit, v.ContentSize())
 		}
@@ -213,7 +248,7 @@ func (s *Store) Write(i VolumeId, n *Needle) (size uint32, err error) {
 
 func (s *Store) Delete(i VolumeId, n *Needle) (uint32, error) {
 	if v := s.findVolume(i); v != nil && !v.readOnly {
-		return v.deleteNeedle(n)
+		return v.deleteNeedle(n, s.FsyncMode)
 	}
 	return 0, nil
 }
@@ -228,6 +263,18 @@ func (s *Store) GetVolume(i VolumeId) *Volume {
 	return s.findVolume(i)
 }
 
+// VolumeIds lists all the volumes, including the read-only ones
+func (s *Store) VolumeIds() (vids []VolumeId) {
+	for _, location := range s.Locations {
+		location.RLock()
+		for vid := range location.volumes {
+			vids = append(vids, vid)
+		}
+		location.RUnlock()
+	}
+	return
+}
+
 func (s *Store) HasVolume(i VolumeId) bool {
 	v := s.findVolume(i)
 	return v != nil
@@ -255,6 +302,23 @@ func (s *Store) UnmountVolume(i VolumeId) error {
 	return fmt.Errorf("Volume %d not found on disk", i)
 }
 
+func (s *Store) MarkVolumeReadonly(i VolumeId) error {
+	v := s.findVolume(i)
+	if v == nil {
+		return fmt.Errorf("volume %d not found", i)
+	}
+	v.MarkReadOnly()
+	return nil
+}
+
+func (s *Store) MarkVolumeWritable(i VolumeId) error {
+	v := s.findVolume(i)
+	if v == nil {
+		return fmt.Errorf("volume %d not found", i)
+	}
+	return v.MarkWritable()
+}
+
 func (s *Store) DeleteVolume(i VolumeId) error {
 	for _, location := range s.Locations {
 		if error := location.deleteVolumeById(i); error == nil {
@@ -0,0 +1,351 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/klauspost/reedsolomon"
+)
+
+func (s *Store) collectEcShardMessages() (ecShardMessages []*master_pb.VolumeEcShardInformationMessage) {
+	for _, location := range s.Locations {
+		location.ecVolumesLock.RLock()
+		for _, ecVolume := range location.ecVolumes {
+			ecShardMessages = append(ecShardMessages, ecVolume.ToVolumeEcShardInformationMessage())
+		}
+		location.ecVolumesLock.RUnlock()
+	}
+	return
+}
+
+func (s *Store) MountEcShards(collection string, vid VolumeId, shardId erasure_coding.ShardId) error {
+	for _, location := range s.Locations {
+		if _, err := os.Stat(EcShardFileName(collection, location.Directory, vid) + shardId.Ext()); err != nil {
+			continue
+		}
+		if err := location.LoadEcShard(collection, vid, shardId); err != nil {
+			return err
+		}
+		glog.V(0).Infof("MountEcShards %d.%d", vid, shardId)
+
+		var shardBits erasure_coding.ShardBits
+		s.NewEcShardsChan <- master_pb.VolumeEcShardInformationMessage{
+			Id:          uint32(vid),
+			Collection:  collection,
+			EcIndexBits: uint32(shardBits.AddShardId(shardId)),
+		}
+		return nil
+	}
+
+	return fmt.Errorf("MountEcShards %d.%d not found on disk", vid, shardId)
+}
+
+func (s *Store) UnmountEcShards(vid VolumeId, shardId erasure_coding.ShardId) error {
+
+	ecShard, found := s.findEcShard(vid, shardId)
+	if !found {
+		return nil
+	}
+
+	var shardBits erasure_coding.ShardBits
+	message := master_pb.VolumeEcShardInformationMessage{
+		Id:          uint32(vid),
+		Collection:  ecShard.Collection,
+		EcIndexBits: uint32(shardBits.AddShardId(shardId)),
+	}
+
+	for _, location := range s.Locations {
+		if location.UnloadEcShard(vid, shardId) {
+			glog.V(0).Infof("UnmountEcShards %d.%d", vid, shardId)
+			s.DeletedEcShardsChan <- message
+			return nil
+		}
+	}
+
+	return fmt.Errorf("UnmountEcShards %d.%d not found on disk", vid, shardId)
+}
+
+func (s *Store) findEcShard(vid VolumeId, shardId erasure_coding.ShardId) (*EcVolumeShard, bool) {
+	for _, location := range s.Locations {
+		if v, found := location.FindEcShard(vid, shardId); found {
+			return v, found
+		}
+	}
+	return nil, false
+}
+
+func (s *Store) FindEcVolume(vid VolumeId) (*EcVolume, bool) {
+	for _, location := range s.Locations {
+		if ecVolume, found := location.FindEcVolume(vid); found {
+			return ecVolume, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Store) HasEcVolume(vid VolumeId) bool {
+	_, found := s.FindEcVolume(vid)
+	return found
+}
+
+// ReadEcShardNeedle reads the needle from the local shards, the remote shards,
+// or reconstructs it from the other shards if some shards are not available.
+func (s *Store) ReadEcShardNeedle(ctx context.Context, vid VolumeId, n *Needle) (int, error) {
+	for _, location := range s.Locations {
+		if localEcVolume, found := location.FindEcVolume(vid); found {
+
+			offset, size, intervals, err := localEcVolume.LocateEcShardNeedle(n)
+			if err != nil {
+				return 0, err
+			}
+
+			glog.V(4).Infof("read ec volume %d offset %d size %d intervals:%+v", vid, int64(offset)*NeedlePaddingSize, size, intervals)
+
+			bytes, err := s.readEcShardIntervals(ctx, localEcVolume, intervals)
+			if err != nil {
+				return 0, fmt.Errorf("ReadEcShardIntervals: %v", err)
+			}
+
+			err = n.ReadBytes(bytes, int64(offset)*NeedlePaddingSize, size, localEcVolume.Version())
+			if err != nil {
+				return 0, fmt.Errorf("readbytes: %v", err)
+			}
+
+			return len(n.Data), nil
+		}
+	}
+	return 0, fmt.Errorf("ec shard %d not found", vid)
+}
+
+func (s *Store) readEcShardIntervals(ctx context.Context, ecVolume *EcVolume, intervals []erasure_coding.Interval) (data []byte, err error) {
+	for i, interval := range intervals {
+		d, e := s.readOneEcShardInterval(ctx, ecVolume, interval)
+		if e != nil {
+			return nil, e
+		}
+		if i == 0 {
+			data = d
+		} else {
+			data = append(data, d...)
+		}
+	}
+	return
+}
+
+func (s *Store) readOneEcShardInterval(ctx context.Context, ecVolume *EcVolume, interval erasure_coding.Interval) (data []byte, err error) {
+	shardId, actualOffset := interval.ToShardIdAndOffset(erasure_coding.ErasureCodingLargeBlockSize, erasure_coding.ErasureCodingSmallBlockSize)
+	data = make([]byte, interval.Size)
+	if shard, found := ecVolume.FindEcVolumeShard(shardId); found {
+		if err = readFullAt(shard, data, actualOffset); err != nil {
+			glog.V(0).Infof("read local ec shard %d.%d: %v", ecVolume.VolumeId, shardId, err)
+		}
+		return
+	}
+
+	if err = s.cachedLookupEcShardLocations(ctx, ecVolume); err != nil {
+		return nil, fmt.Errorf("failed to locate shard via master %s: %v", s.MasterAddress, err)
+	}
+
+	ecVolume.ShardLocationsLock.RLock()
+	sourceDataNodes, hasShardIdLocation := ecVolume.ShardLocations[shardId]
+	ecVolume.ShardLocationsLock.RUnlock()
+
+	// try reading directly
+	if hasShardIdLocation {
+		if err = s.readRemoteEcShardInterval(ctx, sourceDataNodes, ecVolume.VolumeId, shardId, data, actualOffset); err == nil {
+			return
+		}
+		glog.V(0).Infof("clearing ec shard %d.%d locations: %v", ecVolume.VolumeId, shardId, err)
+		forgetShardId(ecVolume, shardId)
+	}
+
+	// try reading by recovering from other shards
+	if err = s.recoverOneRemoteEcShardInterval(ctx, ecVolume, shardId, data, actualOffset); err != nil {
+		glog.V(0).Infof("recover ec shard %d.%d: %v", ecVolume.VolumeId, shardId, err)
+	}
+	return
+}
+
+func forgetShardId(ecVolume *EcVolume, shardId erasure_coding.ShardId) {
+	// failed to access the source data nodes, clear it up
+	ecVolume.ShardLocationsLock.Lock()
+	delete(ecVolume.ShardLocations, shardId)
+	ecVolume.ShardLocationsLock.Unlock()
+}
+
+func (s *Store) cachedLookupEcShardLocations(ctx context.Context, ecVolume *EcVolume) (err error) {
+
+	ecVolume.ShardLocationsLock.RLock()
+	shardCount := len(ecVolume.ShardLocations)
+	refreshTime := ecVolume.ShardLocationsRefreshTime
+	ecVolume.ShardLocationsLock.RUnlock()
+
+	if shardCount < erasure_coding.DataShardsCount && refreshTime.Add(11*time.Second).After(time.Now()) ||
+		shardCount == erasure_coding.TotalShardsCount && refreshTime.Add(37*time.Minute).After(time.Now()) ||
+		shardCount >= erasure_coding.DataShardsCount && refreshTime.Add(7*time.Minute).After(time.Now()) {
+		// still fresh
+		return nil
+	}
+
+	glog.V(3).Infof("lookup and cache ec volume %d locations", ecVolume.VolumeId)
+
+	err = operation.WithMasterServerClient(s.MasterAddress, s.grpcDialOption, func(masterClient master_pb.SeaweedClient) error {
+		req := &master_pb.LookupEcVolumeRequest{
+			VolumeId: uint32(ecVolume.VolumeId),
+		}
+		resp, err := masterClient.LookupEcVolume(ctx, req)
+		if err != nil {
+			return fmt.Errorf("lookup ec volume %d: %v", ecVolume.VolumeId, err)
+		}
+
+		ecVolume.ShardLocationsLock.Lock()
+		defer ecVolume.ShardLocationsLock.Unlock()
+
+		ecVolume.ShardLocations = make(map[erasure_coding.ShardId][]string)
+		for _, shardIdLocations := range resp.ShardIdLocations {
+			shardId := erasure_coding.ShardId(shardIdLocations.ShardId)
+			for _, loc := range shardIdLocations.Locations {
+				ecVolume.ShardLocations[shardId] = append(ecVolume.ShardLocations[shardId], loc.Url)
+			}
+		}
+		ecVolume.ShardLocationsRefreshTime = time.Now()
+
+		return nil
+	})
+	return
+}
+
+func (s *Store) readRemoteEcShardInterval(ctx context.Context, sourceDataNodes []string, vid VolumeId, shardId erasure_coding.ShardId, buf []byte, offset int64) (err error) {
+
+	if len(sourceDataNodes) == 0 {
+		return fmt.Errorf("failed to find ec shard %d.%d", vid, shardId)
+	}
+
+	for _, sourceDataNode := range sourceDataNodes {
+		glog.V(4).Infof("read remote ec shard %d.%d from %s", vid, shardId, sourceDataNode)
+		err = s.doReadRemoteEcShardInterval(ctx, sourceDataNode, vid, shardId, buf, offset)
+		if err == nil {
+			return nil
+		}
+		glog.V(1).Infof("read remote ec shard %d.%d from %s: %v", vid, shardId, sourceDataNode, err)
+	}
+
+	return
+}
+
+func (s *Store) doReadRemoteEcShardInterval(ctx context.Context, sourceDataNode string, vid VolumeId, shardId erasure_coding.ShardId, buf []byte, offset int64) error {
+
+	return operation.WithVolumeServerClient(sourceDataNode, s.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+
+		// copy data slice
+		shardReadClient, err := client.VolumeEcShardRead(ctx, &volume_server_pb.VolumeEcShardReadRequest{
+			VolumdId: uint32(vid),
+			ShardId:  uint32(shardId),
+			Offset:   offset,
+			Size:     int64(len(buf)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start reading ec shard %d.%d from %s: %v", vid, shardId, sourceDataNode, err)
+		}
+
+		var bytesRead int
+		for {
+			resp, receiveErr := shardReadClient.Recv()
+			if receiveErr == io.EOF {
+				break
+			}
+			if receiveErr != nil {
+				return fmt.Errorf("receiving ec shard %d.%d from %s: %v", vid, shardId, sourceDataNode, receiveErr)
+			}
+			bytesRead += copy(buf[bytesRead:], resp.Data)
+		}
+		if bytesRead != len(buf) {
+			return fmt.Errorf("read ec shard %d.%d from %s: %d bytes, expected %d", vid, shardId, sourceDataNode, bytesRead, len(buf))
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) recoverOneRemoteEcShardInterval(ctx context.Context, ecVolume *EcVolume, shardIdToRecover erasure_coding.ShardId, buf []byte, offset int64) error {
+	glog.V(4).Infof("recover ec shard %d.%d from other locations", ecVolume.VolumeId, shardIdToRecover)
+
+	enc, err := reedsolomon.New(erasure_coding.DataShardsCount, erasure_coding.ParityShardsCount)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %v", err)
+	}
+
+	ecVolume.ShardLocationsLock.RLock()
+	shardLocations := make(map[erasure_coding.ShardId][]string, len(ecVolume.ShardLocations))
+	for shardId, locations := range ecVolume.ShardLocations {
+		shardLocations[shardId] = locations
+	}
+	ecVolume.ShardLocationsLock.RUnlock()
+
+	bufs := make([][]byte, erasure_coding.TotalShardsCount)
+
+	var wg sync.WaitGroup
+	var bufsLock sync.Mutex
+	for shardId := erasure_coding.ShardId(0); shardId < erasure_coding.TotalShardsCount; shardId++ {
+		if shardId == shardIdToRecover {
+			continue
+		}
+		wg.Add(1)
+		go func(shardId erasure_coding.ShardId, locations []string) {
+			defer wg.Done()
+			data := make([]byte, len(buf))
+			var readErr error
+			if shard, found := ecVolume.FindEcVolumeShard(shardId); found {
+				readErr = readFullAt(shard, data, offset)
+			} else {
+				readErr = s.readRemoteEcShardInterval(ctx, locations, ecVolume.VolumeId, shardId, data, offset)
+			}
+			if readErr != nil {
+				glog.V(3).Infof("recover: read ec shard %d.%d: %v", ecVolume.VolumeId, shardId, readErr)
+				return
+			}
+			bufsLock.Lock()
+			bufs[shardId] = data
+			bufsLock.Unlock()
+		}(shardId, shardLocations[shardId])
+	}
+	wg.Wait()
+
+	if err = enc.ReconstructData(bufs); err != nil {
+		return err
+	}
+	glog.V(4).Infof("recovered ec shard %d.%d from other locations", ecVolume.VolumeId, shardIdToRecover)
+
+	copy(buf, bufs[shardIdToRecover])
+
+	return nil
+}
+
+func readFullAt(shard *EcVolumeShard, buf []byte, offset int64) error {
+	n, err := shard.ReadAt(buf, offset)
+	if err == io.EOF && n == len(buf) {
+		return nil
+	}
+	return err
+}
+
+// FindEcVolumeBaseFileName finds the file name, without extension, of the .ecx file of the ec volume
+func (s *Store) FindEcVolumeBaseFileName(collection string, vid VolumeId) (baseFileName string, found bool) {
+	for _, location := range s.Locations {
+		baseFileName = EcShardFileName(collection, location.Directory, vid)
+		if _, err := os.Stat(baseFileName + ".ecx"); err == nil {
+			return baseFileName, true
+		}
+	}
+	return "", false
+}
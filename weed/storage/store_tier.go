@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"fmt"
+)
+
+func (s *Store) MoveVolumeDatToRemote(vid VolumeId, collection string, endpoint, region, bucket string) error {
+	v := s.findVolume(vid)
+	if v == nil {
+		return fmt.Errorf("volume id %d is not found", vid)
+	}
+	if v.Collection != collection {
+		return fmt.Errorf("volume %d collection %s, expected %s", vid, v.Collection, collection)
+	}
+	return v.MoveDatToRemote(endpoint, region, bucket)
+}
+
+func (s *Store) MoveVolumeDatFromRemote(vid VolumeId, collection string) error {
+	v := s.findVolume(vid)
+	if v == nil {
+		return fmt.Errorf("volume id %d is not found", vid)
+	}
+	if v.Collection != collection {
+		return fmt.Errorf("volume %d collection %s, expected %s", vid, v.Collection, collection)
+	}
+	return v.MoveDatFromRemote()
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
@@ -16,9 +17,10 @@ type Volume struct {
 	Collection    string
 	dataFile      *os.File
 	nm            NeedleMapper
-	compactingWg  sync.WaitGroup
 	needleMapKind NeedleMapType
 	readOnly      bool
+	// readOnly is set by MarkReadOnly, and can be cleared by MarkWritable
+	markedReadOnly bool
 
 	SuperBlock
 
@@ -27,6 +29,24 @@ type Volume struct {
 
 	lastCompactIndexOffset uint64
 	lastCompactRevision    uint16
+
+	// the .dat file moved to the remote storage, with dataFile being nil
+	remoteDataFile *RemoteDataFile
+	tierLock       sync.Mutex
+
+	scrubLock       sync.Mutex
+	scrubResultLock sync.RWMutex
+	lastScrubResult *ScrubResult
+
+	// The needles are read with positional reads, without the dataFileAccessLock,
+	// so the reads neither wait for the writes nor for each other.
+	// dataFileSwapLock is only write locked, while holding the dataFileAccessLock,
+	// to close or swap the .dat file and the needle map under the readers.
+	dataFileSwapLock sync.RWMutex
+
+	// the writes waiting for the group commit, see commitWriteRequest
+	pendingWrites     []*writeRequest
+	pendingWritesLock sync.Mutex
 }
 
 func NewVolume(dirname string, collection string, id VolumeId, needleMapKind NeedleMapType, replicaPlacement *ReplicaPlacement, ttl *TTL, preallocate int64) (v *Volume, e error) {
@@ -41,26 +61,96 @@ func (v *Volume) String() string {
 	return fmt.Sprintf("Id:%v, dir:%s, Collection:%s, dataFile:%v, nm:%v, readOnly:%v", v.Id, v.dir, v.Collection, v.dataFile, v.nm, v.readOnly)
 }
 
-func (v *Volume) FileName() (fileName string) {
-	if v.Collection == "" {
-		fileName = path.Join(v.dir, v.Id.String())
+func VolumeFileName(collection string, dir string, vid VolumeId) (fileName string) {
+	if collection == "" {
+		fileName = path.Join(dir, vid.String())
 	} else {
-		fileName = path.Join(v.dir, v.Collection+"_"+v.Id.String())
+		fileName = path.Join(dir, collection+"_"+vid.String())
 	}
 	return
 }
+func (v *Volume) FileName() (fileName string) {
+	return VolumeFileName(v.Collection, v.dir, v.Id)
+}
 func (v *Volume) DataFile() *os.File {
 	return v.dataFile
 }
 
+// DataReader reads the needles from either the local .dat file or the remote copy.
+// Each read is protected from the .dat file being closed or swapped.
+func (v *Volume) DataReader() io.ReaderAt {
+	return volumeDataReader{v}
+}
+
+// dataReader requires holding either the dataFileSwapLock or the dataFileAccessLock
+func (v *Volume) dataReader() io.ReaderAt {
+	if v.remoteDataFile != nil {
+		return v.remoteDataFile
+	}
+	return v.dataFile
+}
+
+type volumeDataReader struct {
+	v *Volume
+}
+
+func (r volumeDataReader) ReadAt(p []byte, off int64) (n int, err error) {
+	r.v.dataFileSwapLock.RLock()
+	defer r.v.dataFileSwapLock.RUnlock()
+	if r.v.remoteDataFile == nil && r.v.dataFile == nil {
+		return 0, fmt.Errorf("volume %d is closed", r.v.Id)
+	}
+	return r.v.dataReader().ReadAt(p, off)
+}
+
+func (v *Volume) IsRemote() bool {
+	return v.remoteDataFile != nil
+}
+
+// IsReadOnly waits for the writes in progress, so no more writes land once it returns true
+func (v *Volume) IsReadOnly() bool {
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+	return v.readOnly
+}
+
+// MarkReadOnly stops the writes and deletes, e.g. while the volume is copied to another server
+func (v *Volume) MarkReadOnly() {
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+	if !v.readOnly {
+		v.readOnly = true
+		v.markedReadOnly = true
+	}
+}
+
+// MarkWritable reverts MarkReadOnly. The volumes read-only for other reasons, e.g. moved to the remote storage, stay read-only.
+func (v *Volume) MarkWritable() error {
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+	if !v.readOnly {
+		return nil
+	}
+	if !v.markedReadOnly || v.IsRemote() {
+		return fmt.Errorf("volume %d is read-only", v.Id)
+	}
+	v.readOnly = false
+	v.markedReadOnly = false
+	return nil
+}
+
 func (v *Volume) Version() Version {
 	return v.SuperBlock.Version()
 }
 
+// Size does not wait for the writes, so the heartbeats are not blocked by a slow disk
 func (v *Volume) Size() int64 {
-	v.dataFileAccessLock.Lock()
-	defer v.dataFileAccessLock.Unlock()
+	v.dataFileSwapLock.RLock()
+	defer v.dataFileSwapLock.RUnlock()
 
+	if v.remoteDataFile != nil {
+		return v.remoteDataFile.FileSize
+	}
 	if v.dataFile == nil {
 		return 0
 	}
@@ -77,6 +167,8 @@ func (v *Volume) Size() int64 {
 func (v *Volume) Close() {
 	v.dataFileAccessLock.Lock()
 	defer v.dataFileAccessLock.Unlock()
+	v.dataFileSwapLock.Lock()
+	defer v.dataFileSwapLock.Unlock()
 	if v.nm != nil {
 		v.nm.Close()
 		v.nm = nil
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	. "github.com/chrislusf/seaweedfs/weed/storage/types"
@@ -29,13 +30,61 @@ func CheckVolumeDataIntegrity(v *Volume, indexFile *os.File) error {
 	if offset == 0 || size == TombstoneFileSize {
 		return nil
 	}
-	if e = verifyNeedleIntegrity(v.dataFile, v.Version(), int64(offset)*NeedlePaddingSize, key, size); e != nil {
+	if e = verifyNeedleIntegrity(v.dataReader(), v.Version(), int64(offset)*NeedlePaddingSize, key, size); e != nil {
 		return fmt.Errorf("verifyNeedleIntegrity %s failed: %v", indexFile.Name(), e)
 	}
 
 	return nil
 }
 
+// CheckCopiedVolumeFiles checks the .idx file of a copied volume against its .dat file:
+// every index entry must point inside the .dat file, and the last one to the needle it names.
+func CheckCopiedVolumeFiles(baseFileName string) error {
+	datFile, err := os.Open(baseFileName + ".dat")
+	if err != nil {
+		return err
+	}
+	defer datFile.Close()
+	indexFile, err := os.Open(baseFileName + ".idx")
+	if err != nil {
+		return err
+	}
+	defer indexFile.Close()
+
+	superBlock, err := ReadSuperBlock(datFile)
+	if err != nil {
+		return err
+	}
+	datSize, err := util.GetFileSize(datFile)
+	if err != nil {
+		return err
+	}
+	indexSize, err := verifyIndexFileIntegrity(indexFile)
+	if err != nil || indexSize == 0 {
+		return err
+	}
+
+	err = WalkIndexFile(indexFile, func(key NeedleId, offset Offset, size uint32) error {
+		if offset > 0 && size != TombstoneFileSize && int64(offset)*NeedlePaddingSize+getActualSize(size, superBlock.Version()) > datSize {
+			return fmt.Errorf("needle %d at offset %d size %d is beyond %s.dat size %d", key, int64(offset)*NeedlePaddingSize, size, baseFileName, datSize)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lastIdxEntry, err := readIndexEntryAtOffset(indexFile, indexSize-NeedleEntrySize)
+	if err != nil {
+		return err
+	}
+	key, offset, size := IdxFileEntry(lastIdxEntry)
+	if offset == 0 || size == TombstoneFileSize {
+		return nil
+	}
+	return verifyNeedleIntegrity(datFile, superBlock.Version(), int64(offset)*NeedlePaddingSize, key, size)
+}
+
 func verifyIndexFileIntegrity(indexFile *os.File) (indexSize int64, err error) {
 	if indexSize, err = util.GetFileSize(indexFile); err == nil {
 		if indexSize%NeedleEntrySize != 0 {
@@ -55,7 +104,7 @@ func readIndexEntryAtOffset(indexFile *os.File, offset int64) (bytes []byte, err
 	return
 }
 
-func verifyNeedleIntegrity(datFile *os.File, v Version, offset int64, key NeedleId, size uint32) error {
+func verifyNeedleIntegrity(datFile io.ReaderAt, v Version, offset int64, key NeedleId, size uint32) error {
 	n := new(Needle)
 	err := n.ReadData(datFile, offset, size, v)
 	if err != nil {
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestCheckCopiedVolumeFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "check_copied")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	for i := 1; i <= 10; i++ {
+		if _, _, err := v.writeNeedle(newRandomNeedle(uint64(i)), FsyncNone); err != nil {
+			t.Fatalf("write file %d: %v", i, err)
+		}
+	}
+	baseFileName := v.FileName()
+	v.Close()
+
+	if err = CheckCopiedVolumeFiles(baseFileName); err != nil {
+		t.Fatalf("check complete copy: %v", err)
+	}
+
+	// a .dat file copied before the last write
+	stat, err := os.Stat(baseFileName + ".dat")
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if err = os.Truncate(baseFileName+".dat", stat.Size()-1); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	if err = CheckCopiedVolumeFiles(baseFileName); err == nil {
+		t.Fatalf("check truncated copy: expected an error")
+	}
+}
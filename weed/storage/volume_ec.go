@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+)
+
+// GenerateEcFiles writes the sorted .ecx index and the .ec00 ~ .ec13 shards of this volume.
+// The volume itself is left untouched.
+func (v *Volume) GenerateEcFiles() error {
+	if v.IsRemote() {
+		return fmt.Errorf("volume %d .dat file is moved to the remote storage", v.Id)
+	}
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+
+	baseFileName := v.FileName()
+	if err := v.writeSortedEcxFile(baseFileName); err != nil {
+		return fmt.Errorf("write %s.ecx: %v", baseFileName, err)
+	}
+	if err := erasure_coding.WriteEcFiles(baseFileName); err != nil {
+		return fmt.Errorf("generate ec shards for %s: %v", baseFileName, err)
+	}
+	return nil
+}
+
+/*
+ * The .ecx file starts with a copy of the volume super block, whose extra part
+ * records the erasure coding layout. Then follow the index entries of the live
+ * needles, sorted by needle id, so they can be binary searched.
+ */
+func (v *Volume) writeSortedEcxFile(baseFileName string) error {
+	indexFile, err := os.OpenFile(baseFileName+".idx", os.O_RDONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open index file: %v", err)
+	}
+	defer indexFile.Close()
+
+	nm, err := LoadBtreeNeedleMap(indexFile)
+	if err != nil {
+		return fmt.Errorf("cannot load index file: %v", err)
+	}
+
+	ecxFile, err := os.OpenFile(baseFileName+".ecx", os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer ecxFile.Close()
+
+	superBlock := SuperBlock{
+		version:          v.Version(),
+		ReplicaPlacement: v.ReplicaPlacement,
+		Ttl:              v.Ttl,
+		CompactRevision:  v.CompactRevision,
+		Extra: &master_pb.SuperBlockExtra{
+			ErasureCoding: &master_pb.SuperBlockExtra_ErasureCoding{
+				Data:      erasure_coding.DataShardsCount,
+				Parity:    erasure_coding.ParityShardsCount,
+				VolumeIds: []uint32{uint32(v.Id)},
+			},
+		},
+	}
+	if _, err = ecxFile.Write(superBlock.Bytes()); err != nil {
+		return err
+	}
+
+	return nm.m.Visit(func(value needle.NeedleValue) error {
+		_, writeErr := ecxFile.Write(value.ToBytes())
+		return writeErr
+	})
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+// FsyncMode tells whether the writes are flushed to the disk before being acknowledged
+type FsyncMode int
+
+const (
+	// FsyncNone leaves the flushing to the operating system, so a power loss can lose the acknowledged writes
+	FsyncNone FsyncMode = iota
+	// FsyncBatch appends the concurrent writes to a volume together, with one fsync for all of them
+	FsyncBatch
+	// FsyncWrite appends and fsyncs each write by itself
+	FsyncWrite
+)
+
+func (m FsyncMode) String() string {
+	switch m {
+	case FsyncBatch:
+		return "batch"
+	case FsyncWrite:
+		return "write"
+	}
+	return "none"
+}
+
+// the concurrent writes are split into multiple batches beyond this size
+const maxWriteBatchBytes = 4 * 1024 * 1024
+
+// appending one batch to the .dat file, and flushing it, replaced in the tests to count the batches and the fsyncs
+var (
+	writeBatch = func(dataFile *os.File, b []byte) (int, error) {
+		return dataFile.Write(b)
+	}
+	syncDataFile = func(dataFile *os.File) error {
+		return dataFile.Sync()
+	}
+)
+
+type writeRequest struct {
+	n        *Needle
+	isDelete bool
+	fsync    bool
+
+	// the results, set while holding the dataFileAccessLock
+	done   bool
+	offset uint64
+	size   uint32
+	err    error
+}
+
+/*
+ * commitWriteRequest appends the needle, or its tombstone, to the .dat file and the index.
+ *
+ * Except with FsyncWrite, this is a group commit. The requests queue up while the dataFileAccessLock is held,
+ * and whoever gets the lock next appends all the queued requests with one write and at most one fsync.
+ * The other writers then find their requests done, once they get the lock.
+ */
+func (v *Volume) commitWriteRequest(req *writeRequest, fsyncMode FsyncMode) {
+	req.fsync = fsyncMode != FsyncNone
+
+	if fsyncMode == FsyncWrite {
+		v.dataFileAccessLock.Lock()
+		defer v.dataFileAccessLock.Unlock()
+		v.appendWriteRequests([]*writeRequest{req})
+		return
+	}
+
+	v.pendingWritesLock.Lock()
+	v.pendingWrites = append(v.pendingWrites, req)
+	v.pendingWritesLock.Unlock()
+
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+	if req.done {
+		return
+	}
+
+	v.pendingWritesLock.Lock()
+	requests := v.pendingWrites
+	v.pendingWrites = nil
+	v.pendingWritesLock.Unlock()
+
+	for len(requests) > 0 {
+		count := v.appendWriteRequests(requests)
+		requests = requests[count:]
+	}
+}
+
+// appendWriteRequests appends the leading requests in one write, and returns the number of requests done.
+// A batch stops before a needle already in it, so each request sees the needle map changed by the earlier ones.
+// It requires holding the dataFileAccessLock.
+func (v *Volume) appendWriteRequests(requests []*writeRequest) (count int) {
+
+	if v.readOnly || v.dataFile == nil {
+		for _, req := range requests {
+			req.done, req.err = true, fmt.Errorf("%s is read-only", v.FileName())
+		}
+		return len(requests)
+	}
+
+	end, err := v.dataFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		for _, req := range requests {
+			req.done, req.err = true, fmt.Errorf("Cannot Read Current Volume Position: %v", err)
+		}
+		return len(requests)
+	}
+
+	var writeBytes bytes.Buffer
+	var appended []*writeRequest
+	needleIds := make(map[NeedleId]bool)
+	fsync := false
+	for _, req := range requests {
+		if needleIds[req.n.Id] || len(appended) > 0 && writeBytes.Len() >= maxWriteBatchBytes {
+			break
+		}
+		needleIds[req.n.Id] = true
+		count++
+		req.done = true
+		if v.prepareWriteRequest(req, uint64(end)+uint64(writeBytes.Len()), &writeBytes) {
+			appended = append(appended, req)
+			fsync = fsync || req.fsync
+		}
+	}
+	if len(appended) == 0 {
+		return
+	}
+
+	if _, err = writeBatch(v.dataFile, writeBytes.Bytes()); err == nil && fsync {
+		err = syncDataFile(v.dataFile)
+	}
+	if err != nil {
+		if te := v.dataFile.Truncate(end); te != nil {
+			glog.V(0).Infof("Failed to truncate %s back to %d with error: %v", v.dataFile.Name(), end, te)
+		}
+		for _, req := range appended {
+			req.err = err
+		}
+		return
+	}
+
+	for _, req := range appended {
+		v.updateNeedleMap(req)
+	}
+	if fsync {
+		if err = v.nm.Sync(); err != nil {
+			glog.V(0).Infof("Failed to sync index file %s: %v", v.nm.IndexFileName(), err)
+			for _, req := range appended {
+				req.err = err
+			}
+		}
+	}
+
+	return
+}
+
+// prepareWriteRequest serializes the request into writeBytes, and returns false if nothing needs to be appended
+func (v *Volume) prepareWriteRequest(req *writeRequest, offset uint64, writeBytes *bytes.Buffer) bool {
+	n := req.n
+	if req.isDelete {
+		nv, ok := v.nm.Get(n.Id)
+		if !ok || nv.Size == TombstoneFileSize {
+			return false
+		}
+		req.size = nv.Size
+		n.Data = nil
+	} else if v.isFileUnchanged(n) {
+		req.size = n.DataSize
+		glog.V(4).Infof("needle is unchanged!")
+		return false
+	}
+
+	n.AppendAtNs = uint64(time.Now().UnixNano())
+	size, _, err := n.prepareWriteBuffer(v.Version(), writeBytes)
+	if err != nil {
+		req.err = err
+		return false
+	}
+	req.offset = offset
+	if !req.isDelete {
+		req.size = size
+	}
+	return true
+}
+
+func (v *Volume) updateNeedleMap(req *writeRequest) {
+	n := req.n
+	if req.isDelete {
+		req.err = v.nm.Delete(n.Id, Offset(req.offset/NeedlePaddingSize))
+		return
+	}
+	nv, ok := v.nm.Get(n.Id)
+	if !ok || uint64(nv.Offset)*NeedlePaddingSize < req.offset {
+		if req.err = v.nm.Put(n.Id, Offset(req.offset/NeedlePaddingSize), n.Size); req.err != nil {
+			glog.V(4).Infof("failed to save in needle map %d: %v", n.Id, req.err)
+		}
+	}
+	if v.lastModifiedTime < n.LastModified {
+		v.lastModifiedTime = n.LastModified
+	}
+}
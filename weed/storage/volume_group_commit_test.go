@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupCommit(t *testing.T) {
+	for _, fsyncMode := range []FsyncMode{FsyncNone, FsyncBatch, FsyncWrite} {
+		testGroupCommit(t, fsyncMode)
+	}
+}
+
+func testGroupCommit(t *testing.T, fsyncMode FsyncMode) {
+	dir, err := ioutil.TempDir("", "group_commit")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer v.Close()
+
+	writerCount, fileCount := 8, 50
+	written := make([][]byte, writerCount*fileCount)
+	var wg sync.WaitGroup
+	for w := 0; w < writerCount; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < fileCount; i++ {
+				id := w*fileCount + i
+				n := newRandomNeedle(uint64(id + 1))
+				n.Data = append(n.Data, byte(id))
+				n.Checksum = NewCRC(n.Data)
+				written[id] = n.Data
+				if _, _, err := v.writeNeedle(n, fsyncMode); err != nil {
+					t.Errorf("%s: write file %d: %v", fsyncMode, id+1, err)
+					return
+				}
+				// every other writer deletes its files right after writing them
+				if w%2 == 1 {
+					if _, err := v.deleteNeedle(newEmptyNeedle(uint64(id+1)), fsyncMode); err != nil {
+						t.Errorf("%s: delete file %d: %v", fsyncMode, id+1, err)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for id, data := range written {
+		n := newEmptyNeedle(uint64(id + 1))
+		_, err := v.readNeedle(n)
+		if (id/fileCount)%2 == 1 {
+			if err == nil {
+				t.Fatalf("%s: deleted file %d is still readable", fsyncMode, id+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: read file %d: %v", fsyncMode, id+1, err)
+		}
+		if !bytes.Equal(n.Data, data) {
+			t.Fatalf("%s: file %d content changed", fsyncMode, id+1)
+		}
+	}
+
+	if v.nm.FileCount() != writerCount*fileCount || v.nm.DeletedCount() != writerCount*fileCount/2 {
+		t.Fatalf("%s: %d files and %d deletions in the needle map", fsyncMode, v.nm.FileCount(), v.nm.DeletedCount())
+	}
+}
+
+func TestGroupCommitBatches(t *testing.T) {
+	var batchCount, syncCount int64
+	defer func(write func(*os.File, []byte) (int, error), flush func(*os.File) error) {
+		writeBatch, syncDataFile = write, flush
+	}(writeBatch, syncDataFile)
+	writeBatch = func(dataFile *os.File, b []byte) (int, error) {
+		atomic.AddInt64(&batchCount, 1)
+		return dataFile.Write(b)
+	}
+	syncDataFile = func(dataFile *os.File) error {
+		atomic.AddInt64(&syncCount, 1)
+		// a slow disk, so the concurrent writes queue up meanwhile
+		time.Sleep(time.Millisecond)
+		return dataFile.Sync()
+	}
+
+	writerCount, fileCount := 8, 20
+	writeCount := int64(writerCount * fileCount)
+	for _, fsyncMode := range []FsyncMode{FsyncNone, FsyncBatch, FsyncWrite} {
+		atomic.StoreInt64(&batchCount, 0)
+		atomic.StoreInt64(&syncCount, 0)
+		writeConcurrently(t, fsyncMode, writerCount, fileCount)
+		batches, syncs := atomic.LoadInt64(&batchCount), atomic.LoadInt64(&syncCount)
+
+		switch fsyncMode {
+		case FsyncNone:
+			if syncs != 0 || batches == 0 || batches > writeCount {
+				t.Errorf("%s: %d writes in %d batches with %d syncs, expected no syncs", fsyncMode, writeCount, batches, syncs)
+			}
+		case FsyncBatch:
+			if syncs != batches || batches >= writeCount {
+				t.Errorf("%s: %d writes in %d batches with %d syncs, expected fewer batches each with one sync", fsyncMode, writeCount, batches, syncs)
+			}
+		case FsyncWrite:
+			if syncs != writeCount || batches != writeCount {
+				t.Errorf("%s: %d writes in %d batches with %d syncs, expected one batch and one sync for each write", fsyncMode, writeCount, batches, syncs)
+			}
+		}
+	}
+}
+
+func writeConcurrently(t *testing.T, fsyncMode FsyncMode, writerCount, fileCount int) {
+	dir, err := ioutil.TempDir("", "group_commit_batches")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer v.Close()
+
+	var wg sync.WaitGroup
+	for w := 0; w < writerCount; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < fileCount; i++ {
+				id := uint64(w*fileCount + i + 1)
+				if _, _, err := v.writeNeedle(newRandomNeedle(id), fsyncMode); err != nil {
+					t.Errorf("%s: write file %d: %v", fsyncMode, id, err)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
@@ -18,6 +18,8 @@ type VolumeInfo struct {
 	DeleteCount      int
 	DeletedByteCount uint64
 	ReadOnly         bool
+
+	CorruptedNeedleCount int
 }
 
 func NewVolumeInfo(m *master_pb.VolumeInformationMessage) (vi VolumeInfo, err error) {
@@ -30,6 +32,8 @@ func NewVolumeInfo(m *master_pb.VolumeInformationMessage) (vi VolumeInfo, err er
 		DeletedByteCount: m.DeletedByteCount,
 		ReadOnly:         m.ReadOnly,
 		Version:          Version(m.Version),
+
+		CorruptedNeedleCount: int(m.CorruptedNeedleCount),
 	}
 	rp, e := NewReplicaPlacementFromByte(byte(m.ReplicaPlacement))
 	if e != nil {
@@ -41,8 +45,8 @@ func NewVolumeInfo(m *master_pb.VolumeInformationMessage) (vi VolumeInfo, err er
 }
 
 func (vi VolumeInfo) String() string {
-	return fmt.Sprintf("Id:%d, Size:%d, ReplicaPlacement:%s, Collection:%s, Version:%v, FileCount:%d, DeleteCount:%d, DeletedByteCount:%d, ReadOnly:%v",
-		vi.Id, vi.Size, vi.ReplicaPlacement, vi.Collection, vi.Version, vi.FileCount, vi.DeleteCount, vi.DeletedByteCount, vi.ReadOnly)
+	return fmt.Sprintf("Id:%d, Size:%d, ReplicaPlacement:%s, Collection:%s, Version:%v, FileCount:%d, DeleteCount:%d, DeletedByteCount:%d, ReadOnly:%v, CorruptedNeedleCount:%d",
+		vi.Id, vi.Size, vi.ReplicaPlacement, vi.Collection, vi.Version, vi.FileCount, vi.DeleteCount, vi.DeletedByteCount, vi.ReadOnly, vi.CorruptedNeedleCount)
 }
 
 /*VolumesInfo sorting*/
@@ -64,3 +68,20 @@ func (vis volumeInfos) Swap(i, j int) {
 func sortVolumeInfos(vis volumeInfos) {
 	sort.Sort(vis)
 }
+
+func (vi VolumeInfo) ToVolumeInformationMessage() *master_pb.VolumeInformationMessage {
+	return &master_pb.VolumeInformationMessage{
+		Id:               uint32(vi.Id),
+		Size:             uint64(vi.Size),
+		Collection:       vi.Collection,
+		FileCount:        uint64(vi.FileCount),
+		DeleteCount:      uint64(vi.DeleteCount),
+		DeletedByteCount: vi.DeletedByteCount,
+		ReadOnly:         vi.ReadOnly,
+		ReplicaPlacement: uint32(vi.ReplicaPlacement.Byte()),
+		Version:          uint32(vi.Version),
+		Ttl:              vi.Ttl.ToUint32(),
+
+		CorruptedNeedleCount: uint32(vi.CorruptedNeedleCount),
+	}
+}
@@ -17,11 +17,21 @@ func loadVolumeWithoutIndex(dirname string, collection string, id VolumeId, need
 }
 
 func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind NeedleMapType, preallocate int64) error {
-	var e error
 	fileName := v.FileName()
 	alreadyHasSuperBlock := false
 
-	if exists, canRead, canWrite, modifiedTime, fileSize := checkFile(fileName + ".dat"); exists {
+	exists, canRead, canWrite, modifiedTime, fileSize, e := checkFile(fileName + ".dat")
+	if e != nil {
+		return fmt.Errorf("check Volume Data file %s.dat: %v", fileName, e)
+	}
+	tierExists := false
+	if !exists {
+		if tierExists, canRead, _, _, _, e = checkFile(fileName + ".tier"); e != nil {
+			return fmt.Errorf("check Volume Tier file %s.tier: %v", fileName, e)
+		}
+	}
+
+	if exists {
 		if !canRead {
 			return fmt.Errorf("cannot read Volume Data file %s.dat", fileName)
 		}
@@ -36,6 +46,16 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 		if fileSize >= _SuperBlockSize {
 			alreadyHasSuperBlock = true
 		}
+	} else if tierExists {
+		if !canRead {
+			return fmt.Errorf("cannot read Volume Tier file %s.tier", fileName)
+		}
+		if v.remoteDataFile, e = loadRemoteDataFile(fileName + ".tier"); e == nil {
+			glog.V(0).Infoln("opening remote data file of " + fileName + ".tier in READONLY mode")
+			v.lastModifiedTime = v.remoteDataFile.ModifiedTime
+			v.readOnly = true
+			alreadyHasSuperBlock = true
+		}
 	} else {
 		if createDatIfMissing {
 			v.dataFile, e = createVolumeFile(fileName+".dat", preallocate)
@@ -96,16 +116,21 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 				glog.V(0).Infof("loading index %s to btree error: %v", fileName+".idx", e)
 			}
 		}
+		v.loadScrubResult()
 	}
 
 	return e
 }
 
-func checkFile(filename string) (exists, canRead, canWrite bool, modTime time.Time, fileSize int64) {
+func checkFile(filename string) (exists, canRead, canWrite bool, modTime time.Time, fileSize int64, err error) {
 	exists = true
 	fi, err := os.Stat(filename)
 	if os.IsNotExist(err) {
 		exists = false
+		err = nil
+		return
+	}
+	if err != nil {
 		return
 	}
 	if fi.Mode()&0400 != 0 {
@@ -36,10 +36,11 @@ func (v *Volume) isFileUnchanged(n *Needle) bool {
 	return false
 }
 
-// Destroy removes everything related to this volume
+// Destroy removes everything related to this volume.
+// The volumes only marked read-only by the admin operations, e.g. moving or erasure coding, can be destroyed.
 func (v *Volume) Destroy() (err error) {
-	if v.readOnly {
-		err = fmt.Errorf("%s is read-only", v.dataFile.Name())
+	if v.readOnly && !v.markedReadOnly {
+		err = fmt.Errorf("%s is read-only", v.FileName())
 		return
 	}
 	v.Close()
@@ -49,17 +50,23 @@ func (v *Volume) Destroy() (err error) {
 	os.Remove(v.FileName() + ".cpx")
 	os.Remove(v.FileName() + ".ldb")
 	os.Remove(v.FileName() + ".bdb")
+	os.Remove(v.FileName() + ".scrub")
 	return
 }
 
 // AppendBlob append a blob to end of the data file, used in replication
 func (v *Volume) AppendBlob(b []byte) (offset int64, err error) {
 	if v.readOnly {
-		err = fmt.Errorf("%s is read-only", v.dataFile.Name())
+		err = fmt.Errorf("%s is read-only", v.FileName())
 		return
 	}
 	v.dataFileAccessLock.Lock()
 	defer v.dataFileAccessLock.Unlock()
+	return v.appendBlob(b)
+}
+
+// appendBlob requires holding the dataFileAccessLock
+func (v *Volume) appendBlob(b []byte) (offset int64, err error) {
 	if offset, err = v.dataFile.Seek(0, 2); err != nil {
 		glog.V(0).Infof("failed to seek the end of file: %v", err)
 		return
@@ -76,71 +83,39 @@ func (v *Volume) AppendBlob(b []byte) (offset int64, err error) {
 	return
 }
 
-func (v *Volume) writeNeedle(n *Needle) (offset uint64, size uint32, err error) {
+func (v *Volume) writeNeedle(n *Needle, fsyncMode FsyncMode) (offset uint64, size uint32, err error) {
 	glog.V(4).Infof("writing needle %s", NewFileIdFromNeedle(v.Id, n).String())
 	if v.readOnly {
-		err = fmt.Errorf("%s is read-only", v.dataFile.Name())
+		err = fmt.Errorf("%s is read-only", v.FileName())
 		return
 	}
-	v.dataFileAccessLock.Lock()
-	defer v.dataFileAccessLock.Unlock()
-	if v.isFileUnchanged(n) {
-		size = n.DataSize
-		glog.V(4).Infof("needle is unchanged!")
-		return
-	}
-
-	n.AppendAtNs = uint64(time.Now().UnixNano())
-	if offset, size, _, err = n.Append(v.dataFile, v.Version()); err != nil {
-		return
-	}
-
-	nv, ok := v.nm.Get(n.Id)
-	if !ok || uint64(nv.Offset)*NeedlePaddingSize < offset {
-		if err = v.nm.Put(n.Id, Offset(offset/NeedlePaddingSize), n.Size); err != nil {
-			glog.V(4).Infof("failed to save in needle map %d: %v", n.Id, err)
-		}
-	}
-	if v.lastModifiedTime < n.LastModified {
-		v.lastModifiedTime = n.LastModified
-	}
-	return
+	req := &writeRequest{n: n}
+	v.commitWriteRequest(req, fsyncMode)
+	return req.offset, req.size, req.err
 }
 
-func (v *Volume) deleteNeedle(n *Needle) (uint32, error) {
+func (v *Volume) deleteNeedle(n *Needle, fsyncMode FsyncMode) (uint32, error) {
 	glog.V(4).Infof("delete needle %s", NewFileIdFromNeedle(v.Id, n).String())
 	if v.readOnly {
-		return 0, fmt.Errorf("%s is read-only", v.dataFile.Name())
+		return 0, fmt.Errorf("%s is read-only", v.FileName())
 	}
-	v.dataFileAccessLock.Lock()
-	defer v.dataFileAccessLock.Unlock()
-	nv, ok := v.nm.Get(n.Id)
-	//fmt.Println("key", n.Id, "volume offset", nv.Offset, "data_size", n.Size, "cached size", nv.Size)
-	if ok && nv.Size != TombstoneFileSize {
-		size := nv.Size
-		n.Data = nil
-		n.AppendAtNs = uint64(time.Now().UnixNano())
-		offset, _, _, err := n.Append(v.dataFile, v.Version())
-		if err != nil {
-			return size, err
-		}
-		if err = v.nm.Delete(n.Id, Offset(offset/NeedlePaddingSize)); err != nil {
-			return size, err
-		}
-		return size, err
-	}
-	return 0, nil
+	req := &writeRequest{n: n, isDelete: true}
+	v.commitWriteRequest(req, fsyncMode)
+	return req.size, req.err
 }
 
 // read fills in Needle content by looking up n.Id from NeedleMapper
+// The needle map and the .dat file are not swapped by the compaction during the read.
 func (v *Volume) readNeedle(n *Needle) (int, error) {
+	v.dataFileSwapLock.RLock()
+	defer v.dataFileSwapLock.RUnlock()
+
+	if v.nm == nil {
+		return -1, fmt.Errorf("volume %d is closed", v.Id)
+	}
 	nv, ok := v.nm.Get(n.Id)
 	if !ok || nv.Offset == 0 {
-		v.compactingWg.Wait()
-		nv, ok = v.nm.Get(n.Id)
-		if !ok || nv.Offset == 0 {
-			return -1, ErrorNotFound
-		}
+		return -1, ErrorNotFound
 	}
 	if nv.Size == TombstoneFileSize {
 		return -1, errors.New("already deleted")
@@ -148,25 +123,29 @@ func (v *Volume) readNeedle(n *Needle) (int, error) {
 	if nv.Size == 0 {
 		return 0, nil
 	}
-	err := n.ReadData(v.dataFile, int64(nv.Offset)*NeedlePaddingSize, nv.Size, v.Version())
+	err := n.ReadData(v.dataReader(), int64(nv.Offset)*NeedlePaddingSize, nv.Size, v.Version())
 	if err != nil {
 		return 0, err
 	}
-	bytesRead := len(n.Data)
+	if n.IsExpired() {
+		return -1, ErrorNotFound
+	}
+	return len(n.Data), nil
+}
+
+// IsExpired checks the TTL of the needle itself
+func (n *Needle) IsExpired() bool {
 	if !n.HasTtl() {
-		return bytesRead, nil
+		return false
 	}
 	ttlMinutes := n.Ttl.Minutes()
 	if ttlMinutes == 0 {
-		return bytesRead, nil
+		return false
 	}
 	if !n.HasLastModifiedDate() {
-		return bytesRead, nil
-	}
-	if uint64(time.Now().Unix()) < n.LastModified+uint64(ttlMinutes*60) {
-		return bytesRead, nil
+		return false
 	}
-	return -1, ErrorNotFound
+	return uint64(time.Now().Unix()) >= n.LastModified+uint64(ttlMinutes*60)
 }
 
 type VolumeFileScanner interface {
@@ -190,14 +169,14 @@ func ScanVolumeFile(dirname string, collection string, id VolumeId,
 	version := v.Version()
 
 	offset := int64(v.SuperBlock.BlockSize())
-	n, rest, e := ReadNeedleHeader(v.dataFile, version, offset)
+	n, rest, e := ReadNeedleHeader(v.dataReader(), version, offset)
 	if e != nil {
 		err = fmt.Errorf("cannot read needle header: %v", e)
 		return
 	}
 	for n != nil {
 		if volumeFileScanner.ReadNeedleBody() {
-			if err = n.ReadNeedleBody(v.dataFile, version, offset+NeedleEntrySize, rest); err != nil {
+			if err = n.ReadNeedleBody(v.dataReader(), version, offset+NeedleEntrySize, rest); err != nil {
 				glog.V(0).Infof("cannot read needle body: %v", err)
 				//err = fmt.Errorf("cannot read needle body: %v", err)
 				//return
@@ -212,7 +191,7 @@ func ScanVolumeFile(dirname string, collection string, id VolumeId,
 		}
 		offset += NeedleEntrySize + rest
 		glog.V(4).Infof("==> new entry offset %d", offset)
-		if n, rest, err = ReadNeedleHeader(v.dataFile, version, offset); err != nil {
+		if n, rest, err = ReadNeedleHeader(v.dataReader(), version, offset); err != nil {
 			if err == io.EOF {
 				return nil
 			}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+ * BenchmarkReadNeedleWhileWriting reads the needles of one hot volume concurrently,
+ * while another goroutine keeps writing to the volume with fsync.
+ *
+ * "concurrent" is how the needles are read now, without the dataFileAccessLock.
+ * "locked" takes the dataFileAccessLock for each read, as the reads did before, so they wait for the writes and each other.
+ *
+ *   go test -run=XXX -bench=ReadNeedleWhileWriting -cpu=16 ./weed/storage
+ */
+func BenchmarkReadNeedleWhileWriting(b *testing.B) {
+	b.Run("concurrent", func(b *testing.B) {
+		benchmarkReadNeedleWhileWriting(b, false)
+	})
+	b.Run("locked", func(b *testing.B) {
+		benchmarkReadNeedleWhileWriting(b, true)
+	})
+}
+
+func benchmarkReadNeedleWhileWriting(b *testing.B, readLocked bool) {
+	dir, err := ioutil.TempDir("", "read_while_writing")
+	if err != nil {
+		b.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		b.Fatalf("volume creation: %v", err)
+	}
+	defer v.Close()
+
+	fileCount := 1000
+	for i := 1; i <= fileCount; i++ {
+		if _, _, err := v.writeNeedle(newRandomNeedle(uint64(i)), FsyncNone); err != nil {
+			b.Fatalf("write file %d: %v", i, err)
+		}
+	}
+
+	stop := make(chan struct{})
+	var writer sync.WaitGroup
+	writer.Add(1)
+	go func() {
+		defer writer.Done()
+		for i := fileCount + 1; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, _, err := v.writeNeedle(newRandomNeedle(uint64(i)), FsyncWrite); err != nil {
+				b.Errorf("write file %d: %v", i, err)
+				return
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		random := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for pb.Next() {
+			n := newEmptyNeedle(uint64(random.Intn(fileCount) + 1))
+			if readLocked {
+				v.dataFileAccessLock.Lock()
+			}
+			_, err := v.readNeedle(n)
+			if readLocked {
+				v.dataFileAccessLock.Unlock()
+			}
+			if err != nil {
+				b.Errorf("read file %d: %v", n.Id, err)
+				return
+			}
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	writer.Wait()
+}
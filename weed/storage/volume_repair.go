@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"fmt"
+
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+// ReadNeedleBlob reads the live needle serialized as in the .dat file, verified with its CRC,
+// for the other replicas to repair their corrupted copies.
+func (v *Volume) ReadNeedleBlob(key NeedleId) (blob []byte, size uint32, err error) {
+	v.dataFileSwapLock.RLock()
+	defer v.dataFileSwapLock.RUnlock()
+
+	if v.nm == nil {
+		return nil, 0, fmt.Errorf("volume %d is closed", v.Id)
+	}
+	nv, ok := v.nm.Get(key)
+	if !ok || nv.Offset == 0 || nv.Size == TombstoneFileSize {
+		return nil, 0, ErrorNotFound
+	}
+	offset := int64(nv.Offset) * NeedlePaddingSize
+	if blob, err = ReadNeedleBlob(v.dataReader(), offset, nv.Size, v.Version()); err != nil {
+		return nil, 0, err
+	}
+	n := new(Needle)
+	if err = n.ReadBytes(blob, offset, nv.Size, v.Version()); err != nil {
+		return nil, 0, err
+	}
+	if n.Id != key {
+		return nil, 0, fmt.Errorf("index key %#x does not match needle's Id %#x", key, n.Id)
+	}
+	return blob, nv.Size, nil
+}
+
+// ParseNeedleBlob parses and verifies the needle read by ReadNeedleBlob
+func ParseNeedleBlob(key NeedleId, blob []byte, size uint32, version Version) (*Needle, error) {
+	if int64(len(blob)) < getActualSize(size, version) {
+		return nil, fmt.Errorf("needle %d blob size %d, expected %d", key, len(blob), getActualSize(size, version))
+	}
+	n := new(Needle)
+	if err := n.ReadBytes(blob, 0, size, version); err != nil {
+		return nil, fmt.Errorf("needle %d: %v", key, err)
+	}
+	if n.Id != key {
+		return nil, fmt.Errorf("expected needle %d, but found %d", key, n.Id)
+	}
+	return n, nil
+}
+
+// RepairNeedle replaces the corrupted needle with the good copy from another replica, by appending it to the .dat file.
+// Nothing is changed if the needle is already changed, e.g., overwritten, deleted, or repaired.
+func (v *Volume) RepairNeedle(key NeedleId, blob []byte, size uint32, version Version) error {
+	if version != v.Version() {
+		return fmt.Errorf("replica volume version %d, but local version %d", version, v.Version())
+	}
+	if _, err := ParseNeedleBlob(key, blob, size, version); err != nil {
+		return err
+	}
+	if v.readOnly {
+		return fmt.Errorf("%s is read-only", v.FileName())
+	}
+
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+
+	nv, ok := v.nm.Get(key)
+	if !ok || nv.Offset == 0 || nv.Size != size {
+		return fmt.Errorf("needle %d is changed", key)
+	}
+	if verifyNeedleIntegrity(v.dataFile, v.Version(), int64(nv.Offset)*NeedlePaddingSize, key, size) == nil {
+		v.removeCorruptedNeedle(key)
+		return nil
+	}
+
+	offset, err := v.appendBlob(blob[:getActualSize(size, version)])
+	if err != nil {
+		return fmt.Errorf("append needle %d: %v", key, err)
+	}
+	if err = v.nm.Put(key, Offset(offset/NeedlePaddingSize), size); err != nil {
+		return fmt.Errorf("put needle %d in needle map: %v", key, err)
+	}
+	v.removeCorruptedNeedle(key)
+
+	return nil
+}
+
+// removeCorruptedNeedle updates the last scrubbing result after the needle is repaired
+func (v *Volume) removeCorruptedNeedle(key NeedleId) {
+	v.scrubResultLock.Lock()
+	defer v.scrubResultLock.Unlock()
+
+	if v.lastScrubResult == nil {
+		return
+	}
+	result := *v.lastScrubResult
+	result.CorruptedNeedles = nil
+	for _, corrupted := range v.lastScrubResult.CorruptedNeedles {
+		if corrupted != key {
+			result.CorruptedNeedles = append(result.CorruptedNeedles, corrupted)
+		}
+	}
+	v.setScrubResult(&result)
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+func TestRepairNeedle(t *testing.T) {
+	goodDir, err := ioutil.TempDir("", "repair_good")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(goodDir)
+	badDir, err := ioutil.TempDir("", "repair_bad")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(badDir)
+
+	good, err := NewVolume(goodDir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer good.Close()
+	bad, err := NewVolume(badDir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer bad.Close()
+
+	n := newRandomNeedle(1)
+	n.Data = append(n.Data, 1)
+	n.Checksum = NewCRC(n.Data)
+	for _, v := range []*Volume{good, bad} {
+		if _, _, err := v.writeNeedle(n, FsyncNone); err != nil {
+			t.Fatalf("write needle: %v", err)
+		}
+	}
+
+	// flip the first data byte
+	nv, _ := bad.nm.Get(n.Id)
+	dataOffset := int64(nv.Offset)*NeedlePaddingSize + NeedleEntrySize + 4
+	b := make([]byte, 1)
+	bad.dataFile.ReadAt(b, dataOffset)
+	b[0] = ^b[0]
+	bad.dataFile.WriteAt(b, dataOffset)
+
+	if _, err = bad.readNeedle(newEmptyNeedle(1)); err != ErrorCRC {
+		t.Fatalf("read corrupted needle: %v, expected %v", err, ErrorCRC)
+	}
+	if _, _, err = bad.ReadNeedleBlob(n.Id); err != ErrorCRC {
+		t.Fatalf("read corrupted needle blob: %v, expected %v", err, ErrorCRC)
+	}
+
+	blob, size, err := good.ReadNeedleBlob(n.Id)
+	if err != nil {
+		t.Fatalf("read needle blob: %v", err)
+	}
+	if err = bad.RepairNeedle(n.Id, blob, size, good.Version()); err != nil {
+		t.Fatalf("repair needle: %v", err)
+	}
+
+	repaired := newEmptyNeedle(1)
+	if _, err = bad.readNeedle(repaired); err != nil {
+		t.Fatalf("read repaired needle: %v", err)
+	}
+	if repaired.Checksum != n.Checksum {
+		t.Fatalf("repaired needle checksum %v, expected %v", repaired.Checksum, n.Checksum)
+	}
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+// ScrubResult is the result of verifying all the live needles of a volume,
+// saved in the .scrub file to be reported after restarting
+type ScrubResult struct {
+	ScrubbedAt       time.Time  `json:"scrubbedAt"`
+	NeedleCount      int        `json:"needleCount"`
+	CorruptedNeedles []NeedleId `json:"corruptedNeedles,omitempty"`
+}
+
+/*
+ * Scrub walks through the .dat file, and verifies the CRC of every live needle,
+ * i.e., the needle pointed to by its latest index entry.
+ * Then the index entries are counted, to check whether all of them point to the needles found in the .dat file.
+ * If not, e.g., the needle headers are corrupted, or the volume is changed during scrubbing,
+ * each live index entry is verified directly.
+ *
+ * The reads are throttled to bytesPerSecond, if positive.
+ * The volumes moved to the remote storage are not scrubbed, since reading them all is costly.
+ */
+func (v *Volume) Scrub(bytesPerSecond int64) (*ScrubResult, error) {
+	v.scrubLock.Lock()
+	defer v.scrubLock.Unlock()
+
+	if v.IsRemote() {
+		return nil, fmt.Errorf("volume %d .dat file is in the remote storage, not scrubbed", v.Id)
+	}
+
+	scanner := &VolumeFileScanner4Scrub{
+		v:         v,
+		throttler: newScrubThrottler(bytesPerSecond),
+		corrupted: make(map[NeedleId]bool),
+	}
+	if err := ScanVolumeFile(v.dir, v.Collection, v.Id, v.needleMapKind, scanner); err != nil {
+		// the rest of the .dat file is not walked through, so the live index entries are verified below
+		glog.V(0).Infof("scrub volume %d: %v", v.Id, err)
+	}
+
+	liveCount := 0
+	err := v.walkLiveIndexEntries(func(key NeedleId, offset int64, size uint32) error {
+		liveCount++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scrub volume %d: %v", v.Id, err)
+	}
+
+	if liveCount != scanner.checkedCount {
+		glog.V(0).Infof("scrub volume %d: %d live index entries, but %d needles found in the .dat file", v.Id, liveCount, scanner.checkedCount)
+		scanner.checkedCount = 0
+		err = v.walkLiveIndexEntries(func(key NeedleId, offset int64, size uint32) error {
+			scanner.checkNeedle(key, offset, size)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scrub volume %d: %v", v.Id, err)
+		}
+	}
+
+	result := &ScrubResult{
+		ScrubbedAt:  time.Now(),
+		NeedleCount: scanner.checkedCount,
+	}
+	for key := range scanner.corrupted {
+		result.CorruptedNeedles = append(result.CorruptedNeedles, key)
+	}
+
+	v.scrubResultLock.Lock()
+	v.setScrubResult(result)
+	v.scrubResultLock.Unlock()
+
+	return result, nil
+}
+
+// setScrubResult requires holding the scrubResultLock
+func (v *Volume) setScrubResult(result *ScrubResult) {
+	v.lastScrubResult = result
+	if err := saveScrubResult(v.FileName()+".scrub", result); err != nil {
+		glog.V(0).Infof("save scrub result of volume %d: %v", v.Id, err)
+	}
+}
+
+func saveScrubResult(fileName string, result *ScrubResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(fileName+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(fileName+".tmp", fileName)
+}
+
+// loadScrubResult reads the last scrubbing result saved before restarting, if any
+func (v *Volume) loadScrubResult() {
+	data, err := ioutil.ReadFile(v.FileName() + ".scrub")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.V(0).Infof("read scrub result of volume %d: %v", v.Id, err)
+		}
+		return
+	}
+	result := &ScrubResult{}
+	if err = json.Unmarshal(data, result); err != nil {
+		glog.V(0).Infof("parse scrub result of volume %d: %v", v.Id, err)
+		return
+	}
+	v.scrubResultLock.Lock()
+	v.lastScrubResult = result
+	v.scrubResultLock.Unlock()
+}
+
+// LastScrubResult returns the result of the last scrubbing, or nil if not scrubbed yet
+func (v *Volume) LastScrubResult() *ScrubResult {
+	v.scrubResultLock.RLock()
+	defer v.scrubResultLock.RUnlock()
+	return v.lastScrubResult
+}
+
+func (v *Volume) CorruptedNeedleCount() int {
+	if result := v.LastScrubResult(); result != nil {
+		return len(result.CorruptedNeedles)
+	}
+	return 0
+}
+
+// walkLiveIndexEntries calls fn with the latest index entry of each live needle
+func (v *Volume) walkLiveIndexEntries(fn func(key NeedleId, offset int64, size uint32) error) error {
+	indexFile, err := os.OpenFile(v.nm.IndexFileName(), os.O_RDONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open index file: %v", err)
+	}
+	defer indexFile.Close()
+
+	return WalkIndexFile(indexFile, func(key NeedleId, offset Offset, size uint32) error {
+		nv, ok := v.nm.Get(key)
+		if !ok || nv.Offset != offset || nv.Size != size || offset == 0 || size == TombstoneFileSize {
+			return nil
+		}
+		return fn(key, int64(offset)*NeedlePaddingSize, size)
+	})
+}
+
+type VolumeFileScanner4Scrub struct {
+	v            *Volume
+	version      Version
+	throttler    *scrubThrottler
+	checkedCount int
+	corrupted    map[NeedleId]bool
+}
+
+func (scanner *VolumeFileScanner4Scrub) VisitSuperBlock(superBlock SuperBlock) error {
+	scanner.version = superBlock.Version()
+	return nil
+}
+
+func (scanner *VolumeFileScanner4Scrub) ReadNeedleBody() bool {
+	// the live needles are read with the stored checksum in VisitNeedle
+	return false
+}
+
+func (scanner *VolumeFileScanner4Scrub) VisitNeedle(n *Needle, offset int64) error {
+	nv, ok := scanner.v.nm.Get(n.Id)
+	if !ok || int64(nv.Offset)*NeedlePaddingSize != offset || nv.Size == TombstoneFileSize {
+		// deleted or overwritten
+		return nil
+	}
+	scanner.checkNeedle(n.Id, offset, nv.Size)
+	return nil
+}
+
+func (scanner *VolumeFileScanner4Scrub) checkNeedle(key NeedleId, offset int64, size uint32) {
+	v := scanner.v
+	scanner.checkedCount++
+	scanner.throttler.maybeSlowdown(getActualSize(size, v.Version()))
+
+	err := verifyNeedleIntegrity(v.DataReader(), v.Version(), offset, key, size)
+	if err == nil {
+		return
+	}
+	// the needle may be overwritten, deleted, or moved by compaction since looked up
+	if nv, ok := v.nm.Get(key); !ok || int64(nv.Offset)*NeedlePaddingSize != offset || nv.Size != size {
+		return
+	}
+	glog.Errorf("volume %d needle %d at offset %d size %d is corrupted: %v", v.Id, key, offset, size, err)
+	scanner.corrupted[key] = true
+}
+
+// scrubThrottler sleeps to limit the read speed
+type scrubThrottler struct {
+	bytesPerSecond int64
+	startTime      time.Time
+	readBytes      int64
+}
+
+func newScrubThrottler(bytesPerSecond int64) *scrubThrottler {
+	return &scrubThrottler{
+		bytesPerSecond: bytesPerSecond,
+		startTime:      time.Now(),
+	}
+}
+
+func (t *scrubThrottler) maybeSlowdown(delta int64) {
+	if t.bytesPerSecond <= 0 {
+		return
+	}
+	t.readBytes += delta
+	expected := time.Duration(float64(t.readBytes) / float64(t.bytesPerSecond) * float64(time.Second))
+	if elapsed := time.Since(t.startTime); elapsed < expected {
+		time.Sleep(expected - elapsed)
+	}
+}
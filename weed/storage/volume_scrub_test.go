@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+func TestScrub(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scrub")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer v.Close()
+
+	fileCount := 100
+	for i := 1; i <= fileCount; i++ {
+		n := newRandomNeedle(uint64(i))
+		n.Data = append(n.Data, byte(i))
+		n.Checksum = NewCRC(n.Data)
+		if _, _, err := v.writeNeedle(n, FsyncNone); err != nil {
+			t.Fatalf("write file %d: %v", i, err)
+		}
+	}
+	// overwritten and deleted needles are not checked
+	if _, _, err := v.writeNeedle(newRandomNeedle(1), FsyncNone); err != nil {
+		t.Fatalf("overwrite file 1: %v", err)
+	}
+	if _, err := v.deleteNeedle(newEmptyNeedle(2), FsyncNone); err != nil {
+		t.Fatalf("delete file 2: %v", err)
+	}
+
+	result, err := v.Scrub(0)
+	if err != nil {
+		t.Fatalf("scrub: %v", err)
+	}
+	if result.NeedleCount != fileCount-1 || len(result.CorruptedNeedles) != 0 {
+		t.Fatalf("scrubbed %d needles, %d corrupted", result.NeedleCount, len(result.CorruptedNeedles))
+	}
+
+	// flip the first data byte of needle 7
+	nv, _ := v.nm.Get(Uint64ToNeedleId(7))
+	dataOffset := int64(nv.Offset)*NeedlePaddingSize + NeedleEntrySize + 4
+	b := make([]byte, 1)
+	if _, err = v.dataFile.ReadAt(b, dataOffset); err != nil {
+		t.Fatalf("read needle 7: %v", err)
+	}
+	b[0] = ^b[0]
+	if _, err = v.dataFile.WriteAt(b, dataOffset); err != nil {
+		t.Fatalf("corrupt needle 7: %v", err)
+	}
+
+	result, err = v.Scrub(0)
+	if err != nil {
+		t.Fatalf("scrub: %v", err)
+	}
+	if len(result.CorruptedNeedles) != 1 || result.CorruptedNeedles[0] != Uint64ToNeedleId(7) {
+		t.Fatalf("corrupted needles %v, expected [7]", result.CorruptedNeedles)
+	}
+	if v.CorruptedNeedleCount() != 1 {
+		t.Fatalf("corrupted needle count %d, expected 1", v.CorruptedNeedleCount())
+	}
+
+	// the result is kept after reloading the volume
+	v.Close()
+	reloaded, err := NewVolume(dir, "", 1, NeedleMapInMemory, nil, nil, 0)
+	if err != nil {
+		t.Fatalf("volume reloading: %v", err)
+	}
+	defer reloaded.Close()
+	if reloaded.CorruptedNeedleCount() != 1 || !reloaded.LastScrubResult().ScrubbedAt.Equal(result.ScrubbedAt) {
+		t.Fatalf("reloaded scrub result %+v, expected %+v", reloaded.LastScrubResult(), result)
+	}
+}
@@ -90,6 +90,11 @@ func (v *Volume) maybeWriteSuperBlock() error {
 }
 
 func (v *Volume) readSuperBlock() (err error) {
+	if v.remoteDataFile != nil {
+		// the super block of the remote .dat file is also kept in the .tier file
+		v.SuperBlock, err = ParseSuperBlock(v.remoteDataFile.SuperBlock)
+		return err
+	}
 	v.SuperBlock, err = ReadSuperBlock(v.dataFile)
 	return err
 }
@@ -105,22 +110,44 @@ func ReadSuperBlock(dataFile *os.File) (superBlock SuperBlock, err error) {
 		err = fmt.Errorf("cannot read volume %s super block: %v", dataFile.Name(), e)
 		return
 	}
-	superBlock.version = Version(header[0])
-	if superBlock.ReplicaPlacement, err = NewReplicaPlacementFromByte(header[1]); err != nil {
+	if extraSize := util.BytesToUint16(header[6:8]); extraSize > 0 {
+		// read more
+		extraData := make([]byte, int(extraSize))
+		if _, e := dataFile.Read(extraData); e != nil {
+			err = fmt.Errorf("cannot read volume %s super block extra: %v", dataFile.Name(), e)
+			return
+		}
+		header = append(header, extraData...)
+	}
+	if superBlock, err = ParseSuperBlock(header); err != nil {
+		err = fmt.Errorf("volume %s: %v", dataFile.Name(), err)
+	}
+	return
+}
+
+// ParseSuperBlock parses the super block from its bytes, as returned by SuperBlock.Bytes()
+func ParseSuperBlock(bytes []byte) (superBlock SuperBlock, err error) {
+	if len(bytes) < _SuperBlockSize {
+		err = fmt.Errorf("super block size %d is less than %d", len(bytes), _SuperBlockSize)
+		return
+	}
+	superBlock.version = Version(bytes[0])
+	if superBlock.ReplicaPlacement, err = NewReplicaPlacementFromByte(bytes[1]); err != nil {
 		err = fmt.Errorf("cannot read replica type: %s", err.Error())
 		return
 	}
-	superBlock.Ttl = LoadTTLFromBytes(header[2:4])
-	superBlock.CompactRevision = util.BytesToUint16(header[4:6])
-	superBlock.extraSize = util.BytesToUint16(header[6:8])
+	superBlock.Ttl = LoadTTLFromBytes(bytes[2:4])
+	superBlock.CompactRevision = util.BytesToUint16(bytes[4:6])
+	superBlock.extraSize = util.BytesToUint16(bytes[6:8])
 
 	if superBlock.extraSize > 0 {
-		// read more
-		extraData := make([]byte, int(superBlock.extraSize))
+		if len(bytes) < _SuperBlockSize+int(superBlock.extraSize) {
+			err = fmt.Errorf("super block extra size %d is more than the remaining %d bytes", superBlock.extraSize, len(bytes)-_SuperBlockSize)
+			return
+		}
 		superBlock.Extra = &master_pb.SuperBlockExtra{}
-		err = proto.Unmarshal(extraData, superBlock.Extra)
-		if err != nil {
-			err = fmt.Errorf("cannot read volume %s super block extra: %v", dataFile.Name(), err)
+		if err = proto.Unmarshal(bytes[_SuperBlockSize:_SuperBlockSize+int(superBlock.extraSize)], superBlock.Extra); err != nil {
+			err = fmt.Errorf("cannot read super block extra: %v", err)
 			return
 		}
 	}
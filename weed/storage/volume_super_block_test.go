@@ -21,3 +21,27 @@ func TestSuperBlockReadWrite(t *testing.T) {
 	}
 
 }
+
+func TestSuperBlockParse(t *testing.T) {
+	rp, _ := NewReplicaPlacementFromByte(byte(001))
+	ttl, _ := ReadTTL("15d")
+	s := &SuperBlock{
+		version:          CurrentVersion,
+		ReplicaPlacement: rp,
+		Ttl:              ttl,
+		CompactRevision:  3,
+	}
+
+	parsed, err := ParseSuperBlock(s.Bytes())
+	if err != nil {
+		t.Fatalf("parse super block: %v", err)
+	}
+	if parsed.Version() != s.Version() || parsed.ReplicaPlacement.String() != rp.String() ||
+		parsed.Ttl.String() != ttl.String() || parsed.CompactRevision != 3 {
+		t.Errorf("parsed super block %+v, expected %+v", parsed, s)
+	}
+
+	if _, err = ParseSuperBlock(s.Bytes()[:4]); err == nil {
+		t.Errorf("expect error for the truncated super block")
+	}
+}
@@ -163,14 +163,13 @@ func fetchVolumeFileEntries(volumeServer string, grpcDialOption grpc.DialOption,
 
 func (v *Volume) GetVolumeSyncStatus() *volume_server_pb.VolumeSyncStatusResponse {
 	var syncStatus = &volume_server_pb.VolumeSyncStatusResponse{}
-	if stat, err := v.dataFile.Stat(); err == nil {
-		syncStatus.TailOffset = uint64(stat.Size())
-	}
+	syncStatus.TailOffset = uint64(v.Size())
 	syncStatus.Collection = v.Collection
 	syncStatus.IdxFileSize = v.nm.IndexFileSize()
 	syncStatus.CompactRevision = uint32(v.SuperBlock.CompactRevision)
 	syncStatus.Ttl = v.SuperBlock.Ttl.String()
 	syncStatus.Replication = v.SuperBlock.ReplicaPlacement.String()
+	syncStatus.ReadOnly = v.readOnly
 	return syncStatus
 }
 
@@ -182,7 +181,7 @@ func (v *Volume) IndexFileContent() ([]byte, error) {
 func (v *Volume) removeNeedle(key NeedleId) {
 	n := new(Needle)
 	n.Id = key
-	v.deleteNeedle(n)
+	v.deleteNeedle(n, FsyncNone)
 }
 
 // fetchNeedle fetches a remote volume needle by vid, id, offset
@@ -223,3 +222,48 @@ func (v *Volume) fetchNeedle(volumeServer string, grpcDialOption grpc.DialOption
 	})
 
 }
+
+// VerifySynchronized checks the local volume has exactly the same live needles as the remote volume
+func (v *Volume) VerifySynchronized(volumeServer string, grpcDialOption grpc.DialOption) error {
+	masterMap, _, _, err := fetchVolumeFileEntries(volumeServer, grpcDialOption, v.Id)
+	if err != nil {
+		return fmt.Errorf("fetch volume %d entries from %s: %v", v.Id, volumeServer, err)
+	}
+
+	slaveIdxFile, err := os.Open(v.nm.IndexFileName())
+	if err != nil {
+		return fmt.Errorf("Open volume %d index file: %v", v.Id, err)
+	}
+	defer slaveIdxFile.Close()
+	slaveMap, err := LoadBtreeNeedleMap(slaveIdxFile)
+	if err != nil {
+		return fmt.Errorf("Load volume %d index file: %v", v.Id, err)
+	}
+
+	masterCount := 0
+	if err = masterMap.Visit(func(needleValue needle.NeedleValue) error {
+		if needleValue.Key == NeedleIdEmpty || needleValue.Size == TombstoneFileSize {
+			return nil
+		}
+		masterCount++
+		if slaveValue, ok := slaveMap.Get(needleValue.Key); !ok || slaveValue.Size != needleValue.Size {
+			return fmt.Errorf("needle %v is not synchronized", needleValue.Key)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("volume %d: %v", v.Id, err)
+	}
+
+	slaveCount := 0
+	slaveMap.m.Visit(func(needleValue needle.NeedleValue) error {
+		if needleValue.Key != NeedleIdEmpty {
+			slaveCount++
+		}
+		return nil
+	})
+	if slaveCount != masterCount {
+		return fmt.Errorf("volume %d has %d needles, but %s has %d", v.Id, slaveCount, volumeServer, masterCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+/*
+ * A read-only volume can move its .dat file to a S3 compatible storage, keeping the .idx file locally.
+ * The .tier file replaces the local .dat file, recording where the remote copy is,
+ * and the super block, so loading the volume does not need to read the remote copy.
+ * The needles are then read with ranged GETs.
+ *
+ * The credentials are from SetTierS3Config, or else the default aws credential chain,
+ * e.g., the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+ */
+type VolumeTierInfo struct {
+	Endpoint     string `json:"endpoint,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Bucket       string `json:"bucket"`
+	Key          string `json:"key"`
+	FileSize     int64  `json:"fileSize"`
+	ModifiedTime uint64 `json:"modifiedTime"`
+	SuperBlock   []byte `json:"superBlock"`
+	// the volume was read-only before moving, and stays read-only after moving back
+	WasReadOnly bool `json:"wasReadOnly,omitempty"`
+}
+
+const (
+	remoteReadAttempts = 3
+	remoteReadBackoff  = 200 * time.Millisecond
+)
+
+// tierS3Config is the default endpoint and the credentials of the S3 compatible storage
+var tierS3Config struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+}
+
+// SetTierS3Config sets the endpoint used when the request does not give one,
+// and the credentials, used instead of the default aws credential chain if not empty.
+// It should be called before loading the volumes.
+func SetTierS3Config(endpoint, accessKey, secretKey string) {
+	tierS3Config.endpoint = endpoint
+	tierS3Config.accessKey = accessKey
+	tierS3Config.secretKey = secretKey
+}
+
+// RemoteDataFile reads the remote copy of the .dat file
+type RemoteDataFile struct {
+	VolumeTierInfo
+	conn s3iface.S3API
+}
+
+func newRemoteDataFile(info VolumeTierInfo) (*RemoteDataFile, error) {
+	conn, err := newS3Client(info.Endpoint, info.Region)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteDataFile{
+		VolumeTierInfo: info,
+		conn:           conn,
+	}, nil
+}
+
+func newS3Client(endpoint, region string) (s3iface.S3API, error) {
+	if region == "" {
+		// required by the aws sdk, though not used by most S3 compatible storages
+		region = "us-east-1"
+	}
+	config := &aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(true),
+	}
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+	}
+	if tierS3Config.accessKey != "" && tierS3Config.secretKey != "" {
+		config.Credentials = credentials.NewStaticCredentials(tierS3Config.accessKey, tierS3Config.secretKey, "")
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create aws session: %v", err)
+	}
+	return s3.New(sess), nil
+}
+
+func loadRemoteDataFile(tierFileName string) (*RemoteDataFile, error) {
+	data, err := ioutil.ReadFile(tierFileName)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", tierFileName, err)
+	}
+	var info VolumeTierInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", tierFileName, err)
+	}
+	return newRemoteDataFile(info)
+}
+
+func saveVolumeTierInfo(tierFileName string, info VolumeTierInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(tierFileName, data, 0644)
+}
+
+// ReadAt reads the remote copy with one ranged GET, retried with backoff on errors
+func (f *RemoteDataFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if off >= f.FileSize {
+		return 0, io.EOF
+	}
+	size := int64(len(p))
+	if off+size > f.FileSize {
+		size = f.FileSize - off
+	}
+	if size == 0 {
+		return 0, nil
+	}
+
+	backoff := remoteReadBackoff
+	for attempt := 1; ; attempt++ {
+		if n, err = f.readRange(p[:size], off); err == nil {
+			break
+		}
+		if attempt >= remoteReadAttempts {
+			return n, fmt.Errorf("read %s/%s at %d: %v", f.Bucket, f.Key, off, err)
+		}
+		glog.V(1).Infof("read %s/%s at %d, attempt %d: %v", f.Bucket, f.Key, off, attempt, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	if size < int64(len(p)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (f *RemoteDataFile) readRange(p []byte, off int64) (int, error) {
+	resp, err := f.conn.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(f.Bucket),
+		Key:    aws.String(f.Key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1)),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return io.ReadFull(resp.Body, p)
+}
+
+func (f *RemoteDataFile) deleteRemote() error {
+	_, err := f.conn.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(f.Bucket),
+		Key:    aws.String(f.Key),
+	})
+	return err
+}
+
+// MoveDatToRemote uploads the .dat file, and then removes the local .dat file.
+// The volume becomes read-only before the upload, and reads the needles from the remote copy.
+func (v *Volume) MoveDatToRemote(endpoint, region, bucket string) (err error) {
+	v.tierLock.Lock()
+	defer v.tierLock.Unlock()
+
+	if v.IsRemote() {
+		return fmt.Errorf("volume %d .dat file is already moved to the remote storage", v.Id)
+	}
+
+	// stop the writes first, and upload the .dat file up to its current size
+	v.dataFileAccessLock.Lock()
+	wasReadOnly := v.readOnly
+	v.readOnly = true
+	dataFile, modifiedTime := v.dataFile, v.lastModifiedTime
+	stat, err := dataFile.Stat()
+	v.dataFileAccessLock.Unlock()
+	defer func() {
+		if err != nil {
+			v.restoreReadOnly(wasReadOnly)
+		}
+	}()
+	if err != nil {
+		return fmt.Errorf("stat %s: %v", dataFile.Name(), err)
+	}
+
+	if endpoint == "" {
+		endpoint = tierS3Config.endpoint
+	}
+	remote, err := newRemoteDataFile(VolumeTierInfo{
+		Endpoint:     endpoint,
+		Region:       region,
+		Bucket:       bucket,
+		Key:          fmt.Sprintf("%s_%d.dat", path.Base(v.FileName()), time.Now().UnixNano()),
+		FileSize:     stat.Size(),
+		ModifiedTime: modifiedTime,
+		SuperBlock:   v.SuperBlock.Bytes(),
+		WasReadOnly:  wasReadOnly,
+	})
+	if err != nil {
+		return err
+	}
+
+	glog.V(0).Infof("volume %d uploading %s to %s/%s", v.Id, dataFile.Name(), bucket, remote.Key)
+	uploader := s3manager.NewUploaderWithClient(remote.conn)
+	if _, err = uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(remote.Key),
+		Body:   io.NewSectionReader(dataFile, 0, remote.FileSize),
+	}); err != nil {
+		return fmt.Errorf("upload %s to %s/%s: %v", dataFile.Name(), bucket, remote.Key, err)
+	}
+
+	if err = v.switchToRemoteDataFile(dataFile, remote); err != nil {
+		if deleteErr := remote.deleteRemote(); deleteErr != nil {
+			glog.V(0).Infof("delete %s/%s: %v", bucket, remote.Key, deleteErr)
+		}
+		return err
+	}
+
+	glog.V(0).Infof("volume %d .dat file is moved to %s/%s", v.Id, bucket, remote.Key)
+	return nil
+}
+
+// restoreReadOnly reverts the read-only state set for a failed upload
+func (v *Volume) restoreReadOnly(wasReadOnly bool) {
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+	v.readOnly = wasReadOnly
+}
+
+func (v *Volume) switchToRemoteDataFile(dataFile *os.File, remote *RemoteDataFile) error {
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+
+	// the writes started before being read-only, or the compaction, may have changed the .dat file
+	if v.dataFile != dataFile {
+		return fmt.Errorf("volume %d .dat file is changed during uploading", v.Id)
+	}
+	stat, err := dataFile.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %v", dataFile.Name(), err)
+	}
+	if stat.Size() != remote.FileSize {
+		return fmt.Errorf("volume %d .dat file is changed during uploading, size %d, uploaded %d", v.Id, stat.Size(), remote.FileSize)
+	}
+
+	if err = saveVolumeTierInfo(v.FileName()+".tier", remote.VolumeTierInfo); err != nil {
+		return fmt.Errorf("save %s.tier: %v", v.FileName(), err)
+	}
+
+	v.dataFileSwapLock.Lock()
+	v.remoteDataFile = remote
+	v.dataFile = nil
+	dataFile.Close()
+	v.dataFileSwapLock.Unlock()
+	if err = os.Remove(dataFile.Name()); err != nil {
+		glog.V(0).Infof("remove %s: %v", dataFile.Name(), err)
+	}
+
+	return nil
+}
+
+// MoveDatFromRemote downloads the .dat file back to the local disk, and then deletes the remote copy.
+func (v *Volume) MoveDatFromRemote() error {
+	v.tierLock.Lock()
+	defer v.tierLock.Unlock()
+
+	remote := v.remoteDataFile
+	if remote == nil {
+		return fmt.Errorf("volume %d .dat file is not in the remote storage", v.Id)
+	}
+
+	// download to a temporary file first, which is not recognized as a volume
+	fileName := v.FileName()
+	dataFile, err := os.OpenFile(fileName+".dat.download", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("create %s.dat.download: %v", fileName, err)
+	}
+
+	glog.V(0).Infof("volume %d downloading %s/%s to %s", v.Id, remote.Bucket, remote.Key, dataFile.Name())
+	downloader := s3manager.NewDownloaderWithClient(remote.conn)
+	size, err := downloader.Download(dataFile, &s3.GetObjectInput{
+		Bucket: aws.String(remote.Bucket),
+		Key:    aws.String(remote.Key),
+	})
+	if err == nil && size != remote.FileSize {
+		err = fmt.Errorf("downloaded size %d, expected %d", size, remote.FileSize)
+	}
+	dataFile.Close()
+	if err != nil {
+		os.Remove(dataFile.Name())
+		return fmt.Errorf("download %s/%s: %v", remote.Bucket, remote.Key, err)
+	}
+
+	v.dataFileAccessLock.Lock()
+	if err = os.Rename(dataFile.Name(), fileName+".dat"); err != nil {
+		v.dataFileAccessLock.Unlock()
+		os.Remove(dataFile.Name())
+		return fmt.Errorf("rename %s: %v", dataFile.Name(), err)
+	}
+	dataFile, err = os.OpenFile(fileName+".dat", os.O_RDWR, 0644)
+	if err != nil {
+		v.dataFileAccessLock.Unlock()
+		return fmt.Errorf("open %s.dat: %v", fileName, err)
+	}
+	v.dataFileSwapLock.Lock()
+	v.dataFile = dataFile
+	v.remoteDataFile = nil
+	v.dataFileSwapLock.Unlock()
+	// the downloaded .dat file is writable, so the earlier read-only state can be reverted by MarkWritable
+	v.readOnly = remote.WasReadOnly
+	v.markedReadOnly = remote.WasReadOnly
+	if err = os.Remove(fileName + ".tier"); err != nil {
+		glog.V(0).Infof("remove %s.tier: %v", fileName, err)
+	}
+	v.dataFileAccessLock.Unlock()
+
+	if err = remote.deleteRemote(); err != nil {
+		glog.V(0).Infof("delete %s/%s: %v", remote.Bucket, remote.Key, err)
+	}
+
+	glog.V(0).Infof("volume %d .dat file is moved back from %s/%s", v.Id, remote.Bucket, remote.Key)
+	return nil
+}
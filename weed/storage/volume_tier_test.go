@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// testS3Server keeps the objects in memory, serving the PUT, the ranged GET and the DELETE requests
+type testS3Server struct {
+	sync.Mutex
+	objects map[string][]byte
+	// the next GETs only send half of the content
+	truncatedGets int
+}
+
+func (s *testS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	switch r.Method {
+	case "PUT":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.Path] = data
+		w.Header().Set("ETag", `"etag"`)
+	case "GET":
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		start, end := 0, len(data)-1
+		status := http.StatusOK
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			fmt.Sscanf(rangeHeader, "bytes=%d-%d", &start, &end)
+			if end >= len(data) {
+				end = len(data) - 1
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+			status = http.StatusPartialContent
+		}
+		body := data[start : end+1]
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		if s.truncatedGets > 0 {
+			s.truncatedGets--
+			body = body[:len(body)/2]
+		}
+		w.WriteHeader(status)
+		w.Write(body)
+	case "DELETE":
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestMoveDatToRemote(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		testMoveDatToRemote(t, readOnly)
+	}
+}
+
+func testMoveDatToRemote(t *testing.T, readOnly bool) {
+	s3Server := &testS3Server{objects: make(map[string][]byte)}
+	server := httptest.NewServer(s3Server)
+	defer server.Close()
+	SetTierS3Config(server.URL, "access_key", "secret_key")
+	defer SetTierS3Config("", "", "")
+
+	dir, err := ioutil.TempDir("", "volume_tier")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	fileCount := 20
+	written := make([][]byte, fileCount)
+	for i := 0; i < fileCount; i++ {
+		n := newRandomNeedle(uint64(i + 1))
+		n.Data = append(n.Data, byte(i))
+		n.Checksum = NewCRC(n.Data)
+		written[i] = n.Data
+		if _, _, err := v.writeNeedle(n, FsyncNone); err != nil {
+			t.Fatalf("write file %d: %v", i+1, err)
+		}
+	}
+	if readOnly {
+		v.MarkReadOnly()
+	}
+
+	checkReads := func(v *Volume, step string) {
+		for i, data := range written {
+			n := newEmptyNeedle(uint64(i + 1))
+			if _, err := v.readNeedle(n); err != nil {
+				t.Fatalf("%s: read file %d: %v", step, i+1, err)
+			}
+			if !bytes.Equal(n.Data, data) {
+				t.Fatalf("%s: file %d content changed", step, i+1)
+			}
+		}
+	}
+
+	if err = v.MoveDatToRemote("", "", "bucket"); err != nil {
+		t.Fatalf("move to remote: %v", err)
+	}
+	if !v.IsRemote() || !v.readOnly {
+		t.Fatalf("volume is not read-only in the remote storage")
+	}
+	if len(s3Server.objects) != 1 {
+		t.Fatalf("%d objects uploaded", len(s3Server.objects))
+	}
+	if _, err = os.Stat(v.FileName() + ".dat"); !os.IsNotExist(err) {
+		t.Fatalf("local .dat file is not removed: %v", err)
+	}
+	checkReads(v, "remote")
+
+	// the short reads are retried
+	s3Server.Lock()
+	s3Server.truncatedGets = remoteReadAttempts - 1
+	s3Server.Unlock()
+	checkReads(v, "retried remote")
+
+	// loading from the .tier file
+	v.Close()
+	v, err = NewVolume(dir, "", 1, NeedleMapInMemory, nil, nil, 0)
+	if err != nil {
+		t.Fatalf("volume reloading: %v", err)
+	}
+	defer v.Close()
+	if !v.IsRemote() {
+		t.Fatalf("reloaded volume is not in the remote storage")
+	}
+	checkReads(v, "reloaded remote")
+
+	if err = v.MoveDatFromRemote(); err != nil {
+		t.Fatalf("move from remote: %v", err)
+	}
+	if v.IsRemote() || v.readOnly != readOnly {
+		t.Fatalf("moved back volume is remote %v, read-only %v", v.IsRemote(), v.readOnly)
+	}
+	if len(s3Server.objects) != 0 {
+		t.Fatalf("remote copy is not deleted")
+	}
+	if _, err = os.Stat(v.FileName() + ".tier"); !os.IsNotExist(err) {
+		t.Fatalf("local .tier file is not removed: %v", err)
+	}
+	checkReads(v, "local")
+}
+
+func TestMoveDatToRemoteFailure(t *testing.T) {
+	// no S3 server, so the upload fails
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	SetTierS3Config(server.URL, "access_key", "secret_key")
+	defer SetTierS3Config("", "", "")
+
+	dir, err := ioutil.TempDir("", "volume_tier")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer v.Close()
+
+	// the read-only state is read concurrently while it is restored
+	stop := make(chan struct{})
+	var reader sync.WaitGroup
+	reader.Add(1)
+	go func() {
+		defer reader.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			v.IsReadOnly()
+		}
+	}()
+
+	err = v.MoveDatToRemote("", "", "bucket")
+	close(stop)
+	reader.Wait()
+	if err == nil {
+		t.Fatalf("move to an unavailable remote storage succeeded")
+	}
+	if v.IsRemote() || v.IsReadOnly() {
+		t.Fatalf("volume after the failed move is remote %v, read-only %v", v.IsRemote(), v.IsReadOnly())
+	}
+	if _, _, err = v.writeNeedle(newRandomNeedle(1), FsyncNone); err != nil {
+		t.Fatalf("write after the failed move: %v", err)
+	}
+}
@@ -14,11 +14,18 @@ func (v *Volume) garbageLevel() float64 {
 	if v.ContentSize() == 0 {
 		return 0
 	}
+	if v.IsRemote() {
+		// not compactable until moved back to the local disk
+		return 0
+	}
 	return float64(v.nm.DeletedSize()) / float64(v.ContentSize())
 }
 
 func (v *Volume) Compact(preallocate int64) error {
 	glog.V(3).Infof("Compacting volume %d ...", v.Id)
+	if v.IsRemote() {
+		return fmt.Errorf("volume %d .dat file is moved to the remote storage", v.Id)
+	}
 	//no need to lock for copy on write
 	//v.accessLock.Lock()
 	//defer v.accessLock.Unlock()
@@ -33,6 +40,9 @@ func (v *Volume) Compact(preallocate int64) error {
 
 func (v *Volume) Compact2() error {
 	glog.V(3).Infof("Compact2 volume %d ...", v.Id)
+	if v.IsRemote() {
+		return fmt.Errorf("volume %d .dat file is moved to the remote storage", v.Id)
+	}
 	filePath := v.FileName()
 	glog.V(3).Infof("creating copies for volume %d ...", v.Id)
 	return v.copyDataBasedOnIndexFile(filePath+".cpd", filePath+".cpx")
@@ -42,9 +52,9 @@ func (v *Volume) commitCompact() error {
 	glog.V(0).Infof("Committing volume %d vacuuming...", v.Id)
 	v.dataFileAccessLock.Lock()
 	defer v.dataFileAccessLock.Unlock()
+	v.dataFileSwapLock.Lock()
+	defer v.dataFileSwapLock.Unlock()
 	glog.V(3).Infof("Got volume %d committing lock...", v.Id)
-	v.compactingWg.Add(1)
-	defer v.compactingWg.Done()
 	v.nm.Close()
 	if err := v.dataFile.Close(); err != nil {
 		glog.V(0).Infof("fail to close volume %d", v.Id)
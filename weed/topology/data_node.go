@@ -5,12 +5,14 @@ import (
 	"strconv"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
 	"github.com/chrislusf/seaweedfs/weed/storage"
 )
 
 type DataNode struct {
 	NodeImpl
 	volumes   map[storage.VolumeId]storage.VolumeInfo
+	ecShards  map[storage.VolumeId]*EcShardInfo
 	Ip        string
 	Port      int
 	PublicUrl string
@@ -22,6 +24,7 @@ func NewDataNode(id string) *DataNode {
 	s.id = NodeId(id)
 	s.nodeType = "DataNode"
 	s.volumes = make(map[storage.VolumeId]storage.VolumeInfo)
+	s.ecShards = make(map[storage.VolumeId]*EcShardInfo)
 	s.NodeImpl.value = s
 	return s
 }
@@ -35,7 +38,11 @@ func (dn *DataNode) String() string {
 func (dn *DataNode) AddOrUpdateVolume(v storage.VolumeInfo) (isNew bool) {
 	dn.Lock()
 	defer dn.Unlock()
-	if _, ok := dn.volumes[v.Id]; !ok {
+	oldVolume, found := dn.volumes[v.Id]
+	if v.CorruptedNeedleCount > 0 && v.CorruptedNeedleCount != oldVolume.CorruptedNeedleCount {
+		glog.Warningf("volume %d on %s has %d corrupted needles", v.Id, dn.Url(), v.CorruptedNeedleCount)
+	}
+	if !found {
 		dn.volumes[v.Id] = v
 		dn.UpAdjustVolumeCountDelta(1)
 		if !v.ReadOnly {
@@ -74,6 +81,18 @@ func (dn *DataNode) UpdateVolumes(actualVolumes []storage.VolumeInfo) (newVolume
 	return
 }
 
+// RemoveVolume forgets the volume without waiting for the heartbeat, e.g. after deleting it from the data node
+func (dn *DataNode) RemoveVolume(vid storage.VolumeId) (v storage.VolumeInfo, found bool) {
+	dn.Lock()
+	defer dn.Unlock()
+	if v, found = dn.volumes[vid]; found {
+		delete(dn.volumes, vid)
+		dn.UpAdjustVolumeCountDelta(-1)
+		dn.UpAdjustActiveVolumeCountDelta(-1)
+	}
+	return
+}
+
 func (dn *DataNode) GetVolumes() (ret []storage.VolumeInfo) {
 	dn.RLock()
 	for _, v := range dn.volumes {
@@ -125,6 +144,24 @@ func (dn *DataNode) ToMap() interface{} {
 	ret["Volumes"] = dn.GetVolumeCount()
 	ret["Max"] = dn.GetMaxVolumeCount()
 	ret["Free"] = dn.FreeSpace()
+	ret["EcShards"] = dn.GetEcShardCount()
 	ret["PublicUrl"] = dn.PublicUrl
 	return ret
 }
+
+func (dn *DataNode) ToDataNodeInfo() *master_pb.DataNodeInfo {
+	m := &master_pb.DataNodeInfo{
+		Id:                string(dn.Id()),
+		VolumeCount:       uint64(dn.GetVolumeCount()),
+		MaxVolumeCount:    uint64(dn.GetMaxVolumeCount()),
+		FreeVolumeCount:   uint64(dn.FreeSpace()),
+		ActiveVolumeCount: uint64(dn.GetActiveVolumeCount()),
+	}
+	for _, v := range dn.GetVolumes() {
+		m.VolumeInfos = append(m.VolumeInfos, v.ToVolumeInformationMessage())
+	}
+	for _, ecShards := range dn.GetEcShards() {
+		m.EcShardInfos = append(m.EcShardInfos, ecShards.ToVolumeEcShardInformationMessage())
+	}
+	return m
+}
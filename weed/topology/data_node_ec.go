@@ -0,0 +1,107 @@
+package topology
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+)
+
+// EcShardInfo is the set of shards of one ec volume on one data node
+type EcShardInfo struct {
+	VolumeId   storage.VolumeId
+	Collection string
+	ShardBits  erasure_coding.ShardBits
+}
+
+func NewEcShardInfo(m *master_pb.VolumeEcShardInformationMessage) *EcShardInfo {
+	return &EcShardInfo{
+		VolumeId:   storage.VolumeId(m.Id),
+		Collection: m.Collection,
+		ShardBits:  erasure_coding.ShardBits(m.EcIndexBits),
+	}
+}
+
+func (dn *DataNode) GetEcShards() (ret []*EcShardInfo) {
+	dn.RLock()
+	for _, ecShards := range dn.ecShards {
+		ret = append(ret, &EcShardInfo{ecShards.VolumeId, ecShards.Collection, ecShards.ShardBits})
+	}
+	dn.RUnlock()
+	return ret
+}
+
+func (dn *DataNode) GetEcShardCount() (count int) {
+	dn.RLock()
+	for _, ecShards := range dn.ecShards {
+		count += ecShards.ShardBits.ShardIdCount()
+	}
+	dn.RUnlock()
+	return
+}
+
+func (dn *DataNode) HasEcShards(vid storage.VolumeId) (found bool) {
+	dn.RLock()
+	_, found = dn.ecShards[vid]
+	dn.RUnlock()
+	return
+}
+
+func (dn *DataNode) UpdateEcShards(actualShards []*EcShardInfo) (newShards, deletedShards []*EcShardInfo) {
+	actualEcShardMap := make(map[storage.VolumeId]*EcShardInfo)
+	for _, ecShards := range actualShards {
+		actualEcShardMap[ecShards.VolumeId] = ecShards
+	}
+
+	dn.Lock()
+	for vid, ecShards := range dn.ecShards {
+		actual, ok := actualEcShardMap[vid]
+		if !ok {
+			deletedShards = append(deletedShards, ecShards)
+			continue
+		}
+		if a := actual.ShardBits.Minus(ecShards.ShardBits); a > 0 {
+			newShards = append(newShards, &EcShardInfo{vid, actual.Collection, a})
+		}
+		if d := ecShards.ShardBits.Minus(actual.ShardBits); d > 0 {
+			deletedShards = append(deletedShards, &EcShardInfo{vid, ecShards.Collection, d})
+		}
+	}
+	for _, ecShards := range actualShards {
+		if _, found := dn.ecShards[ecShards.VolumeId]; !found {
+			newShards = append(newShards, ecShards)
+		}
+	}
+	dn.ecShards = actualEcShardMap
+	dn.Unlock()
+
+	return
+}
+
+func (dn *DataNode) DeltaUpdateEcShards(newShards, deletedShards []*EcShardInfo) {
+	dn.Lock()
+	defer dn.Unlock()
+
+	for _, s := range newShards {
+		if existing, found := dn.ecShards[s.VolumeId]; found {
+			existing.ShardBits = existing.ShardBits.Plus(s.ShardBits)
+		} else {
+			dn.ecShards[s.VolumeId] = &EcShardInfo{s.VolumeId, s.Collection, s.ShardBits}
+		}
+	}
+	for _, s := range deletedShards {
+		if existing, found := dn.ecShards[s.VolumeId]; found {
+			existing.ShardBits = existing.ShardBits.Minus(s.ShardBits)
+			if existing.ShardBits == 0 {
+				delete(dn.ecShards, s.VolumeId)
+			}
+		}
+	}
+}
+
+func (ecInfo *EcShardInfo) ToVolumeEcShardInformationMessage() *master_pb.VolumeEcShardInformationMessage {
+	return &master_pb.VolumeEcShardInformationMessage{
+		Id:          uint32(ecInfo.VolumeId),
+		Collection:  ecInfo.Collection,
+		EcIndexBits: uint32(ecInfo.ShardBits),
+	}
+}
@@ -3,6 +3,7 @@ package topology
 import (
 	"errors"
 	"math/rand"
+	"sync"
 
 	"github.com/chrislusf/raft"
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -17,6 +18,12 @@ type Topology struct {
 
 	collectionMap *util.ConcurrentReadMap
 
+	ecShardMap     map[storage.VolumeId]*EcShardLocations
+	ecShardMapLock sync.RWMutex
+
+	replicationTasks     map[storage.VolumeId]*ReplicationTask
+	replicationTasksLock sync.RWMutex
+
 	pulse int64
 
 	volumeSizeLimit uint64
@@ -37,6 +44,8 @@ func NewTopology(id string, seq sequence.Sequencer, volumeSizeLimit uint64, puls
 	t.NodeImpl.value = t
 	t.children = make(map[NodeId]Node)
 	t.collectionMap = util.NewConcurrentReadMap()
+	t.ecShardMap = make(map[storage.VolumeId]*EcShardLocations)
+	t.replicationTasks = make(map[storage.VolumeId]*ReplicationTask)
 	t.pulse = int64(pulse)
 	t.volumeSizeLimit = volumeSizeLimit
 
@@ -82,7 +91,16 @@ func (t *Topology) Lookup(collection string, vid storage.VolumeId) []*DataNode {
 		}
 	} else {
 		if c, ok := t.collectionMap.Find(collection); ok {
-			return c.(*Collection).Lookup(vid)
+			if list := c.(*Collection).Lookup(vid); list != nil {
+				return list
+			}
+		}
+	}
+
+	// erasure coded volumes can be read from any data node holding some of its shards
+	if locations, found := t.LookupEcShards(vid); found {
+		if collection == "" || locations.Collection == collection {
+			return locations.DataNodes()
 		}
 	}
 	return nil
@@ -123,6 +141,13 @@ func (t *Topology) FindCollection(collectionName string) (*Collection, bool) {
 	return c.(*Collection), hasCollection
 }
 
+func (t *Topology) ListCollections() (collectionNames []string) {
+	for _, c := range t.collectionMap.Items() {
+		collectionNames = append(collectionNames, c.(*Collection).Name)
+	}
+	return
+}
+
 func (t *Topology) DeleteCollection(collectionName string) {
 	t.collectionMap.Delete(collectionName)
 }
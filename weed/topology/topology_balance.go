@@ -0,0 +1,350 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"google.golang.org/grpc"
+)
+
+/*
+Volume balancing moves volumes from the data nodes with the highest ratio of
+used volume slots to the data nodes with the lowest ratio.
+
+1. plan the moves on a snapshot of the topology, keeping each volume's replica placement
+2. for each move, allocate an empty volume on the target data node
+3. the target synchronizes the volume from the source and verifies the needles
+4. delete the volume on the source data node
+*/
+
+type VolumeMove struct {
+	VolumeId   storage.VolumeId `json:"volumeId"`
+	Collection string           `json:"collection"`
+	Source     string           `json:"source"`
+	Target     string           `json:"target"`
+	volumeInfo storage.VolumeInfo
+	source     *DataNode
+	target     *DataNode
+}
+
+type balancingNode struct {
+	dn      *DataNode
+	volumes map[storage.VolumeId]storage.VolumeInfo
+}
+
+func (n *balancingNode) ratio(delta int) float64 {
+	return float64(len(n.volumes)+delta) / float64(n.dn.GetMaxVolumeCount())
+}
+
+// PlanVolumeBalance lists the volume moves to even out the volume counts across data nodes.
+// Only volumes in the collection are moved, unless the collection is "*".
+func (t *Topology) PlanVolumeBalance(collection string) (moves []*VolumeMove) {
+
+	var nodes []*balancingNode
+	replicas := make(map[storage.VolumeId][]*DataNode)
+	for _, c := range t.Children() {
+		for _, r := range c.Children() {
+			for _, n := range r.Children() {
+				dn := n.(*DataNode)
+				if dn.GetMaxVolumeCount() <= 0 {
+					continue
+				}
+				node := &balancingNode{dn: dn, volumes: make(map[storage.VolumeId]storage.VolumeInfo)}
+				for _, v := range dn.GetVolumes() {
+					node.volumes[v.Id] = v
+					replicas[v.Id] = append(replicas[v.Id], dn)
+				}
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	for {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].ratio(0) > nodes[j].ratio(0)
+		})
+		move := pickVolumeMove(collection, nodes, replicas)
+		if move == nil {
+			return moves
+		}
+		moves = append(moves, move)
+
+		// apply the move to the snapshot
+		for _, node := range nodes {
+			if node.dn == move.source {
+				delete(node.volumes, move.VolumeId)
+			}
+			if node.dn == move.target {
+				node.volumes[move.VolumeId] = move.volumeInfo
+			}
+		}
+		replicas[move.VolumeId] = replaceDataNode(replicas[move.VolumeId], move.source, move.target)
+	}
+
+}
+
+// pickVolumeMove moves one volume from the fullest node to the emptiest node that can take it.
+// The nodes are sorted by volume ratio, fullest first.
+func pickVolumeMove(collection string, nodes []*balancingNode, replicas map[storage.VolumeId][]*DataNode) *VolumeMove {
+	source := nodes[0]
+
+	var vids []storage.VolumeId
+	for vid, v := range source.volumes {
+		if collection == "*" || v.Collection == collection {
+			vids = append(vids, vid)
+		}
+	}
+	sort.Slice(vids, func(i, j int) bool {
+		return vids[i] < vids[j]
+	})
+
+	for i := len(nodes) - 1; i > 0; i-- {
+		target := nodes[i]
+		if len(target.volumes) >= target.dn.GetMaxVolumeCount() {
+			continue
+		}
+		if source.ratio(-1) < target.ratio(1) {
+			// the following targets are even fuller
+			return nil
+		}
+		for _, vid := range vids {
+			if _, found := target.volumes[vid]; found {
+				continue
+			}
+			v := source.volumes[vid]
+			newReplicas := replaceDataNode(replicas[vid], source.dn, target.dn)
+			if !satisfyReplicaPlacement(v.ReplicaPlacement, newReplicas) {
+				continue
+			}
+			return &VolumeMove{
+				VolumeId:   vid,
+				Collection: v.Collection,
+				Source:     source.dn.Url(),
+				Target:     target.dn.Url(),
+				volumeInfo: v,
+				source:     source.dn,
+				target:     target.dn,
+			}
+		}
+	}
+	return nil
+}
+
+func replaceDataNode(dataNodes []*DataNode, from, to *DataNode) (ret []*DataNode) {
+	for _, dn := range dataNodes {
+		if dn != from {
+			ret = append(ret, dn)
+		}
+	}
+	return append(ret, to)
+}
+
+// satisfyReplicaPlacement checks whether the data nodes can hold the replicas of a volume,
+// following the same layout as VolumeGrowth: one main data center with DiffRackCount+1 racks,
+// one main rack with SameRackCount+1 data nodes, and one data node in each other rack or data center.
+func satisfyReplicaPlacement(rp *storage.ReplicaPlacement, dataNodes []*DataNode) bool {
+	if len(dataNodes) != rp.GetCopyCount() {
+		return false
+	}
+
+	dcRacks := make(map[NodeId]map[NodeId]int)
+	for _, dn := range dataNodes {
+		dcId, rackId := dn.GetDataCenter().Id(), dn.GetRack().Id()
+		if _, found := dcRacks[dcId]; !found {
+			dcRacks[dcId] = make(map[NodeId]int)
+		}
+		dcRacks[dcId][rackId]++
+	}
+	if len(dcRacks) != rp.DiffDataCenterCount+1 {
+		return false
+	}
+
+	foundMainDc := false
+	for _, racks := range dcRacks {
+		if !foundMainDc && isMainDataCenter(rp, racks) {
+			foundMainDc = true
+			continue
+		}
+		if countDataNodes(racks) != 1 {
+			return false
+		}
+	}
+
+	return foundMainDc
+}
+
+func isMainDataCenter(rp *storage.ReplicaPlacement, racks map[NodeId]int) bool {
+	if len(racks) != rp.DiffRackCount+1 {
+		return false
+	}
+	foundMainRack := false
+	for _, count := range racks {
+		if !foundMainRack && count == rp.SameRackCount+1 {
+			foundMainRack = true
+			continue
+		}
+		if count != 1 {
+			return false
+		}
+	}
+	return foundMainRack
+}
+
+func countDataNodes(racks map[NodeId]int) (count int) {
+	for _, c := range racks {
+		count += c
+	}
+	return
+}
+
+/*
+ * MoveVolume copies the volume to the target data node, and then deletes it from the source data node.
+ *
+ * The source volume is read-only during the copy, so the copy can be verified to be complete.
+ * The target is registered before the source is deleted, so the volume stays readable.
+ * On any error, the partial copy is deleted, and the source becomes writable again.
+ */
+func (t *Topology) MoveVolume(grpcDialOption grpc.DialOption, move *VolumeMove, preallocate int64) (err error) {
+
+	v := move.volumeInfo
+	vl := t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl)
+	vl.accessLock.Lock()
+	vl.removeFromWritable(move.VolumeId)
+	vl.accessLock.Unlock()
+
+	glog.V(0).Infof("moving volume %d from %s to %s", move.VolumeId, move.Source, move.Target)
+
+	if err = markVolumeReadonly(grpcDialOption, move.Source, move.VolumeId); err != nil {
+		return fmt.Errorf("mark volume %d on %s read-only: %v", move.VolumeId, move.Source, err)
+	}
+	if !v.ReadOnly {
+		defer func() {
+			if err == nil {
+				return
+			}
+			if writableErr := markVolumeWritable(grpcDialOption, move.Source, move.VolumeId); writableErr != nil {
+				glog.V(0).Infof("mark volume %d on %s writable: %v", move.VolumeId, move.Source, writableErr)
+			}
+		}()
+	}
+
+	option := &VolumeGrowOption{
+		Collection:       v.Collection,
+		ReplicaPlacement: v.ReplicaPlacement,
+		Ttl:              v.Ttl,
+		Prealloacte:      preallocate,
+	}
+	if err = AllocateVolume(move.target, grpcDialOption, move.VolumeId, option); err != nil {
+		return fmt.Errorf("allocate volume %d on %s: %v", move.VolumeId, move.Target, err)
+	}
+
+	if err = copyVolume(grpcDialOption, move.VolumeId, move.Source, move.Target); err != nil {
+		if deleteErr := deleteVolume(grpcDialOption, move.Target, move.VolumeId); deleteErr != nil {
+			glog.V(0).Infof("remove partial volume %d on %s: %v", move.VolumeId, move.Target, deleteErr)
+		}
+		return fmt.Errorf("copy volume %d from %s to %s: %v", move.VolumeId, move.Source, move.Target, err)
+	}
+
+	// switch over to the copy, which stays as read-only as the source was
+	if v.ReadOnly {
+		if err = markVolumeReadonly(grpcDialOption, move.Target, move.VolumeId); err != nil {
+			if deleteErr := deleteVolume(grpcDialOption, move.Target, move.VolumeId); deleteErr != nil {
+				glog.V(0).Infof("remove volume %d copy on %s: %v", move.VolumeId, move.Target, deleteErr)
+			}
+			return fmt.Errorf("mark volume %d on %s read-only: %v", move.VolumeId, move.Target, err)
+		}
+	}
+	move.target.AddOrUpdateVolume(v)
+	t.RegisterVolumeLayout(v, move.target)
+
+	if err = deleteVolume(grpcDialOption, move.Source, move.VolumeId); err != nil {
+		// keep the source, and drop the copy, so the writes do not go to two diverging copies
+		if copied, found := move.target.RemoveVolume(move.VolumeId); found {
+			t.UnRegisterVolumeLayout(copied, move.target)
+		}
+		if deleteErr := deleteVolume(grpcDialOption, move.Target, move.VolumeId); deleteErr != nil {
+			glog.V(0).Infof("remove volume %d copy on %s: %v", move.VolumeId, move.Target, deleteErr)
+		}
+		return fmt.Errorf("delete volume %d on %s: %v", move.VolumeId, move.Source, err)
+	}
+	if sourceVolume, found := move.source.RemoveVolume(move.VolumeId); found {
+		t.UnRegisterVolumeLayout(sourceVolume, move.source)
+	}
+
+	glog.V(0).Infof("moved volume %d from %s to %s", move.VolumeId, move.Source, move.Target)
+	return nil
+}
+
+// copyVolume synchronizes the target volume from the read-only source volume, and verifies they are the same
+func copyVolume(grpcDialOption grpc.DialOption, vid storage.VolumeId, source, target string) error {
+	err := operation.WithVolumeServerClient(target, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		_, syncErr := client.VolumeSynchronize(context.Background(), &volume_server_pb.VolumeSynchronizeRequest{
+			VolumdId:       uint32(vid),
+			SourceDataNode: source,
+		})
+		return syncErr
+	})
+	if err != nil {
+		return err
+	}
+
+	sourceStatus, err := volumeSyncStatus(grpcDialOption, source, vid)
+	if err != nil {
+		return err
+	}
+	targetStatus, err := volumeSyncStatus(grpcDialOption, target, vid)
+	if err != nil {
+		return err
+	}
+	if sourceStatus.TailOffset != targetStatus.TailOffset ||
+		sourceStatus.IdxFileSize != targetStatus.IdxFileSize ||
+		sourceStatus.CompactRevision != targetStatus.CompactRevision {
+		return fmt.Errorf("copy differs: source %+v, target %+v", sourceStatus, targetStatus)
+	}
+	return nil
+}
+
+func volumeSyncStatus(grpcDialOption grpc.DialOption, url string, vid storage.VolumeId) (status *volume_server_pb.VolumeSyncStatusResponse, err error) {
+	err = operation.WithVolumeServerClient(url, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		status, err = client.VolumeSyncStatus(context.Background(), &volume_server_pb.VolumeSyncStatusRequest{
+			VolumdId: uint32(vid),
+		})
+		return err
+	})
+	return
+}
+
+func markVolumeReadonly(grpcDialOption grpc.DialOption, url string, vid storage.VolumeId) error {
+	return operation.WithVolumeServerClient(url, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		_, markErr := client.VolumeMarkReadonly(context.Background(), &volume_server_pb.VolumeMarkReadonlyRequest{
+			VolumdId: uint32(vid),
+		})
+		return markErr
+	})
+}
+
+func markVolumeWritable(grpcDialOption grpc.DialOption, url string, vid storage.VolumeId) error {
+	return operation.WithVolumeServerClient(url, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		_, markErr := client.VolumeMarkWritable(context.Background(), &volume_server_pb.VolumeMarkWritableRequest{
+			VolumdId: uint32(vid),
+		})
+		return markErr
+	})
+}
+
+func deleteVolume(grpcDialOption grpc.DialOption, url string, vid storage.VolumeId) error {
+	return operation.WithVolumeServerClient(url, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		_, deleteErr := client.VolumeDelete(context.Background(), &volume_server_pb.VolumeDeleteRequest{
+			VolumdId: uint32(vid),
+		})
+		return deleteErr
+	})
+}
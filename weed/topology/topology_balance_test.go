@@ -0,0 +1,298 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/sequence"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+	"google.golang.org/grpc"
+)
+
+func setupBalancingTopology(rp *storage.ReplicaPlacement, layout map[string]map[string]map[string][]int) (*Topology, map[string]*DataNode) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	servers := make(map[string]*DataNode)
+	for dcName, racks := range layout {
+		dc := NewDataCenter(dcName)
+		topo.LinkChildNode(dc)
+		for rackName, dataNodes := range racks {
+			rack := NewRack(rackName)
+			dc.LinkChildNode(rack)
+			for serverName, vids := range dataNodes {
+				server := NewDataNode(serverName)
+				rack.LinkChildNode(server)
+				for _, vid := range vids {
+					server.AddOrUpdateVolume(storage.VolumeInfo{
+						Id:               storage.VolumeId(vid),
+						ReplicaPlacement: rp,
+						Ttl:              storage.EMPTY_TTL,
+						Version:          storage.CurrentVersion,
+					})
+				}
+				server.UpAdjustMaxVolumeCountDelta(10)
+				servers[serverName] = server
+			}
+		}
+	}
+	return topo, servers
+}
+
+func TestPlanVolumeBalance(t *testing.T) {
+	rp, _ := storage.NewReplicaPlacementFromString("000")
+	topo, servers := setupBalancingTopology(rp, map[string]map[string]map[string][]int{
+		"dc1": {
+			"rack1": {
+				"server1": {1, 2, 3, 4, 5, 6},
+				"server2": {},
+			},
+		},
+	})
+
+	moves := topo.PlanVolumeBalance("*")
+	if len(moves) != 3 {
+		t.Fatalf("expected 3 moves, but got %d", len(moves))
+	}
+	for _, move := range moves {
+		if move.source != servers["server1"] || move.target != servers["server2"] {
+			t.Errorf("unexpected move of volume %d from %s to %s", move.VolumeId, move.Source, move.Target)
+		}
+	}
+
+	if moves := topo.PlanVolumeBalance("some_collection"); len(moves) != 0 {
+		t.Errorf("expected no moves for other collections, but got %d", len(moves))
+	}
+}
+
+func TestPlanVolumeBalanceKeepsReplicaPlacement(t *testing.T) {
+	rp, _ := storage.NewReplicaPlacementFromString("001")
+	topo, servers := setupBalancingTopology(rp, map[string]map[string]map[string][]int{
+		"dc1": {
+			"rack1": {
+				"server11": {1, 2, 3, 4},
+				"server12": {1, 2, 3, 4},
+			},
+			"rack2": {
+				"server21": {},
+			},
+		},
+	})
+
+	// moving any volume to server21 would split the replicas into two racks
+	if moves := topo.PlanVolumeBalance("*"); len(moves) != 0 {
+		t.Errorf("expected no moves, but got %d", len(moves))
+	}
+
+	if !satisfyReplicaPlacement(rp, []*DataNode{servers["server11"], servers["server12"]}) {
+		t.Errorf("replicas in the same rack should satisfy %s", rp)
+	}
+	if satisfyReplicaPlacement(rp, []*DataNode{servers["server11"], servers["server21"]}) {
+		t.Errorf("replicas in different racks should not satisfy %s", rp)
+	}
+}
+
+// testVolumeServer serves the volume admin requests of MoveVolume with a real store
+type testVolumeServer struct {
+	volume_server_pb.VolumeServerServer
+	url    string
+	dir    string
+	store  *storage.Store
+	server *grpc.Server
+}
+
+var (
+	testVolumeServers     = make(map[string]*testVolumeServer)
+	testVolumeServersLock sync.Mutex
+)
+
+// startTestVolumeServer listens for grpc at the port of the volume server url plus 10000, as the volume servers do
+func startTestVolumeServer(t *testing.T) *testVolumeServer {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcPort := listener.Addr().(*net.TCPAddr).Port
+	if grpcPort <= 10000 {
+		listener.Close()
+		t.Skipf("grpc port %d can not be mapped from a volume server port", grpcPort)
+	}
+	dir, err := ioutil.TempDir("", "move_volume")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+
+	vs := &testVolumeServer{
+		url:    fmt.Sprintf("localhost:%d", grpcPort-10000),
+		dir:    dir,
+		store:  storage.NewStore(grpc.WithInsecure(), grpcPort-10000, "localhost", "", []string{dir}, []int{10}, storage.NeedleMapInMemory, storage.FsyncNone),
+		server: grpc.NewServer(),
+	}
+	go func() {
+		for {
+			select {
+			case <-vs.store.NewVolumeIdChan:
+			case <-vs.store.DeletedVolumeIdChan:
+			}
+		}
+	}()
+	volume_server_pb.RegisterVolumeServerServer(vs.server, vs)
+	go vs.server.Serve(listener)
+
+	testVolumeServersLock.Lock()
+	testVolumeServers[vs.url] = vs
+	testVolumeServersLock.Unlock()
+	return vs
+}
+
+func (vs *testVolumeServer) stop() {
+	vs.server.Stop()
+	vs.store.Close()
+	os.RemoveAll(vs.dir)
+	testVolumeServersLock.Lock()
+	delete(testVolumeServers, vs.url)
+	testVolumeServersLock.Unlock()
+}
+
+func (vs *testVolumeServer) AssignVolume(ctx context.Context, req *volume_server_pb.AssignVolumeRequest) (*volume_server_pb.AssignVolumeResponse, error) {
+	err := vs.store.AddVolume(storage.VolumeId(req.VolumdId), req.Collection, storage.NeedleMapInMemory, req.Replication, req.Ttl, req.Preallocate)
+	return &volume_server_pb.AssignVolumeResponse{}, err
+}
+
+func (vs *testVolumeServer) VolumeMarkReadonly(ctx context.Context, req *volume_server_pb.VolumeMarkReadonlyRequest) (*volume_server_pb.VolumeMarkReadonlyResponse, error) {
+	return &volume_server_pb.VolumeMarkReadonlyResponse{}, vs.store.MarkVolumeReadonly(storage.VolumeId(req.VolumdId))
+}
+
+func (vs *testVolumeServer) VolumeMarkWritable(ctx context.Context, req *volume_server_pb.VolumeMarkWritableRequest) (*volume_server_pb.VolumeMarkWritableResponse, error) {
+	return &volume_server_pb.VolumeMarkWritableResponse{}, vs.store.MarkVolumeWritable(storage.VolumeId(req.VolumdId))
+}
+
+func (vs *testVolumeServer) VolumeDelete(ctx context.Context, req *volume_server_pb.VolumeDeleteRequest) (*volume_server_pb.VolumeDeleteResponse, error) {
+	return &volume_server_pb.VolumeDeleteResponse{}, vs.store.DeleteVolume(storage.VolumeId(req.VolumdId))
+}
+
+func (vs *testVolumeServer) VolumeSyncStatus(ctx context.Context, req *volume_server_pb.VolumeSyncStatusRequest) (*volume_server_pb.VolumeSyncStatusResponse, error) {
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+	if v == nil {
+		return nil, fmt.Errorf("not found volume id %d", req.VolumdId)
+	}
+	return v.GetVolumeSyncStatus(), nil
+}
+
+// VolumeSynchronize copies the .dat and .idx files of the read-only source volume directly
+func (vs *testVolumeServer) VolumeSynchronize(ctx context.Context, req *volume_server_pb.VolumeSynchronizeRequest) (*volume_server_pb.VolumeSynchronizeResponse, error) {
+	testVolumeServersLock.Lock()
+	source := testVolumeServers[req.SourceDataNode]
+	testVolumeServersLock.Unlock()
+	vid := storage.VolumeId(req.VolumdId)
+	if source == nil || source.store.GetVolume(vid) == nil || vs.store.GetVolume(vid) == nil {
+		return nil, fmt.Errorf("not found volume id %d", vid)
+	}
+	sourceFileName, targetFileName := source.store.GetVolume(vid).FileName(), vs.store.GetVolume(vid).FileName()
+	if err := vs.store.UnmountVolume(vid); err != nil {
+		return nil, err
+	}
+	for _, ext := range []string{".dat", ".idx"} {
+		if err := copyTestFile(sourceFileName+ext, targetFileName+ext); err != nil {
+			return nil, err
+		}
+	}
+	return &volume_server_pb.VolumeSynchronizeResponse{}, vs.store.MountVolume(vid)
+}
+
+func copyTestFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func TestMoveVolume(t *testing.T) {
+	source, target := startTestVolumeServer(t), startTestVolumeServer(t)
+	defer source.stop()
+	defer target.stop()
+
+	rp, _ := storage.NewReplicaPlacementFromString("000")
+	topo, servers := setupBalancingTopology(rp, map[string]map[string]map[string][]int{
+		"dc1": {
+			"rack1": {
+				"server1": {1},
+				"server2": {},
+			},
+		},
+	})
+	for name, vs := range map[string]*testVolumeServer{"server1": source, "server2": target} {
+		servers[name].Ip = "localhost"
+		servers[name].Port = vs.store.Port
+	}
+
+	vid := storage.VolumeId(1)
+	if err := source.store.AddVolume(vid, "", storage.NeedleMapInMemory, rp.String(), "", 0); err != nil {
+		t.Fatalf("add volume: %v", err)
+	}
+	fileCount := 10
+	for i := 1; i <= fileCount; i++ {
+		n := &storage.Needle{Id: types.Uint64ToNeedleId(uint64(i)), Data: []byte(fmt.Sprintf("file %d", i))}
+		n.Checksum = storage.NewCRC(n.Data)
+		if _, err := source.store.Write(vid, n); err != nil {
+			t.Fatalf("write file %d: %v", i, err)
+		}
+	}
+	sourceFileName := source.store.GetVolume(vid).FileName()
+
+	volumeInfo, err := servers["server1"].GetVolumesById(vid)
+	if err != nil {
+		t.Fatalf("volume info: %v", err)
+	}
+	move := &VolumeMove{
+		VolumeId:   vid,
+		Source:     servers["server1"].Url(),
+		Target:     servers["server2"].Url(),
+		volumeInfo: volumeInfo,
+		source:     servers["server1"],
+		target:     servers["server2"],
+	}
+	if err = topo.MoveVolume(grpc.WithInsecure(), move, 0); err != nil {
+		t.Fatalf("move volume: %v", err)
+	}
+
+	// the source is deleted
+	if source.store.HasVolume(vid) {
+		t.Errorf("volume %d is still on the source", vid)
+	}
+	if _, err = os.Stat(sourceFileName + ".dat"); !os.IsNotExist(err) {
+		t.Errorf("source .dat file is not deleted: %v", err)
+	}
+	if _, err = servers["server1"].GetVolumesById(vid); err == nil {
+		t.Errorf("volume %d is still registered on the source", vid)
+	}
+
+	// the target has all the files
+	if _, err = servers["server2"].GetVolumesById(vid); err != nil {
+		t.Errorf("volume %d is not registered on the target: %v", vid, err)
+	}
+	for i := 1; i <= fileCount; i++ {
+		n := &storage.Needle{Id: types.Uint64ToNeedleId(uint64(i))}
+		if _, err = target.store.ReadVolumeNeedle(vid, n); err != nil {
+			t.Fatalf("read file %d on the target: %v", i, err)
+		}
+		if !bytes.Equal(n.Data, []byte(fmt.Sprintf("file %d", i))) {
+			t.Errorf("file %d content changed: %s", i, n.Data)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package topology
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+)
+
+type EcShardLocations struct {
+	Collection string
+	Locations  [erasure_coding.TotalShardsCount][]*DataNode
+}
+
+func NewEcShardLocations(collection string) *EcShardLocations {
+	return &EcShardLocations{
+		Collection: collection,
+	}
+}
+
+func (loc *EcShardLocations) AddShard(shardId erasure_coding.ShardId, dn *DataNode) (added bool) {
+	dataNodes := loc.Locations[shardId]
+	for _, n := range dataNodes {
+		if n.Id() == dn.Id() {
+			return false
+		}
+	}
+	loc.Locations[shardId] = append(dataNodes, dn)
+	return true
+}
+
+func (loc *EcShardLocations) DeleteShard(shardId erasure_coding.ShardId, dn *DataNode) (deleted bool) {
+	dataNodes := loc.Locations[shardId]
+	foundIndex := -1
+	for index, n := range dataNodes {
+		if n.Id() == dn.Id() {
+			foundIndex = index
+		}
+	}
+	if foundIndex < 0 {
+		return false
+	}
+	loc.Locations[shardId] = append(dataNodes[:foundIndex], dataNodes[foundIndex+1:]...)
+	return true
+}
+
+func (loc *EcShardLocations) isEmpty() bool {
+	for _, dataNodes := range loc.Locations {
+		if len(dataNodes) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// DataNodes lists each data node holding any shard once
+func (loc *EcShardLocations) DataNodes() (dataNodes []*DataNode) {
+	seen := make(map[NodeId]bool)
+	for _, shardLocations := range loc.Locations {
+		for _, dn := range shardLocations {
+			if !seen[dn.Id()] {
+				seen[dn.Id()] = true
+				dataNodes = append(dataNodes, dn)
+			}
+		}
+	}
+	return
+}
+
+// ShardBits is the set of shards having at least one location
+func (loc *EcShardLocations) ShardBits() (shardBits erasure_coding.ShardBits) {
+	for shardId, shardLocations := range loc.Locations {
+		if len(shardLocations) > 0 {
+			shardBits = shardBits.AddShardId(erasure_coding.ShardId(shardId))
+		}
+	}
+	return
+}
+
+func (t *Topology) SyncDataNodeEcShards(shardInfos []*master_pb.VolumeEcShardInformationMessage, dn *DataNode) (newVids, deletedVids []storage.VolumeId) {
+	var shards []*EcShardInfo
+	for _, shardInfo := range shardInfos {
+		shards = append(shards, NewEcShardInfo(shardInfo))
+	}
+
+	before := ecVolumeIdSet(dn)
+	newShards, deletedShards := dn.UpdateEcShards(shards)
+	for _, v := range newShards {
+		t.RegisterEcShards(v, dn)
+	}
+	for _, v := range deletedShards {
+		t.UnRegisterEcShards(v, dn)
+	}
+	return diffEcVolumeIds(before, ecVolumeIdSet(dn))
+}
+
+func (t *Topology) IncrementalSyncDataNodeEcShards(newEcShards, deletedEcShards []*master_pb.VolumeEcShardInformationMessage, dn *DataNode) (newVids, deletedVids []storage.VolumeId) {
+	var newShards, deletedShards []*EcShardInfo
+	for _, shardInfo := range newEcShards {
+		newShards = append(newShards, NewEcShardInfo(shardInfo))
+	}
+	for _, shardInfo := range deletedEcShards {
+		deletedShards = append(deletedShards, NewEcShardInfo(shardInfo))
+	}
+
+	before := ecVolumeIdSet(dn)
+	dn.DeltaUpdateEcShards(newShards, deletedShards)
+	for _, v := range newShards {
+		t.RegisterEcShards(v, dn)
+	}
+	for _, v := range deletedShards {
+		t.UnRegisterEcShards(v, dn)
+	}
+	return diffEcVolumeIds(before, ecVolumeIdSet(dn))
+}
+
+func ecVolumeIdSet(dn *DataNode) map[storage.VolumeId]bool {
+	vids := make(map[storage.VolumeId]bool)
+	for _, s := range dn.GetEcShards() {
+		vids[s.VolumeId] = true
+	}
+	return vids
+}
+
+func diffEcVolumeIds(before, after map[storage.VolumeId]bool) (newVids, deletedVids []storage.VolumeId) {
+	for vid := range after {
+		if !before[vid] {
+			newVids = append(newVids, vid)
+		}
+	}
+	for vid := range before {
+		if !after[vid] {
+			deletedVids = append(deletedVids, vid)
+		}
+	}
+	return
+}
+
+func (t *Topology) RegisterEcShards(ecShardInfos *EcShardInfo, dn *DataNode) {
+
+	t.ecShardMapLock.Lock()
+	defer t.ecShardMapLock.Unlock()
+
+	locations, found := t.ecShardMap[ecShardInfos.VolumeId]
+	if !found {
+		locations = NewEcShardLocations(ecShardInfos.Collection)
+		t.ecShardMap[ecShardInfos.VolumeId] = locations
+	}
+	for _, shardId := range ecShardInfos.ShardBits.ShardIds() {
+		locations.AddShard(shardId, dn)
+	}
+}
+
+func (t *Topology) UnRegisterEcShards(ecShardInfos *EcShardInfo, dn *DataNode) {
+	glog.Infof("removing ec shard info:%+v", ecShardInfos)
+
+	t.ecShardMapLock.Lock()
+	defer t.ecShardMapLock.Unlock()
+
+	locations, found := t.ecShardMap[ecShardInfos.VolumeId]
+	if !found {
+		return
+	}
+	for _, shardId := range ecShardInfos.ShardBits.ShardIds() {
+		locations.DeleteShard(shardId, dn)
+	}
+	if locations.isEmpty() {
+		delete(t.ecShardMap, ecShardInfos.VolumeId)
+	}
+}
+
+// LookupEcShards returns a copy of the shard locations of the ec volume
+func (t *Topology) LookupEcShards(vid storage.VolumeId) (locations *EcShardLocations, found bool) {
+	t.ecShardMapLock.RLock()
+	defer t.ecShardMapLock.RUnlock()
+
+	existing, found := t.ecShardMap[vid]
+	if !found {
+		return nil, false
+	}
+	locations = NewEcShardLocations(existing.Collection)
+	for shardId, dataNodes := range existing.Locations {
+		locations.Locations[shardId] = append([]*DataNode(nil), dataNodes...)
+	}
+	return locations, true
+}
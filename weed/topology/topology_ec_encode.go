@@ -0,0 +1,406 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+	"google.golang.org/grpc"
+)
+
+// EcEncodeVolume converts a sealed volume into erasure coded shards spread across the data nodes,
+// and then deletes the original volume replicas.
+func (t *Topology) EcEncodeVolume(grpcDialOption grpc.DialOption, collection string, vid storage.VolumeId) error {
+
+	if _, found := t.LookupEcShards(vid); found {
+		return fmt.Errorf("volume %d is already erasure coded", vid)
+	}
+	locations := t.Lookup(collection, vid)
+	if len(locations) == 0 {
+		return fmt.Errorf("volume %d not found", vid)
+	}
+	volumeInfo, err := locations[0].GetVolumesById(vid)
+	if err != nil {
+		return fmt.Errorf("volume %d: %v", vid, err)
+	}
+	if volumeInfo.Collection != collection {
+		return fmt.Errorf("volume %d belongs to collection %s", vid, volumeInfo.Collection)
+	}
+	if !volumeInfo.ReadOnly && volumeInfo.Size < t.volumeSizeLimit {
+		return fmt.Errorf("volume %d is still writable", vid)
+	}
+
+	// check the shards can be placed to survive the same failures as the replicas, before changing anything
+	allocation, err := t.allocateEcShards(volumeInfo.ReplicaPlacement)
+	if err != nil {
+		return fmt.Errorf("ec encode volume %d: %v", vid, err)
+	}
+
+	// stop assigning writes to the volume, and let the writes already assigned finish or fail on every replica,
+	// since the replicas are deleted after generating the ec shards.
+	// On any failure the volume stays read-only, and the encoding can be retried.
+	vl := t.GetVolumeLayout(volumeInfo.Collection, volumeInfo.ReplicaPlacement, volumeInfo.Ttl)
+	vl.accessLock.Lock()
+	vl.removeFromWritable(vid)
+	vl.accessLock.Unlock()
+	for _, dn := range locations {
+		if err = markVolumeReadonly(grpcDialOption, dn.Url(), vid); err != nil {
+			return fmt.Errorf("mark volume %d on %s read-only: %v", vid, dn.Url(), err)
+		}
+	}
+
+	// generate the ec shards on one of the replicas
+	source := locations[0]
+	glog.V(0).Infof("ec encode volume %d on %s", vid, source.Url())
+	err = operation.WithVolumeServerClient(source.Url(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		_, genErr := client.VolumeEcShardsGenerate(context.Background(), &volume_server_pb.VolumeEcShardsGenerateRequest{
+			VolumdId:   uint32(vid),
+			Collection: collection,
+		})
+		return genErr
+	})
+	if err != nil {
+		return fmt.Errorf("generate ec shards for volume %d on %s: %v", vid, source.Url(), err)
+	}
+
+	// spread the shards
+	if err = spreadEcShards(grpcDialOption, collection, vid, source, allocation); err != nil {
+		cleanupEcShards(grpcDialOption, collection, vid, source, allocation)
+		return err
+	}
+
+	// remove the shards not assigned to the source data node, and the .ecx file if the source has no shards
+	var unusedShardIds []uint32
+	for shardId := 0; shardId < erasure_coding.TotalShardsCount; shardId++ {
+		if !allocation[source].HasShardId(erasure_coding.ShardId(shardId)) {
+			unusedShardIds = append(unusedShardIds, uint32(shardId))
+		}
+	}
+	if err = deleteEcShards(grpcDialOption, collection, vid, source, unusedShardIds); err != nil {
+		glog.V(0).Infof("remove unused ec shards of volume %d on %s: %v", vid, source.Url(), err)
+	}
+
+	// only delete the original volume after every shard is reported mounted
+	if err = t.waitForEcShards(vid, allocation); err != nil {
+		cleanupEcShards(grpcDialOption, collection, vid, source, allocation)
+		return err
+	}
+
+	// delete the original volume
+	for _, dn := range locations {
+		err = operation.WithVolumeServerClient(dn.Url(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+			_, deleteErr := client.VolumeDelete(context.Background(), &volume_server_pb.VolumeDeleteRequest{
+				VolumdId: uint32(vid),
+			})
+			return deleteErr
+		})
+		if err != nil {
+			return fmt.Errorf("delete volume %d on %s: %v", vid, dn.Url(), err)
+		}
+	}
+
+	glog.V(0).Infof("ec encoded volume %d", vid)
+	return nil
+}
+
+/*
+ * allocateEcShards spreads the shards evenly across the data centers, racks, or data nodes,
+ * so the ec volume survives the same failures as the replicas it replaces:
+ * losing as many data centers, racks, or data nodes as the replica placement tolerates
+ * must leave at least erasure_coding.DataShardsCount shards.
+ */
+func (t *Topology) allocateEcShards(rp *storage.ReplicaPlacement) (map[*DataNode]erasure_coding.ShardBits, error) {
+	var dataNodes []*DataNode
+	for _, c := range t.Children() {
+		for _, r := range c.Children() {
+			for _, n := range r.Children() {
+				dataNodes = append(dataNodes, n.(*DataNode))
+			}
+		}
+	}
+	if len(dataNodes) == 0 {
+		return nil, fmt.Errorf("no data nodes to hold ec shards")
+	}
+	sort.Slice(dataNodes, func(i, j int) bool {
+		if dataNodes[i].FreeSpace() != dataNodes[j].FreeSpace() {
+			return dataNodes[i].FreeSpace() > dataNodes[j].FreeSpace()
+		}
+		return dataNodes[i].GetEcShardCount() < dataNodes[j].GetEcShardCount()
+	})
+
+	dcShardCounts := make(map[*DataCenter]int)
+	rackShardCounts := make(map[*Rack]int)
+	nodeShardCounts := make(map[*DataNode]int)
+	// the shard counts of each data node, ordered by the failure domains to spread over first
+	shardCounts := func(dn *DataNode) []int {
+		dc, rack, node := dcShardCounts[dn.GetDataCenter()], rackShardCounts[dn.GetRack()], nodeShardCounts[dn]
+		if rp.DiffDataCenterCount > 0 {
+			return []int{dc, rack, node}
+		}
+		if rp.DiffRackCount > 0 {
+			return []int{rack, node, dc}
+		}
+		return []int{node, rack, dc}
+	}
+
+	allocation := make(map[*DataNode]erasure_coding.ShardBits)
+	for shardId := 0; shardId < erasure_coding.TotalShardsCount; shardId++ {
+		var target *DataNode
+		var targetCounts []int
+		for _, dn := range dataNodes {
+			if counts := shardCounts(dn); target == nil || lessShardCounts(counts, targetCounts) {
+				target, targetCounts = dn, counts
+			}
+		}
+		allocation[target] = allocation[target].AddShardId(erasure_coding.ShardId(shardId))
+		dcShardCounts[target.GetDataCenter()]++
+		rackShardCounts[target.GetRack()]++
+		nodeShardCounts[target]++
+	}
+
+	var dcCounts, rackCounts, nodeCounts []int
+	for _, count := range dcShardCounts {
+		dcCounts = append(dcCounts, count)
+	}
+	for _, count := range rackShardCounts {
+		rackCounts = append(rackCounts, count)
+	}
+	for _, count := range nodeShardCounts {
+		nodeCounts = append(nodeCounts, count)
+	}
+	if !survivesFailures(dcCounts, rp.DiffDataCenterCount) {
+		return nil, fmt.Errorf("not enough data centers to survive losing %d of them as replication %s", rp.DiffDataCenterCount, rp)
+	}
+	if !survivesFailures(rackCounts, rp.DiffDataCenterCount+rp.DiffRackCount) {
+		return nil, fmt.Errorf("not enough racks to survive losing %d of them as replication %s", rp.DiffDataCenterCount+rp.DiffRackCount, rp)
+	}
+	if !survivesFailures(nodeCounts, rp.GetCopyCount()-1) {
+		return nil, fmt.Errorf("not enough data nodes to survive losing %d of them as replication %s", rp.GetCopyCount()-1, rp)
+	}
+
+	return allocation, nil
+}
+
+func lessShardCounts(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// survivesFailures checks whether losing the failure domains with the most shards still leaves enough shards
+func survivesFailures(shardCounts []int, failures int) bool {
+	sort.Sort(sort.Reverse(sort.IntSlice(shardCounts)))
+	lost := 0
+	for i := 0; i < failures && i < len(shardCounts); i++ {
+		lost += shardCounts[i]
+	}
+	return erasure_coding.TotalShardsCount-lost >= erasure_coding.DataShardsCount
+}
+
+// waitForEcShards waits until the master sees all the allocated shards mounted, via the heartbeats
+func (t *Topology) waitForEcShards(vid storage.VolumeId, allocation map[*DataNode]erasure_coding.ShardBits) error {
+	deadline := time.Now().Add(time.Duration(3*t.pulse) * time.Second)
+	for {
+		if t.hasEcShards(vid, allocation) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("ec shards of volume %d are not all reported mounted", vid)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (t *Topology) hasEcShards(vid storage.VolumeId, allocation map[*DataNode]erasure_coding.ShardBits) bool {
+	ecLocations, found := t.LookupEcShards(vid)
+	if !found {
+		return false
+	}
+	for dn, shardBits := range allocation {
+		for _, shardId := range shardBits.ShardIds() {
+			mounted := false
+			for _, location := range ecLocations.Locations[shardId] {
+				if location == dn {
+					mounted = true
+				}
+			}
+			if !mounted {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func spreadEcShards(grpcDialOption grpc.DialOption, collection string, vid storage.VolumeId, source *DataNode, allocation map[*DataNode]erasure_coding.ShardBits) error {
+	var wg sync.WaitGroup
+	var errLock sync.Mutex
+	var lastErr error
+	for dn, shardBits := range allocation {
+		wg.Add(1)
+		go func(dn *DataNode, shardIds []uint32) {
+			defer wg.Done()
+			err := operation.WithVolumeServerClient(dn.Url(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+				if dn != source {
+					_, copyErr := client.VolumeEcShardsCopy(context.Background(), &volume_server_pb.VolumeEcShardsCopyRequest{
+						VolumdId:       uint32(vid),
+						Collection:     collection,
+						ShardIds:       shardIds,
+						CopyEcxFile:    true,
+						SourceDataNode: source.Url(),
+					})
+					if copyErr != nil {
+						return copyErr
+					}
+				}
+				_, mountErr := client.VolumeEcShardsMount(context.Background(), &volume_server_pb.VolumeEcShardsMountRequest{
+					VolumdId:   uint32(vid),
+					Collection: collection,
+					ShardIds:   shardIds,
+				})
+				return mountErr
+			})
+			if err != nil {
+				glog.Errorf("spread ec shards %d.%v to %s: %v", vid, shardIds, dn.Url(), err)
+				errLock.Lock()
+				lastErr = fmt.Errorf("spread ec shards %d.%v to %s: %v", vid, shardIds, dn.Url(), err)
+				errLock.Unlock()
+			} else {
+				glog.V(0).Infof("spread ec shards %d.%v to %s", vid, shardIds, dn.Url())
+			}
+		}(dn, toShardIdList(shardBits))
+	}
+	wg.Wait()
+	return lastErr
+}
+
+func cleanupEcShards(grpcDialOption grpc.DialOption, collection string, vid storage.VolumeId, source *DataNode, allocation map[*DataNode]erasure_coding.ShardBits) {
+	var allShardIds []uint32
+	for shardId := 0; shardId < erasure_coding.TotalShardsCount; shardId++ {
+		allShardIds = append(allShardIds, uint32(shardId))
+	}
+	for dn := range allocation {
+		if dn == source {
+			continue
+		}
+		if err := deleteEcShards(grpcDialOption, collection, vid, dn, allShardIds); err != nil {
+			glog.V(0).Infof("cleanup ec shards of volume %d on %s: %v", vid, dn.Url(), err)
+		}
+	}
+	if err := deleteEcShards(grpcDialOption, collection, vid, source, allShardIds); err != nil {
+		glog.V(0).Infof("cleanup ec shards of volume %d on %s: %v", vid, source.Url(), err)
+	}
+}
+
+// EcRebuildVolume regenerates the missing shards of an ec volume on the data node holding the most shards
+func (t *Topology) EcRebuildVolume(grpcDialOption grpc.DialOption, vid storage.VolumeId) (rebuiltShardIds []uint32, err error) {
+
+	ecLocations, found := t.LookupEcShards(vid)
+	if !found {
+		return nil, fmt.Errorf("ec volume %d not found", vid)
+	}
+	existingShardBits := ecLocations.ShardBits()
+	existingShardCount := existingShardBits.ShardIdCount()
+	if existingShardCount == erasure_coding.TotalShardsCount {
+		return nil, nil
+	}
+	if existingShardCount < erasure_coding.DataShardsCount {
+		return nil, fmt.Errorf("ec volume %d has only %d shards, can not rebuild", vid, existingShardCount)
+	}
+
+	// pick the data node with the most local shards
+	localShardBits := make(map[*DataNode]erasure_coding.ShardBits)
+	var rebuilder *DataNode
+	for shardId, dataNodes := range ecLocations.Locations {
+		for _, dn := range dataNodes {
+			localShardBits[dn] = localShardBits[dn].AddShardId(erasure_coding.ShardId(shardId))
+			if rebuilder == nil || localShardBits[dn].ShardIdCount() > localShardBits[rebuilder].ShardIdCount() {
+				rebuilder = dn
+			}
+		}
+	}
+
+	// copy the other existing shards to the rebuilder
+	var copiedShardIds []uint32
+	for shardId, dataNodes := range ecLocations.Locations {
+		if len(dataNodes) == 0 || localShardBits[rebuilder].HasShardId(erasure_coding.ShardId(shardId)) {
+			continue
+		}
+		err = operation.WithVolumeServerClient(rebuilder.Url(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+			_, copyErr := client.VolumeEcShardsCopy(context.Background(), &volume_server_pb.VolumeEcShardsCopyRequest{
+				VolumdId:       uint32(vid),
+				Collection:     ecLocations.Collection,
+				ShardIds:       []uint32{uint32(shardId)},
+				CopyEcxFile:    false,
+				SourceDataNode: dataNodes[0].Url(),
+			})
+			return copyErr
+		})
+		if err != nil {
+			deleteEcShards(grpcDialOption, ecLocations.Collection, vid, rebuilder, copiedShardIds)
+			return nil, fmt.Errorf("copy ec shard %d.%d from %s to %s: %v", vid, shardId, dataNodes[0].Url(), rebuilder.Url(), err)
+		}
+		copiedShardIds = append(copiedShardIds, uint32(shardId))
+	}
+
+	// generate and mount the missing shards
+	err = operation.WithVolumeServerClient(rebuilder.Url(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		resp, rebuildErr := client.VolumeEcShardsRebuild(context.Background(), &volume_server_pb.VolumeEcShardsRebuildRequest{
+			VolumdId:   uint32(vid),
+			Collection: ecLocations.Collection,
+		})
+		if rebuildErr != nil {
+			return rebuildErr
+		}
+		rebuiltShardIds = resp.RebuiltShardIds
+		_, mountErr := client.VolumeEcShardsMount(context.Background(), &volume_server_pb.VolumeEcShardsMountRequest{
+			VolumdId:   uint32(vid),
+			Collection: ecLocations.Collection,
+			ShardIds:   rebuiltShardIds,
+		})
+		return mountErr
+	})
+
+	// the copied shards are only needed for rebuilding
+	if deleteErr := deleteEcShards(grpcDialOption, ecLocations.Collection, vid, rebuilder, copiedShardIds); deleteErr != nil {
+		glog.V(0).Infof("remove copied ec shards of volume %d on %s: %v", vid, rebuilder.Url(), deleteErr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("rebuild ec volume %d on %s: %v", vid, rebuilder.Url(), err)
+	}
+
+	glog.V(0).Infof("rebuilt ec shards %d.%v on %s", vid, rebuiltShardIds, rebuilder.Url())
+	return rebuiltShardIds, nil
+}
+
+func deleteEcShards(grpcDialOption grpc.DialOption, collection string, vid storage.VolumeId, dn *DataNode, shardIds []uint32) error {
+	if len(shardIds) == 0 {
+		return nil
+	}
+	return operation.WithVolumeServerClient(dn.Url(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		_, deleteErr := client.VolumeEcShardsDelete(context.Background(), &volume_server_pb.VolumeEcShardsDeleteRequest{
+			VolumdId:   uint32(vid),
+			Collection: collection,
+			ShardIds:   shardIds,
+		})
+		return deleteErr
+	})
+}
+
+func toShardIdList(shardBits erasure_coding.ShardBits) (shardIds []uint32) {
+	for _, shardId := range shardBits.ShardIds() {
+		shardIds = append(shardIds, uint32(shardId))
+	}
+	return
+}
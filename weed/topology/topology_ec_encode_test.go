@@ -0,0 +1,78 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+)
+
+// ecLayout puts nodesPerRack data nodes in each rack of each data center
+func ecLayout(dataCenters, racksPerDataCenter, nodesPerRack int) map[string]map[string]map[string][]int {
+	layout := make(map[string]map[string]map[string][]int)
+	for d := 1; d <= dataCenters; d++ {
+		racks := make(map[string]map[string][]int)
+		for r := 1; r <= racksPerDataCenter; r++ {
+			nodes := make(map[string][]int)
+			for n := 1; n <= nodesPerRack; n++ {
+				nodes[fmt.Sprintf("server%d%d%d", d, r, n)] = nil
+			}
+			racks[fmt.Sprintf("rack%d", r)] = nodes
+		}
+		layout[fmt.Sprintf("dc%d", d)] = racks
+	}
+	return layout
+}
+
+func TestAllocateEcShards(t *testing.T) {
+	testCases := []struct {
+		replication                                   string
+		dataCenters, racksPerDataCenter, nodesPerRack int
+		expectError                                   bool
+	}{
+		{"000", 1, 1, 1, false},
+		{"002", 1, 1, 6, true},
+		{"002", 1, 1, 7, false},
+		{"010", 1, 2, 4, true},
+		{"010", 1, 4, 1, false},
+		{"100", 2, 2, 2, true},
+		{"100", 4, 1, 1, false},
+	}
+
+	for _, tc := range testCases {
+		rp, _ := storage.NewReplicaPlacementFromString(tc.replication)
+		topo, _ := setupBalancingTopology(rp, ecLayout(tc.dataCenters, tc.racksPerDataCenter, tc.nodesPerRack))
+		allocation, err := topo.allocateEcShards(rp)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("replication %s on %d/%d/%d: expected an error", tc.replication, tc.dataCenters, tc.racksPerDataCenter, tc.nodesPerRack)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("replication %s on %d/%d/%d: %v", tc.replication, tc.dataCenters, tc.racksPerDataCenter, tc.nodesPerRack, err)
+			continue
+		}
+
+		var allShards erasure_coding.ShardBits
+		rackShardCounts := make(map[*Rack]int)
+		for dn, shardBits := range allocation {
+			if allShards&shardBits != 0 {
+				t.Errorf("replication %s: shards %v allocated twice", tc.replication, shardBits.ShardIds())
+			}
+			allShards |= shardBits
+			rackShardCounts[dn.GetRack()] += shardBits.ShardIdCount()
+		}
+		if allShards.ShardIdCount() != erasure_coding.TotalShardsCount {
+			t.Errorf("replication %s: %d shards allocated", tc.replication, allShards.ShardIdCount())
+		}
+		if rp.DiffRackCount > 0 {
+			for rack, count := range rackShardCounts {
+				if count > erasure_coding.ParityShardsCount {
+					t.Errorf("replication %s: rack %s has %d shards", tc.replication, rack.Id(), count)
+				}
+			}
+		}
+	}
+}
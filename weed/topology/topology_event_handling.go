@@ -15,6 +15,7 @@ func (t *Topology) StartRefreshWritableVolumes(grpcDialOption grpc.DialOption, g
 			if t.IsLeader() {
 				freshThreshHold := time.Now().Unix() - 3*t.pulse //3 times of sleep interval
 				t.CollectDeadNodeAndFullVolumes(freshThreshHold, t.volumeSizeLimit)
+				t.UpdateMetrics()
 			}
 			time.Sleep(time.Duration(float32(t.pulse*1e3)*(1+rand.Float32())) * time.Millisecond)
 		}
@@ -27,6 +28,14 @@ func (t *Topology) StartRefreshWritableVolumes(grpcDialOption grpc.DialOption, g
 			}
 		}
 	}(garbageThreshold)
+	go func() {
+		c := time.Tick(time.Minute)
+		for _ = range c {
+			if t.IsLeader() {
+				t.ReplicateVolumes(grpcDialOption, preallocate)
+			}
+		}
+	}()
 	go func() {
 		for {
 			select {
@@ -58,6 +67,10 @@ func (t *Topology) UnRegisterDataNode(dn *DataNode) {
 		vl := t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl)
 		vl.SetVolumeUnavailable(dn, v.Id)
 	}
+	for _, s := range dn.GetEcShards() {
+		glog.V(0).Infoln("Removing Ec Volume", s.VolumeId, "shards", s.ShardBits.ShardIds(), "from the dead volume server", dn.Id())
+		t.UnRegisterEcShards(s, dn)
+	}
 	dn.UpAdjustVolumeCountDelta(-dn.GetVolumeCount())
 	dn.UpAdjustActiveVolumeCountDelta(-dn.GetActiveVolumeCount())
 	dn.UpAdjustMaxVolumeCountDelta(-dn.GetMaxVolumeCount())
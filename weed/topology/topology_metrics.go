@@ -0,0 +1,46 @@
+package topology
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/stats"
+)
+
+// UpdateMetrics refreshes the topology gauges exposed at /metrics
+func (t *Topology) UpdateMetrics() {
+	dataCenterCount, rackCount, dataNodeCount, ecShardCount := 0, 0, 0, 0
+	for _, c := range t.Children() {
+		dataCenterCount++
+		for _, r := range c.Children() {
+			rackCount++
+			for _, n := range r.Children() {
+				dataNodeCount++
+				ecShardCount += n.(*DataNode).GetEcShardCount()
+			}
+		}
+	}
+	stats.MasterTopologyGauge.WithLabelValues("dataCenters").Set(float64(dataCenterCount))
+	stats.MasterTopologyGauge.WithLabelValues("racks").Set(float64(rackCount))
+	stats.MasterTopologyGauge.WithLabelValues("dataNodes").Set(float64(dataNodeCount))
+	stats.MasterTopologyGauge.WithLabelValues("maxVolumes").Set(float64(t.GetMaxVolumeCount()))
+	stats.MasterTopologyGauge.WithLabelValues("freeVolumes").Set(float64(t.FreeSpace()))
+	stats.MasterTopologyGauge.WithLabelValues("volumes").Set(float64(t.GetVolumeCount()))
+	stats.MasterTopologyGauge.WithLabelValues("activeVolumes").Set(float64(t.GetActiveVolumeCount()))
+	stats.MasterTopologyGauge.WithLabelValues("ecShards").Set(float64(ecShardCount))
+
+	stats.MasterCollectionVolumeGauge.Reset()
+	for _, col := range t.collectionMap.Items() {
+		c := col.(*Collection)
+		volumeCount, writableCount := 0, 0
+		for _, vl := range c.storageType2VolumeLayout.Items() {
+			if vl == nil {
+				continue
+			}
+			volumeLayout := vl.(*VolumeLayout)
+			volumeLayout.accessLock.RLock()
+			volumeCount += len(volumeLayout.vid2location)
+			writableCount += len(volumeLayout.writables)
+			volumeLayout.accessLock.RUnlock()
+		}
+		stats.MasterCollectionVolumeGauge.WithLabelValues(c.Name, "volumes").Set(float64(volumeCount))
+		stats.MasterCollectionVolumeGauge.WithLabelValues(c.Name, "writable").Set(float64(writableCount))
+	}
+}
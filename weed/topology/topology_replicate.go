@@ -0,0 +1,294 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"google.golang.org/grpc"
+)
+
+/*
+Re-replication restores the replica count of volumes which lost some replicas,
+usually because a volume server is dead.
+
+1. find volumes with fewer locations than the replica placement requires
+2. wait for a while, in case the volume server is just restarting
+3. allocate an empty volume on a data node which keeps the replica placement
+4. the existing replicas become read-only, so they do not change during the copy
+5. the new replica synchronizes the volume from one of the existing replicas, and is verified against it
+6. the new replica is registered, and the existing replicas become writable again
+*/
+
+const (
+	ReplicationWaiting = "waiting"
+	ReplicationCopying = "copying"
+	ReplicationDone    = "done"
+	ReplicationFailed  = "failed"
+
+	replicationDelay     = 5 * time.Minute
+	replicationRetention = time.Hour
+)
+
+type ReplicationTask struct {
+	VolumeId    storage.VolumeId `json:"volumeId"`
+	Collection  string           `json:"collection"`
+	Replication string           `json:"replication"`
+	Replicas    int              `json:"replicas"`
+	Source      string           `json:"source,omitempty"`
+	Target      string           `json:"target,omitempty"`
+	Status      string           `json:"status"`
+	Error       string           `json:"error,omitempty"`
+	FoundAt     time.Time        `json:"foundAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+type underReplicatedVolume struct {
+	volumeInfo storage.VolumeInfo
+	locations  []*DataNode
+}
+
+// ReplicationStatus lists the current and recently finished re-replication tasks
+func (t *Topology) ReplicationStatus() (tasks []ReplicationTask) {
+	t.replicationTasksLock.RLock()
+	defer t.replicationTasksLock.RUnlock()
+
+	for _, task := range t.replicationTasks {
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].VolumeId < tasks[j].VolumeId
+	})
+	return
+}
+
+// ReplicateVolumes adds one replica to each volume which stays under-replicated longer than replicationDelay
+func (t *Topology) ReplicateVolumes(grpcDialOption grpc.DialOption, preallocate int64) {
+
+	volumes := t.collectUnderReplicatedVolumes()
+	now := time.Now()
+
+	var toReplicate []*underReplicatedVolume
+	t.replicationTasksLock.Lock()
+	for vid, task := range t.replicationTasks {
+		if _, found := volumes[vid]; !found && task.Status != ReplicationDone && task.Status != ReplicationFailed {
+			// recovered by itself, e.g., the volume server is back
+			delete(t.replicationTasks, vid)
+		} else if now.Sub(task.UpdatedAt) > replicationRetention {
+			delete(t.replicationTasks, vid)
+		}
+	}
+	for vid, v := range volumes {
+		task, found := t.replicationTasks[vid]
+		if !found || task.Status == ReplicationDone {
+			task = &ReplicationTask{
+				VolumeId:    vid,
+				Collection:  v.volumeInfo.Collection,
+				Replication: v.volumeInfo.ReplicaPlacement.String(),
+				Status:      ReplicationWaiting,
+				FoundAt:     now,
+			}
+			t.replicationTasks[vid] = task
+		}
+		task.Replicas = len(v.locations)
+		task.UpdatedAt = now
+		if now.Sub(task.FoundAt) >= replicationDelay {
+			toReplicate = append(toReplicate, v)
+		}
+	}
+	t.replicationTasksLock.Unlock()
+
+	for _, v := range toReplicate {
+		source, target, err := t.replicateOneVolume(grpcDialOption, v, preallocate)
+		t.replicationTasksLock.Lock()
+		if task, found := t.replicationTasks[v.volumeInfo.Id]; found {
+			task.Source, task.Target, task.UpdatedAt = source, target, time.Now()
+			if err != nil {
+				task.Status, task.Error = ReplicationFailed, err.Error()
+			} else {
+				task.Status, task.Error = ReplicationDone, ""
+				task.Replicas++
+			}
+		}
+		t.replicationTasksLock.Unlock()
+	}
+
+}
+
+func (t *Topology) collectUnderReplicatedVolumes() map[storage.VolumeId]*underReplicatedVolume {
+	volumes := make(map[storage.VolumeId]*underReplicatedVolume)
+	for _, col := range t.collectionMap.Items() {
+		c := col.(*Collection)
+		for _, vl := range c.storageType2VolumeLayout.Items() {
+			if vl == nil {
+				continue
+			}
+			volumeLayout := vl.(*VolumeLayout)
+			volumeLayout.accessLock.RLock()
+			for vid, locationList := range volumeLayout.vid2location {
+				if locationList.Length() == 0 || locationList.Length() >= volumeLayout.rp.GetCopyCount() {
+					continue
+				}
+				volumeInfo, err := locationList.Head().GetVolumesById(vid)
+				if err != nil {
+					continue
+				}
+				volumes[vid] = &underReplicatedVolume{
+					volumeInfo: volumeInfo,
+					locations:  append([]*DataNode(nil), locationList.list...),
+				}
+			}
+			volumeLayout.accessLock.RUnlock()
+		}
+	}
+	return volumes
+}
+
+func (t *Topology) replicateOneVolume(grpcDialOption grpc.DialOption, v *underReplicatedVolume, preallocate int64) (source, target string, err error) {
+
+	vid := v.volumeInfo.Id
+	targetNode := t.findReplicationTarget(v.volumeInfo.ReplicaPlacement, v.locations)
+	if targetNode == nil {
+		return "", "", fmt.Errorf("no data node can hold another replica of volume %d", vid)
+	}
+	source, target = v.locations[0].Url(), targetNode.Url()
+
+	t.replicationTasksLock.Lock()
+	if task, found := t.replicationTasks[vid]; found {
+		task.Source, task.Target, task.Status, task.UpdatedAt = source, target, ReplicationCopying, time.Now()
+	}
+	t.replicationTasksLock.Unlock()
+
+	glog.V(0).Infof("replicating volume %d from %s to %s", vid, source, target)
+
+	option := &VolumeGrowOption{
+		Collection:       v.volumeInfo.Collection,
+		ReplicaPlacement: v.volumeInfo.ReplicaPlacement,
+		Ttl:              v.volumeInfo.Ttl,
+		Prealloacte:      preallocate,
+	}
+	// the replicas must not change during the copy, since the new replica would miss the changes
+	if err = markVolumeReplicasReadonly(grpcDialOption, vid, v.locations); err != nil {
+		return source, target, err
+	}
+	if !v.volumeInfo.ReadOnly {
+		defer markVolumeReplicasWritable(grpcDialOption, vid, v.locations)
+	}
+
+	if err = AllocateVolume(targetNode, grpcDialOption, vid, option); err != nil {
+		return source, target, fmt.Errorf("allocate volume %d on %s: %v", vid, target, err)
+	}
+
+	if err = copyVolume(grpcDialOption, vid, source, target); err != nil {
+		if deleteErr := deleteVolume(grpcDialOption, target, vid); deleteErr != nil {
+			glog.V(0).Infof("remove partial volume %d on %s: %v", vid, target, deleteErr)
+		}
+		return source, target, fmt.Errorf("copy volume %d from %s to %s: %v", vid, source, target, err)
+	}
+
+	// register the new replica right away, instead of waiting for its heartbeat
+	if v.volumeInfo.ReadOnly {
+		if err = markVolumeReadonly(grpcDialOption, target, vid); err != nil {
+			if deleteErr := deleteVolume(grpcDialOption, target, vid); deleteErr != nil {
+				glog.V(0).Infof("remove volume %d copy on %s: %v", vid, target, deleteErr)
+			}
+			return source, target, fmt.Errorf("mark volume %d on %s read-only: %v", vid, target, err)
+		}
+	}
+	targetNode.AddOrUpdateVolume(v.volumeInfo)
+	t.RegisterVolumeLayout(v.volumeInfo, targetNode)
+
+	glog.V(0).Infof("replicated volume %d from %s to %s", vid, source, target)
+	return source, target, nil
+}
+
+func markVolumeReplicasReadonly(grpcDialOption grpc.DialOption, vid storage.VolumeId, locations []*DataNode) error {
+	for i, dn := range locations {
+		if err := markVolumeReadonly(grpcDialOption, dn.Url(), vid); err != nil {
+			markVolumeReplicasWritable(grpcDialOption, vid, locations[:i])
+			return fmt.Errorf("mark volume %d on %s read-only: %v", vid, dn.Url(), err)
+		}
+	}
+	return nil
+}
+
+func markVolumeReplicasWritable(grpcDialOption grpc.DialOption, vid storage.VolumeId, locations []*DataNode) {
+	for _, dn := range locations {
+		if err := markVolumeWritable(grpcDialOption, dn.Url(), vid); err != nil {
+			glog.V(0).Infof("mark volume %d on %s writable: %v", vid, dn.Url(), err)
+		}
+	}
+}
+
+// findReplicationTarget picks the data node with the most free slots, which keeps the replica placement possible
+func (t *Topology) findReplicationTarget(rp *storage.ReplicaPlacement, locations []*DataNode) (target *DataNode) {
+	existing := make(map[*DataNode]bool)
+	for _, dn := range locations {
+		existing[dn] = true
+	}
+	for _, c := range t.Children() {
+		for _, r := range c.Children() {
+			for _, n := range r.Children() {
+				dn := n.(*DataNode)
+				if existing[dn] || dn.FreeSpace() <= 0 {
+					continue
+				}
+				newLocations := append(append([]*DataNode(nil), locations...), dn)
+				if len(newLocations) == rp.GetCopyCount() && !satisfyReplicaPlacement(rp, newLocations) {
+					continue
+				}
+				if len(newLocations) < rp.GetCopyCount() && !isPartialReplicaPlacement(rp, newLocations) {
+					continue
+				}
+				if target == nil || dn.FreeSpace() > target.FreeSpace() {
+					target = dn
+				}
+			}
+		}
+	}
+	return
+}
+
+// isPartialReplicaPlacement checks whether more data nodes can be added to satisfy the replica placement
+func isPartialReplicaPlacement(rp *storage.ReplicaPlacement, dataNodes []*DataNode) bool {
+	dcRacks := make(map[NodeId]map[NodeId]int)
+	for _, dn := range dataNodes {
+		dcId, rackId := dn.GetDataCenter().Id(), dn.GetRack().Id()
+		if _, found := dcRacks[dcId]; !found {
+			dcRacks[dcId] = make(map[NodeId]int)
+		}
+		dcRacks[dcId][rackId]++
+	}
+	if len(dcRacks) > rp.DiffDataCenterCount+1 {
+		return false
+	}
+
+	// only the main data center can have more than one data node,
+	// and only the main rack can have more than one data node
+	mainDcCount := 0
+	for _, racks := range dcRacks {
+		if countDataNodes(racks) < 2 {
+			continue
+		}
+		mainDcCount++
+		if len(racks) > rp.DiffRackCount+1 {
+			return false
+		}
+		mainRackCount := 0
+		for _, count := range racks {
+			if count > rp.SameRackCount+1 {
+				return false
+			}
+			if count > 1 {
+				mainRackCount++
+			}
+		}
+		if mainRackCount > 1 {
+			return false
+		}
+	}
+
+	return mainDcCount <= 1
+}
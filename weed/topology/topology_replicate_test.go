@@ -0,0 +1,79 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage"
+)
+
+func TestFindReplicationTarget(t *testing.T) {
+	layout := map[string]map[string]map[string][]int{
+		"dc1": {
+			"rack1": {
+				"server111": {1},
+				"server112": {},
+			},
+			"rack2": {
+				"server121": {},
+			},
+		},
+		"dc2": {
+			"rack1": {
+				"server211": {},
+			},
+		},
+	}
+
+	testCases := []struct {
+		replication string
+		expected    string
+	}{
+		{"001", "server112"},
+		{"010", "server121"},
+		{"100", "server211"},
+	}
+
+	for _, tc := range testCases {
+		rp, _ := storage.NewReplicaPlacementFromString(tc.replication)
+		topo, servers := setupBalancingTopology(rp, layout)
+		target := topo.findReplicationTarget(rp, []*DataNode{servers["server111"]})
+		if target == nil {
+			t.Errorf("replication %s: no target found", tc.replication)
+			continue
+		}
+		if target != servers[tc.expected] {
+			t.Errorf("replication %s: expected %s, but got %s", tc.replication, tc.expected, target.Id())
+		}
+	}
+}
+
+func TestIsPartialReplicaPlacement(t *testing.T) {
+	rp, _ := storage.NewReplicaPlacementFromString("011")
+	_, servers := setupBalancingTopology(rp, map[string]map[string]map[string][]int{
+		"dc1": {
+			"rack1": {
+				"server111": {},
+				"server112": {},
+			},
+			"rack2": {
+				"server121": {},
+				"server122": {},
+			},
+		},
+		"dc2": {
+			"rack1": {
+				"server211": {},
+			},
+		},
+	})
+
+	if !isPartialReplicaPlacement(rp, []*DataNode{servers["server111"], servers["server112"]}) {
+		t.Errorf("two replicas in the main rack should be possible for %s", rp)
+	}
+	if !isPartialReplicaPlacement(rp, []*DataNode{servers["server111"], servers["server121"]}) {
+		t.Errorf("two replicas in two racks should be possible for %s", rp)
+	}
+	if isPartialReplicaPlacement(rp, []*DataNode{servers["server111"], servers["server211"]}) {
+		t.Errorf("two replicas in two data centers should not be possible for %s", rp)
+	}
+}
@@ -122,3 +122,39 @@ func TestAddRemoveVolume(t *testing.T) {
 	}
 
 }
+
+func TestRemoveVolumeReplica(t *testing.T) {
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	dn1 := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+	dn2 := rack.GetOrCreateDataNode("127.0.0.1", 34535, "127.0.0.1", 25)
+
+	v := storage.VolumeInfo{
+		Id:               storage.VolumeId(1),
+		Collection:       "xcollection",
+		Version:          storage.CurrentVersion,
+		ReplicaPlacement: &storage.ReplicaPlacement{},
+		Ttl:              storage.EMPTY_TTL,
+	}
+
+	for _, dn := range []*DataNode{dn1, dn2} {
+		dn.UpdateVolumes([]storage.VolumeInfo{v})
+		topo.RegisterVolumeLayout(v, dn)
+	}
+
+	if removed, found := dn1.RemoveVolume(v.Id); found {
+		topo.UnRegisterVolumeLayout(removed, dn1)
+	}
+
+	locations := topo.Lookup(v.Collection, v.Id)
+	if len(locations) != 1 || locations[0] != dn2 {
+		t.Errorf("volume %d should only be on %s: %v", v.Id, dn2.Id(), locations)
+	}
+	if len(dn1.GetVolumes()) != 0 {
+		t.Errorf("volume %d should be removed from %s", v.Id, dn1.Id())
+	}
+
+}
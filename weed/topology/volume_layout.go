@@ -92,7 +92,13 @@ func (vl *VolumeLayout) UnRegisterVolume(v *storage.VolumeInfo, dn *DataNode) {
 	defer vl.accessLock.Unlock()
 
 	vl.removeFromWritable(v.Id)
-	delete(vl.vid2location, v.Id)
+	// keep the other replicas, e.g. the new copy of a moved volume
+	if location, ok := vl.vid2location[v.Id]; ok {
+		location.Remove(dn)
+		if location.Length() == 0 {
+			delete(vl.vid2location, v.Id)
+		}
+	}
 }
 
 func (vl *VolumeLayout) addToWritable(vid storage.VolumeId) {
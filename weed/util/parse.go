@@ -1,7 +1,10 @@
 package util
 
 import (
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 func ParseInt(text string, defaultValue int) int {
@@ -24,3 +27,27 @@ func ParseUint64(text string, defaultValue uint64) uint64 {
 	}
 	return count
 }
+
+func ParseFilerUrl(entryPath string) (filerServer string, filerPort int64, path string, err error) {
+	if !strings.HasPrefix(entryPath, "http://") && !strings.HasPrefix(entryPath, "https://") {
+		entryPath = "http://" + entryPath
+	}
+
+	var u *url.URL
+	u, err = url.Parse(entryPath)
+	if err != nil {
+		return
+	}
+	filerServer = u.Hostname()
+	portString := u.Port()
+	if portString != "" {
+		filerPort, err = strconv.ParseInt(portString, 10, 32)
+	} else {
+		err = fmt.Errorf("filer port should be specified: %s", entryPath)
+	}
+	path = u.Path
+	if path == "" {
+		path = "/"
+	}
+	return
+}
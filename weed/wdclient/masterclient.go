@@ -116,3 +116,8 @@ func withMasterClient(master string, grpcDialOption grpc.DialOption, fn func(cli
 
 	return fn(client)
 }
+
+// WithClient runs fn against the currently connected master
+func (mc *MasterClient) WithClient(fn func(client master_pb.SeaweedClient) error) error {
+	return withMasterClient(mc.currentMaster, mc.grpcDialOption, fn)
+}